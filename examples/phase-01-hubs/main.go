@@ -128,14 +128,19 @@ Respond concisely in 1-2 sentences as flight engineer.`,
 	evaConfig.Name = "eva-hub"
 	evaConfig.Logger = logger
 	evaHub := hub.New(ctx, evaConfig)
-	defer evaHub.Shutdown(5 * time.Second)
 
 	// Create ISS Hub (crew inside the station)
 	issConfig := config.DefaultHubConfig()
 	issConfig.Name = "iss-hub"
 	issConfig.Logger = logger
 	issHub := hub.New(ctx, issConfig)
-	defer issHub.Shutdown(5 * time.Second)
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		evaHub.Shutdown(shutdownCtx)
+		issHub.Shutdown(shutdownCtx)
+	}()
 
 	fmt.Printf("  ✓ Created eva-hub (EVA crew)\n")
 	fmt.Printf("  ✓ Created iss-hub (ISS internal operations)\n")
@@ -268,7 +273,7 @@ Respond concisely in 1-2 sentences as flight engineer.`,
 	fmt.Println("   mission-commander → all EVA crew")
 	fmt.Println("   Message: Orbital sunset in 20 minutes, prioritize the cooling line connection")
 
-	evaHub.Broadcast(ctx, commander.ID(), "Orbital sunset in 20 minutes, prioritize the cooling line connection")
+	evaHub.Broadcast(ctx, messaging.NewBroadcast(commander.ID(), "Orbital sunset in 20 minutes, prioritize the cooling line connection").Build())
 
 	fmt.Printf("   %s\n", <-responses)
 	fmt.Printf("   %s\n", <-responses)
@@ -283,7 +288,7 @@ Respond concisely in 1-2 sentences as flight engineer.`,
 	fmt.Println("   mission-commander publishes to topic 'equipment'")
 	fmt.Println("   Message: Spare thermal blanket available in airlock if needed")
 
-	evaHub.Publish(ctx, commander.ID(), "equipment", "Spare thermal blanket available in airlock if needed")
+	evaHub.Publish(ctx, "equipment", messaging.NewNotification(commander.ID(), "", "Spare thermal blanket available in airlock if needed").Build())
 
 	fmt.Printf("   %s\n", <-responses)
 	fmt.Println()
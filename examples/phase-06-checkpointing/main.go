@@ -95,7 +95,10 @@ Keep responses to 1-2 sentences focusing on key findings or actions.`
 		fmt.Printf("     Characteristics: %s\n", characteristics)
 		fmt.Printf("     ✓ Stage 1 complete\n")
 
-		return s.Set("characteristics", characteristics).Set("stage", "ingested"), nil
+		return s.SetMany(map[string]any{
+			"characteristics": characteristics,
+			"stage":           "ingested",
+		}), nil
 	})
 
 	preprocessNode := state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
@@ -114,7 +117,10 @@ Keep responses to 1-2 sentences focusing on key findings or actions.`
 		fmt.Printf("     Steps: %s\n", preprocessSteps)
 		fmt.Printf("     ✓ Stage 2 complete\n")
 
-		return s.Set("preprocessing", preprocessSteps).Set("stage", "preprocessed"), nil
+		return s.SetMany(map[string]any{
+			"preprocessing": preprocessSteps,
+			"stage":         "preprocessed",
+		}), nil
 	})
 
 	analyzeNode := state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
@@ -142,7 +148,10 @@ Keep responses to 1-2 sentences focusing on key findings or actions.`
 		fmt.Printf("     Insights: %s\n", insights)
 		fmt.Printf("     ✓ Stage 3 complete\n")
 
-		return s.Set("insights", insights).Set("stage", "analyzed"), nil
+		return s.SetMany(map[string]any{
+			"insights": insights,
+			"stage":    "analyzed",
+		}), nil
 	})
 
 	reportNode := state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
@@ -161,7 +170,10 @@ Keep responses to 1-2 sentences focusing on key findings or actions.`
 		fmt.Printf("     Summary: %s\n", reportSummary)
 		fmt.Printf("     ✓ Stage 4 complete\n")
 
-		return s.Set("report", reportSummary).Set("stage", "completed"), nil
+		return s.SetMany(map[string]any{
+			"report": reportSummary,
+			"stage":  "completed",
+		}), nil
 	})
 
 	if err := graph.AddNode("ingest", ingestNode); err != nil {
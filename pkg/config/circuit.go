@@ -0,0 +1,56 @@
+package config
+
+import "time"
+
+// CircuitBreakerConfig defines when the hub stops delivering to a failing agent.
+//
+// After Threshold consecutive handler failures the circuit opens and messages
+// to the agent are dead-lettered. Once Timeout elapses the circuit is half-open
+// and deliveries resume on trial; HalfOpenAttempts consecutive successes close
+// the circuit, while any failure re-opens it.
+//
+// Example JSON:
+//
+//	{
+//	  "threshold": 5,
+//	  "timeout": 30000000000,
+//	  "half_open_attempts": 1
+//	}
+type CircuitBreakerConfig struct {
+	// Threshold is the number of consecutive failures that opens the circuit
+	Threshold int `json:"threshold"`
+
+	// Timeout is how long the circuit stays open before allowing trial deliveries
+	Timeout time.Duration `json:"timeout"`
+
+	// HalfOpenAttempts is the number of consecutive successes that closes the circuit
+	HalfOpenAttempts int `json:"half_open_attempts"`
+}
+
+// DefaultCircuitBreakerConfig returns conservative circuit breaker settings.
+//
+// Default values:
+//   - Threshold: 5 consecutive failures
+//   - Timeout: 30s
+//   - HalfOpenAttempts: 1
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Threshold:        5,
+		Timeout:          30 * time.Second,
+		HalfOpenAttempts: 1,
+	}
+}
+
+func (c *CircuitBreakerConfig) Merge(source *CircuitBreakerConfig) {
+	if source.Threshold > 0 {
+		c.Threshold = source.Threshold
+	}
+
+	if source.Timeout > 0 {
+		c.Timeout = source.Timeout
+	}
+
+	if source.HalfOpenAttempts > 0 {
+		c.HalfOpenAttempts = source.HalfOpenAttempts
+	}
+}
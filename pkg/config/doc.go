@@ -77,6 +77,17 @@
 //   - Pointers: Merge if source is non-nil
 //   - Nested configs: Recursive merge
 //
+// # Loading from JSON
+//
+// NewHubConfigFromJSON and NewGraphConfigFromJSON unmarshal over the defaults
+// and validate the result in one call, so fields missing from the JSON keep
+// their default values instead of silently becoming zero:
+//
+//	//go:embed graph.json
+//	var graphJSON []byte
+//
+//	cfg, err := config.NewGraphConfigFromJSON(graphJSON)
+//
 // # Boolean Fields with Non-False Defaults
 //
 // For boolean fields where the default is true (e.g., ParallelConfig.FailFast),
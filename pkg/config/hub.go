@@ -3,28 +3,68 @@ package config
 import (
 	"log/slog"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 // HubConfig defines configuration for a Hub instance.
 type HubConfig struct {
 	// Hub identity
-	Name string
+	Name string `json:"name"`
 
 	// Communication settings
-	ChannelBufferSize int
-	DefaultTimeout    time.Duration
+	ChannelBufferSize         int           `json:"channel_buffer_size"`
+	PriorityChannelBufferSize int           `json:"priority_channel_buffer_size"`
+	TopicBufferSize           int           `json:"topic_buffer_size"`
+	DefaultTimeout            time.Duration `json:"default_timeout"`
+	DeadLetterBufferSize      int           `json:"dead_letter_buffer_size"`
+
+	// Message expiry applied to messages without ExpiresAt, measured from the
+	// message Timestamp: zero DefaultMessageTTL means messages never expire
+	DefaultMessageTTL time.Duration `json:"default_message_ttl"`
+
+	// Flow control: zero PerAgentRateLimit means unlimited
+	PerAgentRateLimit rate.Limit `json:"per_agent_rate_limit"`
+	PerAgentRateBurst int        `json:"per_agent_rate_burst"`
+
+	// Handler retry for errors implementing Retryable() bool
+	HandlerRetry RetryPolicy `json:"handler_retry"`
+
+	// Deduplication drops messages an agent has already processed, tracking
+	// the most recent DeduplicationWindowSize message IDs per hub
+	EnableDeduplication     bool `json:"enable_deduplication"`
+	DeduplicationWindowSize int  `json:"deduplication_window_size"`
+
+	// Liveness probes registered with RegisterWithHealthCheck run every
+	// HealthCheckInterval; each probe must finish within the interval
+	HealthCheckInterval time.Duration `json:"health_check_interval"`
 
 	// Observability
-	Logger *slog.Logger
+	Logger   *slog.Logger `json:"-"`
+	Observer string       `json:"observer"`
+
+	// AuditLog names the hub audit log: "" disables auditing, "memory" gives
+	// the hub its own in-memory log, and other names resolve logs registered
+	// with hub.RegisterAuditLog
+	AuditLog string `json:"audit_log,omitempty"`
 }
 
 // DefaultHubConfig returns a HubConfig with sensible defaults.
 func DefaultHubConfig() HubConfig {
 	return HubConfig{
-		Name:              "default",
-		ChannelBufferSize: 100,
-		DefaultTimeout:    30 * time.Second,
-		Logger:            slog.Default(),
+		Name:                      "default",
+		ChannelBufferSize:         100,
+		PriorityChannelBufferSize: 10,
+		TopicBufferSize:           100,
+		DefaultTimeout:            30 * time.Second,
+		DeadLetterBufferSize:      100,
+		PerAgentRateLimit:         rate.Inf,
+		PerAgentRateBurst:         1,
+		HandlerRetry:              DefaultRetryPolicy(),
+		DeduplicationWindowSize:   1000,
+		HealthCheckInterval:       30 * time.Second,
+		Logger:                    slog.Default(),
+		Observer:                  "noop",
 	}
 }
 
@@ -37,11 +77,57 @@ func (c *HubConfig) Merge(source *HubConfig) {
 		c.ChannelBufferSize = source.ChannelBufferSize
 	}
 
+	if source.TopicBufferSize > 0 {
+		c.TopicBufferSize = source.TopicBufferSize
+	}
+
 	if source.DefaultTimeout > 0 {
 		c.DefaultTimeout = source.DefaultTimeout
 	}
 
+	if source.DeadLetterBufferSize > 0 {
+		c.DeadLetterBufferSize = source.DeadLetterBufferSize
+	}
+
+	if source.DefaultMessageTTL > 0 {
+		c.DefaultMessageTTL = source.DefaultMessageTTL
+	}
+
+	if source.PriorityChannelBufferSize > 0 {
+		c.PriorityChannelBufferSize = source.PriorityChannelBufferSize
+	}
+
+	if source.PerAgentRateLimit > 0 {
+		c.PerAgentRateLimit = source.PerAgentRateLimit
+	}
+
+	if source.PerAgentRateBurst > 0 {
+		c.PerAgentRateBurst = source.PerAgentRateBurst
+	}
+
+	c.HandlerRetry.Merge(&source.HandlerRetry)
+
+	if source.EnableDeduplication {
+		c.EnableDeduplication = source.EnableDeduplication
+	}
+
+	if source.DeduplicationWindowSize > 0 {
+		c.DeduplicationWindowSize = source.DeduplicationWindowSize
+	}
+
+	if source.HealthCheckInterval > 0 {
+		c.HealthCheckInterval = source.HealthCheckInterval
+	}
+
 	if source.Logger != nil {
 		c.Logger = source.Logger
 	}
+
+	if source.Observer != "" {
+		c.Observer = source.Observer
+	}
+
+	if source.AuditLog != "" {
+		c.AuditLog = source.AuditLog
+	}
 }
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NewHubConfigFromJSON creates a HubConfig from JSON, starting from
+// DefaultHubConfig so fields absent from the JSON keep their defaults.
+//
+// Duration fields (default_timeout, default_message_ttl,
+// health_check_interval, and the handler_retry backoffs) accept either a Go
+// duration string such as "30s" or an integer count of nanoseconds.
+//
+// Logger is not configurable through JSON and remains slog.Default().
+//
+// Returns an error if the JSON is malformed or the resulting configuration is
+// invalid (negative buffer sizes, or a non-positive DefaultTimeout).
+//
+// Example:
+//
+//	//go:embed hub.json
+//	var hubJSON []byte
+//
+//	cfg, err := config.NewHubConfigFromJSON(hubJSON)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	h := hub.New(ctx, cfg)
+func NewHubConfigFromJSON(data []byte) (HubConfig, error) {
+	cfg := DefaultHubConfig()
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return HubConfig{}, fmt.Errorf("failed to parse hub config: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return HubConfig{}, fmt.Errorf("invalid hub config: %w", err)
+	}
+	return cfg, nil
+}
+
+// NewGraphConfigFromJSON creates a GraphConfig from JSON, starting from
+// DefaultGraphConfig so fields absent from the JSON keep their defaults.
+// Nested checkpoint settings inherit defaults the same way. execution_timeout
+// accepts either a Go duration string such as "5m" or an integer count of
+// nanoseconds.
+//
+// Returns an error if the JSON is malformed or the resulting configuration is
+// invalid (missing name, or a non-positive max_iterations).
+//
+// Example:
+//
+//	cfg, err := config.NewGraphConfigFromJSON([]byte(`{"name": "review", "execution_timeout": "5m", "checkpoint": {"interval": 1}}`))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	graph, err := state.NewGraph(cfg)
+func NewGraphConfigFromJSON(data []byte) (GraphConfig, error) {
+	cfg := DefaultGraphConfig("")
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return GraphConfig{}, fmt.Errorf("failed to parse graph config: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return GraphConfig{}, fmt.Errorf("invalid graph config: %w", err)
+	}
+	return cfg, nil
+}
+
+// UnmarshalJSON decodes a HubConfig, accepting duration strings for its
+// duration fields. Fields absent from data are left unchanged.
+func (c *HubConfig) UnmarshalJSON(data []byte) error {
+	type plain HubConfig
+	aux := struct {
+		*plain
+		DefaultTimeout      *jsonDuration `json:"default_timeout"`
+		DefaultMessageTTL   *jsonDuration `json:"default_message_ttl"`
+		HealthCheckInterval *jsonDuration `json:"health_check_interval"`
+	}{
+		plain:               (*plain)(c),
+		DefaultTimeout:      (*jsonDuration)(&c.DefaultTimeout),
+		DefaultMessageTTL:   (*jsonDuration)(&c.DefaultMessageTTL),
+		HealthCheckInterval: (*jsonDuration)(&c.HealthCheckInterval),
+	}
+	return json.Unmarshal(data, &aux)
+}
+
+// UnmarshalJSON decodes a GraphConfig, accepting a duration string for
+// execution_timeout. Fields absent from data are left unchanged.
+func (c *GraphConfig) UnmarshalJSON(data []byte) error {
+	type plain GraphConfig
+	aux := struct {
+		*plain
+		ExecutionTimeout *jsonDuration `json:"execution_timeout"`
+	}{
+		plain:            (*plain)(c),
+		ExecutionTimeout: (*jsonDuration)(&c.ExecutionTimeout),
+	}
+	return json.Unmarshal(data, &aux)
+}
+
+// UnmarshalJSON decodes a RetryPolicy, accepting duration strings for its
+// backoffs. Fields absent from data are left unchanged.
+func (p *RetryPolicy) UnmarshalJSON(data []byte) error {
+	type plain RetryPolicy
+	aux := struct {
+		*plain
+		InitialBackoff *jsonDuration `json:"initial_backoff"`
+		MaxBackoff     *jsonDuration `json:"max_backoff"`
+	}{
+		plain:          (*plain)(p),
+		InitialBackoff: (*jsonDuration)(&p.InitialBackoff),
+		MaxBackoff:     (*jsonDuration)(&p.MaxBackoff),
+	}
+	return json.Unmarshal(data, &aux)
+}
+
+// jsonDuration decodes a time.Duration from a Go duration string ("30s") or
+// an integer count of nanoseconds.
+type jsonDuration time.Duration
+
+func (d *jsonDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		*d = jsonDuration(parsed)
+		return nil
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\" or an integer count of nanoseconds: %s", data)
+	}
+	*d = jsonDuration(n)
+	return nil
+}
+
+func (c *HubConfig) validate() error {
+	sizes := []struct {
+		name  string
+		value int
+	}{
+		{"channel_buffer_size", c.ChannelBufferSize},
+		{"priority_channel_buffer_size", c.PriorityChannelBufferSize},
+		{"topic_buffer_size", c.TopicBufferSize},
+		{"dead_letter_buffer_size", c.DeadLetterBufferSize},
+		{"deduplication_window_size", c.DeduplicationWindowSize},
+	}
+	for _, size := range sizes {
+		if size.value < 0 {
+			return fmt.Errorf("%s cannot be negative: %d", size.name, size.value)
+		}
+	}
+
+	if c.DefaultTimeout <= 0 {
+		return fmt.Errorf("default_timeout must be positive: %v", c.DefaultTimeout)
+	}
+	if c.DefaultMessageTTL < 0 {
+		return fmt.Errorf("default_message_ttl cannot be negative: %v", c.DefaultMessageTTL)
+	}
+	if c.HealthCheckInterval < 0 {
+		return fmt.Errorf("health_check_interval cannot be negative: %v", c.HealthCheckInterval)
+	}
+	return nil
+}
+
+func (c *GraphConfig) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if c.MaxIterations <= 0 {
+		return fmt.Errorf("max_iterations must be positive: %d", c.MaxIterations)
+	}
+	if c.ExecutionTimeout < 0 {
+		return fmt.Errorf("execution_timeout cannot be negative: %v", c.ExecutionTimeout)
+	}
+	if c.Checkpoint.Interval < 0 {
+		return fmt.Errorf("checkpoint.interval cannot be negative: %d", c.Checkpoint.Interval)
+	}
+	if c.Checkpoint.MaxVersions < 0 {
+		return fmt.Errorf("checkpoint.max_versions cannot be negative: %d", c.Checkpoint.MaxVersions)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import "time"
+
+// RetryPolicy defines retry behavior with exponential backoff.
+//
+// The delay before retry N (1-based) is InitialBackoff * Multiplier^(N-1), capped
+// at MaxBackoff. Consumers may add jitter on top of the computed delay.
+//
+// Backoffs decode from JSON as Go duration strings or integer nanoseconds.
+//
+// Example JSON:
+//
+//	{
+//	  "max_attempts": 3,
+//	  "initial_backoff": "100ms",
+//	  "max_backoff": "5s",
+//	  "multiplier": 2
+//	}
+type RetryPolicy struct {
+	// MaxAttempts is the number of retries after the initial failure (0 = no retry)
+	MaxAttempts int `json:"max_attempts"`
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration `json:"initial_backoff"`
+
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration `json:"max_backoff"`
+
+	// Multiplier scales the delay after each retry
+	Multiplier float64 `json:"multiplier"`
+}
+
+// DefaultRetryPolicy returns a policy with retries disabled.
+//
+// Default values:
+//   - MaxAttempts: 0 (no retry)
+//   - InitialBackoff: 100ms
+//   - MaxBackoff: 5s
+//   - Multiplier: 2
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    0,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// Backoff returns the delay before the given 1-based retry attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for range attempt - 1 {
+		delay *= max(p.Multiplier, 1)
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(delay)
+}
+
+func (p *RetryPolicy) Merge(source *RetryPolicy) {
+	if source.MaxAttempts > 0 {
+		p.MaxAttempts = source.MaxAttempts
+	}
+
+	if source.InitialBackoff > 0 {
+		p.InitialBackoff = source.InitialBackoff
+	}
+
+	if source.MaxBackoff > 0 {
+		p.MaxBackoff = source.MaxBackoff
+	}
+
+	if source.Multiplier > 0 {
+		p.Multiplier = source.Multiplier
+	}
+}
@@ -1,11 +1,25 @@
 package config
 
+import (
+	"maps"
+	"time"
+)
+
 // CheckpointConfig controls workflow state persistence during graph execution.
 //
 // Configuration fields:
 //   - Store: Name of CheckpointStore implementation to use (resolved via registry)
 //   - Interval: Save checkpoint every N node executions (0 = disabled)
 //   - Preserve: Keep checkpoints after successful completion (false = auto-cleanup)
+//   - Codec: Serialization format for stores that persist bytes ("json" or "gob")
+//   - Compression: Compressor applied to encoded checkpoints ("" = none, "gzip")
+//   - Dir: Directory for the "file" store
+//   - MaxVersions: Checkpoint versions retained per run by versioned stores
+//   - Options: Store-specific settings passed to the store's factory
+//   - Async: Save checkpoints on a background worker instead of between nodes
+//   - AsyncQueueSize: Saves waiting for the async worker before Overflow applies
+//   - AsyncOverflow: Behavior when the async queue is full ("block" or "drop_oldest")
+//   - OnAsyncError: Callback receiving async save failures
 //
 // Example enabling checkpointing:
 //
@@ -22,6 +36,39 @@ type CheckpointConfig struct {
 
 	// Preserve keeps checkpoints after successful execution (false = auto-cleanup)
 	Preserve bool `json:"preserve"`
+
+	// Codec names the state serialization format (resolved via registry)
+	Codec string `json:"codec,omitempty"`
+
+	// Compression names the compressor applied to encoded checkpoints
+	// (resolved via registry; empty disables compression)
+	Compression string `json:"compression,omitempty"`
+
+	// Dir is the checkpoint directory when Store is "file"
+	Dir string `json:"dir,omitempty"`
+
+	// MaxVersions caps the checkpoint versions kept per run when the store
+	// supports versioning, discarding the oldest (1 = latest only)
+	MaxVersions int `json:"max_versions,omitempty"`
+
+	// Options holds store-specific settings passed to the store factory
+	// (e.g. {"dir": "/var/lib/checkpoints"} for "file")
+	Options map[string]any `json:"options,omitempty"`
+
+	// Async queues checkpoint saves to a background worker so execution
+	// continues without waiting on the store; queued saves are flushed
+	// before the run returns
+	Async bool `json:"async,omitempty"`
+
+	// AsyncQueueSize bounds the saves waiting for the async worker
+	AsyncQueueSize int `json:"async_queue_size,omitempty"`
+
+	// AsyncOverflow selects the behavior when the async queue is full:
+	// "block" waits for room, "drop_oldest" discards the oldest queued save
+	AsyncOverflow string `json:"async_overflow,omitempty"`
+
+	// OnAsyncError receives async save failures, which do not fail the run
+	OnAsyncError func(runID string, err error) `json:"-"`
 }
 
 // DefaultCheckpointConfig returns checkpoint configuration with checkpointing disabled.
@@ -30,11 +77,20 @@ type CheckpointConfig struct {
 //   - Store: "memory" (though unused when Interval=0)
 //   - Interval: 0 (checkpointing disabled)
 //   - Preserve: false (auto-cleanup)
+//   - Codec: "json"
+//   - MaxVersions: 1 (no checkpoint history)
+//   - Async: false (saves complete before the next node runs)
+//   - AsyncQueueSize: 16
+//   - AsyncOverflow: "block"
 func DefaultCheckpointConfig() CheckpointConfig {
 	return CheckpointConfig{
-		Store:    "memory",
-		Interval: 0,
-		Preserve: false,
+		Store:          "memory",
+		Interval:       0,
+		Preserve:       false,
+		Codec:          "json",
+		MaxVersions:    1,
+		AsyncQueueSize: 16,
+		AsyncOverflow:  "block",
 	}
 }
 
@@ -50,13 +106,53 @@ func (c *CheckpointConfig) Merge(source *CheckpointConfig) {
 	if source.Preserve {
 		c.Preserve = source.Preserve
 	}
+
+	if source.Codec != "" {
+		c.Codec = source.Codec
+	}
+
+	if source.Compression != "" {
+		c.Compression = source.Compression
+	}
+
+	if source.Dir != "" {
+		c.Dir = source.Dir
+	}
+
+	if source.MaxVersions > 0 {
+		c.MaxVersions = source.MaxVersions
+	}
+
+	if len(source.Options) > 0 {
+		options := make(map[string]any, len(c.Options)+len(source.Options))
+		maps.Copy(options, c.Options)
+		maps.Copy(options, source.Options)
+		c.Options = options
+	}
+
+	if source.Async {
+		c.Async = source.Async
+	}
+
+	if source.AsyncQueueSize > 0 {
+		c.AsyncQueueSize = source.AsyncQueueSize
+	}
+
+	if source.AsyncOverflow != "" {
+		c.AsyncOverflow = source.AsyncOverflow
+	}
+
+	if source.OnAsyncError != nil {
+		c.OnAsyncError = source.OnAsyncError
+	}
 }
 
 // GraphConfig defines configuration for state graph execution.
 //
 // This configuration follows the go-agents pattern: used only during initialization,
-// then transformed into domain objects. The Observer and Checkpoint.Store fields
-// are strings to enable JSON configuration with runtime resolution via registries.
+// then transformed into domain objects. The Observer, Checkpoint.Store, and Schema
+// fields are strings to enable JSON configuration with runtime resolution via
+// registries.
 //
 // Example JSON:
 //
@@ -67,8 +163,24 @@ func (c *CheckpointConfig) Merge(source *CheckpointConfig) {
 //	  "checkpoint": {
 //	    "store": "memory",
 //	    "interval": 10,
-//	    "preserve": false
-//	  }
+//	    "preserve": false,
+//	    "codec": "json",
+//	    "max_versions": 1,
+//	    "options": {},
+//	    "async": false,
+//	    "async_queue_size": 16,
+//	    "async_overflow": "block"
+//	  },
+//	  "acyclic": false,
+//	  "deep_clone": false,
+//	  "node_diff": false,
+//	  "track_history": false,
+//	  "track_provenance": false,
+//	  "max_history": 100,
+//	  "schema": "",
+//	  "sensitive_keys": ["api_key", "customer.email"],
+//	  "enable_audit_log": false,
+//	  "require_compile": false
 //	}
 //
 // Example resolution:
@@ -86,8 +198,43 @@ type GraphConfig struct {
 	// MaxIterations limits graph execution to prevent infinite loops
 	MaxIterations int `json:"max_iterations"`
 
+	// ExecutionTimeout bounds the wall-clock time of an entire graph run (0 = no limit)
+	ExecutionTimeout time.Duration `json:"execution_timeout,omitempty"`
+
 	// Checkpoint configures workflow state persistence and recovery
 	Checkpoint CheckpointConfig `json:"checkpoint"`
+
+	// Acyclic rejects graphs containing cycles at validation time
+	Acyclic bool `json:"acyclic"`
+
+	// DeepClone hands each node a deep copy of the state (see State.CloneDeep)
+	DeepClone bool `json:"deep_clone"`
+
+	// NodeDiff includes a state diff summary in NodeComplete events (see state.Diff)
+	NodeDiff bool `json:"node_diff"`
+
+	// TrackHistory records a state snapshot after every node (see state.StateHistory)
+	TrackHistory bool `json:"track_history"`
+
+	// TrackProvenance records which node last wrote each key (see State.Provenance)
+	TrackProvenance bool `json:"track_provenance"`
+
+	// MaxHistory caps retained history entries, discarding the oldest (0 = unlimited)
+	MaxHistory int `json:"max_history"`
+
+	// Schema names a registered state.Schema used to validate state after every node ("" = disabled)
+	Schema string `json:"schema"`
+
+	// SensitiveKeys lists state keys whose values are redacted from events, history, and checkpoints
+	SensitiveKeys []string `json:"sensitive_keys,omitempty"`
+
+	// EnableAuditLog records execution traces for StateGraph.ExportAuditLog and
+	// ExportAuditLogFor
+	EnableAuditLog bool `json:"enable_audit_log"`
+
+	// RequireCompile makes execution fail until StateGraph.Compile is called
+	// instead of compiling on the first run
+	RequireCompile bool `json:"require_compile"`
 }
 
 // DefaultGraphConfig returns sensible defaults for graph execution.
@@ -96,12 +243,14 @@ type GraphConfig struct {
 //   - Observer: "slog" for structured logging
 //   - MaxIterations: 1000 to protect against infinite loops
 //   - Checkpoint: Disabled (Interval=0) for zero-overhead execution
+//   - MaxHistory: 100 entries when TrackHistory is enabled
 func DefaultGraphConfig(name string) GraphConfig {
 	return GraphConfig{
 		Name:          name,
 		Observer:      "slog",
 		MaxIterations: 1000,
 		Checkpoint:    DefaultCheckpointConfig(),
+		MaxHistory:    100,
 	}
 }
 
@@ -118,5 +267,49 @@ func (c *GraphConfig) Merge(source *GraphConfig) {
 		c.MaxIterations = source.MaxIterations
 	}
 
+	if source.ExecutionTimeout > 0 {
+		c.ExecutionTimeout = source.ExecutionTimeout
+	}
+
 	c.Checkpoint.Merge(&source.Checkpoint)
+
+	if source.Acyclic {
+		c.Acyclic = source.Acyclic
+	}
+
+	if source.DeepClone {
+		c.DeepClone = source.DeepClone
+	}
+
+	if source.NodeDiff {
+		c.NodeDiff = source.NodeDiff
+	}
+
+	if source.TrackHistory {
+		c.TrackHistory = source.TrackHistory
+	}
+
+	if source.TrackProvenance {
+		c.TrackProvenance = source.TrackProvenance
+	}
+
+	if source.MaxHistory > 0 {
+		c.MaxHistory = source.MaxHistory
+	}
+
+	if source.Schema != "" {
+		c.Schema = source.Schema
+	}
+
+	if len(source.SensitiveKeys) > 0 {
+		c.SensitiveKeys = source.SensitiveKeys
+	}
+
+	if source.EnableAuditLog {
+		c.EnableAuditLog = source.EnableAuditLog
+	}
+
+	if source.RequireCompile {
+		c.RequireCompile = source.RequireCompile
+	}
 }
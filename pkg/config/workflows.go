@@ -1,5 +1,11 @@
 package config
 
+import (
+	"runtime"
+	"slices"
+	"time"
+)
+
 // ChainConfig defines configuration for sequential chain execution.
 //
 // This configuration follows the go-agents pattern: used only during initialization,
@@ -10,7 +16,9 @@ package config
 //
 //	{
 //	  "capture_intermediate_states": true,
-//	  "observer": "slog"
+//	  "max_concurrency": 4,
+//	  "observer": "slog",
+//	  "step_names": ["extract", "classify", "summarize"]
 //	}
 //
 // Example usage:
@@ -24,17 +32,27 @@ type ChainConfig struct {
 	// When false, only final state is returned.
 	CaptureIntermediateStates bool `json:"capture_intermediate_states"`
 
+	// MaxConcurrency bounds how many items are processed at once (0 or 1 = sequential).
+	// Concurrent chains require the context type to implement workflows.Merger.
+	MaxConcurrency int `json:"max_concurrency"`
+
 	// Observer specifies which observer implementation to use ("noop", "slog", etc.)
 	Observer string `json:"observer"`
+
+	// StepNames labels steps by index for error messages. Steps beyond the end
+	// of the list, or with an empty name, are identified by index only.
+	StepNames []string `json:"step_names,omitempty"`
 }
 
 // DefaultChainConfig returns sensible defaults for chain execution.
 //
 // Uses "noop" observer for zero-overhead execution when observability not needed.
 // Intermediate state capture is disabled by default to minimize memory usage.
+// Items are processed sequentially by default.
 func DefaultChainConfig() ChainConfig {
 	return ChainConfig{
 		CaptureIntermediateStates: false,
+		MaxConcurrency:            0,
 		Observer:                  "slog",
 	}
 }
@@ -44,9 +62,58 @@ func (c *ChainConfig) Merge(source *ChainConfig) {
 		c.CaptureIntermediateStates = source.CaptureIntermediateStates
 	}
 
+	if source.MaxConcurrency > 0 {
+		c.MaxConcurrency = source.MaxConcurrency
+	}
+
 	if source.Observer != "" {
 		c.Observer = source.Observer
 	}
+
+	if len(source.StepNames) > 0 {
+		c.StepNames = slices.Clone(source.StepNames)
+	}
+}
+
+// WithStepNames returns a copy of the configuration with StepNames set to names.
+//
+// Example:
+//
+//	cfg := config.DefaultChainConfig().WithStepNames("extract", "classify", "summarize")
+func (c ChainConfig) WithStepNames(names ...string) ChainConfig {
+	c.StepNames = slices.Clone(names)
+	return c
+}
+
+// ResumableChainConfig configures a chain that checkpoints its progress so a
+// failed run can continue where it stopped.
+//
+// Example JSON:
+//
+//	{
+//	  "observer": "slog",
+//	  "step_names": ["extract", "classify", "summarize"],
+//	  "run_id": "ingest-2026-10-15"
+//	}
+//
+// Example usage:
+//
+//	cfg := config.ResumableChainConfig{ChainConfig: config.DefaultChainConfig(), RunID: batchID}
+//	result, err := workflows.ProcessChainResumable(ctx, cfg, items, initial, processor, store)
+type ResumableChainConfig struct {
+	ChainConfig
+
+	// RunID identifies the chain's checkpoint in the store. Invocations with the
+	// same RunID resume from the last completed step.
+	RunID string `json:"run_id"`
+}
+
+func (c *ResumableChainConfig) Merge(source *ResumableChainConfig) {
+	c.ChainConfig.Merge(&source.ChainConfig)
+
+	if source.RunID != "" {
+		c.RunID = source.RunID
+	}
 }
 
 // ParallelConfig defines configuration for parallel execution pattern.
@@ -140,6 +207,66 @@ func (c *ParallelConfig) Merge(source *ParallelConfig) {
 	}
 }
 
+// WorkerPoolConfig defines configuration for a reusable worker pool
+// (see workflows.NewWorkerPool).
+//
+// Example JSON:
+//
+//	{
+//	  "workers": 8,
+//	  "queue_depth": 100,
+//	  "idle_timeout": 30000000000,
+//	  "max_queue_wait": 0
+//	}
+type WorkerPoolConfig struct {
+	// Workers caps the worker goroutines running at once
+	Workers int `json:"workers"`
+
+	// QueueDepth bounds the tasks waiting for a free worker
+	QueueDepth int `json:"queue_depth"`
+
+	// IdleTimeout retires workers left idle this long (0 = keep until Close)
+	IdleTimeout time.Duration `json:"idle_timeout"`
+
+	// MaxQueueWait bounds how long a submission waits for queue room
+	// (0 = wait until its context ends)
+	MaxQueueWait time.Duration `json:"max_queue_wait"`
+}
+
+// DefaultWorkerPoolConfig returns sensible defaults for a shared worker pool.
+//
+// Default configuration:
+//   - Workers: min(NumCPU*2, 16), matching ProcessParallel's auto-detection
+//   - QueueDepth: 100
+//   - IdleTimeout: 30s (idle workers exit and are restarted on demand)
+//   - MaxQueueWait: 0 (submissions wait for room until their context ends)
+func DefaultWorkerPoolConfig() WorkerPoolConfig {
+	return WorkerPoolConfig{
+		Workers:      min(runtime.NumCPU()*2, 16),
+		QueueDepth:   100,
+		IdleTimeout:  30 * time.Second,
+		MaxQueueWait: 0,
+	}
+}
+
+func (c *WorkerPoolConfig) Merge(source *WorkerPoolConfig) {
+	if source.Workers > 0 {
+		c.Workers = source.Workers
+	}
+
+	if source.QueueDepth > 0 {
+		c.QueueDepth = source.QueueDepth
+	}
+
+	if source.IdleTimeout > 0 {
+		c.IdleTimeout = source.IdleTimeout
+	}
+
+	if source.MaxQueueWait > 0 {
+		c.MaxQueueWait = source.MaxQueueWait
+	}
+}
+
 type ConditionalConfig struct {
 	Observer string `json:"observer"`
 }
@@ -155,3 +282,118 @@ func (c *ConditionalConfig) Merge(source *ConditionalConfig) {
 		c.Observer = source.Observer
 	}
 }
+
+// ScatterGatherConfig defines configuration for the scatter-gather pattern.
+//
+// Scatter-gather distributes a single input to multiple processors concurrently
+// and merges their results. Worker sizing and error handling follow the same
+// semantics as ParallelConfig.
+//
+// Error Handling:
+//   - FailFast = true: Stop on first processor error, gather is not called
+//   - FailFast = false: Gather receives successful results, error only if all processors failed
+//
+// Example JSON:
+//
+//	{
+//	  "max_workers": 3,
+//	  "fail_fast": false,
+//	  "observer": "slog"
+//	}
+type ScatterGatherConfig struct {
+	// MaxWorkers specifies exact worker pool size (0 = one worker per processor)
+	MaxWorkers int `json:"max_workers"`
+
+	// FailFastNil controls error handling behavior. Use FailFast() method to access.
+	// When nil, defaults to true. Use pointer to distinguish unset from explicit false.
+	FailFastNil *bool `json:"fail_fast"`
+
+	// Observer specifies which observer implementation to use ("noop", "slog", etc.)
+	Observer string `json:"observer"`
+}
+
+func (c *ScatterGatherConfig) FailFast() bool {
+	if c.FailFastNil == nil {
+		return true
+	}
+	return *c.FailFastNil
+}
+
+// DefaultScatterGatherConfig returns sensible defaults for scatter-gather execution.
+//
+// Default configuration:
+//   - MaxWorkers: 0 (one worker per processor)
+//   - FailFast: true (all-or-nothing gathering)
+//   - Observer: "slog"
+func DefaultScatterGatherConfig() ScatterGatherConfig {
+	failFast := true
+	return ScatterGatherConfig{
+		MaxWorkers:  0,
+		FailFastNil: &failFast,
+		Observer:    "slog",
+	}
+}
+
+func (c *ScatterGatherConfig) Merge(source *ScatterGatherConfig) {
+	if source.MaxWorkers > 0 {
+		c.MaxWorkers = source.MaxWorkers
+	}
+
+	if source.FailFastNil != nil {
+		c.FailFastNil = source.FailFastNil
+	}
+
+	if source.Observer != "" {
+		c.Observer = source.Observer
+	}
+}
+
+// SagaConfig defines configuration for saga execution with compensating steps.
+//
+// Example JSON:
+//
+//	{
+//	  "observer": "slog"
+//	}
+type SagaConfig struct {
+	// Observer specifies which observer implementation to use ("noop", "slog", etc.)
+	Observer string `json:"observer"`
+}
+
+// DefaultSagaConfig returns sensible defaults for saga execution.
+func DefaultSagaConfig() SagaConfig {
+	return SagaConfig{
+		Observer: "slog",
+	}
+}
+
+func (c *SagaConfig) Merge(source *SagaConfig) {
+	if source.Observer != "" {
+		c.Observer = source.Observer
+	}
+}
+
+// PipelineConfig defines configuration for pipeline stage composition.
+//
+// Example JSON:
+//
+//	{
+//	  "observer": "slog"
+//	}
+type PipelineConfig struct {
+	// Observer specifies which observer implementation to use ("noop", "slog", etc.)
+	Observer string `json:"observer"`
+}
+
+// DefaultPipelineConfig returns sensible defaults for pipeline execution.
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Observer: "slog",
+	}
+}
+
+func (c *PipelineConfig) Merge(source *PipelineConfig) {
+	if source.Observer != "" {
+		c.Observer = source.Observer
+	}
+}
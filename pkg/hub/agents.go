@@ -0,0 +1,172 @@
+package hub
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"golang.org/x/time/rate"
+)
+
+// AgentStatus describes whether a registered agent is receiving messages.
+type AgentStatus string
+
+const (
+	// AgentStatusActive agents receive messages normally.
+	AgentStatusActive AgentStatus = "active"
+
+	// AgentStatusDraining agents are finishing work before going away: the hub
+	// is shutting down and handling their queued messages, or handlers are
+	// still running against an agent implementation swapped out by Replace.
+	AgentStatusDraining AgentStatus = "draining"
+
+	// AgentStatusPaused agents hold their messages until resumed.
+	AgentStatusPaused AgentStatus = "paused"
+)
+
+// AgentInfo is a point-in-time snapshot of a registered agent.
+type AgentInfo struct {
+	ID            string
+	RegisteredAt  time.Time
+	MessageCount  int64
+	LastMessageAt time.Time
+	Status        AgentStatus
+	Capabilities  []string
+	Circuit       CircuitState
+	Metrics       AgentMetrics
+
+	// Version starts at 1 and increments each time Replace swaps the agent
+	Version int
+
+	// Healthy is false while the agent's liveness probe is failing; agents
+	// without a probe are always healthy and have a zero LastHealthCheck
+	Healthy         bool
+	LastHealthCheck time.Time
+}
+
+func (h *hub) ListAgents() []AgentInfo {
+	h.agentsMutex.RLock()
+	defer h.agentsMutex.RUnlock()
+
+	agents := make([]AgentInfo, 0, len(h.agents))
+	for agentID, reg := range h.agents {
+		healthy, lastCheck := reg.health.status()
+		status := reg.Status
+		if status == AgentStatusActive && reg.draining() {
+			status = AgentStatusDraining
+		}
+		agents = append(agents, AgentInfo{
+			ID:            agentID,
+			RegisteredAt:  reg.RegisteredAt,
+			MessageCount:  reg.MessageCount,
+			LastMessageAt: reg.LastMessageAt,
+			Status:        status,
+			Capabilities:  slices.Clone(reg.Capabilities),
+			Circuit:       reg.circuitState(),
+			Metrics:       reg.Stats.snapshot(),
+			Version:       reg.version(),
+
+			Healthy:         healthy,
+			LastHealthCheck: lastCheck,
+		})
+	}
+
+	slices.SortFunc(agents, func(a, b AgentInfo) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return agents
+}
+
+// Pause stops delivery to an agent without unregistering it. Messages sent while
+// paused are held in the agent's channels; once a channel fills, further sends
+// block until their context ends and broadcasts dead-letter as channel full.
+func (h *hub) Pause(agentID string) error {
+	err := h.setStatus(agentID, AgentStatusPaused, observability.EventHubAgentPause)
+	h.audit(AuditPause, agentID, "", "", err)
+	return err
+}
+
+// Resume re-enables delivery to a paused agent. Held messages are delivered
+// first, in the order they were sent.
+func (h *hub) Resume(agentID string) error {
+	err := h.setStatus(agentID, AgentStatusActive, observability.EventHubAgentResume)
+	h.audit(AuditResume, agentID, "", "", err)
+	return err
+}
+
+func (h *hub) setStatus(agentID string, status AgentStatus, eventType observability.EventType) error {
+	h.agentsMutex.Lock()
+	if h.IsShutdown() {
+		h.agentsMutex.Unlock()
+		return ErrHubShutdown
+	}
+	reg, exists := h.agents[agentID]
+	if !exists {
+		h.agentsMutex.Unlock()
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+	if reg.Status == status {
+		h.agentsMutex.Unlock()
+		return fmt.Errorf("agent %s already %s", agentID, status)
+	}
+	previous := reg.Status
+	reg.Status = status
+	held := reg.Channel.QueueLength() + reg.PriorityChannel.QueueLength()
+	h.agentsMutex.Unlock()
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":        h.name,
+			"agent_id":        agentID,
+			"previous_status": string(previous),
+			"held_messages":   held,
+		},
+	})
+
+	h.logger.DebugContext(
+		h.ctx,
+		"agent status changed",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", agentID),
+		slog.String("status", string(status)),
+	)
+
+	return nil
+}
+
+// SetAgentRateLimit replaces the delivery rate limit for an agent. Use rate.Inf
+// to remove the limit. Senders already waiting observe the new limit.
+func (h *hub) SetAgentRateLimit(agentID string, limit rate.Limit, burst int) error {
+	h.agentsMutex.RLock()
+	reg, exists := h.agents[agentID]
+	h.agentsMutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+
+	reg.Limiter.SetLimit(limit)
+	reg.Limiter.SetBurst(max(burst, 1))
+
+	return nil
+}
+
+func (h *hub) newLimiter() *rate.Limiter {
+	return rate.NewLimiter(h.rateLimit, max(h.rateBurst, 1))
+}
+
+func (h *hub) recordMessage(agentID string) {
+	h.agentsMutex.Lock()
+	if reg, exists := h.agents[agentID]; exists {
+		reg.MessageCount++
+		reg.LastMessageAt = time.Now()
+	}
+	h.agentsMutex.Unlock()
+}
@@ -0,0 +1,296 @@
+package hub
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Audit operations recorded in HubAuditEntry.Operation.
+const (
+	AuditRegister      = "register"
+	AuditUnregister    = "unregister"
+	AuditReplace       = "replace"
+	AuditPause         = "pause"
+	AuditResume        = "resume"
+	AuditSend          = "send"
+	AuditRequest       = "request"
+	AuditSendToCapable = "send_to_capable"
+	AuditBroadcast     = "broadcast"
+	AuditPublish       = "publish"
+	AuditSubscribe     = "subscribe"
+	AuditUnsubscribe   = "unsubscribe"
+	AuditShutdown      = "shutdown"
+)
+
+// HubAuditEntry records one hub operation.
+type HubAuditEntry struct {
+	// EventTime is when the operation completed
+	EventTime time.Time `json:"event_time"`
+
+	// Hub names the hub that performed the operation
+	Hub string `json:"hub"`
+
+	// AgentID is the agent acting: the sender of a message, or the agent
+	// registered, replaced, paused, resumed, or subscribed
+	AgentID string `json:"agent_id,omitempty"`
+
+	// Target is the destination agent or topic, when the operation has one
+	Target string `json:"target,omitempty"`
+
+	// MessageID identifies the message for messaging operations
+	MessageID string `json:"message_id,omitempty"`
+
+	// Operation is one of the Audit constants
+	Operation string `json:"operation"`
+
+	// Success reports whether the operation returned without error
+	Success bool `json:"success"`
+
+	// Error holds the operation's error message when Success is false
+	Error string `json:"error,omitempty"`
+}
+
+// AuditFilter selects audit entries in HubEventLog.Query. Zero fields match
+// every entry.
+type AuditFilter struct {
+	// Since excludes entries before this time
+	Since time.Time
+
+	// Until excludes entries at or after this time
+	Until time.Time
+
+	// AgentID matches entries where the agent is either AgentID or Target
+	AgentID string
+
+	// Operation matches entries with this operation
+	Operation string
+}
+
+// Matches reports whether entry passes the filter.
+func (f AuditFilter) Matches(entry HubAuditEntry) bool {
+	if !f.Since.IsZero() && entry.EventTime.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.EventTime.Before(f.Until) {
+		return false
+	}
+	if f.AgentID != "" && entry.AgentID != f.AgentID && entry.Target != f.AgentID {
+		return false
+	}
+	if f.Operation != "" && entry.Operation != f.Operation {
+		return false
+	}
+	return true
+}
+
+// HubEventLog is an append-only record of hub operations for compliance
+// auditing.
+//
+// The hub appends an entry after every registration, lifecycle, messaging, and
+// subscription operation, successful or not. Append failures are logged and do
+// not fail the operation. Implementations must be safe for concurrent use and
+// should never modify or drop appended entries.
+type HubEventLog interface {
+	// Append records entry.
+	Append(entry HubAuditEntry) error
+
+	// Query returns the entries matching filter in the order they were
+	// appended.
+	Query(filter AuditFilter) ([]HubAuditEntry, error)
+}
+
+// memoryAuditLog implements HubEventLog with an in-memory slice.
+type memoryAuditLog struct {
+	entries []HubAuditEntry
+	mu      sync.RWMutex
+}
+
+// NewMemoryAuditLog returns a HubEventLog held in memory.
+//
+// Entries are kept for the life of the process and never trimmed, so the
+// memory log suits tests and short-lived hubs; use NewFileAuditLog or a
+// custom HubEventLog for durable records.
+func NewMemoryAuditLog() HubEventLog {
+	return &memoryAuditLog{}
+}
+
+func (m *memoryAuditLog) Append(entry HubAuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memoryAuditLog) Query(filter AuditFilter) ([]HubAuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]HubAuditEntry, 0)
+	for _, entry := range m.entries {
+		if filter.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched, nil
+}
+
+// fileAuditLog implements HubEventLog as a newline-delimited JSON file.
+type fileAuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileAuditLog returns a HubEventLog appending one JSON object per line to
+// the file at path. The file is created with mode 0600 on the first Append and
+// opened in append mode for each entry, so no file handle is held between
+// operations and external log rotation is safe.
+//
+// Query reads the whole file; a missing file has no entries.
+func NewFileAuditLog(path string) HubEventLog {
+	return &fileAuditLog{path: path}
+}
+
+func (f *fileAuditLog) Append(entry HubAuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return file.Close()
+}
+
+func (f *fileAuditLog) Query(filter AuditFilter) ([]HubAuditEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	matched := make([]HubAuditEntry, 0)
+
+	file, err := os.Open(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return matched, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry HubAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode audit log line %d: %w", line, err)
+		}
+		if filter.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return matched, nil
+}
+
+// auditLogs is the global registry of named HubEventLog instances.
+var (
+	auditLogs      = make(map[string]HubEventLog)
+	auditLogsMutex sync.RWMutex
+)
+
+// RegisterAuditLog adds a named HubEventLog to the global registry so hubs
+// can select it with HubConfig.AuditLog. Hubs configured with the same name
+// share the log.
+//
+// Example:
+//
+//	hub.RegisterAuditLog("compliance", hub.NewFileAuditLog("/var/log/workflow/hub-audit.ndjson"))
+//
+//	cfg := config.DefaultHubConfig()
+//	cfg.AuditLog = "compliance"
+//	h := hub.New(ctx, cfg)
+func RegisterAuditLog(name string, log HubEventLog) {
+	auditLogsMutex.Lock()
+	defer auditLogsMutex.Unlock()
+
+	auditLogs[name] = log
+}
+
+// resolveAuditLog returns the audit log named by HubConfig.AuditLog: nil when
+// name is empty, a new memory log per hub for "memory", otherwise the
+// registered log.
+func resolveAuditLog(name string) (HubEventLog, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "memory":
+		return NewMemoryAuditLog(), nil
+	}
+
+	auditLogsMutex.RLock()
+	defer auditLogsMutex.RUnlock()
+
+	log, exists := auditLogs[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown audit log: %s", name)
+	}
+	return log, nil
+}
+
+// AuditLog returns the hub's audit log, or nil when auditing is disabled.
+func (h *hub) AuditLog() HubEventLog {
+	return h.auditLog
+}
+
+// audit appends an entry for a completed operation to the hub's audit log.
+func (h *hub) audit(operation, agentID, target, messageID string, err error) {
+	if h.auditLog == nil {
+		return
+	}
+
+	entry := HubAuditEntry{
+		EventTime: time.Now(),
+		Hub:       h.name,
+		AgentID:   agentID,
+		Target:    target,
+		MessageID: messageID,
+		Operation: operation,
+		Success:   err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if appendErr := h.auditLog.Append(entry); appendErr != nil {
+		h.logger.WarnContext(
+			h.ctx,
+			"failed to append audit entry",
+			slog.String("hub_name", h.name),
+			slog.String("operation", operation),
+			slog.String("error", appendErr.Error()),
+		)
+	}
+}
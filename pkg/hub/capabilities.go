@@ -0,0 +1,93 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+// SendToCapable routes a request to the least-loaded active agent advertising
+// capability and waits for its response.
+//
+// Load is the number of messages queued for the agent; ties go to the agent
+// that has handled the fewest messages, then to the lowest agent ID. The sender
+// (msg.From) and paused agents are never selected. The message is cloned and
+// sent as a request addressed to the selected agent, so handlers respond as
+// they would to Request.
+func (h *hub) SendToCapable(ctx context.Context, capability string, msg *messaging.Message) (response *messaging.Message, err error) {
+	target := ""
+	defer func() { h.audit(AuditSendToCapable, msg.From, target, msg.ID, err) }()
+
+	if h.IsShutdown() {
+		return nil, ErrHubShutdown
+	}
+
+	transformed, err := h.transformIncoming(msg)
+	if err != nil {
+		return nil, err
+	}
+	msg = transformed
+
+	reg := h.selectCapable(capability, msg.From)
+	if reg == nil {
+		h.deadLetter(msg, msg.To, DeadLetterNoCapableAgent)
+		return nil, messaging.WrapError(msg.ID, fmt.Errorf("no agent available with capability: %s", capability))
+	}
+
+	message := msg.Clone()
+	message.To = reg.ID
+	message.Type = messaging.MessageTypeRequest
+	target = reg.ID
+
+	h.logger.DebugContext(
+		ctx,
+		"routing to capable agent",
+		slog.String("hub_name", h.name),
+		slog.String("capability", capability),
+		slog.String("agent_id", message.To),
+		slog.String("message_id", message.ID),
+	)
+
+	return h.request(ctx, reg, message)
+}
+
+func (h *hub) selectCapable(capability, from string) *registration {
+	h.agentsMutex.RLock()
+	defer h.agentsMutex.RUnlock()
+
+	var selected *registration
+	var selectedLoad int
+	for agentID, reg := range h.agents {
+		if agentID == from || reg.Status != AgentStatusActive || !reg.isHealthy() {
+			continue
+		}
+		if !slices.Contains(reg.Capabilities, capability) {
+			continue
+		}
+
+		load := reg.Channel.QueueLength() + reg.PriorityChannel.QueueLength()
+		if selected == nil || load < selectedLoad ||
+			(load == selectedLoad && lessBusy(reg, selected)) {
+			selected = reg
+			selectedLoad = load
+		}
+	}
+
+	return selected
+}
+
+func lessBusy(a, b *registration) bool {
+	if a.MessageCount != b.MessageCount {
+		return a.MessageCount < b.MessageCount
+	}
+	return a.ID < b.ID
+}
+
+func normalizeCapabilities(capabilities []string) []string {
+	normalized := slices.Clone(capabilities)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
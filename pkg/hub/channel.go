@@ -31,6 +31,15 @@ func (mc *MessageChannel[T]) Send(ctx context.Context, message T) error {
 	}
 }
 
+func (mc *MessageChannel[T]) TrySend(message T) bool {
+	select {
+	case mc.channel <- message:
+		return true
+	default:
+		return false
+	}
+}
+
 func (mc *MessageChannel[T]) Receive(ctx context.Context) (T, error) {
 	select {
 	case message := <-mc.channel:
@@ -0,0 +1,187 @@
+package hub
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// circuitBreaker tracks handler outcomes for a single agent.
+type circuitBreaker struct {
+	cfg config.CircuitBreakerConfig
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	successes int
+	openedAt  time.Time
+}
+
+// allow reports whether a message may be delivered, moving an open circuit to
+// half-open once its timeout has elapsed. Returns the previous state when a
+// transition occurred.
+func (b *circuitBreaker) allow(now time.Time) (bool, CircuitState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitOpen {
+		return true, ""
+	}
+
+	if now.Sub(b.openedAt) < b.cfg.Timeout {
+		return false, ""
+	}
+
+	b.state = CircuitHalfOpen
+	b.successes = 0
+	return true, CircuitOpen
+}
+
+// record applies a handler outcome. Returns the previous state when a
+// transition occurred.
+func (b *circuitBreaker) record(success bool, now time.Time) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	previous := b.state
+
+	switch {
+	case success && b.state == CircuitHalfOpen:
+		b.successes++
+		if b.successes < b.cfg.HalfOpenAttempts {
+			return ""
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+	case success:
+		b.failures = 0
+		return ""
+	case b.state == CircuitHalfOpen:
+		b.state = CircuitOpen
+		b.openedAt = now
+	case b.state == CircuitClosed:
+		b.failures++
+		if b.failures < b.cfg.Threshold {
+			return ""
+		}
+		b.state = CircuitOpen
+		b.openedAt = now
+	default:
+		return ""
+	}
+
+	return previous
+}
+
+func (b *circuitBreaker) current() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// circuitState returns the breaker state, or empty when no breaker is installed.
+// Callers must hold agentsMutex.
+func (reg *registration) circuitState() CircuitState {
+	if reg.Breaker == nil {
+		return ""
+	}
+	return reg.Breaker.current()
+}
+
+// SetAgentCircuitBreaker installs a circuit breaker for an agent, replacing any
+// existing breaker and starting in the closed state.
+func (h *hub) SetAgentCircuitBreaker(agentID string, cfg config.CircuitBreakerConfig) error {
+	if cfg.Threshold < 1 || cfg.Timeout <= 0 || cfg.HalfOpenAttempts < 1 {
+		return fmt.Errorf("invalid circuit breaker config: threshold and half-open attempts must be positive and timeout greater than zero")
+	}
+
+	h.agentsMutex.Lock()
+	defer h.agentsMutex.Unlock()
+
+	reg, exists := h.agents[agentID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+
+	reg.Breaker = &circuitBreaker{
+		cfg:   cfg,
+		state: CircuitClosed,
+	}
+
+	return nil
+}
+
+func (h *hub) breakerFor(reg *registration) *circuitBreaker {
+	h.agentsMutex.RLock()
+	defer h.agentsMutex.RUnlock()
+	return reg.Breaker
+}
+
+// allowDelivery checks the agent's circuit breaker before delivery.
+func (h *hub) allowDelivery(reg *registration) bool {
+	breaker := h.breakerFor(reg)
+	if breaker == nil {
+		return true
+	}
+
+	allowed, previous := breaker.allow(time.Now())
+	if previous != "" {
+		h.circuitTransition(reg, previous, CircuitHalfOpen)
+	}
+	return allowed
+}
+
+// recordOutcome feeds a handler result into the agent's circuit breaker.
+func (h *hub) recordOutcome(reg *registration, err error) {
+	breaker := h.breakerFor(reg)
+	if breaker == nil {
+		return
+	}
+
+	if previous := breaker.record(err == nil, time.Now()); previous != "" {
+		h.circuitTransition(reg, previous, breaker.current())
+	}
+}
+
+func (h *hub) circuitTransition(reg *registration, from, to CircuitState) {
+	eventType := observability.EventHubCircuitClose
+	switch to {
+	case CircuitOpen:
+		eventType = observability.EventHubCircuitOpen
+	case CircuitHalfOpen:
+		eventType = observability.EventHubCircuitHalfOpen
+	}
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":       h.name,
+			"agent_id":       reg.ID,
+			"previous_state": string(from),
+			"state":          string(to),
+		},
+	})
+
+	h.logger.WarnContext(
+		h.ctx,
+		"circuit state changed",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", reg.ID),
+		slog.String("previous_state", string(from)),
+		slog.String("state", string(to)),
+	)
+}
@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+const (
+	DeadLetterAgentNotFound  = "agent not found"
+	DeadLetterChannelFull    = "channel full"
+	DeadLetterTimeout        = "timeout"
+	DeadLetterRateLimited    = "rate limited"
+	DeadLetterHandlerFailed  = "handler failed"
+	DeadLetterRetryExhausted = "retries exhausted"
+	DeadLetterDeliveryFailed = "delivery failed"
+	DeadLetterNoCapableAgent = "no capable agent"
+	DeadLetterCircuitOpen    = "circuit open"
+	DeadLetterExpired        = "expired"
+	DeadLetterUnhealthy      = "agent unhealthy"
+
+	// DeadLetterTransformFailed prefixes the reason of messages rejected by a
+	// MessageTransformer; the transformer's error follows after ": ".
+	DeadLetterTransformFailed = "transform failed"
+)
+
+// DeadLetter records a message the hub could not deliver.
+type DeadLetter struct {
+	Message     *messaging.Message
+	Reason      string
+	FailedAt    time.Time
+	TargetAgent string
+}
+
+func (h *hub) DeadLetterQueue() <-chan DeadLetter {
+	return h.deadLetters
+}
+
+// deadLetter queues an undeliverable message without blocking. When the queue
+// is full the dead letter is dropped and logged. Dead letters are counted in
+// metrics either way. Callers must not hold agentsMutex.
+func (h *hub) deadLetter(message *messaging.Message, target, reason string) {
+	h.metrics.RecordDeadLetter()
+
+	h.agentsMutex.RLock()
+	if reg, exists := h.agents[target]; exists {
+		reg.Stats.deadLettered.Add(1)
+	}
+	h.agentsMutex.RUnlock()
+
+	letter := DeadLetter{
+		Message:     message,
+		Reason:      reason,
+		FailedAt:    time.Now(),
+		TargetAgent: target,
+	}
+
+	select {
+	case h.deadLetters <- letter:
+	default:
+		h.logger.WarnContext(
+			h.ctx,
+			"dead letter queue full, dropping message",
+			slog.String("hub_name", h.name),
+			slog.String("message_id", message.ID),
+			slog.String("target", target),
+			slog.String("reason", reason),
+		)
+	}
+}
+
+// deliveryFailureReason maps a channel send error to a dead letter reason.
+func deliveryFailureReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return DeadLetterTimeout
+	}
+	return DeadLetterDeliveryFailed
+}
@@ -0,0 +1,82 @@
+package hub
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// dedupKey identifies a delivery. Broadcast and publish copies share the
+// original message ID, so the recipient is part of the key.
+type dedupKey struct {
+	agentID   string
+	messageID string
+}
+
+// dedupWindow is a fixed-size LRU set of recently processed deliveries.
+type dedupWindow struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[dedupKey]*list.Element
+}
+
+func newDedupWindow(size int) *dedupWindow {
+	return &dedupWindow{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[dedupKey]*list.Element),
+	}
+}
+
+// seen reports whether key is already in the window, recording it if not.
+// Repeated keys are refreshed as most recently used.
+func (w *dedupWindow) seen(key dedupKey) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if element, exists := w.entries[key]; exists {
+		w.order.MoveToFront(element)
+		return true
+	}
+
+	w.entries[key] = w.order.PushFront(key)
+
+	if w.order.Len() > w.size {
+		oldest := w.order.Back()
+		w.order.Remove(oldest)
+		delete(w.entries, oldest.Value.(dedupKey))
+	}
+
+	return false
+}
+
+// isDuplicate reports whether the agent has already processed message within
+// the deduplication window, emitting EventMessageDuplicate when it has.
+// Messages without an ID are never treated as duplicates.
+func (h *hub) isDuplicate(reg *registration, message *messaging.Message) bool {
+	if h.dedup == nil || message.ID == "" {
+		return false
+	}
+
+	if !h.dedup.seen(dedupKey{agentID: reg.ID, messageID: message.ID}) {
+		return false
+	}
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      observability.EventMessageDuplicate,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":   h.name,
+			"agent_id":   reg.ID,
+			"message_id": message.ID,
+			"from":       message.From,
+		},
+	})
+
+	return true
+}
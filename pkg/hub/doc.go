@@ -26,6 +26,25 @@
 //
 //	err := hub.RegisterAgent(agent, handler)
 //
+// Agents can also advertise capabilities, which are reported by ListAgents and
+// used for capability-based routing:
+//
+//	err := hub.RegisterWithCapabilities(agent, handler, []string{"summarize"})
+//
+// Replace swaps the implementation behind a registered ID during rolling
+// upgrades. Messages keep routing to the same ID: handlers already running
+// finish with the old agent, and later messages see the new one:
+//
+//	err := hub.Replace(agentID, upgradedAgent)
+//
+// Agents registered with RegisterWithHealthCheck are probed every
+// HubConfig.HealthCheckInterval. While an agent's probe is failing, messages
+// addressed to it go to the dead letter queue with DeadLetterUnhealthy:
+//
+//	err := hub.RegisterWithHealthCheck(agent, handler, func(ctx context.Context) error {
+//	    return backend.Ping(ctx)
+//	})
+//
 // # Communication Patterns
 //
 // Point-to-Point Messaging:
@@ -36,14 +55,80 @@
 //
 //	response, err := hub.Request(ctx, "requester-id", "processor-id", request)
 //
+// Requests wait up to HubConfig.DefaultTimeout unless ctx has an earlier
+// deadline. SendWithTimeout sets a per-call deadline; when it passes, the
+// error wraps context.DeadlineExceeded:
+//
+//	response, err := hub.SendWithTimeout(ctx, "processor-id", msg, 2*time.Second)
+//
+// Asynchronous Request-Response:
+//
+//	msg := messaging.NewRequest("requester-id", "processor-id", task).
+//	    ReplyToAgent("collector-id").
+//	    Build()
+//	err := hub.SendMessage(ctx, msg)
+//
+// The sender continues working while the processor handles the request. The
+// hub routes the processor's response to the ReplyToAgent (or the sender when
+// unset) and sets its ReplyTo to the request ID for correlation. Handlers can
+// also reply later, outside the handler's return value, with
+// MessageContext.SendReply.
+//
+// Capability Routing:
+//
+//	msg := messaging.NewRequest("requester-id", "", document).Build()
+//	response, err := hub.SendToCapable(ctx, "summarize", msg)
+//
+// The request goes to the least-loaded active agent advertising the capability,
+// spreading work across a pool of interchangeable agents without naming them.
+//
+// Routing Rules:
+//
+//	hub.AddRoutingRule(hub.RoutingRule{
+//	    When:        hub.RouteCondition{Headers: map[string]string{"X-Priority": "high"}},
+//	    TargetAgent: "fast-agent",
+//	})
+//
+// Rules are evaluated in registration order for point-to-point sends and
+// requests; the first match readdresses the message, and messages no rule
+// matches go to their explicit destination. RoutingRule is JSON-serializable,
+// so routing tables can be loaded from configuration.
+//
+// Prioritized Messages:
+//
+//	msg := messaging.NewNotification("sender-id", "receiver-id", "cancel").
+//	    Priority(messaging.PriorityCritical).
+//	    Build()
+//	err := hub.SendMessage(ctx, msg)
+//
+// Critical messages travel on a separate per-agent priority channel (sized by
+// HubConfig.PriorityChannelBufferSize) that the hub drains before the regular
+// channel. Handlers can check MessageContext.IsPriority to tell them apart.
+//
 // Broadcast:
 //
-//	err := hub.Broadcast(ctx, "sender-id", announcement)
+//	msg := messaging.NewBroadcast("sender-id", announcement).Build()
+//	err := hub.Broadcast(ctx, msg)
+//
+// Broadcast never blocks on a busy agent. Agents whose channels are full are
+// skipped and reported through a *BroadcastError:
+//
+//	var broadcastErr *hub.BroadcastError
+//	if errors.As(err, &broadcastErr) {
+//	    log.Printf("skipped: %v", broadcastErr.Skipped)
+//	}
 //
 // Publish-Subscribe:
 //
 //	hub.Subscribe("subscriber-id", "events.user.created")
-//	hub.Publish(ctx, "publisher-id", "events.user.created", event)
+//	msg := messaging.NewNotification("publisher-id", "", event).Build()
+//	hub.Publish(ctx, "events.user.created", msg)
+//	hub.Unsubscribe("subscriber-id", "events.user.created")
+//
+// Each topic buffers up to HubConfig.TopicBufferSize published messages and
+// fans them out to subscribers (excluding the sender) on a dedicated goroutine,
+// so publishers only block when the topic buffer is full. Agents can use
+// point-to-point messaging and subscriptions at the same time.
 //
 // # Message Handlers
 //
@@ -62,19 +147,151 @@
 //	    return nil, nil
 //	}
 //
+// Handler errors are dead-lettered. Transient failures can be retried with
+// exponential backoff (plus up to 20% jitter) by returning an error that
+// implements Retryable and configuring HubConfig.HandlerRetry:
+//
+//	cfg := config.DefaultHubConfig()
+//	cfg.HandlerRetry.MaxAttempts = 3
+//
+// # Deduplication
+//
+// When HubConfig.EnableDeduplication is set, the hub remembers the most recent
+// DeduplicationWindowSize (agent, message ID) pairs in an LRU window. A message
+// an agent has already processed is dropped before reaching its handler and
+// EventMessageDuplicate is emitted, making redelivery after sender retries safe.
+//
+// # Message Expiry
+//
+// Messages built with TTL (or NewMessageWithTTL) carry an ExpiresAt deadline.
+// A message still queued past its deadline, for example behind a paused or
+// rate-limited agent, is dead-lettered with DeadLetterExpired instead of being
+// handled, and EventMessageExpired is emitted. HubConfig.DefaultMessageTTL
+// applies an expiry, measured from the message timestamp, to messages without
+// one.
+//
+// # Middleware
+//
+// Middleware wraps every agent's handler with cross-cutting behavior such as
+// logging, authentication, or tracing. Middleware runs in registration order,
+// with the first registered as the outermost wrapper:
+//
+//	h.Use(hub.RecoveryMiddleware())
+//	h.Use(hub.LoggingMiddleware(logger))
+//
+// The chain is applied when a message is dispatched, so middleware also covers
+// agents registered before Use was called.
+//
+// Handlers read message headers through MessageContext.Headers. LoggingMiddleware
+// logs the standard X-Correlation-ID and X-Trace-ID headers and copies them onto
+// responses, so a correlation ID set on a request follows the reply.
+//
+// # Message Transformers
+//
+// Transformers rewrite messages before handlers see them, for example to
+// decrypt or decompress content. Incoming transformers run in registration
+// order on every message sent into the hub, before routing rules; response
+// transformers run on handler responses before they are delivered:
+//
+//	h.AddTransformer(hub.NewDecompressionTransformer())
+//	h.AddTransformer(hub.NewTracingHeaderTransformer())
+//	h.AddResponseTransformer(hub.NewTracingHeaderTransformer())
+//
+// A transformer error rejects the message: it is dead-lettered with a
+// DeadLetterTransformFailed reason and the send returns ErrTransformFailed.
+//
 // # Lifecycle Management
 //
-// Hubs support graceful shutdown with timeout:
+// Shutdown stops accepting new messages, drains queued messages, and waits for
+// in-flight handlers before stopping. Operations called after Shutdown return
+// ErrHubShutdown. If the context ends first, the error wraps ctx.Err() and
+// reports how many queued messages were dropped:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	err := hub.Shutdown(ctx)
 //
-//	err := hub.Shutdown(30 * time.Second)
+//	<-hub.Done() // closed once the hub has stopped
 //
 // # Metrics
 //
-// The hub tracks operational metrics:
+// The hub tracks operational metrics atomically, including handler outcomes,
+// dead letters, and handler latency, both hub-wide and per agent:
 //
 //	metrics := hub.Metrics()
 //	fmt.Printf("Agents: %d, Sent: %d, Received: %d",
 //	    metrics.LocalAgents, metrics.MessagesSent, metrics.MessagesRecv)
+//	fmt.Printf("Failed: %d, Peak latency: %v",
+//	    metrics.TotalMessagesFailed, metrics.PeakHandlerLatency)
+//
+//	worker := metrics.AgentMetrics["worker-1"]
+//
+// ListAgents reports the same per-agent values in AgentInfo.Metrics.
+//
+// # Dead Letters
+//
+// Messages that cannot be delivered (unknown agent, full channel on broadcast,
+// or a send that times out) are queued on a dead letter channel in addition to
+// any error returned to the caller. The queue is non-blocking; when it is full
+// further dead letters are dropped and logged. Size it with
+// HubConfig.DeadLetterBufferSize.
+//
+//	go func() {
+//	    for letter := range hub.DeadLetterQueue() {
+//	        log.Printf("undeliverable to %s: %s", letter.TargetAgent, letter.Reason)
+//	    }
+//	}()
+//
+// # Audit Trail
+//
+// HubConfig.AuditLog enables an append-only HubEventLog recording every
+// registration, lifecycle, messaging, and subscription operation with its
+// agent, message ID, and outcome. "memory" keeps entries in memory; register
+// NewFileAuditLog (NDJSON) or a custom log for durable records:
+//
+//	hub.RegisterAuditLog("compliance", hub.NewFileAuditLog("/var/log/hub-audit.ndjson"))
+//	cfg.AuditLog = "compliance"
+//
+//	entries, err := h.AuditLog().Query(hub.AuditFilter{AgentID: "classifier", Since: start})
+//
+// # Introspection
+//
+// ListAgents returns a snapshot of registered agents, sorted by ID, including
+// registration time, handled message count, and status:
+//
+//	for _, info := range hub.ListAgents() {
+//	    fmt.Printf("%s: %s (%d messages)\n", info.ID, info.Status, info.MessageCount)
+//	}
+//
+// Status is active, paused, or draining. Agents drain while Shutdown handles
+// their queued messages, and while handlers still run against an agent
+// implementation swapped out by Replace.
+//
+// # Flow Control
+//
+// Pause stops delivery to an agent without unregistering it, which is useful
+// during rolling upgrades or load shedding. Messages sent while paused are held
+// in the agent's channel and delivered in order after Resume:
+//
+//	hub.Pause("worker-1")
+//	// ... upgrade worker ...
+//	hub.Resume("worker-1")
+//
+// Per-agent rate limits apply backpressure to senders. Set a default for all
+// agents with HubConfig.PerAgentRateLimit, or adjust one agent at runtime:
+//
+//	hub.SetAgentRateLimit("slow-agent", rate.Limit(10), 5)
+//
+// Send, Request, and Publish wait for the limiter; Broadcast never waits and
+// skips rate-limited agents instead.
+//
+// Circuit breakers stop delivery to an agent whose handler keeps failing.
+// After Threshold consecutive failures the circuit opens and messages to the
+// agent are dead-lettered with reason "circuit open" (Send returns
+// ErrCircuitOpen). After Timeout, deliveries resume on trial and the circuit
+// closes after HalfOpenAttempts consecutive successes:
+//
+//	hub.SetAgentCircuitBreaker("flaky-agent", config.DefaultCircuitBreakerConfig())
 //
 // # Concurrency
 //
@@ -0,0 +1,41 @@
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrHubShutdown is returned by hub operations after Shutdown has been called.
+var ErrHubShutdown = errors.New("hub is shut down")
+
+// ErrAgentNotFound is returned when an operation names an agent that is not
+// registered with the hub.
+var ErrAgentNotFound = errors.New("agent not found")
+
+// ErrCircuitOpen is returned when delivery is refused because the target
+// agent's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// ErrAgentUnhealthy is returned when delivery is refused because the target
+// agent's most recent health probe failed.
+var ErrAgentUnhealthy = errors.New("agent unhealthy")
+
+// ErrTransformFailed is returned when a MessageTransformer rejects a message.
+var ErrTransformFailed = errors.New("message transform failed")
+
+// BroadcastError reports agents that did not receive a broadcast because their
+// message channels were full, they were rate limited, their circuit was open,
+// or they were unhealthy.
+// Delivery to all other agents still succeeded.
+type BroadcastError struct {
+	Skipped []string
+}
+
+func (e *BroadcastError) Error() string {
+	return fmt.Sprintf(
+		"broadcast skipped %d agent(s): %s",
+		len(e.Skipped),
+		strings.Join(e.Skipped, ", "),
+	)
+}
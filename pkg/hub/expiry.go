@@ -0,0 +1,53 @@
+package hub
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// isExpired reports whether message expired while waiting in the agent's
+// queue. Expired messages are dead-lettered and EventMessageExpired is emitted.
+//
+// Messages with ExpiresAt set use it directly. Otherwise the hub's default TTL,
+// when configured, is measured from the message Timestamp.
+func (h *hub) isExpired(reg *registration, message *messaging.Message) bool {
+	now := time.Now()
+
+	expiresAt := message.ExpiresAt
+	if expiresAt.IsZero() && h.defaultTTL > 0 && !message.Timestamp.IsZero() {
+		expiresAt = message.Timestamp.Add(h.defaultTTL)
+	}
+
+	if expiresAt.IsZero() || !now.After(expiresAt) {
+		return false
+	}
+
+	h.deadLetter(message, reg.ID, DeadLetterExpired)
+
+	h.logger.DebugContext(
+		h.ctx,
+		"message expired before dispatch",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", reg.ID),
+		slog.String("message_id", message.ID),
+		slog.Duration("overdue", now.Sub(expiresAt)),
+	)
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      observability.EventMessageExpired,
+		Timestamp: now,
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":   h.name,
+			"agent_id":   reg.ID,
+			"message_id": message.ID,
+			"from":       message.From,
+			"expires_at": expiresAt,
+		},
+	})
+
+	return true
+}
@@ -2,6 +2,7 @@ package hub
 
 import (
 	"context"
+	"maps"
 
 	"github.com/JaimeStill/go-agents/pkg/agent"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
@@ -10,6 +11,25 @@ import (
 type MessageContext struct {
 	HubName string
 	Agent   agent.Agent
+
+	priority bool
+	message  *messaging.Message
+	hub      *hub
+}
+
+// IsPriority reports whether the message was delivered through the agent's
+// priority channel ahead of regular traffic.
+func (c *MessageContext) IsPriority() bool {
+	return c.priority
+}
+
+// Headers returns a copy of the headers of the message being handled. The
+// result is nil when the message has no headers.
+func (c *MessageContext) Headers() map[string]string {
+	if c.message == nil {
+		return nil
+	}
+	return maps.Clone(c.message.Headers)
 }
 
 type MessageHandler func(
@@ -0,0 +1,160 @@
+package hub
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/agent"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// HealthProbe reports whether an agent is alive. A non-nil error marks the
+// agent unhealthy until a later probe succeeds. The context is cancelled
+// after HubConfig.HealthCheckInterval.
+type HealthProbe func(ctx context.Context) error
+
+// agentHealth tracks the liveness probe results of one registration.
+type agentHealth struct {
+	probe HealthProbe
+
+	mu        sync.Mutex
+	healthy   bool
+	lastCheck time.Time
+}
+
+func newAgentHealth(probe HealthProbe) *agentHealth {
+	return &agentHealth{probe: probe, healthy: true}
+}
+
+// status returns whether the agent is healthy and when it was last probed.
+// Agents registered without a probe are always healthy.
+func (a *agentHealth) status() (bool, time.Time) {
+	if a == nil {
+		return true, time.Time{}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.healthy, a.lastCheck
+}
+
+// record stores a probe result and reports whether it changed health.
+func (a *agentHealth) record(err error, at time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	healthy := err == nil
+	changed := healthy != a.healthy
+	a.healthy = healthy
+	a.lastCheck = at
+	return changed
+}
+
+// RegisterWithHealthCheck registers an agent whose liveness is checked by
+// probe every HubConfig.HealthCheckInterval.
+//
+// Agents start healthy. When a probe fails the agent is marked unhealthy and
+// messages addressed to it are dead-lettered with DeadLetterUnhealthy instead
+// of being queued; SendToCapable skips it. The next successful probe restores
+// delivery. Transitions emit EventHubAgentUnhealthy and EventHubAgentHealthy,
+// and AgentInfo reports Healthy and LastHealthCheck.
+//
+// Probes for all agents run concurrently on a single background goroutine
+// per hub. A zero HealthCheckInterval disables probing.
+//
+// Example:
+//
+//	err := h.RegisterWithHealthCheck(worker, handler, func(ctx context.Context) error {
+//	    return workerClient.Ping(ctx)
+//	})
+func (h *hub) RegisterWithHealthCheck(ag agent.Agent, handler MessageHandler, probe HealthProbe) error {
+	return h.register(ag, handler, nil, probe)
+}
+
+// isHealthy reports whether reg can receive messages according to its probe.
+func (reg *registration) isHealthy() bool {
+	healthy, _ := reg.health.status()
+	return healthy
+}
+
+// healthLoop runs every registered probe each interval until the hub stops.
+func (h *hub) healthLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+			h.runHealthChecks(interval)
+		}
+	}
+}
+
+// runHealthChecks probes all agents with a health check concurrently and
+// waits for every probe to finish or time out.
+func (h *hub) runHealthChecks(timeout time.Duration) {
+	h.agentsMutex.RLock()
+	probed := make([]*registration, 0)
+	for _, reg := range h.agents {
+		if reg.health != nil {
+			probed = append(probed, reg)
+		}
+	}
+	h.agentsMutex.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, reg := range probed {
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(h.ctx, timeout)
+			defer cancel()
+
+			err := reg.health.probe(ctx)
+			if reg.health.record(err, time.Now()) {
+				h.healthTransition(reg, err)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func (h *hub) healthTransition(reg *registration, err error) {
+	data := map[string]any{
+		"hub_name": h.name,
+		"agent_id": reg.ID,
+	}
+
+	eventType := observability.EventHubAgentHealthy
+	if err != nil {
+		eventType = observability.EventHubAgentUnhealthy
+		data["error"] = err.Error()
+	}
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data:      data,
+	})
+
+	if err != nil {
+		h.logger.WarnContext(
+			h.ctx,
+			"agent health check failed",
+			slog.String("hub_name", h.name),
+			slog.String("agent_id", reg.ID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	h.logger.InfoContext(
+		h.ctx,
+		"agent healthy",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", reg.ID),
+	)
+}
@@ -4,34 +4,80 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/JaimeStill/go-agents/pkg/agent"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"golang.org/x/time/rate"
 )
 
 type registration struct {
-	Agent    agent.Agent
+	ID       string
 	Handler  MessageHandler
 	Channel  *MessageChannel[*messaging.Message]
 	LastSeen time.Time
+
+	PriorityChannel *MessageChannel[*messaging.Message]
+	Limiter         *rate.Limiter
+
+	RegisteredAt  time.Time
+	MessageCount  int64
+	LastMessageAt time.Time
+	Status        AgentStatus
+	Capabilities  []string
+	Breaker       *circuitBreaker
+	Stats         *handlerStats
+
+	// health is nil for agents registered without a liveness probe
+	health *agentHealth
+
+	// slot holds the current agent implementation; see Replace. retired
+	// holds replaced slots that may still have handlers running.
+	slot      *agentSlot
+	retired   []*agentSlot
+	slotMutex sync.Mutex
 }
 
 type Hub interface {
 	RegisterAgent(ag agent.Agent, handler MessageHandler) error
+	RegisterWithCapabilities(ag agent.Agent, handler MessageHandler, capabilities []string) error
+	RegisterWithHealthCheck(ag agent.Agent, handler MessageHandler, probe HealthProbe) error
 	UnregisterAgent(agentID string) error
+	Replace(agentID string, newAgent agent.Agent) error
+	ListAgents() []AgentInfo
+	Pause(agentID string) error
+	Resume(agentID string) error
+	SetAgentRateLimit(agentID string, limit rate.Limit, burst int) error
+	SetAgentCircuitBreaker(agentID string, cfg config.CircuitBreakerConfig) error
+	Use(middleware MessageMiddleware)
+	AddRoutingRule(rule RoutingRule) error
+	AddTransformer(transformer MessageTransformer) error
+	AddResponseTransformer(transformer MessageTransformer) error
 
 	Send(ctx context.Context, from, to string, data any) error
+	SendMessage(ctx context.Context, msg *messaging.Message) error
 	Request(ctx context.Context, from, to string, data any) (*messaging.Message, error)
-	Broadcast(ctx context.Context, from string, data any) error
+	RequestMessage(ctx context.Context, msg *messaging.Message) (*messaging.Message, error)
+	SendWithTimeout(ctx context.Context, agentID string, msg *messaging.Message, timeout time.Duration) (*messaging.Message, error)
+	Broadcast(ctx context.Context, msg *messaging.Message) error
+	SendToCapable(ctx context.Context, capability string, msg *messaging.Message) (*messaging.Message, error)
 
 	Subscribe(agentID, topic string) error
-	Publish(ctx context.Context, from, topic string, data any) error
+	Unsubscribe(agentID, topic string) error
+	Publish(ctx context.Context, topic string, msg *messaging.Message) error
+
+	DeadLetterQueue() <-chan DeadLetter
+	AuditLog() HubEventLog
 
-	Metrics() MetricsSnapshot
-	Shutdown(timeout time.Duration) error
+	Metrics() HubMetrics
+	Shutdown(ctx context.Context) error
+	Done() <-chan struct{}
+	IsShutdown() bool
 }
 
 type hub struct {
@@ -43,14 +89,38 @@ type hub struct {
 	responseChannels map[string]chan *messaging.Message
 	responsesMutex   sync.RWMutex
 
-	subscriptions map[string]map[string]*registration
-	subsMutex     sync.RWMutex
+	topics    map[string]*topic
+	subsMutex sync.RWMutex
+
+	channelBufferSize         int
+	topicBufferSize           int
+	priorityChannelBufferSize int
+	rateLimit                 rate.Limit
+	rateBurst                 int
+	defaultTimeout            time.Duration
+	defaultTTL                time.Duration
+	retry                     config.RetryPolicy
+
+	deadLetters chan DeadLetter
+	dedup       *dedupWindow
+
+	middleware      []MessageMiddleware
+	middlewareMutex sync.RWMutex
+
+	routes      []RoutingRule
+	routesMutex sync.RWMutex
+
+	transformers         []MessageTransformer
+	responseTransformers []MessageTransformer
+	transformersMutex    sync.RWMutex
 
-	channelBufferSize int
-	defaultTimeout    time.Duration
+	logger   *slog.Logger
+	observer observability.Observer
+	metrics  *Metrics
+	auditLog HubEventLog
 
-	logger  *slog.Logger
-	metrics *Metrics
+	inFlight     atomic.Int64
+	shuttingDown atomic.Bool
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -60,27 +130,87 @@ type hub struct {
 func New(ctx context.Context, hubConfig config.HubConfig) Hub {
 	hubCtx, cancel := context.WithCancel(ctx)
 
+	observer, err := observability.GetObserver(hubConfig.Observer)
+	if err != nil {
+		if hubConfig.Observer != "" {
+			hubConfig.Logger.WarnContext(
+				ctx,
+				"falling back to noop observer",
+				slog.String("hub_name", hubConfig.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+		observer = observability.NoOpObserver{}
+	}
+
+	auditLog, err := resolveAuditLog(hubConfig.AuditLog)
+	if err != nil {
+		hubConfig.Logger.WarnContext(
+			ctx,
+			"hub auditing disabled",
+			slog.String("hub_name", hubConfig.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if hubConfig.PerAgentRateLimit == 0 {
+		hubConfig.PerAgentRateLimit = rate.Inf
+	}
+
 	h := &hub{
-		name:              hubConfig.Name,
-		agents:            make(map[string]*registration),
-		responseChannels:  make(map[string]chan *messaging.Message),
-		subscriptions:     make(map[string]map[string]*registration),
-		channelBufferSize: hubConfig.ChannelBufferSize,
-		defaultTimeout:    hubConfig.DefaultTimeout,
-		logger:            hubConfig.Logger,
-		metrics:           NewMetrics(),
-		ctx:               hubCtx,
-		cancel:            cancel,
-		done:              make(chan struct{}),
+		name:                      hubConfig.Name,
+		agents:                    make(map[string]*registration),
+		responseChannels:          make(map[string]chan *messaging.Message),
+		topics:                    make(map[string]*topic),
+		channelBufferSize:         hubConfig.ChannelBufferSize,
+		topicBufferSize:           hubConfig.TopicBufferSize,
+		priorityChannelBufferSize: hubConfig.PriorityChannelBufferSize,
+		rateLimit:                 hubConfig.PerAgentRateLimit,
+		rateBurst:                 hubConfig.PerAgentRateBurst,
+		defaultTimeout:            hubConfig.DefaultTimeout,
+		defaultTTL:                hubConfig.DefaultMessageTTL,
+		retry:                     hubConfig.HandlerRetry,
+		logger:                    hubConfig.Logger,
+		observer:                  observer,
+		auditLog:                  auditLog,
+		deadLetters:               make(chan DeadLetter, max(hubConfig.DeadLetterBufferSize, 0)),
+		metrics:                   NewMetrics(),
+		ctx:                       hubCtx,
+		cancel:                    cancel,
+		done:                      make(chan struct{}),
+	}
+
+	if hubConfig.EnableDeduplication {
+		h.dedup = newDedupWindow(hubConfig.DeduplicationWindowSize)
 	}
 
 	go h.messageLoop()
 
+	if hubConfig.HealthCheckInterval > 0 {
+		go h.healthLoop(hubConfig.HealthCheckInterval)
+	}
+
 	return h
 }
 
 func (h *hub) RegisterAgent(ag agent.Agent, handler MessageHandler) error {
+	return h.RegisterWithCapabilities(ag, handler, nil)
+}
+
+// RegisterWithCapabilities registers an agent along with the capabilities it
+// advertises for SendToCapable routing. Duplicate capabilities are removed.
+func (h *hub) RegisterWithCapabilities(ag agent.Agent, handler MessageHandler, capabilities []string) error {
+	return h.register(ag, handler, capabilities, nil)
+}
+
+func (h *hub) register(ag agent.Agent, handler MessageHandler, capabilities []string, probe HealthProbe) (err error) {
 	agentID := ag.ID()
+	defer func() { h.audit(AuditRegister, agentID, "", "", err) }()
+
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+
 	h.agentsMutex.Lock()
 	defer h.agentsMutex.Unlock()
 
@@ -89,12 +219,24 @@ func (h *hub) RegisterAgent(ag agent.Agent, handler MessageHandler) error {
 	}
 
 	channel := NewMessageChannel[*messaging.Message](h.ctx, h.channelBufferSize)
+	priorityChannel := NewMessageChannel[*messaging.Message](h.ctx, h.priorityChannelBufferSize)
 
+	now := time.Now()
 	reg := &registration{
-		Agent:    ag,
-		Handler:  handler,
-		Channel:  channel,
-		LastSeen: time.Now(),
+		ID:              agentID,
+		Handler:         handler,
+		Channel:         channel,
+		PriorityChannel: priorityChannel,
+		Limiter:         h.newLimiter(),
+		LastSeen:        now,
+		RegisteredAt:    now,
+		Status:          AgentStatusActive,
+		Capabilities:    normalizeCapabilities(capabilities),
+		Stats:           &handlerStats{},
+		slot:            &agentSlot{agent: ag, version: 1},
+	}
+	if probe != nil {
+		reg.health = newAgentHealth(probe)
 	}
 
 	h.agents[agentID] = reg
@@ -105,32 +247,31 @@ func (h *hub) RegisterAgent(ag agent.Agent, handler MessageHandler) error {
 		"agent registered",
 		slog.String("hub_name", h.name),
 		slog.String("agent_id", agentID),
+		slog.Any("capabilities", reg.Capabilities),
 	)
 
 	return nil
 }
 
-func (h *hub) UnregisterAgent(agentID string) error {
+func (h *hub) UnregisterAgent(agentID string) (err error) {
+	defer func() { h.audit(AuditUnregister, agentID, "", "", err) }()
+
 	h.agentsMutex.Lock()
 	reg, exists := h.agents[agentID]
 	if exists {
 		delete(h.agents, agentID)
 		reg.Channel.Close()
+		reg.PriorityChannel.Close()
 	}
 	h.agentsMutex.Unlock()
 
 	if !exists {
-		return fmt.Errorf("agent not found: %s", agentID)
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
 	}
 
 	h.subsMutex.Lock()
-	for topic, subs := range h.subscriptions {
-		if _, exists := subs[agentID]; exists {
-			delete(subs, agentID)
-			if len(subs) == 0 {
-				delete(h.subscriptions, topic)
-			}
-		}
+	for _, t := range h.topics {
+		delete(t.subscribers, agentID)
 	}
 	h.subsMutex.Unlock()
 
@@ -146,36 +287,145 @@ func (h *hub) UnregisterAgent(agentID string) error {
 }
 
 func (h *hub) Send(ctx context.Context, from, to string, data any) error {
+	return h.SendMessage(ctx, messaging.NewNotification(from, to, data).Build())
+}
+
+func (h *hub) SendMessage(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func() { h.audit(AuditSend, msg.From, msg.To, msg.ID, err) }()
+
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+
+	transformed, err := h.transformIncoming(msg)
+	if err != nil {
+		return err
+	}
+	msg = transformed
+
+	if h.handToWaiter(msg) {
+		h.updateLastSeen(msg.From)
+		h.metrics.RecordMessageSent(1)
+		return nil
+	}
+
+	msg = h.route(msg)
+
 	h.agentsMutex.RLock()
-	reg, exists := h.agents[to]
+	reg, exists := h.agents[msg.To]
 	h.agentsMutex.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("destination agent not found: %s", to)
+		h.deadLetter(msg, msg.To, DeadLetterAgentNotFound)
+		return messaging.WrapError(msg.ID, fmt.Errorf("destination %w: %s", ErrAgentNotFound, msg.To))
 	}
 
-	message := messaging.NewNotification(from, to, data).Build()
-	err := reg.Channel.Send(ctx, message)
-	if err != nil {
-		return fmt.Errorf("failed to deliver message: %w", err)
+	if err := h.deliver(ctx, reg, msg); err != nil {
+		return messaging.WrapError(msg.ID, fmt.Errorf("failed to deliver message: %w", err))
 	}
 
-	h.updateLastSeen(from)
+	h.updateLastSeen(msg.From)
 	h.metrics.RecordMessageSent(1)
 
 	return nil
 }
 
-func (h *hub) Request(ctx context.Context, from, to string, data any) (*messaging.Message, error) {
+func (h *hub) Request(ctx context.Context, from, to string, data any) (response *messaging.Message, err error) {
+	message := messaging.NewRequest(from, to, data).Build()
+	defer func() { h.audit(AuditRequest, message.From, message.To, message.ID, err) }()
+
+	if h.IsShutdown() {
+		return nil, ErrHubShutdown
+	}
+
+	transformed, err := h.transformIncoming(message)
+	if err != nil {
+		return nil, err
+	}
+	message = transformed
+	message = h.route(message)
+
 	h.agentsMutex.RLock()
-	reg, exists := h.agents[to]
+	reg, exists := h.agents[message.To]
 	h.agentsMutex.RUnlock()
 
 	if !exists {
-		return nil, fmt.Errorf("destination agent not found: %s", to)
+		h.deadLetter(message, message.To, DeadLetterAgentNotFound)
+		return nil, messaging.WrapError(message.ID, fmt.Errorf("destination %w: %s", ErrAgentNotFound, message.To))
 	}
 
-	message := messaging.NewRequest(from, to, data).Build()
+	return h.request(ctx, reg, message)
+}
+
+// RequestMessage sends a prebuilt request and waits for the response.
+//
+// Unlike Request, the message's ID, headers, and other metadata are preserved,
+// which lets transports forward requests received from other processes. The
+// message is sent as a request regardless of its Type.
+func (h *hub) RequestMessage(ctx context.Context, msg *messaging.Message) (response *messaging.Message, err error) {
+	message := msg
+	defer func() { h.audit(AuditRequest, message.From, message.To, message.ID, err) }()
+
+	if h.IsShutdown() {
+		return nil, ErrHubShutdown
+	}
+
+	if !msg.IsRequest() {
+		message = msg.Clone()
+		message.Type = messaging.MessageTypeRequest
+	}
+	transformed, err := h.transformIncoming(message)
+	if err != nil {
+		return nil, err
+	}
+	message = transformed
+	message = h.route(message)
+
+	h.agentsMutex.RLock()
+	reg, exists := h.agents[message.To]
+	h.agentsMutex.RUnlock()
+
+	if !exists {
+		h.deadLetter(message, message.To, DeadLetterAgentNotFound)
+		return nil, messaging.WrapError(message.ID, fmt.Errorf("destination %w: %s", ErrAgentNotFound, message.To))
+	}
+
+	return h.request(ctx, reg, message)
+}
+
+// SendWithTimeout sends msg as a request to agentID and waits at most timeout
+// for the response, overriding HubConfig.DefaultTimeout for this call.
+//
+// The deadline is derived from ctx, so an earlier deadline or cancellation
+// of ctx still applies. When the deadline passes the returned error wraps
+// context.DeadlineExceeded. msg is cloned if it is not already addressed to
+// agentID.
+//
+// Example:
+//
+//	response, err := h.SendWithTimeout(ctx, "classifier", msg, 2*time.Second)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    // fall back to the default classification
+//	}
+func (h *hub) SendWithTimeout(ctx context.Context, agentID string, msg *messaging.Message, timeout time.Duration) (*messaging.Message, error) {
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive: %v", timeout)
+	}
+
+	if msg.To != agentID {
+		msg = msg.Clone()
+		msg.To = agentID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return h.RequestMessage(ctx, msg)
+}
+
+// request delivers a request message to reg and waits for the handler's
+// response, the context to end, or the hub's default timeout.
+func (h *hub) request(ctx context.Context, reg *registration, message *messaging.Message) (*messaging.Message, error) {
 	responseChannel := make(chan *messaging.Message, 1)
 
 	h.responsesMutex.Lock()
@@ -189,173 +439,222 @@ func (h *hub) Request(ctx context.Context, from, to string, data any) (*messagin
 		close(responseChannel)
 	}()
 
-	err := reg.Channel.Send(ctx, message)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	if err := h.deliver(ctx, reg, message); err != nil {
+		return nil, messaging.WrapError(message.ID, fmt.Errorf("failed to send request: %w", err))
 	}
 
-	h.updateLastSeen(from)
+	h.updateLastSeen(message.From)
 
-	timeout := h.defaultTimeout
-	if deadline, ok := ctx.Deadline(); ok {
-		timeout = time.Until(deadline)
+	// A context deadline replaces the default timeout and is reported through
+	// ctx.Err, so callers can test for context.DeadlineExceeded.
+	var timeout <-chan time.Time
+	if _, ok := ctx.Deadline(); !ok {
+		timeout = time.After(h.defaultTimeout)
 	}
 
 	select {
 	case response := <-responseChannel:
 		return response, nil
 	case <-ctx.Done():
-		return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
-	case <-time.After(timeout):
-		return nil, fmt.Errorf("request timed out after %v", timeout)
+		return nil, messaging.WrapError(message.ID, fmt.Errorf("request cancelled: %w", ctx.Err()))
+	case <-timeout:
+		return nil, messaging.WrapError(message.ID, fmt.Errorf("request timed out after %v", h.defaultTimeout))
 	}
 }
 
-func (h *hub) Broadcast(ctx context.Context, from string, data any) error {
+func (h *hub) Broadcast(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func() { h.audit(AuditBroadcast, msg.From, "", msg.ID, err) }()
+
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+
+	transformed, err := h.transformIncoming(msg)
+	if err != nil {
+		return err
+	}
+	msg = transformed
+
 	h.agentsMutex.RLock()
 	registrations := make([]*registration, 0, len(h.agents))
 	for agentID, reg := range h.agents {
-		if agentID != from {
+		if agentID != msg.From {
 			registrations = append(registrations, reg)
 		}
 	}
 	h.agentsMutex.RUnlock()
 
 	delivered := 0
+	skipped := make([]string, 0)
 	for _, reg := range registrations {
-		message := messaging.NewMessage(
-			from,
-			reg.Agent.ID(),
-			messaging.MessageTypeBroadcast,
-			data,
-		).Build()
-
-		if err := reg.Channel.Send(ctx, message); err != nil {
-			h.logger.WarnContext(
-				ctx,
-				"failed to deliver broadcast",
-				slog.String("hub_name", h.name),
-				slog.String("from", from),
-				slog.String("to", reg.Agent.ID()),
-				slog.String("error", err.Error()),
-			)
-		} else {
+		message := msg.Clone()
+		message.To = reg.ID
+		message.Type = messaging.MessageTypeBroadcast
+
+		if !reg.isHealthy() {
+			skipped = append(skipped, reg.ID)
+			h.deadLetter(message, reg.ID, DeadLetterUnhealthy)
+		} else if !h.allowDelivery(reg) {
+			skipped = append(skipped, reg.ID)
+			h.deadLetter(message, reg.ID, DeadLetterCircuitOpen)
+		} else if !reg.Limiter.Allow() {
+			skipped = append(skipped, reg.ID)
+			h.deadLetter(message, reg.ID, DeadLetterRateLimited)
+		} else if reg.channelFor(message).TrySend(message) {
 			delivered++
+		} else {
+			skipped = append(skipped, reg.ID)
+			h.deadLetter(message, reg.ID, DeadLetterChannelFull)
 		}
 	}
 
-	h.updateLastSeen(from)
+	h.updateLastSeen(msg.From)
+	h.metrics.RecordMessageSent(delivered)
+
+	h.observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventHubBroadcast,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":   h.name,
+			"from":       msg.From,
+			"message_id": msg.ID,
+			"recipients": len(registrations),
+			"delivered":  delivered,
+			"skipped":    len(skipped),
+		},
+	})
+
 	h.logger.DebugContext(
 		ctx,
 		"broadcast sent",
 		slog.String("hub_name", h.name),
-		slog.String("from", from),
+		slog.String("message_id", msg.ID),
+		slog.String("from", msg.From),
 		slog.Int("recipients", len(registrations)),
 		slog.Int("delivered", delivered),
+		slog.Int("skipped", len(skipped)),
 	)
 
+	if len(skipped) > 0 {
+		slices.Sort(skipped)
+		return &BroadcastError{Skipped: skipped}
+	}
+
 	return nil
 }
 
-func (h *hub) Subscribe(agentID, topic string) error {
-	h.agentsMutex.RLock()
-	reg, exists := h.agents[agentID]
-	h.agentsMutex.RUnlock()
+// Metrics returns a snapshot of hub-wide and per-agent counters. Per-agent
+// metrics cover currently registered agents only.
+func (h *hub) Metrics() HubMetrics {
+	snapshot := h.metrics.Snapshot()
 
-	if !exists {
-		return fmt.Errorf("agent not found: %s", agentID)
-	}
+	h.agentsMutex.RLock()
+	defer h.agentsMutex.RUnlock()
 
-	h.subsMutex.Lock()
-	if h.subscriptions[topic] == nil {
-		h.subscriptions[topic] = make(map[string]*registration)
+	snapshot.AgentMetrics = make(map[string]AgentMetrics, len(h.agents))
+	for agentID, reg := range h.agents {
+		snapshot.AgentMetrics[agentID] = reg.Stats.snapshot()
+		if reg.Status == AgentStatusActive {
+			snapshot.ActiveAgentCount++
+		}
 	}
-	h.subscriptions[topic][agentID] = reg
-	h.subsMutex.Unlock()
 
-	h.logger.DebugContext(
-		h.ctx,
-		"agent subscribed to topic",
-		slog.String("hub_name", h.name),
-		slog.String("agent_id", agentID),
-		slog.String("topic", topic),
-	)
-
-	return nil
+	return snapshot
 }
 
-func (h *hub) Publish(ctx context.Context, from, topic string, data any) error {
-	h.subsMutex.RLock()
-	subscribers, exists := h.subscriptions[topic]
-	if !exists {
-		h.subsMutex.RUnlock()
-		h.logger.DebugContext(
-			ctx,
-			"no subscribers for topic",
-			slog.String("hub_name", h.name),
-			slog.String("topic", topic),
-		)
-		return nil
-	}
-
-	subscriberList := make([]*registration, 0, len(subscribers))
-	for _, reg := range subscribers {
-		subscriberList = append(subscriberList, reg)
-	}
-	h.subsMutex.RUnlock()
+// shutdownPollInterval controls how often Shutdown checks for drained channels.
+const shutdownPollInterval = 10 * time.Millisecond
 
-	delivered := 0
-	for _, reg := range subscriberList {
-		if reg.Agent.ID() == from {
-			continue
-		}
+// Shutdown stops accepting new messages, waits for queued messages to be
+// handled and in-flight handlers to return, then stops the hub. If ctx ends
+// first, the hub is stopped immediately and the returned error wraps ctx.Err()
+// with the number of queued messages that were dropped.
+//
+// While Shutdown waits, ListAgents reports every agent as AgentStatusDraining
+// and Pause and Resume return ErrHubShutdown.
+func (h *hub) Shutdown(ctx context.Context) (err error) {
+	defer func() { h.audit(AuditShutdown, "", "", "", err) }()
 
-		message := messaging.NewNotification(from, reg.Agent.ID(), data).Topic(topic).Build()
-		if err := reg.Channel.Send(ctx, message); err != nil {
-			h.logger.WarnContext(
-				ctx,
-				"failed to deliver published message",
-				slog.String("hub_name", h.name),
-				slog.String("topic", topic),
-				slog.String("subscriber", reg.Agent.ID()),
-				slog.String("error", err.Error()),
-			)
-		} else {
-			delivered++
+	if !h.shuttingDown.CompareAndSwap(false, true) {
+		select {
+		case <-h.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
 
-	h.updateLastSeen(from)
 	h.logger.DebugContext(
 		ctx,
-		"message published",
+		"shutting down hub",
 		slog.String("hub_name", h.name),
-		slog.String("topic", topic),
-		slog.Int("subscribers", len(subscriberList)),
-		slog.Int("delivered", delivered),
 	)
 
+	h.markDraining()
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for h.pendingMessages() > 0 || h.inFlight.Load() > 0 {
+		select {
+		case <-h.done:
+			return nil
+		case <-ctx.Done():
+			dropped := h.pendingMessages()
+			h.stop()
+			return fmt.Errorf("hub shutdown dropped %d messages: %w", dropped, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	h.stop()
 	return nil
 }
 
-func (h *hub) Metrics() MetricsSnapshot {
-	return h.metrics.Snapshot()
+func (h *hub) Done() <-chan struct{} {
+	return h.done
 }
 
-func (h *hub) Shutdown(timeout time.Duration) error {
-	h.logger.DebugContext(
-		h.ctx,
-		"shutting down hub",
-		slog.String("hub_name", h.name),
-	)
+func (h *hub) IsShutdown() bool {
+	return h.shuttingDown.Load()
+}
+
+// markDraining reports every registration as draining while Shutdown waits
+// for queued messages.
+func (h *hub) markDraining() {
+	h.agentsMutex.Lock()
+	defer h.agentsMutex.Unlock()
+
+	for _, reg := range h.agents {
+		reg.Status = AgentStatusDraining
+	}
+}
+
+// stop cancels the hub context and waits for the message loop to exit.
+// Handlers still running observe the cancelled context.
+func (h *hub) stop() {
 	h.cancel()
+	<-h.done
+}
 
-	select {
-	case <-h.done:
-		return nil
-	case <-time.After(timeout):
-		return fmt.Errorf("hub shutdown timeout after %v", timeout)
+// pendingMessages counts messages queued in agent channels but not yet handled.
+func (h *hub) pendingMessages() int {
+	h.agentsMutex.RLock()
+	defer h.agentsMutex.RUnlock()
+
+	pending := 0
+	for _, reg := range h.agents {
+		pending += reg.Channel.QueueLength() + reg.PriorityChannel.QueueLength()
 	}
+
+	h.subsMutex.RLock()
+	for _, t := range h.topics {
+		pending += t.queue.QueueLength()
+	}
+	h.subsMutex.RUnlock()
+
+	return pending
 }
 
 func (h *hub) messageLoop() {
@@ -380,7 +679,9 @@ func (h *hub) processAgentMessages() {
 
 	registrations := make([]*registration, 0, len(h.agents))
 	for _, reg := range h.agents {
-		registrations = append(registrations, reg)
+		if reg.Status != AgentStatusPaused || h.IsShutdown() {
+			registrations = append(registrations, reg)
+		}
 	}
 	h.agentsMutex.RUnlock()
 
@@ -389,70 +690,130 @@ func (h *hub) processAgentMessages() {
 		case <-h.ctx.Done():
 			return
 		default:
+			for {
+				message, ok := reg.PriorityChannel.TryReceive()
+				if !ok || message == nil {
+					break
+				}
+				h.inFlight.Add(1)
+				go h.handleMessage(reg, message, true)
+			}
+
 			if message, ok := reg.Channel.TryReceive(); ok && message != nil {
-				go h.handleMessage(reg, message)
+				h.inFlight.Add(1)
+				go h.handleMessage(reg, message, false)
 			}
 		}
 	}
 }
 
-func (h *hub) handleMessage(reg *registration, message *messaging.Message) {
+func (h *hub) handleMessage(reg *registration, message *messaging.Message, priority bool) {
+	defer h.inFlight.Add(-1)
+
 	if reg.Handler == nil {
 		return
 	}
 
+	if h.isExpired(reg, message) {
+		return
+	}
+
+	if h.isDuplicate(reg, message) {
+		return
+	}
+
 	h.metrics.RecordMessageRecv(1)
+	h.recordMessage(reg.ID)
+
+	slot := reg.acquire()
+	defer slot.release()
 
 	context := &MessageContext{
-		HubName: h.name,
-		Agent:   reg.Agent,
+		HubName:  h.name,
+		Agent:    slot.agent,
+		priority: priority,
+		message:  message,
+		hub:      h,
 	}
 
-	response, err := reg.Handler(h.ctx, message, context)
+	start := time.Now()
+	response, attempts, err := h.invokeHandler(reg, message, context)
+	latency := time.Since(start)
+
+	h.metrics.RecordHandler(latency, err)
+	reg.Stats.recordHandler(latency, err)
+	h.recordOutcome(reg, err)
 	if err != nil {
+		reason := DeadLetterHandlerFailed
+		if attempts > 1 {
+			reason = DeadLetterRetryExhausted
+		}
+		h.deadLetter(message, reg.ID, reason)
+
 		h.logger.ErrorContext(
 			h.ctx,
 			"message handler failed",
 			slog.String("hub_name", h.name),
-			slog.String("agent_id", reg.Agent.ID()),
+			slog.String("agent_id", reg.ID),
+			slog.String("message_id", message.ID),
 			slog.String("from", message.From),
+			slog.Int("attempts", attempts),
 			slog.String("error", err.Error()),
 		)
 		return
 	}
 
-	if response != nil {
-		if response.Type == messaging.MessageTypeResponse && response.ReplyTo != "" {
-			h.responsesMutex.RLock()
-			respChan, exists := h.responseChannels[response.ReplyTo]
-			h.responsesMutex.RUnlock()
+	if response == nil {
+		return
+	}
 
-			if exists {
-				select {
-				case respChan <- response:
-				default:
-				}
-				return
-			}
-		}
+	response = addressReply(message, response)
+	if err := h.routeResponse(h.ctx, response); err != nil {
+		h.logger.ErrorContext(
+			h.ctx,
+			"failed to send response",
+			slog.String("hub_name", h.name),
+			slog.String("message_id", response.ID),
+			slog.String("from", response.From),
+			slog.String("to", response.To),
+			slog.String("error", err.Error()),
+		)
+	}
+}
 
-		h.agentsMutex.RLock()
-		targetReg, exists := h.agents[response.To]
-		h.agentsMutex.RUnlock()
+// deliver waits for the agent's rate limiter, then places message on the
+// appropriate channel. Failures are recorded in the dead letter queue.
+func (h *hub) deliver(ctx context.Context, reg *registration, message *messaging.Message) error {
+	if !reg.isHealthy() {
+		h.deadLetter(message, reg.ID, DeadLetterUnhealthy)
+		return fmt.Errorf("%w: %s", ErrAgentUnhealthy, reg.ID)
+	}
 
-		if exists {
-			if err := targetReg.Channel.Send(h.ctx, response); err != nil {
-				h.logger.ErrorContext(
-					h.ctx,
-					"failed to send response",
-					slog.String("hub_name", h.name),
-					slog.String("from", response.From),
-					slog.String("to", response.To),
-					slog.String("error", err.Error()),
-				)
-			}
-		}
+	if !h.allowDelivery(reg) {
+		h.deadLetter(message, reg.ID, DeadLetterCircuitOpen)
+		return fmt.Errorf("%w: %s", ErrCircuitOpen, reg.ID)
+	}
+
+	if err := reg.Limiter.Wait(ctx); err != nil {
+		h.deadLetter(message, reg.ID, DeadLetterRateLimited)
+		return fmt.Errorf("rate limit wait failed: %w", err)
+	}
+
+	if err := reg.channelFor(message).Send(ctx, message); err != nil {
+		h.deadLetter(message, reg.ID, deliveryFailureReason(err))
+		return err
+	}
+
+	return nil
+}
+
+// channelFor selects the priority channel for critical messages and the
+// regular channel for everything else.
+func (reg *registration) channelFor(message *messaging.Message) *MessageChannel[*messaging.Message] {
+	if message.Priority >= messaging.PriorityCritical {
+		return reg.PriorityChannel
 	}
+	return reg.Channel
 }
 
 func (h *hub) updateLastSeen(agentID string) {
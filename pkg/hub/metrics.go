@@ -1,17 +1,88 @@
 package hub
 
-import "sync/atomic"
+import (
+	"sync/atomic"
+	"time"
+)
 
-type MetricsSnapshot struct {
+// HubMetrics is a point-in-time snapshot of hub activity.
+//
+// Delivered counts handler invocations that succeeded and Failed those that
+// returned an error after any retries. Handler latency covers the full
+// invocation including middleware and retries.
+type HubMetrics struct {
 	LocalAgents  int64
 	MessagesSent int64
 	MessagesRecv int64
+
+	TotalMessagesDelivered int64
+	TotalMessagesFailed    int64
+	TotalDeadLettered      int64
+	ActiveAgentCount       int
+	AverageHandlerLatency  time.Duration
+	PeakHandlerLatency     time.Duration
+
+	AgentMetrics map[string]AgentMetrics
+}
+
+// AgentMetrics is a point-in-time snapshot of a single agent's handler activity.
+type AgentMetrics struct {
+	MessagesDelivered     int64
+	MessagesFailed        int64
+	DeadLettered          int64
+	AverageHandlerLatency time.Duration
+	PeakHandlerLatency    time.Duration
+}
+
+// handlerStats accumulates handler outcomes and latency without locking.
+type handlerStats struct {
+	delivered    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+	totalLatency atomic.Int64
+	peakLatency  atomic.Int64
+}
+
+func (s *handlerStats) recordHandler(latency time.Duration, err error) {
+	if err != nil {
+		s.failed.Add(1)
+	} else {
+		s.delivered.Add(1)
+	}
+
+	s.totalLatency.Add(int64(latency))
+	for {
+		peak := s.peakLatency.Load()
+		if int64(latency) <= peak || s.peakLatency.CompareAndSwap(peak, int64(latency)) {
+			break
+		}
+	}
+}
+
+func (s *handlerStats) snapshot() AgentMetrics {
+	delivered := s.delivered.Load()
+	failed := s.failed.Load()
+
+	var average time.Duration
+	if handled := delivered + failed; handled > 0 {
+		average = time.Duration(s.totalLatency.Load() / handled)
+	}
+
+	return AgentMetrics{
+		MessagesDelivered:     delivered,
+		MessagesFailed:        failed,
+		DeadLettered:          s.deadLettered.Load(),
+		AverageHandlerLatency: average,
+		PeakHandlerLatency:    time.Duration(s.peakLatency.Load()),
+	}
 }
 
 type Metrics struct {
 	localAgents  atomic.Int64
 	messagesSent atomic.Int64
 	messagesRecv atomic.Int64
+
+	handlers handlerStats
 }
 
 func NewMetrics() *Metrics {
@@ -30,10 +101,27 @@ func (m *Metrics) RecordMessageRecv(delta int) {
 	m.messagesRecv.Add(int64(delta))
 }
 
-func (m *Metrics) Snapshot() MetricsSnapshot {
-	return MetricsSnapshot{
-		LocalAgents:  m.localAgents.Load(),
-		MessagesSent: m.messagesSent.Load(),
-		MessagesRecv: m.messagesRecv.Load(),
+func (m *Metrics) RecordHandler(latency time.Duration, err error) {
+	m.handlers.recordHandler(latency, err)
+}
+
+func (m *Metrics) RecordDeadLetter() {
+	m.handlers.deadLettered.Add(1)
+}
+
+// Snapshot returns hub-wide counters. ActiveAgentCount and AgentMetrics are
+// populated by Hub.Metrics, which has access to agent registrations.
+func (m *Metrics) Snapshot() HubMetrics {
+	handlers := m.handlers.snapshot()
+
+	return HubMetrics{
+		LocalAgents:            m.localAgents.Load(),
+		MessagesSent:           m.messagesSent.Load(),
+		MessagesRecv:           m.messagesRecv.Load(),
+		TotalMessagesDelivered: handlers.MessagesDelivered,
+		TotalMessagesFailed:    handlers.MessagesFailed,
+		TotalDeadLettered:      handlers.DeadLettered,
+		AverageHandlerLatency:  handlers.AverageHandlerLatency,
+		PeakHandlerLatency:     handlers.PeakHandlerLatency,
 	}
 }
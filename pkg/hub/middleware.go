@@ -0,0 +1,121 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+// MessageMiddleware wraps a MessageHandler with cross-cutting behavior.
+//
+// Middleware follows the standard HTTP middleware pattern: it receives the next
+// handler in the chain and returns a handler that may run code before and after
+// calling next, short-circuit by not calling it, or transform its result.
+type MessageMiddleware func(next MessageHandler) MessageHandler
+
+// Use registers middleware applied to every agent's handler.
+//
+// Middleware runs in registration order: the first middleware registered is the
+// outermost wrapper. The chain is built when a message is dispatched, so
+// middleware registered after an agent still applies to that agent's messages.
+// Each retry attempt passes through the full chain.
+func (h *hub) Use(middleware MessageMiddleware) {
+	if middleware == nil {
+		return
+	}
+
+	h.middlewareMutex.Lock()
+	defer h.middlewareMutex.Unlock()
+
+	h.middleware = append(h.middleware, middleware)
+}
+
+// wrapHandler applies the registered middleware chain to handler.
+func (h *hub) wrapHandler(handler MessageHandler) MessageHandler {
+	h.middlewareMutex.RLock()
+	defer h.middlewareMutex.RUnlock()
+
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		handler = h.middleware[i](handler)
+	}
+	return handler
+}
+
+// standardHeaders are the headers LoggingMiddleware logs and propagates.
+var standardHeaders = []struct {
+	header string
+	attr   string
+}{
+	{messaging.HeaderCorrelationID, "correlation_id"},
+	{messaging.HeaderTraceID, "trace_id"},
+}
+
+// LoggingMiddleware logs each handled message with its outcome and duration.
+//
+// Successful messages are logged at debug level; handler errors are logged at
+// error level. The standard X-Correlation-ID and X-Trace-ID headers are
+// included in the log attributes when present and copied onto the handler's
+// response unless the handler set them itself.
+func LoggingMiddleware(logger *slog.Logger) MessageMiddleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *messaging.Message, msgCtx *MessageContext) (*messaging.Message, error) {
+			start := time.Now()
+			response, err := next(ctx, message, msgCtx)
+
+			attrs := []slog.Attr{
+				slog.String("hub_name", msgCtx.HubName),
+				slog.String("agent_id", msgCtx.Agent.ID()),
+				slog.String("message_id", message.ID),
+				slog.String("from", message.From),
+				slog.String("type", string(message.Type)),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			for _, std := range standardHeaders {
+				value, exists := message.GetHeader(std.header)
+				if !exists {
+					continue
+				}
+
+				attrs = append(attrs, slog.String(std.attr, value))
+				if response != nil {
+					if _, set := response.GetHeader(std.header); !set {
+						response = response.SetHeader(std.header, value)
+					}
+				}
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "message handler failed", attrs...)
+			} else {
+				logger.LogAttrs(ctx, slog.LevelDebug, "message handled", attrs...)
+			}
+
+			return response, err
+		}
+	}
+}
+
+// RecoveryMiddleware converts handler panics into errors.
+//
+// The recovered value and stack trace are included in the error, which flows
+// through the hub's normal error handling (retry policy and dead letter queue).
+func RecoveryMiddleware() MessageMiddleware {
+	return func(next MessageHandler) MessageHandler {
+		return func(ctx context.Context, message *messaging.Message, msgCtx *MessageContext) (response *messaging.Message, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					response = nil
+					err = fmt.Errorf("handler panic: %v\n%s", r, debug.Stack())
+				}
+			}()
+
+			return next(ctx, message, msgCtx)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package hub
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/agent"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// agentSlot is one generation of a registration's agent implementation.
+// inFlight counts handlers still running against it, so a replaced slot can
+// be observed draining.
+type agentSlot struct {
+	agent    agent.Agent
+	version  int
+	inFlight atomic.Int64
+}
+
+// acquire returns the current agent slot with its drain counter incremented.
+// Callers must release the slot once the handler returns.
+func (r *registration) acquire() *agentSlot {
+	r.slotMutex.Lock()
+	defer r.slotMutex.Unlock()
+
+	r.slot.inFlight.Add(1)
+	return r.slot
+}
+
+func (s *agentSlot) release() {
+	s.inFlight.Add(-1)
+}
+
+// draining reports whether handlers are still running against a replaced
+// agent slot, forgetting slots that have drained.
+func (r *registration) draining() bool {
+	r.slotMutex.Lock()
+	defer r.slotMutex.Unlock()
+
+	r.retired = slices.DeleteFunc(r.retired, func(s *agentSlot) bool {
+		return s.inFlight.Load() == 0
+	})
+	return len(r.retired) > 0
+}
+
+// version returns the number of agent implementations the registration has had.
+func (r *registration) version() int {
+	r.slotMutex.Lock()
+	defer r.slotMutex.Unlock()
+
+	return r.slot.version
+}
+
+// Replace atomically substitutes the agent implementation registered under
+// agentID, without the message loss of an unregister/register cycle.
+//
+// Messages keep routing to agentID; the handler, channels, queued messages,
+// subscriptions, capabilities, and statistics are preserved. Handlers already
+// running finish with the old agent, while every message handled after the
+// swap receives newAgent in its MessageContext. Replace does not wait for the
+// old agent to drain; EventHubAgentReplace reports how many handlers were
+// still using it, and ListAgents reports the agent as AgentStatusDraining
+// until they return.
+//
+// newAgent's own ID does not need to match agentID. AgentInfo.Version
+// increments on each replacement.
+//
+// Example:
+//
+//	upgraded, _ := agent.New(newCfg)
+//	if err := h.Replace("classifier", upgraded); err != nil {
+//	    return err
+//	}
+func (h *hub) Replace(agentID string, newAgent agent.Agent) (err error) {
+	defer func() { h.audit(AuditReplace, agentID, "", "", err) }()
+
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+	if newAgent == nil {
+		return fmt.Errorf("replacement agent for %s cannot be nil", agentID)
+	}
+
+	h.agentsMutex.RLock()
+	reg, exists := h.agents[agentID]
+	h.agentsMutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+
+	reg.slotMutex.Lock()
+	old := reg.slot
+	reg.slot = &agentSlot{agent: newAgent, version: old.version + 1}
+	draining := old.inFlight.Load()
+	if draining > 0 {
+		reg.retired = append(reg.retired, old)
+	}
+	reg.slotMutex.Unlock()
+
+	h.observer.OnEvent(h.ctx, observability.Event{
+		Type:      observability.EventHubAgentReplace,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":       h.name,
+			"agent_id":       agentID,
+			"old_agent_id":   old.agent.ID(),
+			"new_agent_id":   newAgent.ID(),
+			"old_version":    old.version,
+			"new_version":    old.version + 1,
+			"draining_count": draining,
+		},
+	})
+
+	h.logger.DebugContext(
+		h.ctx,
+		"agent replaced",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", agentID),
+		slog.String("old_agent_id", old.agent.ID()),
+		slog.String("new_agent_id", newAgent.ID()),
+		slog.Int("version", old.version+1),
+		slog.Int64("draining", draining),
+	)
+
+	return nil
+}
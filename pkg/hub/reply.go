@@ -0,0 +1,120 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+// SendReply routes reply to the agent awaiting a response to the message being
+// handled, without returning it from the handler.
+//
+// The reply is addressed like a handler's returned response: it is sent to the
+// message's ReplyToAgent, or its sender when unset, and a response with no
+// ReplyTo is correlated with the handled message's ID. A blocked Request
+// caller receives the reply directly. Use SendReply to acknowledge early or to
+// reply from a goroutine that outlives the handler.
+//
+// Example:
+//
+//	go func() {
+//	    result := longRunningWork(msg.Data)
+//	    reply := messaging.NewResponse(msgCtx.Agent.ID(), "", msg.ID, result).Build()
+//	    msgCtx.SendReply(ctx, reply)
+//	}()
+//	return nil, nil
+func (c *MessageContext) SendReply(ctx context.Context, reply *messaging.Message) error {
+	if c.hub == nil || c.message == nil {
+		return errors.New("message context is not bound to a hub")
+	}
+
+	if reply == nil {
+		return errors.New("reply cannot be nil")
+	}
+
+	if c.hub.IsShutdown() {
+		return ErrHubShutdown
+	}
+
+	reply = addressReply(c.message, reply)
+	if err := c.hub.routeResponse(ctx, reply); err != nil {
+		return messaging.WrapError(reply.ID, err)
+	}
+	return nil
+}
+
+// addressReply fills in routing for a handler's response to message.
+//
+// Responses are sent to message's reply target (ReplyToAgent, else the
+// sender) and correlated with message's ID when ReplyTo is empty. Other
+// message types keep their destination unless it is empty. response is cloned
+// before modification.
+func addressReply(message, response *messaging.Message) *messaging.Message {
+	to := response.To
+	if to == "" || (response.IsResponse() && message.ReplyToAgent != "") {
+		to = message.ReplyTarget()
+	}
+
+	replyTo := response.ReplyTo
+	if response.IsResponse() && replyTo == "" {
+		replyTo = message.ID
+	}
+
+	if to == response.To && replyTo == response.ReplyTo {
+		return response
+	}
+
+	addressed := response.Clone()
+	addressed.To = to
+	addressed.ReplyTo = replyTo
+	return addressed
+}
+
+// handToWaiter passes a response to the Request call blocked on its ReplyTo
+// ID, reporting whether one was waiting.
+func (h *hub) handToWaiter(response *messaging.Message) bool {
+	if !response.IsResponse() || response.ReplyTo == "" {
+		return false
+	}
+
+	// The read lock is held through the non-blocking send so a Request that
+	// times out cannot close the channel mid-send.
+	h.responsesMutex.RLock()
+	defer h.responsesMutex.RUnlock()
+
+	respChan, exists := h.responseChannels[response.ReplyTo]
+	if exists {
+		select {
+		case respChan <- response:
+		default:
+		}
+	}
+	return exists
+}
+
+// routeResponse applies the response transformers, then hands the response to
+// a blocked Request caller waiting on its ReplyTo ID, or delivers it to the
+// destination agent's channel.
+func (h *hub) routeResponse(ctx context.Context, response *messaging.Message) error {
+	response, err := h.transformResponse(response)
+	if err != nil {
+		return err
+	}
+
+	if h.handToWaiter(response) {
+		return nil
+	}
+
+	h.agentsMutex.RLock()
+	targetReg, exists := h.agents[response.To]
+	h.agentsMutex.RUnlock()
+
+	if !exists {
+		h.deadLetter(response, response.To, DeadLetterAgentNotFound)
+		return fmt.Errorf("destination %w: %s", ErrAgentNotFound, response.To)
+	}
+
+	return h.deliver(ctx, targetReg, response)
+}
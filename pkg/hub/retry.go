@@ -0,0 +1,68 @@
+package hub
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// Retryable is implemented by handler errors that may succeed on retry.
+//
+// When HubConfig.HandlerRetry.MaxAttempts is greater than zero, handler errors
+// whose chain contains a Retryable returning true are retried with exponential
+// backoff. All other errors are dead-lettered immediately.
+type Retryable interface {
+	Retryable() bool
+}
+
+// maxRetryJitter is the maximum fraction of the backoff delay added as jitter.
+const maxRetryJitter = 0.2
+
+// invokeHandler calls the agent's handler through the middleware chain,
+// retrying transient failures according to the hub's retry policy. Returns the
+// number of attempts made.
+func (h *hub) invokeHandler(reg *registration, message *messaging.Message, msgCtx *MessageContext) (*messaging.Message, int, error) {
+	handler := h.wrapHandler(reg.Handler)
+
+	attempt := 1
+	for {
+		response, err := handler(h.ctx, message, msgCtx)
+		if err == nil || !isRetryable(err) || attempt > h.retry.MaxAttempts {
+			return response, attempt, err
+		}
+
+		delay := h.retry.Backoff(attempt)
+		delay += time.Duration(rand.Float64() * maxRetryJitter * float64(delay))
+
+		h.observer.OnEvent(h.ctx, observability.Event{
+			Type:      observability.EventMessageRetry,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceHub, h.name),
+			Data: map[string]any{
+				"hub_name":     h.name,
+				"agent_id":     reg.ID,
+				"message_id":   message.ID,
+				"attempt":      attempt,
+				"max_attempts": h.retry.MaxAttempts,
+				"delay":        delay,
+				"error":        err.Error(),
+			},
+		})
+
+		select {
+		case <-h.ctx.Done():
+			return nil, attempt, err
+		case <-time.After(delay):
+		}
+
+		attempt++
+	}
+}
+
+func isRetryable(err error) bool {
+	var retryable Retryable
+	return errors.As(err, &retryable) && retryable.Retryable()
+}
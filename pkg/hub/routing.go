@@ -0,0 +1,150 @@
+package hub
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+// RouteCondition is a declarative message predicate. Every non-empty field
+// must match; the zero value matches every message.
+type RouteCondition struct {
+	// From and To match the sender and the explicitly addressed agent
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	// Type and ContentType match the message fields of the same name
+	Type        messaging.MessageType `json:"type,omitempty"`
+	ContentType string                `json:"content_type,omitempty"`
+
+	// Headers must all be present with the given values
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// MinPriority matches messages at or above this priority
+	MinPriority messaging.Priority `json:"min_priority,omitempty"`
+}
+
+// Matches reports whether msg satisfies every field of the condition.
+func (c RouteCondition) Matches(msg *messaging.Message) bool {
+	if c.From != "" && msg.From != c.From {
+		return false
+	}
+	if c.To != "" && msg.To != c.To {
+		return false
+	}
+	if c.Type != "" && msg.Type != c.Type {
+		return false
+	}
+	if c.ContentType != "" && msg.ContentType != c.ContentType {
+		return false
+	}
+	if msg.Priority < c.MinPriority {
+		return false
+	}
+	for key, value := range c.Headers {
+		if v, ok := msg.Headers[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// RoutingRule redirects matching messages to TargetAgent.
+//
+// A rule matches when When matches and, if set, Match returns true. Match
+// covers conditions a RouteCondition cannot express; it is not serialized, so
+// rules loaded from JSON configuration use When alone.
+//
+// Example JSON:
+//
+//	{
+//	    "name": "high-priority-json",
+//	    "when": {
+//	        "content_type": "application/json",
+//	        "headers": {"X-Priority": "high"}
+//	    },
+//	    "target_agent": "fast-agent"
+//	}
+type RoutingRule struct {
+	Name        string                        `json:"name,omitempty"`
+	When        RouteCondition                `json:"when"`
+	Match       func(*messaging.Message) bool `json:"-"`
+	TargetAgent string                        `json:"target_agent"`
+}
+
+func (r RoutingRule) matches(msg *messaging.Message) bool {
+	if !r.When.Matches(msg) {
+		return false
+	}
+	return r.Match == nil || r.Match(msg)
+}
+
+// AddRoutingRule appends a rule to the hub's routing table.
+//
+// Send, SendMessage, Request, and RequestMessage evaluate rules in
+// registration order before resolving the destination; the first matching
+// rule readdresses the message to its TargetAgent. Messages no rule matches
+// go to their explicit To. Responses, broadcasts, and topic publications are
+// never rerouted. The target does not need to be registered yet; a message
+// routed to a missing agent is dead-lettered like any other.
+//
+// Example:
+//
+//	h.AddRoutingRule(hub.RoutingRule{
+//	    Name: "high-priority-json",
+//	    When: hub.RouteCondition{
+//	        ContentType: "application/json",
+//	        Headers:     map[string]string{"X-Priority": "high"},
+//	    },
+//	    TargetAgent: "fast-agent",
+//	})
+func (h *hub) AddRoutingRule(rule RoutingRule) error {
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+	if rule.TargetAgent == "" {
+		return fmt.Errorf("routing rule %q has no target agent", rule.Name)
+	}
+
+	h.routesMutex.Lock()
+	defer h.routesMutex.Unlock()
+
+	h.routes = append(h.routes, rule)
+	return nil
+}
+
+// route returns msg readdressed by the first matching routing rule, or msg
+// itself when no rule applies. The caller's message is never modified.
+func (h *hub) route(msg *messaging.Message) *messaging.Message {
+	if msg.IsResponse() {
+		return msg
+	}
+
+	h.routesMutex.RLock()
+	defer h.routesMutex.RUnlock()
+
+	for _, rule := range h.routes {
+		if !rule.matches(msg) {
+			continue
+		}
+		if rule.TargetAgent == msg.To {
+			return msg
+		}
+
+		h.logger.DebugContext(
+			h.ctx,
+			"message routed by rule",
+			slog.String("hub_name", h.name),
+			slog.String("message_id", msg.ID),
+			slog.String("rule", rule.Name),
+			slog.String("to", msg.To),
+			slog.String("target_agent", rule.TargetAgent),
+		)
+
+		routed := msg.Clone()
+		routed.To = rule.TargetAgent
+		return routed
+	}
+	return msg
+}
@@ -0,0 +1,196 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// topic buffers published messages and fans them out to subscribers.
+//
+// Each topic has a dedicated dispatcher goroutine so publishers are decoupled
+// from slow subscribers until the topic buffer fills. Topics live for the
+// lifetime of the hub once created.
+type topic struct {
+	queue       *MessageChannel[*messaging.Message]
+	subscribers map[string]*registration
+}
+
+func (h *hub) Subscribe(agentID, topicName string) (err error) {
+	defer func() { h.audit(AuditSubscribe, agentID, topicName, "", err) }()
+
+	h.agentsMutex.RLock()
+	reg, exists := h.agents[agentID]
+	h.agentsMutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+
+	h.subsMutex.Lock()
+	t, exists := h.topics[topicName]
+	if !exists {
+		t = &topic{
+			queue:       NewMessageChannel[*messaging.Message](h.ctx, h.topicBufferSize),
+			subscribers: make(map[string]*registration),
+		}
+		h.topics[topicName] = t
+		go h.dispatchTopic(topicName, t)
+	}
+	t.subscribers[agentID] = reg
+	h.subsMutex.Unlock()
+
+	h.logger.DebugContext(
+		h.ctx,
+		"agent subscribed to topic",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", agentID),
+		slog.String("topic", topicName),
+	)
+
+	return nil
+}
+
+func (h *hub) Unsubscribe(agentID, topicName string) (err error) {
+	defer func() { h.audit(AuditUnsubscribe, agentID, topicName, "", err) }()
+
+	h.subsMutex.Lock()
+	defer h.subsMutex.Unlock()
+
+	t, exists := h.topics[topicName]
+	if !exists {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+
+	if _, subscribed := t.subscribers[agentID]; !subscribed {
+		return fmt.Errorf("agent %s not subscribed to topic %s", agentID, topicName)
+	}
+
+	delete(t.subscribers, agentID)
+
+	h.logger.DebugContext(
+		h.ctx,
+		"agent unsubscribed from topic",
+		slog.String("hub_name", h.name),
+		slog.String("agent_id", agentID),
+		slog.String("topic", topicName),
+	)
+
+	return nil
+}
+
+// Publish queues msg for delivery to every subscriber of the topic except the
+// sender. It blocks only while the topic buffer is full.
+func (h *hub) Publish(ctx context.Context, topicName string, msg *messaging.Message) (err error) {
+	defer func() { h.audit(AuditPublish, msg.From, topicName, msg.ID, err) }()
+
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+
+	transformed, err := h.transformIncoming(msg)
+	if err != nil {
+		return err
+	}
+	msg = transformed
+
+	h.subsMutex.RLock()
+	t, exists := h.topics[topicName]
+	subscribers := 0
+	if exists {
+		subscribers = len(t.subscribers)
+	}
+	h.subsMutex.RUnlock()
+
+	h.observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventHubPublish,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceHub, h.name),
+		Data: map[string]any{
+			"hub_name":    h.name,
+			"topic":       topicName,
+			"from":        msg.From,
+			"message_id":  msg.ID,
+			"subscribers": subscribers,
+		},
+	})
+
+	if subscribers == 0 {
+		h.logger.DebugContext(
+			ctx,
+			"no subscribers for topic",
+			slog.String("hub_name", h.name),
+			slog.String("topic", topicName),
+		)
+		return nil
+	}
+
+	message := msg.Clone()
+	message.Topic = topicName
+
+	h.inFlight.Add(1)
+	if err := t.queue.Send(ctx, message); err != nil {
+		h.inFlight.Add(-1)
+		return fmt.Errorf("failed to publish to topic %s: %w", topicName, err)
+	}
+
+	h.updateLastSeen(msg.From)
+
+	return nil
+}
+
+// dispatchTopic fans out queued messages to the topic's current subscribers.
+func (h *hub) dispatchTopic(topicName string, t *topic) {
+	for {
+		message, err := t.queue.Receive(h.ctx)
+		if err != nil {
+			return
+		}
+
+		h.subsMutex.RLock()
+		subscribers := make([]*registration, 0, len(t.subscribers))
+		for _, reg := range t.subscribers {
+			if reg.ID != message.From {
+				subscribers = append(subscribers, reg)
+			}
+		}
+		h.subsMutex.RUnlock()
+
+		delivered := 0
+		for _, reg := range subscribers {
+			copied := message.Clone()
+			copied.To = reg.ID
+
+			if err := h.deliver(h.ctx, reg, copied); err != nil {
+				h.logger.WarnContext(
+					h.ctx,
+					"failed to deliver published message",
+					slog.String("hub_name", h.name),
+					slog.String("topic", topicName),
+					slog.String("subscriber", reg.ID),
+					slog.String("message_id", message.ID),
+					slog.String("error", err.Error()),
+				)
+			} else {
+				delivered++
+			}
+		}
+
+		h.metrics.RecordMessageSent(delivered)
+		h.inFlight.Add(-1)
+
+		h.logger.DebugContext(
+			h.ctx,
+			"message published",
+			slog.String("hub_name", h.name),
+			slog.String("topic", topicName),
+			slog.String("message_id", message.ID),
+			slog.Int("subscribers", len(subscribers)),
+			slog.Int("delivered", delivered),
+		)
+	}
+}
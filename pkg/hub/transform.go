@@ -0,0 +1,165 @@
+package hub
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/google/uuid"
+)
+
+// MessageTransformer rewrites a message as it passes through the hub, for
+// example to decrypt, decompress, or normalize its content.
+//
+// Transformers must not modify the message they receive; return a clone
+// (see Message.Clone and Message.SetHeader) or the message itself when no
+// change is needed. Returning an error rejects the message.
+type MessageTransformer func(*messaging.Message) (*messaging.Message, error)
+
+// AddTransformer registers a transformer applied to every message entering
+// the hub through Send, SendMessage, Request, RequestMessage, SendWithTimeout,
+// SendToCapable, Broadcast, and Publish, before routing rules are evaluated.
+//
+// Transformers run in registration order, each receiving the previous
+// result. When one fails, the original message is sent to the dead letter
+// queue with a DeadLetterTransformFailed reason carrying the error, and the
+// call returns an error wrapping ErrTransformFailed.
+func (h *hub) AddTransformer(transformer MessageTransformer) error {
+	return h.addTransformer(&h.transformers, transformer)
+}
+
+// AddResponseTransformer registers a transformer applied to every handler
+// response, whether returned from the handler or sent with
+// MessageContext.SendReply, before it is routed to its recipient.
+//
+// Response transformers run in registration order and fail like incoming
+// transformers: the response is dead-lettered and not delivered.
+func (h *hub) AddResponseTransformer(transformer MessageTransformer) error {
+	return h.addTransformer(&h.responseTransformers, transformer)
+}
+
+func (h *hub) addTransformer(chain *[]MessageTransformer, transformer MessageTransformer) error {
+	if h.IsShutdown() {
+		return ErrHubShutdown
+	}
+	if transformer == nil {
+		return errors.New("transformer cannot be nil")
+	}
+
+	h.transformersMutex.Lock()
+	defer h.transformersMutex.Unlock()
+
+	*chain = append(*chain, transformer)
+	return nil
+}
+
+// transformIncoming applies the incoming transformers to msg.
+func (h *hub) transformIncoming(msg *messaging.Message) (*messaging.Message, error) {
+	return h.transform(msg, &h.transformers)
+}
+
+// transformResponse applies the response transformers to response.
+func (h *hub) transformResponse(response *messaging.Message) (*messaging.Message, error) {
+	return h.transform(response, &h.responseTransformers)
+}
+
+// transform runs msg through chain, dead-lettering msg when a transformer
+// fails. chain is read under transformersMutex.
+func (h *hub) transform(msg *messaging.Message, chain *[]MessageTransformer) (*messaging.Message, error) {
+	h.transformersMutex.RLock()
+	transformers := slices.Clip(*chain)
+	h.transformersMutex.RUnlock()
+
+	current := msg
+	for i, transformer := range transformers {
+		next, err := transformer(current)
+		if err == nil && next == nil {
+			err = errors.New("transformer returned nil message")
+		}
+		if err != nil {
+			err = fmt.Errorf("transformer %d: %w", i, err)
+			h.deadLetter(msg, msg.To, DeadLetterTransformFailed+": "+err.Error())
+			return nil, messaging.WrapError(msg.ID, fmt.Errorf("%w: %w", ErrTransformFailed, err))
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// NewDecompressionTransformer returns a transformer that decompresses message
+// data according to the Content-Encoding header.
+//
+// "gzip" and "deflate" data held as []byte or string is replaced with the
+// decompressed bytes and the header is removed. Messages without the header,
+// or with "identity", pass through unchanged. Other encodings, non-byte data,
+// and corrupt data are rejected.
+//
+// Example:
+//
+//	h.AddTransformer(hub.NewDecompressionTransformer())
+func NewDecompressionTransformer() MessageTransformer {
+	return func(msg *messaging.Message) (*messaging.Message, error) {
+		encoding, exists := msg.GetHeader(messaging.HeaderContentEncoding)
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if !exists || encoding == "" || encoding == "identity" {
+			return msg, nil
+		}
+
+		var data []byte
+		switch v := msg.Data.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			return nil, fmt.Errorf("cannot decompress %T data", msg.Data)
+		}
+
+		var reader io.ReadCloser
+		switch encoding {
+		case "gzip":
+			gz, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress gzip data: %w", err)
+			}
+			reader = gz
+		case "deflate":
+			reader = flate.NewReader(bytes.NewReader(data))
+		default:
+			return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+		}
+		defer reader.Close()
+
+		decompressed, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s data: %w", encoding, err)
+		}
+
+		out := msg.Clone()
+		out.Data = decompressed
+		delete(out.Headers, messaging.HeaderContentEncoding)
+		return out, nil
+	}
+}
+
+// NewTracingHeaderTransformer returns a transformer that assigns a new
+// X-Trace-ID header to messages that do not carry one, so every message can
+// be followed through logs. Existing trace IDs are kept.
+//
+// Register it with both AddTransformer and AddResponseTransformer to trace
+// requests and their responses; LoggingMiddleware copies a request's trace ID
+// onto its response.
+func NewTracingHeaderTransformer() MessageTransformer {
+	return func(msg *messaging.Message) (*messaging.Message, error) {
+		if traceID, exists := msg.GetHeader(messaging.HeaderTraceID); exists && traceID != "" {
+			return msg, nil
+		}
+		return msg.SetHeader(messaging.HeaderTraceID, uuid.NewString()), nil
+	}
+}
@@ -0,0 +1,339 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: hub.proto
+
+package hubpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HubService_Send_FullMethodName             = "/orchestration.hub.v1.HubService/Send"
+	HubService_Broadcast_FullMethodName        = "/orchestration.hub.v1.HubService/Broadcast"
+	HubService_Subscribe_FullMethodName        = "/orchestration.hub.v1.HubService/Subscribe"
+	HubService_Publish_FullMethodName          = "/orchestration.hub.v1.HubService/Publish"
+	HubService_SubscribeTopic_FullMethodName   = "/orchestration.hub.v1.HubService/SubscribeTopic"
+	HubService_UnsubscribeTopic_FullMethodName = "/orchestration.hub.v1.HubService/UnsubscribeTopic"
+)
+
+// HubServiceClient is the client API for HubService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HubService exposes an in-process hub to agents running in other processes.
+type HubServiceClient interface {
+	// Send delivers a message. When await_response is set the message is sent
+	// as a request and the call blocks until the destination agent responds;
+	// otherwise it returns once the message is queued.
+	Send(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	// Broadcast delivers a message to every agent except the sender.
+	Broadcast(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	// Subscribe registers a remote agent with the hub and streams the messages
+	// addressed to it until the stream ends, which unregisters the agent.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageEvent], error)
+	// Publish delivers a message to every subscriber of a topic except the
+	// sender.
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// SubscribeTopic subscribes a registered agent to a topic.
+	SubscribeTopic(ctx context.Context, in *TopicRequest, opts ...grpc.CallOption) (*TopicResponse, error)
+	// UnsubscribeTopic removes an agent's subscription to a topic.
+	UnsubscribeTopic(ctx context.Context, in *TopicRequest, opts ...grpc.CallOption) (*TopicResponse, error)
+}
+
+type hubServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHubServiceClient(cc grpc.ClientConnInterface) HubServiceClient {
+	return &hubServiceClient{cc}
+}
+
+func (c *hubServiceClient) Send(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, HubService_Send_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubServiceClient) Broadcast(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResponse)
+	err := c.cc.Invoke(ctx, HubService_Broadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MessageEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HubService_ServiceDesc.Streams[0], HubService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, MessageEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HubService_SubscribeClient = grpc.ServerStreamingClient[MessageEvent]
+
+func (c *hubServiceClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, HubService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubServiceClient) SubscribeTopic(ctx context.Context, in *TopicRequest, opts ...grpc.CallOption) (*TopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopicResponse)
+	err := c.cc.Invoke(ctx, HubService_SubscribeTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubServiceClient) UnsubscribeTopic(ctx context.Context, in *TopicRequest, opts ...grpc.CallOption) (*TopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopicResponse)
+	err := c.cc.Invoke(ctx, HubService_UnsubscribeTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HubServiceServer is the server API for HubService service.
+// All implementations must embed UnimplementedHubServiceServer
+// for forward compatibility.
+//
+// HubService exposes an in-process hub to agents running in other processes.
+type HubServiceServer interface {
+	// Send delivers a message. When await_response is set the message is sent
+	// as a request and the call blocks until the destination agent responds;
+	// otherwise it returns once the message is queued.
+	Send(context.Context, *MessageRequest) (*MessageResponse, error)
+	// Broadcast delivers a message to every agent except the sender.
+	Broadcast(context.Context, *MessageRequest) (*BroadcastResponse, error)
+	// Subscribe registers a remote agent with the hub and streams the messages
+	// addressed to it until the stream ends, which unregisters the agent.
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[MessageEvent]) error
+	// Publish delivers a message to every subscriber of a topic except the
+	// sender.
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// SubscribeTopic subscribes a registered agent to a topic.
+	SubscribeTopic(context.Context, *TopicRequest) (*TopicResponse, error)
+	// UnsubscribeTopic removes an agent's subscription to a topic.
+	UnsubscribeTopic(context.Context, *TopicRequest) (*TopicResponse, error)
+	mustEmbedUnimplementedHubServiceServer()
+}
+
+// UnimplementedHubServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHubServiceServer struct{}
+
+func (UnimplementedHubServiceServer) Send(context.Context, *MessageRequest) (*MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
+}
+func (UnimplementedHubServiceServer) Broadcast(context.Context, *MessageRequest) (*BroadcastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedHubServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[MessageEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedHubServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedHubServiceServer) SubscribeTopic(context.Context, *TopicRequest) (*TopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeTopic not implemented")
+}
+func (UnimplementedHubServiceServer) UnsubscribeTopic(context.Context, *TopicRequest) (*TopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeTopic not implemented")
+}
+func (UnimplementedHubServiceServer) mustEmbedUnimplementedHubServiceServer() {}
+func (UnimplementedHubServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeHubServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HubServiceServer will
+// result in compilation errors.
+type UnsafeHubServiceServer interface {
+	mustEmbedUnimplementedHubServiceServer()
+}
+
+func RegisterHubServiceServer(s grpc.ServiceRegistrar, srv HubServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHubServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HubService_ServiceDesc, srv)
+}
+
+func _HubService_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServiceServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HubService_Send_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServiceServer).Send(ctx, req.(*MessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HubService_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServiceServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HubService_Broadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServiceServer).Broadcast(ctx, req.(*MessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HubService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, MessageEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HubService_SubscribeServer = grpc.ServerStreamingServer[MessageEvent]
+
+func _HubService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HubService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HubService_SubscribeTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServiceServer).SubscribeTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HubService_SubscribeTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServiceServer).SubscribeTopic(ctx, req.(*TopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HubService_UnsubscribeTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServiceServer).UnsubscribeTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HubService_UnsubscribeTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServiceServer).UnsubscribeTopic(ctx, req.(*TopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HubService_ServiceDesc is the grpc.ServiceDesc for HubService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HubService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orchestration.hub.v1.HubService",
+	HandlerType: (*HubServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Send",
+			Handler:    _HubService_Send_Handler,
+		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _HubService_Broadcast_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _HubService_Publish_Handler,
+		},
+		{
+			MethodName: "SubscribeTopic",
+			Handler:    _HubService_SubscribeTopic_Handler,
+		},
+		{
+			MethodName: "UnsubscribeTopic",
+			Handler:    _HubService_UnsubscribeTopic_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _HubService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hub.proto",
+}
@@ -32,11 +32,35 @@ func NewNotification(from, to string, data any) *MessageBuilder {
 	return NewMessage(from, to, MessageTypeNotification, data)
 }
 
+func NewBroadcast(from string, data any) *MessageBuilder {
+	return NewMessage(from, "", MessageTypeBroadcast, data)
+}
+
+// NewMessageWithTTL creates a notification that expires ttl after creation.
+func NewMessageWithTTL(from, to string, data any, ttl time.Duration) *MessageBuilder {
+	return NewNotification(from, to, data).TTL(ttl)
+}
+
+// WithID replaces the generated ID with an external one, such as an
+// idempotency key supplied by an upstream system. An empty id keeps a
+// generated ID.
+func (mb *MessageBuilder) WithID(id string) *MessageBuilder {
+	mb.message.ID = id
+	return mb
+}
+
 func (mb *MessageBuilder) ReplyTo(replyTo string) *MessageBuilder {
 	mb.message.ReplyTo = replyTo
 	return mb
 }
 
+// ReplyToAgent routes responses to the message to agentID instead of the
+// sender, enabling asynchronous request-response across agents.
+func (mb *MessageBuilder) ReplyToAgent(agentID string) *MessageBuilder {
+	mb.message.ReplyToAgent = agentID
+	return mb
+}
+
 func (mb *MessageBuilder) Topic(topic string) *MessageBuilder {
 	mb.message.Topic = topic
 	return mb
@@ -47,11 +71,33 @@ func (mb *MessageBuilder) Priority(priority Priority) *MessageBuilder {
 	return mb
 }
 
+// TTL sets the message to expire ttl after its creation timestamp. The hub
+// dead-letters expired messages instead of handling them. A non-positive ttl
+// clears any expiry.
+func (mb *MessageBuilder) TTL(ttl time.Duration) *MessageBuilder {
+	if ttl <= 0 {
+		mb.message.ExpiresAt = time.Time{}
+		return mb
+	}
+	mb.message.ExpiresAt = mb.message.Timestamp.Add(ttl)
+	return mb
+}
+
+// ContentType records the media type of the message data, such as
+// "application/json", so receivers can decode it with DecodeContent.
+func (mb *MessageBuilder) ContentType(contentType string) *MessageBuilder {
+	mb.message.ContentType = contentType
+	return mb
+}
+
 func (mb *MessageBuilder) Headers(headers map[string]string) *MessageBuilder {
 	mb.message.Headers = headers
 	return mb
 }
 
 func (mb *MessageBuilder) Build() *Message {
+	if mb.message.ID == "" {
+		mb.message.ID = generateID()
+	}
 	return mb.message
 }
@@ -0,0 +1,181 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+)
+
+// Standard content types with codecs registered by default.
+const (
+	ContentTypeText = "text/plain"
+	ContentTypeJSON = "application/json"
+)
+
+// ContentRegistry encodes and decodes message content by content type.
+type ContentRegistry interface {
+	Marshal(v any, contentType string) ([]byte, error)
+	Unmarshal(data []byte, contentType string, target any) error
+}
+
+// ContentCodec encodes and decodes content for a single content type.
+type ContentCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, target any) error
+}
+
+// codecs registry maps content types to codecs. Initialized with JSON and
+// plain text codecs.
+var (
+	codecs = map[string]ContentCodec{
+		ContentTypeJSON: jsonCodec{},
+		ContentTypeText: textCodec{},
+	}
+	codecsMutex sync.RWMutex
+)
+
+// DefaultContentRegistry resolves codecs registered with RegisterContentCodec.
+// An empty content type is treated as application/json.
+var DefaultContentRegistry ContentRegistry = registeredContent{}
+
+// GetContentCodec retrieves the codec registered for contentType.
+//
+// Media type parameters such as "; charset=utf-8" are ignored. Returns an
+// error if no codec is registered for the content type.
+func GetContentCodec(contentType string) (ContentCodec, error) {
+	mediaType := normalizeContentType(contentType)
+
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+
+	codec, exists := codecs[mediaType]
+	if !exists {
+		return nil, fmt.Errorf("unknown content type: %s", contentType)
+	}
+	return codec, nil
+}
+
+// RegisterContentCodec registers a codec for contentType, replacing any
+// existing codec.
+//
+// Example:
+//
+//	messaging.RegisterContentCodec("application/protobuf", protoCodec{})
+func RegisterContentCodec(contentType string, codec ContentCodec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+
+	codecs[normalizeContentType(contentType)] = codec
+}
+
+// DecodeContent decodes the message's Data into target using the codec for
+// ContentType. A nil registry uses DefaultContentRegistry.
+//
+// Data held as []byte or string is decoded directly, as received from a
+// transport. Any other value is first encoded with the same codec, converting
+// in-process payloads such as map[string]any into target's type.
+//
+// Example:
+//
+//	var task TaskRequest
+//	if err := msg.DecodeContent(&task, nil); err != nil {
+//	    return nil, err
+//	}
+func (msg *Message) DecodeContent(target any, registry ContentRegistry) error {
+	if registry == nil {
+		registry = DefaultContentRegistry
+	}
+
+	var data []byte
+	switch v := msg.Data.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		encoded, err := registry.Marshal(v, msg.ContentType)
+		if err != nil {
+			return WrapError(msg.ID, fmt.Errorf("failed to encode content: %w", err))
+		}
+		data = encoded
+	}
+
+	if err := registry.Unmarshal(data, msg.ContentType, target); err != nil {
+		return WrapError(msg.ID, fmt.Errorf("failed to decode content: %w", err))
+	}
+	return nil
+}
+
+// registeredContent implements ContentRegistry over the package codec registry.
+type registeredContent struct{}
+
+func (registeredContent) Marshal(v any, contentType string) ([]byte, error) {
+	codec, err := GetContentCodec(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Marshal(v)
+}
+
+func (registeredContent) Unmarshal(data []byte, contentType string, target any) error {
+	codec, err := GetContentCodec(contentType)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(data, target)
+}
+
+// normalizeContentType strips media type parameters and lowercases the type,
+// defaulting to application/json when empty.
+func normalizeContentType(contentType string) string {
+	if contentType == "" {
+		return ContentTypeJSON
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, target any) error {
+	return json.Unmarshal(data, target)
+}
+
+// textCodec encodes strings, byte slices, and fmt.Stringer values, and decodes
+// into *string or *[]byte.
+type textCodec struct{}
+
+func (textCodec) Marshal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	default:
+		return nil, fmt.Errorf("cannot encode %T as %s", v, ContentTypeText)
+	}
+}
+
+func (textCodec) Unmarshal(data []byte, target any) error {
+	switch t := target.(type) {
+	case *string:
+		*t = string(data)
+	case *[]byte:
+		*t = append((*t)[:0], data...)
+	default:
+		return fmt.Errorf("cannot decode %s into %T", ContentTypeText, target)
+	}
+	return nil
+}
@@ -27,13 +27,34 @@
 //
 // Each message includes:
 //
-//   - ID: UUIDv7 providing time-sortable unique identification
+//   - ID: UUIDv7 providing time-sortable unique identification (override with WithID)
 //   - Timestamp: Creation time for ordering and expiration
 //   - Priority: Four levels (Low, Normal, High, Critical)
 //   - Topic: Optional routing key for pub/sub patterns
-//   - Headers: Extensible key-value metadata
+//   - Headers: Extensible key-value metadata (trace IDs, tenant IDs) kept out of
+//     the payload; read with GetHeader, copy-on-write with SetHeader
 //   - ReplyTo: Reference to original request for responses
 //
+// # Content Types
+//
+// ContentType records how Data is encoded. DecodeContent selects a codec from a
+// ContentRegistry by content type, giving handlers strongly-typed payloads
+// while Message stays generic. JSON (the default for an empty content type) and
+// plain text codecs are registered; add others with RegisterContentCodec:
+//
+//	msg := messaging.NewRequest("api", "worker", body).
+//	    ContentType(messaging.ContentTypeJSON).
+//	    Build()
+//
+//	var task TaskRequest
+//	err := msg.DecodeContent(&task, nil)
+//
+// # Error Correlation
+//
+// MessageError attaches a message ID to an error. The hub wraps send and
+// request failures with WrapError, and MessageIDOf recovers the ID from any
+// error chain so workflow errors and logs can reference the originating message.
+//
 // # Usage Example
 //
 //	// Create a request
@@ -0,0 +1,40 @@
+package messaging
+
+import "errors"
+
+// MessageError associates an error with the message that caused it.
+//
+// The hub wraps send and request failures in a MessageError so callers, and
+// workflow error types built on top of them, can correlate failures with
+// message IDs in logs, dead letters, and observer events. Error returns the
+// wrapped error's message unchanged.
+type MessageError struct {
+	MessageID string
+	Err       error
+}
+
+func (e *MessageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// WrapError wraps err with the given message ID. Returns nil if err is nil.
+func WrapError(messageID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &MessageError{MessageID: messageID, Err: err}
+}
+
+// MessageIDOf returns the message ID of the first MessageError in err's chain,
+// or "" if there is none.
+func MessageIDOf(err error) string {
+	var msgErr *MessageError
+	if errors.As(err, &msgErr) {
+		return msgErr.MessageID
+	}
+	return ""
+}
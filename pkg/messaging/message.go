@@ -26,17 +26,31 @@ const (
 	PriorityCritical
 )
 
+// Standard headers propagated by the hub's logging middleware from requests to
+// their responses.
+const (
+	HeaderCorrelationID = "X-Correlation-ID"
+	HeaderTraceID       = "X-Trace-ID"
+)
+
+// HeaderContentEncoding names the compression applied to a message's Data,
+// such as "gzip". The hub's decompression transformer reads and removes it.
+const HeaderContentEncoding = "Content-Encoding"
+
 type Message struct {
-	ID        string            `json:"id"`
-	From      string            `json:"from"`
-	To        string            `json:"to"`
-	Type      MessageType       `json:"type"`
-	Data      any               `json:"data"`
-	ReplyTo   string            `json:"reply_to,omitempty"`
-	Topic     string            `json:"topic,omitempty"`
-	Timestamp time.Time         `json:"timestamp"`
-	Priority  Priority          `json:"priority,omitempty"`
-	Headers   map[string]string `json:"headers,omitempty"`
+	ID           string            `json:"id"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Type         MessageType       `json:"type"`
+	Data         any               `json:"data"`
+	ContentType  string            `json:"content_type,omitempty"`
+	ReplyTo      string            `json:"reply_to,omitempty"`
+	ReplyToAgent string            `json:"reply_to_agent,omitempty"`
+	Topic        string            `json:"topic,omitempty"`
+	Timestamp    time.Time         `json:"timestamp"`
+	Priority     Priority          `json:"priority,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	ExpiresAt    time.Time         `json:"expires_at,omitzero"`
 }
 
 func (msg *Message) IsRequest() bool {
@@ -51,12 +65,44 @@ func (msg *Message) IsBroadcast() bool {
 	return msg.Type == MessageTypeBroadcast
 }
 
+// IsExpired reports whether the message has an expiry that is before now.
+// Messages with a zero ExpiresAt never expire.
+func (msg *Message) IsExpired(now time.Time) bool {
+	return !msg.ExpiresAt.IsZero() && now.After(msg.ExpiresAt)
+}
+
 func (msg *Message) Clone() *Message {
 	clone := *msg
 	clone.Headers = maps.Clone(msg.Headers)
 	return &clone
 }
 
+// ReplyTarget returns the agent that should receive replies to the message:
+// ReplyToAgent when set, otherwise the sender.
+func (msg *Message) ReplyTarget() string {
+	if msg.ReplyToAgent != "" {
+		return msg.ReplyToAgent
+	}
+	return msg.From
+}
+
+// GetHeader returns the value of a header and whether it is set.
+func (msg *Message) GetHeader(key string) (string, bool) {
+	value, exists := msg.Headers[key]
+	return value, exists
+}
+
+// SetHeader returns a copy of the message with the header set. The original
+// message is not modified.
+func (msg *Message) SetHeader(key, value string) *Message {
+	clone := msg.Clone()
+	if clone.Headers == nil {
+		clone.Headers = make(map[string]string, 1)
+	}
+	clone.Headers[key] = value
+	return clone
+}
+
 func (msg *Message) String() string {
 	return fmt.Sprintf(
 		"Message{ID: %s, From: %s, To: %s, Type: %s, Topic: %s}",
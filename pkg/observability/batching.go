@@ -0,0 +1,156 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Defaults applied by NewBatchingObserver for non-positive arguments.
+const (
+	DefaultBatchSize          = 100
+	DefaultBatchFlushInterval = time.Second
+)
+
+// BatchingObserver buffers events and forwards them to an inner observer in
+// batches, keeping slow sinks off the hot path of high-throughput workflows.
+//
+// A batch is flushed when batchSize events accumulate or flushInterval passes,
+// whichever comes first. Flushes run on a background goroutine, so OnEvent
+// only appends to the buffer. The inner observer receives each event through
+// its own OnEvent call, in emission order, with the event's context stripped
+// of cancellation so a finished run does not cancel delivery. The buffer is
+// unbounded: an inner observer slower than the event rate grows it until the
+// next flush catches up.
+//
+// Close flushes pending events and stops the background goroutine; it does
+// not close the inner observer. Events arriving after Close are forwarded to
+// the inner observer directly, after the final flush.
+//
+// Example:
+//
+//	batched := observability.NewBatchingObserver(observer, 500, 250*time.Millisecond)
+//	defer batched.Close()
+//
+//	observability.RegisterObserver("batched", batched)
+type BatchingObserver struct {
+	inner     Observer
+	batchSize int
+
+	mu      sync.Mutex
+	pending []batchedEvent
+	closed  bool
+
+	deliver sync.Mutex
+	notify  chan struct{}
+	done    chan struct{}
+	drained chan struct{}
+	wg      sync.WaitGroup
+}
+
+// batchedEvent is a buffered event with the context it was emitted with.
+type batchedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewBatchingObserver creates a BatchingObserver forwarding to inner.
+// batchSize below 1 uses DefaultBatchSize and flushInterval of zero or less
+// uses DefaultBatchFlushInterval. A nil inner observer uses NoOpObserver.
+func NewBatchingObserver(inner Observer, batchSize int, flushInterval time.Duration) *BatchingObserver {
+	if inner == nil {
+		inner = NoOpObserver{}
+	}
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultBatchFlushInterval
+	}
+
+	o := &BatchingObserver{
+		inner:     inner,
+		batchSize: batchSize,
+		pending:   make([]batchedEvent, 0, batchSize),
+		notify:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		drained:   make(chan struct{}),
+	}
+
+	o.wg.Go(func() { o.run(flushInterval) })
+
+	return o
+}
+
+// OnEvent buffers event and wakes the background flush once the buffer holds
+// batchSize events.
+func (o *BatchingObserver) OnEvent(ctx context.Context, event Event) {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		<-o.drained
+		o.inner.OnEvent(ctx, event)
+		return
+	}
+
+	o.pending = append(o.pending, batchedEvent{ctx: context.WithoutCancel(ctx), event: event})
+	full := len(o.pending) >= o.batchSize
+	o.mu.Unlock()
+
+	if full {
+		select {
+		case o.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush forwards all buffered events to the inner observer before returning.
+func (o *BatchingObserver) Flush() {
+	o.deliver.Lock()
+	defer o.deliver.Unlock()
+
+	o.mu.Lock()
+	batch := o.pending
+	o.pending = make([]batchedEvent, 0, o.batchSize)
+	o.mu.Unlock()
+
+	for _, b := range batch {
+		o.inner.OnEvent(b.ctx, b.event)
+	}
+}
+
+// Close stops the background flush and forwards buffered events to the inner
+// observer. Calling Close more than once is safe.
+func (o *BatchingObserver) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	close(o.done)
+	o.mu.Unlock()
+
+	o.wg.Wait()
+	o.Flush()
+	close(o.drained)
+	return nil
+}
+
+// run flushes on each interval tick and whenever OnEvent reports a full batch.
+func (o *BatchingObserver) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.Flush()
+		case <-o.notify:
+			o.Flush()
+		case <-o.done:
+			return
+		}
+	}
+}
@@ -12,6 +12,8 @@
 //
 // EventType - Constants for all observable events across orchestration primitives
 //
+// EventSource - Structured event origin (kind: graph, state, workflow, hub; name: instance)
+//
 // NoOpObserver - Zero-cost observer implementation when observability not needed
 //
 // # Observer Registry
@@ -0,0 +1,157 @@
+package observability
+
+// EventType categorizes observable events across orchestration primitives.
+//
+// Event types are defined for all phases (2-8) to establish a consistent event
+// model across the entire orchestration infrastructure.
+type EventType string
+
+const (
+	// Phase 2: State operations
+	EventStateCreate        EventType = "state.create"
+	EventStateClone         EventType = "state.clone"
+	EventStateSet           EventType = "state.set"
+	EventStateMerge         EventType = "state.merge"
+	EventStateDelete        EventType = "state.delete"
+	EventStateFilter        EventType = "state.filter"
+	EventStateMergeConflict EventType = "state.merge.conflict"
+
+	// Phase 3: Graph execution
+	EventGraphStart     EventType = "graph.start"
+	EventGraphComplete  EventType = "graph.complete"
+	EventGraphResume    EventType = "graph.resume"
+	EventGraphError     EventType = "graph.error"
+	EventNodeStart      EventType = "node.start"
+	EventNodeComplete   EventType = "node.complete"
+	EventEdgeEvaluate   EventType = "edge.evaluate"
+	EventEdgeTransition EventType = "edge.transition"
+	EventCycleDetected  EventType = "cycle.detected"
+
+	// Phase 4: Sequential chains
+	EventChainStart    EventType = "chain.start"
+	EventChainComplete EventType = "chain.complete"
+	EventStepStart     EventType = "step.start"
+	EventStepComplete  EventType = "step.complete"
+
+	// Phase 5: Parallel execution
+	EventParallelStart    EventType = "parallel.start"
+	EventParallelComplete EventType = "parallel.complete"
+	EventWorkerStart      EventType = "worker.start"
+	EventWorkerComplete   EventType = "worker.complete"
+
+	// Phase 6: Checkpointing
+	EventCheckpointSave   EventType = "checkpoint.save"
+	EventCheckpointLoad   EventType = "checkpoint.load"
+	EventCheckpointResume EventType = "checkpoint.resume"
+	EventCheckpointError  EventType = "checkpoint.error"
+
+	// Phase 7: Conditional routing
+	EventRouteEvaluate EventType = "route.evaluate"
+	EventRouteSelect   EventType = "route.select"
+	EventRouteExecute  EventType = "route.execute"
+
+	// Scatter-gather
+	EventGatherStart    EventType = "gather.start"
+	EventGatherComplete EventType = "gather.complete"
+
+	// Saga execution
+	EventSagaStart          EventType = "saga.start"
+	EventSagaComplete       EventType = "saga.complete"
+	EventCompensateStart    EventType = "compensate.start"
+	EventCompensateComplete EventType = "compensate.complete"
+
+	// Pipeline composition
+	EventPipelineStart    EventType = "pipeline.start"
+	EventPipelineComplete EventType = "pipeline.complete"
+	EventStageStart       EventType = "stage.start"
+	EventStageComplete    EventType = "stage.complete"
+
+	// Hub messaging
+	EventHubBroadcast     EventType = "hub.broadcast"
+	EventHubAgentPause    EventType = "hub.agent.pause"
+	EventHubAgentResume   EventType = "hub.agent.resume"
+	EventHubAgentReplace  EventType = "hub.agent.replace"
+	EventMessageRetry     EventType = "message.retry"
+	EventMessageDuplicate EventType = "message.duplicate"
+	EventMessageExpired   EventType = "message.expired"
+	EventHubPublish       EventType = "hub.publish"
+
+	// Hub circuit breaker events
+	EventHubCircuitOpen     EventType = "hub.circuit.open"
+	EventHubCircuitHalfOpen EventType = "hub.circuit.half_open"
+	EventHubCircuitClose    EventType = "hub.circuit.close"
+
+	// Hub health check events
+	EventHubAgentUnhealthy EventType = "hub.agent.unhealthy"
+	EventHubAgentHealthy   EventType = "hub.agent.healthy"
+)
+
+// eventTypeNames maps each declared EventType to its constant name.
+var eventTypeNames = map[EventType]string{
+	EventStateCreate:        "EventStateCreate",
+	EventStateClone:         "EventStateClone",
+	EventStateSet:           "EventStateSet",
+	EventStateMerge:         "EventStateMerge",
+	EventStateDelete:        "EventStateDelete",
+	EventStateFilter:        "EventStateFilter",
+	EventStateMergeConflict: "EventStateMergeConflict",
+	EventGraphStart:         "EventGraphStart",
+	EventGraphComplete:      "EventGraphComplete",
+	EventGraphResume:        "EventGraphResume",
+	EventGraphError:         "EventGraphError",
+	EventNodeStart:          "EventNodeStart",
+	EventNodeComplete:       "EventNodeComplete",
+	EventEdgeEvaluate:       "EventEdgeEvaluate",
+	EventEdgeTransition:     "EventEdgeTransition",
+	EventCycleDetected:      "EventCycleDetected",
+	EventChainStart:         "EventChainStart",
+	EventChainComplete:      "EventChainComplete",
+	EventStepStart:          "EventStepStart",
+	EventStepComplete:       "EventStepComplete",
+	EventParallelStart:      "EventParallelStart",
+	EventParallelComplete:   "EventParallelComplete",
+	EventWorkerStart:        "EventWorkerStart",
+	EventWorkerComplete:     "EventWorkerComplete",
+	EventCheckpointSave:     "EventCheckpointSave",
+	EventCheckpointLoad:     "EventCheckpointLoad",
+	EventCheckpointResume:   "EventCheckpointResume",
+	EventCheckpointError:    "EventCheckpointError",
+	EventRouteEvaluate:      "EventRouteEvaluate",
+	EventRouteSelect:        "EventRouteSelect",
+	EventRouteExecute:       "EventRouteExecute",
+	EventGatherStart:        "EventGatherStart",
+	EventGatherComplete:     "EventGatherComplete",
+	EventSagaStart:          "EventSagaStart",
+	EventSagaComplete:       "EventSagaComplete",
+	EventCompensateStart:    "EventCompensateStart",
+	EventCompensateComplete: "EventCompensateComplete",
+	EventPipelineStart:      "EventPipelineStart",
+	EventPipelineComplete:   "EventPipelineComplete",
+	EventStageStart:         "EventStageStart",
+	EventStageComplete:      "EventStageComplete",
+	EventHubBroadcast:       "EventHubBroadcast",
+	EventHubAgentPause:      "EventHubAgentPause",
+	EventHubAgentResume:     "EventHubAgentResume",
+	EventHubAgentReplace:    "EventHubAgentReplace",
+	EventMessageRetry:       "EventMessageRetry",
+	EventMessageDuplicate:   "EventMessageDuplicate",
+	EventMessageExpired:     "EventMessageExpired",
+	EventHubPublish:         "EventHubPublish",
+	EventHubCircuitOpen:     "EventHubCircuitOpen",
+	EventHubCircuitHalfOpen: "EventHubCircuitHalfOpen",
+	EventHubCircuitClose:    "EventHubCircuitClose",
+	EventHubAgentUnhealthy:  "EventHubAgentUnhealthy",
+	EventHubAgentHealthy:    "EventHubAgentHealthy",
+}
+
+// String returns the Go constant name of a declared event type (for example,
+// "EventGraphStart"), or the raw value for event types declared elsewhere.
+//
+// The raw value (e.g., "graph.start") remains the stable wire format used by
+// SlogObserver and JSON encoding.
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return string(t)
+}
@@ -0,0 +1,328 @@
+package observability
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Defaults applied by NewFileObserver.
+const (
+	DefaultFileMaxBytes      = 100 << 20
+	DefaultFileMaxBackups    = 3
+	DefaultFileFlushInterval = time.Second
+)
+
+// ErrObserverClosed is returned by Flush after Close.
+var ErrObserverClosed = errors.New("observer is closed")
+
+// FileOption configures a FileObserver.
+type FileOption func(*FileObserver)
+
+// WithFileMaxBytes sets the size at which the log file is rotated. Zero or a
+// negative value disables rotation.
+func WithFileMaxBytes(n int64) FileOption {
+	return func(o *FileObserver) {
+		o.maxBytes = n
+	}
+}
+
+// WithFileMaxBackups sets how many rotated files (path.1 through path.N) are
+// kept. Zero discards the old file on rotation.
+func WithFileMaxBackups(n int) FileOption {
+	return func(o *FileObserver) {
+		o.maxBackups = max(n, 0)
+	}
+}
+
+// WithFileFlushInterval sets how often buffered events are written to the
+// file. Zero or a negative value writes every event immediately.
+func WithFileFlushInterval(d time.Duration) FileOption {
+	return func(o *FileObserver) {
+		o.flushInterval = d
+	}
+}
+
+// WithFilePretty writes indented JSON instead of one compact object per line.
+// Pretty output is easier to read by hand but is no longer line-delimited;
+// read it back with a json.Decoder.
+func WithFilePretty() FileOption {
+	return func(o *FileObserver) {
+		o.pretty = true
+	}
+}
+
+// FileObserver appends events to a file as JSON lines for durable event logs
+// without a logging stack.
+//
+// Each event is written as an object with type, timestamp, source, run_id
+// (from the event's "run_id" data field, when present), and data. Writes are
+// buffered and flushed every flush interval, on Flush, and on Close. When a
+// write would grow the file past the max size, the file is rotated: path
+// becomes path.1, path.1 becomes path.2, and so on up to the max backups, with
+// the oldest removed.
+//
+// Observers must not affect execution, so write errors are not reported from
+// OnEvent; the first one is retained and returned by the next Flush or Close.
+// FileObserver is safe for concurrent use by multiple graphs.
+//
+// Example:
+//
+//	observer, err := observability.NewFileObserver("/var/log/orchestration/events.jsonl",
+//	    observability.WithFileMaxBytes(50<<20),
+//	    observability.WithFileMaxBackups(5),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer observer.Close()
+//
+//	observability.RegisterObserver("file", observer)
+type FileObserver struct {
+	path          string
+	maxBytes      int64
+	maxBackups    int
+	flushInterval time.Duration
+	pretty        bool
+
+	mu     sync.Mutex
+	file   *os.File
+	buf    *bufio.Writer
+	size   int64
+	err    error
+	closed bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// fileRecord is the on-disk form of an Event.
+type fileRecord struct {
+	Type      EventType      `json:"type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Source    EventSource    `json:"source"`
+	RunID     string         `json:"run_id,omitempty"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// NewFileObserver opens path for appending, creating it if needed, and starts
+// the background flush. Rotation defaults to DefaultFileMaxBytes and
+// DefaultFileMaxBackups, flushing to DefaultFileFlushInterval.
+func NewFileObserver(path string, opts ...FileOption) (*FileObserver, error) {
+	o := &FileObserver{
+		path:          path,
+		maxBytes:      DefaultFileMaxBytes,
+		maxBackups:    DefaultFileMaxBackups,
+		flushInterval: DefaultFileFlushInterval,
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := o.open(); err != nil {
+		return nil, err
+	}
+
+	if o.flushInterval > 0 {
+		o.wg.Go(o.flushLoop)
+	}
+
+	return o, nil
+}
+
+// OnEvent encodes event and writes it to the buffer, rotating the file first
+// when the record would exceed the max size. Events after Close are dropped.
+func (o *FileObserver) OnEvent(ctx context.Context, event Event) {
+	record, err := o.encode(event)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+	if err != nil {
+		o.fail(err)
+		return
+	}
+
+	if o.maxBytes > 0 && o.size > 0 && o.size+int64(len(record)) > o.maxBytes {
+		if err := o.rotate(); err != nil {
+			o.fail(err)
+			return
+		}
+	}
+
+	n, err := o.buf.Write(record)
+	o.size += int64(n)
+	if err != nil {
+		o.fail(err)
+		return
+	}
+
+	if o.flushInterval <= 0 {
+		o.fail(o.buf.Flush())
+	}
+}
+
+// Flush writes buffered events to the file and returns the first write error
+// since the previous Flush, if any.
+func (o *FileObserver) Flush() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return ErrObserverClosed
+	}
+
+	o.fail(o.buf.Flush())
+	return o.takeErr()
+}
+
+// Close stops the background flush, writes buffered events, and closes the
+// file. Later events are dropped. Calling Close more than once is safe.
+func (o *FileObserver) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	close(o.done)
+	o.mu.Unlock()
+
+	o.wg.Wait()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.fail(o.buf.Flush())
+	o.fail(o.file.Close())
+	return o.takeErr()
+}
+
+func (o *FileObserver) flushLoop() {
+	ticker := time.NewTicker(o.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.mu.Lock()
+			o.fail(o.buf.Flush())
+			o.mu.Unlock()
+		case <-o.done:
+			return
+		}
+	}
+}
+
+func (o *FileObserver) encode(event Event) ([]byte, error) {
+	record := fileRecord{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Source:    event.Source,
+		Data:      event.Data,
+	}
+	if runID, ok := event.Data["run_id"].(string); ok {
+		record.RunID = runID
+	}
+
+	data, err := o.marshal(record)
+	if err != nil {
+		// Keep the event when a data value cannot be encoded as JSON.
+		record.Data = stringifyData(event.Data)
+		if data, err = o.marshal(record); err != nil {
+			return nil, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+		}
+	}
+	return append(data, '\n'), nil
+}
+
+func (o *FileObserver) marshal(record fileRecord) ([]byte, error) {
+	if o.pretty {
+		return json.MarshalIndent(record, "", "  ")
+	}
+	return json.Marshal(record)
+}
+
+func (o *FileObserver) open() error {
+	file, err := os.OpenFile(o.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log %s: %w", o.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat event log %s: %w", o.path, err)
+	}
+
+	o.file = file
+	o.size = info.Size()
+	if o.buf == nil {
+		o.buf = bufio.NewWriter(file)
+	} else {
+		o.buf.Reset(file)
+	}
+	return nil
+}
+
+// rotate closes the current file, shifts backups, and opens a fresh file.
+func (o *FileObserver) rotate() error {
+	if err := o.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush event log before rotation: %w", err)
+	}
+	if err := o.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event log for rotation: %w", err)
+	}
+
+	if o.maxBackups == 0 {
+		if err := os.Remove(o.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove event log for rotation: %w", err)
+		}
+		return o.open()
+	}
+
+	for i := o.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", o.path, i)
+		to := fmt.Sprintf("%s.%d", o.path, i+1)
+		if err := os.Rename(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate event log backup %s: %w", from, err)
+		}
+	}
+	if err := os.Rename(o.path, o.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate event log: %w", err)
+	}
+
+	return o.open()
+}
+
+// fail retains err if it is the first error since the last Flush or Close.
+func (o *FileObserver) fail(err error) {
+	if err != nil && o.err == nil {
+		o.err = err
+	}
+}
+
+func (o *FileObserver) takeErr() error {
+	err := o.err
+	o.err = nil
+	return err
+}
+
+// stringifyData formats each value with fmt so data that JSON cannot encode
+// (channels, functions, cyclic values) is still logged.
+func stringifyData(data map[string]any) map[string]any {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
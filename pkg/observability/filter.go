@@ -0,0 +1,116 @@
+package observability
+
+import (
+	"context"
+	"strings"
+)
+
+// FilterOptions selects the events a FilterObserver forwards.
+//
+// An event is forwarded when it passes every configured check:
+//   - its type is not in DenyTypes, and is in AllowTypes when AllowTypes is set
+//   - its source matches no DenySources prefix, and matches an AllowSources
+//     prefix when AllowSources is set
+//   - Predicate, when set, returns true
+//
+// Source prefixes are matched against EventSource.String(), so "graph" matches
+// every graph and "graph:review" matches one graph by name.
+type FilterOptions struct {
+	AllowTypes   []EventType
+	DenyTypes    []EventType
+	AllowSources []string
+	DenySources  []string
+	Predicate    func(Event) bool
+}
+
+// FilterObserver forwards only the events selected by its FilterOptions to an
+// inner observer.
+//
+// Combine FilterObservers with MultiObserver to give each sink its own view of
+// the event stream.
+//
+// Example:
+//
+//	observer := observability.NewMultiObserver(
+//	    fileObserver,
+//	    observability.NewFilterObserver(slogObserver, observability.FilterOptions{
+//	        AllowTypes: []observability.EventType{
+//	            observability.EventGraphComplete,
+//	            observability.EventGraphError,
+//	        },
+//	    }),
+//	)
+type FilterObserver struct {
+	inner        Observer
+	allowTypes   map[EventType]bool
+	denyTypes    map[EventType]bool
+	allowSources []string
+	denySources  []string
+	predicate    func(Event) bool
+}
+
+// NewFilterObserver creates a FilterObserver forwarding the events selected by
+// opts to inner. The option slices are copied. A nil inner observer uses
+// NoOpObserver.
+func NewFilterObserver(inner Observer, opts FilterOptions) *FilterObserver {
+	if inner == nil {
+		inner = NoOpObserver{}
+	}
+
+	return &FilterObserver{
+		inner:        inner,
+		allowTypes:   typeSet(opts.AllowTypes),
+		denyTypes:    typeSet(opts.DenyTypes),
+		allowSources: append([]string(nil), opts.AllowSources...),
+		denySources:  append([]string(nil), opts.DenySources...),
+		predicate:    opts.Predicate,
+	}
+}
+
+// OnEvent forwards event to the inner observer when it passes the filter.
+func (f *FilterObserver) OnEvent(ctx context.Context, event Event) {
+	if f.Allows(event) {
+		f.inner.OnEvent(ctx, event)
+	}
+}
+
+// Allows reports whether event passes the filter.
+func (f *FilterObserver) Allows(event Event) bool {
+	if f.denyTypes[event.Type] {
+		return false
+	}
+	if f.allowTypes != nil && !f.allowTypes[event.Type] {
+		return false
+	}
+
+	source := event.Source.String()
+	if hasAnyPrefix(source, f.denySources) {
+		return false
+	}
+	if len(f.allowSources) > 0 && !hasAnyPrefix(source, f.allowSources) {
+		return false
+	}
+
+	return f.predicate == nil || f.predicate(event)
+}
+
+func typeSet(types []EventType) map[EventType]bool {
+	if len(types) == 0 {
+		return nil
+	}
+
+	set := make(map[EventType]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -30,55 +30,10 @@ type Event struct {
 	// Timestamp records when the event occurred
 	Timestamp time.Time
 
-	// Source identifies the component that emitted the event (state, graph, chain, etc.)
-	Source string
+	// Source identifies the component that emitted the event (see EventSource)
+	Source EventSource
 
 	// Data contains metadata about the event (keys changed, duration, progress, etc.)
 	// This is execution telemetry, not application data
 	Data map[string]any
 }
-
-// EventType categorizes observable events across orchestration primitives.
-//
-// Event types are defined for all phases (2-8) to establish a consistent event
-// model across the entire orchestration infrastructure.
-type EventType string
-
-const (
-	// Phase 2: State operations
-	EventStateCreate EventType = "state.create"
-	EventStateClone  EventType = "state.clone"
-	EventStateSet    EventType = "state.set"
-	EventStateMerge  EventType = "state.merge"
-
-	// Phase 3: Graph execution
-	EventGraphStart     EventType = "graph.start"
-	EventGraphComplete  EventType = "graph.complete"
-	EventNodeStart      EventType = "node.start"
-	EventNodeComplete   EventType = "node.complete"
-	EventEdgeEvaluate   EventType = "edge.evaluate"
-	EventEdgeTransition EventType = "edge.transition"
-	EventCycleDetected  EventType = "cycle.detected"
-
-	// Phase 4: Sequential chains
-	EventChainStart    EventType = "chain.start"
-	EventChainComplete EventType = "chain.complete"
-	EventStepStart     EventType = "step.start"
-	EventStepComplete  EventType = "step.complete"
-
-	// Phase 5: Parallel execution
-	EventParallelStart    EventType = "parallel.start"
-	EventParallelComplete EventType = "parallel.complete"
-	EventWorkerStart      EventType = "worker.start"
-	EventWorkerComplete   EventType = "worker.complete"
-
-	// Phase 6: Checkpointing
-	EventCheckpointSave   EventType = "checkpoint.save"
-	EventCheckpointLoad   EventType = "checkpoint.load"
-	EventCheckpointResume EventType = "checkpoint.resume"
-
-	// Phase 7: Conditional routing
-	EventRouteEvaluate EventType = "route.evaluate"
-	EventRouteSelect   EventType = "route.select"
-	EventRouteExecute  EventType = "route.execute"
-)
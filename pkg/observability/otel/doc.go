@@ -0,0 +1,26 @@
+// Package otel provides an observability.Observer that exports graph and chain
+// execution as OpenTelemetry traces.
+//
+// Each graph run or chain call becomes a root span, opened by EventGraphStart or
+// EventChainStart, with a child span per node or step built from the matching
+// start and complete events. Spans are correlated by the "run_id" that graphs
+// and chains put on their events, so one Observer can trace many concurrent
+// runs. Event Data becomes span attributes, reported errors set the span status,
+// and other events carrying a run's ID, such as checkpoint saves, are recorded
+// as span events on the run's root span.
+//
+// The OpenTelemetry dependency is only linked into programs that import this
+// package. Importing it registers an Observer under "otel" that uses the global
+// TracerProvider, so configurations can select it by name:
+//
+//	import _ "github.com/JaimeStill/go-agents-orchestration/pkg/observability/otel"
+//
+//	cfg := config.DefaultGraphConfig("review")
+//	cfg.Observer = "otel"
+//	graph, err := state.NewGraph(cfg)
+//
+// Use NewObserver with WithTracerProvider to trace through a specific provider:
+//
+//	observer := otel.NewObserver(otel.WithTracerProvider(provider))
+//	graph, err := state.NewGraphWithDeps(cfg, observer, nil)
+package otel
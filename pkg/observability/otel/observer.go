@@ -0,0 +1,228 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the tracer the Observer creates spans with.
+const InstrumentationName = "github.com/JaimeStill/go-agents-orchestration/pkg/observability/otel"
+
+func init() {
+	observability.RegisterObserver("otel", NewObserver())
+}
+
+// Option configures an Observer.
+type Option func(*Observer)
+
+// WithTracerProvider creates spans through provider instead of the global
+// TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *Observer) {
+		o.tracer = provider.Tracer(InstrumentationName)
+	}
+}
+
+// Observer converts graph and chain events into OpenTelemetry spans.
+//
+// Spans of a run are tracked by run ID from its start event until its terminal
+// event (EventGraphComplete, EventGraphError, or EventChainComplete), which
+// ends the run's root span and any child spans still open. A start event for a
+// run ID that is already open, such as a resumed run, ends the stale spans
+// first. Events without a run ID, or for runs that are not open, are ignored.
+// Observer is safe for concurrent use.
+type Observer struct {
+	tracer trace.Tracer
+
+	mu   sync.Mutex
+	runs map[string]*run
+}
+
+// run holds the open spans of one graph run or chain call.
+type run struct {
+	span     trace.Span
+	ctx      context.Context
+	children map[string]trace.Span
+}
+
+// NewObserver creates an Observer using the global TracerProvider unless
+// WithTracerProvider is given.
+//
+// Example:
+//
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+//	observer := otel.NewObserver(otel.WithTracerProvider(provider))
+//	observability.RegisterObserver("otel", observer)
+func NewObserver(opts ...Option) *Observer {
+	o := &Observer{
+		tracer: otelapi.GetTracerProvider().Tracer(InstrumentationName),
+		runs:   make(map[string]*run),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// OnEvent records event on the spans of the run it belongs to.
+func (o *Observer) OnEvent(ctx context.Context, event observability.Event) {
+	runID, _ := event.Data["run_id"].(string)
+	if runID == "" {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	switch event.Type {
+	case observability.EventGraphStart, observability.EventChainStart:
+		o.startRun(ctx, runID, event)
+	case observability.EventNodeStart:
+		o.startChild(runID, "node:"+fmt.Sprint(event.Data["node"]), "node "+fmt.Sprint(event.Data["node"]), event)
+	case observability.EventStepStart:
+		o.startChild(runID, "step:"+fmt.Sprint(event.Data["step_index"]), "step "+fmt.Sprint(event.Data["step_index"]), event)
+	case observability.EventNodeComplete:
+		o.endChild(runID, "node:"+fmt.Sprint(event.Data["node"]), event)
+	case observability.EventStepComplete:
+		o.endChild(runID, "step:"+fmt.Sprint(event.Data["step_index"]), event)
+	case observability.EventGraphComplete, observability.EventGraphError, observability.EventChainComplete:
+		o.endRun(runID, event)
+	default:
+		if r, open := o.runs[runID]; open {
+			r.span.AddEvent(string(event.Type), trace.WithTimestamp(event.Timestamp), trace.WithAttributes(attributes(event.Data)...))
+		}
+	}
+}
+
+// startRun opens the root span of a run as a child of any span in ctx.
+func (o *Observer) startRun(ctx context.Context, runID string, event observability.Event) {
+	if stale, open := o.runs[runID]; open {
+		stale.end(event.Timestamp)
+	}
+
+	attrs := append(attributes(event.Data),
+		attribute.String("source.kind", event.Source.Kind),
+		attribute.String("source.name", event.Source.Name),
+	)
+	spanCtx, span := o.tracer.Start(ctx, event.Source.Kind+" "+event.Source.Name,
+		trace.WithTimestamp(event.Timestamp),
+		trace.WithAttributes(attrs...),
+	)
+	o.runs[runID] = &run{
+		span:     span,
+		ctx:      spanCtx,
+		children: make(map[string]trace.Span),
+	}
+}
+
+func (o *Observer) endRun(runID string, event observability.Event) {
+	r, open := o.runs[runID]
+	if !open {
+		return
+	}
+	delete(o.runs, runID)
+
+	r.span.SetAttributes(attributes(event.Data)...)
+	recordError(r.span, event.Data)
+	r.end(event.Timestamp)
+}
+
+func (o *Observer) startChild(runID, key, name string, event observability.Event) {
+	r, open := o.runs[runID]
+	if !open {
+		return
+	}
+	if stale, exists := r.children[key]; exists {
+		stale.End(trace.WithTimestamp(event.Timestamp))
+	}
+
+	_, span := o.tracer.Start(r.ctx, name,
+		trace.WithTimestamp(event.Timestamp),
+		trace.WithAttributes(attributes(event.Data)...),
+	)
+	r.children[key] = span
+}
+
+func (o *Observer) endChild(runID, key string, event observability.Event) {
+	r, open := o.runs[runID]
+	if !open {
+		return
+	}
+	span, exists := r.children[key]
+	if !exists {
+		return
+	}
+	delete(r.children, key)
+
+	span.SetAttributes(attributes(event.Data)...)
+	recordError(span, event.Data)
+	span.End(trace.WithTimestamp(event.Timestamp))
+}
+
+// end ends the run's open child spans, then its root span.
+func (r *run) end(at time.Time) {
+	for _, child := range r.children {
+		child.End(trace.WithTimestamp(at))
+	}
+	r.span.End(trace.WithTimestamp(at))
+}
+
+// recordError marks span as failed when data reports an error, either as true
+// or as a non-empty message (see observability.SlogObserver).
+func recordError(span trace.Span, data map[string]any) {
+	switch err := data["error"].(type) {
+	case string:
+		if err == "" {
+			return
+		}
+		span.RecordError(errors.New(err))
+		span.SetStatus(codes.Error, err)
+	case bool:
+		if !err {
+			return
+		}
+		description, _ := data["error_type"].(string)
+		span.SetStatus(codes.Error, description)
+	}
+}
+
+// attributes converts event data to span attributes. Maps, such as state
+// snapshots and diffs, are omitted; values of other unsupported types are
+// formatted as strings.
+func attributes(data map[string]any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(data))
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			attrs = append(attrs, attribute.String(key, v))
+		case bool:
+			attrs = append(attrs, attribute.Bool(key, v))
+		case int:
+			attrs = append(attrs, attribute.Int(key, v))
+		case int64:
+			attrs = append(attrs, attribute.Int64(key, v))
+		case uint64:
+			attrs = append(attrs, attribute.String(key, strconv.FormatUint(v, 10)))
+		case float64:
+			attrs = append(attrs, attribute.Float64(key, v))
+		case time.Duration:
+			attrs = append(attrs, attribute.Int64(key+"_ms", v.Milliseconds()))
+		case []string:
+			attrs = append(attrs, attribute.StringSlice(key, v))
+		case map[string]any, nil:
+		default:
+			attrs = append(attrs, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
@@ -54,7 +54,7 @@ func GetObserver(name string) (Observer, error) {
 //
 //	type MyObserver struct{ logger *slog.Logger }
 //	func (o *MyObserver) OnEvent(ctx context.Context, event Event) {
-//	    o.logger.Info("event", "type", event.Type, "source", event.Source)
+//	    o.logger.Info("event", "type", event.Type, "source", event.Source.String())
 //	}
 //
 //	observability.RegisterObserver("my-observer", &MyObserver{logger})
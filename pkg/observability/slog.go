@@ -3,16 +3,19 @@ package observability
 import (
 	"context"
 	"log/slog"
+	"maps"
 )
 
 // SlogObserver provides structured logging observability using Go's slog package.
 //
-// SlogObserver writes all orchestration events to a structured logger at Info level,
-// capturing event type, source, timestamp, and associated metadata. This enables
-// debugging and monitoring of workflow execution through standard log aggregation tools.
+// SlogObserver writes all orchestration events to a structured logger, capturing event
+// type, source, timestamp, and associated metadata. This enables debugging and
+// monitoring of workflow execution through standard log aggregation tools.
 //
-// The observer uses slog's context-aware logging (InfoContext) to propagate cancellation
-// signals and tracing context from the workflow execution context.
+// Observers created with NewSlogObserver log every event at Info level;
+// NewSlogObserverWithLevels assigns levels per event type. The observer uses slog's
+// context-aware logging to propagate cancellation signals and tracing context from
+// the workflow execution context.
 //
 // Example:
 //
@@ -24,6 +27,7 @@ import (
 //	result, err := workflows.ProcessChain(ctx, cfg, items, initial, processor, nil)
 type SlogObserver struct {
 	logger *slog.Logger
+	levels map[EventType]slog.Level
 }
 
 // NewSlogObserver creates a new SlogObserver with the specified logger.
@@ -48,22 +52,118 @@ func NewSlogObserver(logger *slog.Logger) *SlogObserver {
 	}
 }
 
-// OnEvent logs the event at Info level with structured fields.
+// NewSlogObserverWithLevels creates a SlogObserver that logs each event type at
+// the level given in levels.
+//
+// Event types missing from levels are logged at slog.LevelDebug. Events reporting
+// a failure (an "error" data field that is true or a non-empty string) are logged
+// at slog.LevelError whatever their type. levels is copied; later changes to the
+// map do not affect the observer.
+//
+// Example:
+//
+//	levels := observability.DefaultSlogLevels()
+//	levels[observability.EventCheckpointSave] = slog.LevelInfo
+//	observer := observability.NewSlogObserverWithLevels(logger, levels)
+func NewSlogObserverWithLevels(logger *slog.Logger, levels map[EventType]slog.Level) *SlogObserver {
+	copied := make(map[EventType]slog.Level, len(levels))
+	maps.Copy(copied, levels)
+
+	return &SlogObserver{
+		logger: logger,
+		levels: copied,
+	}
+}
+
+// DefaultSlogLevels returns a new level map suited to production logging:
+//   - Debug: state, node, edge, step, worker, and stage events, and other
+//     per-item detail
+//   - Info: graph, chain, parallel, pipeline, saga, and gather lifecycle events,
+//     checkpoint loads, routing decisions, and agent lifecycle changes
+//   - Warn: cycles, retries, expired messages, compensation, opened circuits,
+//     and unhealthy agents
+//
+// Failed operations are logged at Error by NewSlogObserverWithLevels regardless of
+// this map.
+func DefaultSlogLevels() map[EventType]slog.Level {
+	return map[EventType]slog.Level{
+		EventNodeStart:          slog.LevelDebug,
+		EventNodeComplete:       slog.LevelDebug,
+		EventStepStart:          slog.LevelDebug,
+		EventStepComplete:       slog.LevelDebug,
+		EventGraphStart:         slog.LevelInfo,
+		EventGraphComplete:      slog.LevelInfo,
+		EventGraphResume:        slog.LevelInfo,
+		EventChainStart:         slog.LevelInfo,
+		EventChainComplete:      slog.LevelInfo,
+		EventParallelStart:      slog.LevelInfo,
+		EventParallelComplete:   slog.LevelInfo,
+		EventPipelineStart:      slog.LevelInfo,
+		EventPipelineComplete:   slog.LevelInfo,
+		EventSagaStart:          slog.LevelInfo,
+		EventSagaComplete:       slog.LevelInfo,
+		EventGatherStart:        slog.LevelInfo,
+		EventGatherComplete:     slog.LevelInfo,
+		EventCheckpointLoad:     slog.LevelInfo,
+		EventCheckpointResume:   slog.LevelInfo,
+		EventRouteSelect:        slog.LevelInfo,
+		EventHubAgentPause:      slog.LevelInfo,
+		EventHubAgentResume:     slog.LevelInfo,
+		EventHubAgentReplace:    slog.LevelInfo,
+		EventHubAgentHealthy:    slog.LevelInfo,
+		EventHubCircuitHalfOpen: slog.LevelInfo,
+		EventHubCircuitClose:    slog.LevelInfo,
+		EventCycleDetected:      slog.LevelWarn,
+		EventMessageRetry:       slog.LevelWarn,
+		EventMessageExpired:     slog.LevelWarn,
+		EventCompensateStart:    slog.LevelWarn,
+		EventCompensateComplete: slog.LevelWarn,
+		EventHubCircuitOpen:     slog.LevelWarn,
+		EventHubAgentUnhealthy:  slog.LevelWarn,
+	}
+}
+
+// OnEvent logs the event with structured fields at the level configured for its
+// type (Info for observers created with NewSlogObserver).
 //
 // The event is logged with the following slog attributes:
 //   - type: The EventType constant (e.g., "chain.start")
-//   - source: The component that emitted the event (e.g., "workflows.ProcessChain")
+//   - source: The component that emitted the event (e.g., "workflow:ProcessChain")
 //   - timestamp: When the event occurred
 //   - data: Event-specific metadata map
 //
-// The context is propagated to InfoContext for cancellation and tracing integration.
+// The context is propagated to the logger for cancellation and tracing integration.
 func (o *SlogObserver) OnEvent(ctx context.Context, event Event) {
-	o.logger.InfoContext(
+	o.logger.Log(
 		ctx,
+		o.level(event),
 		"Event",
-		"type", event.Type,
-		"source", event.Source,
+		"type", string(event.Type),
+		"source", event.Source.String(),
 		"timestamp", event.Timestamp,
 		"data", event.Data,
 	)
 }
+
+// level returns the level event is logged at.
+func (o *SlogObserver) level(event Event) slog.Level {
+	if o.levels == nil {
+		return slog.LevelInfo
+	}
+
+	switch failure := event.Data["error"].(type) {
+	case bool:
+		if failure {
+			return slog.LevelError
+		}
+	case string:
+		if failure != "" {
+			return slog.LevelError
+		}
+	}
+
+	if level, ok := o.levels[event.Type]; ok {
+		return level
+	}
+	return slog.LevelDebug
+}
@@ -0,0 +1,42 @@
+package observability
+
+// Event source kinds identify the category of component that emitted an event.
+const (
+	SourceGraph    = "graph"
+	SourceState    = "state"
+	SourceWorkflow = "workflow"
+	SourceHub      = "hub"
+)
+
+// EventSource identifies the component that emitted an event.
+//
+// Kind categorizes the component (SourceGraph, SourceState, SourceWorkflow,
+// SourceHub) so observers can filter events by origin without parsing strings.
+// Name identifies the instance: the graph or hub name, the state's run ID, or
+// the workflow pattern (e.g., "ProcessChain").
+//
+// Example:
+//
+//	func (o *GraphOnly) OnEvent(ctx context.Context, event observability.Event) {
+//	    if event.Source.Kind != observability.SourceGraph {
+//	        return
+//	    }
+//	    o.record(event.Source.Name, event)
+//	}
+type EventSource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name,omitempty"`
+}
+
+// NewEventSource creates an EventSource with the given kind and instance name.
+func NewEventSource(kind, name string) EventSource {
+	return EventSource{Kind: kind, Name: name}
+}
+
+// String returns the source as "kind:name", or just the kind when Name is empty.
+func (s EventSource) String() string {
+	if s.Name == "" {
+		return s.Kind
+	}
+	return s.Kind + ":" + s.Name
+}
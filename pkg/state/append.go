@@ -0,0 +1,86 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Append creates a new State with values appended to the slice stored at key.
+//
+// A missing key is treated as an empty []any. An existing slice keeps its type:
+// values are appended when assignable to its element type, and the slice is
+// copied so States sharing it are not affected. Emits EventStateSet like Set.
+//
+// When the key holds a non-slice value, or a value cannot be appended to its
+// slice type, the State is returned unchanged. Use TryAppend to detect this.
+//
+// Example:
+//
+//	s = s.Append("findings", "missing license header", "unused import")
+func (s State) Append(key string, values ...any) State {
+	newState, err := s.TryAppend(key, values...)
+	if err != nil {
+		return s
+	}
+	return newState
+}
+
+// TryAppend is Append for callers that need to know when values cannot be
+// appended.
+//
+// Returns the original State and an error when the key holds a value that is
+// not a slice, a value is not assignable to the slice's element type, or the
+// key is frozen (wrapping ErrFrozenKey).
+func (s State) TryAppend(key string, values ...any) (State, error) {
+	return s.Update(key, func(current any, exists bool) (any, error) {
+		if !exists || current == nil {
+			return append([]any{}, values...), nil
+		}
+		return appendValues(current, values)
+	})
+}
+
+// AppendAs creates a new State with items appended to the []T stored at key.
+//
+// A missing key is treated as an empty []T, so the stored value stays typed
+// for GetAs[[]T]. The slice is copied rather than modified. Returns the
+// original State and an error when the key holds a value that is not a []T,
+// or the key is frozen.
+//
+// Example:
+//
+//	s, err := state.AppendAs(s, "messages", Message{Role: "user", Content: prompt})
+func AppendAs[T any](s State, key string, items ...T) (State, error) {
+	return UpdateAs(s, key, func(current []T) []T {
+		return append(slices.Clip(current), items...)
+	})
+}
+
+// appendValues returns a copy of the slice current with values appended.
+func appendValues(current any, values []any) (any, error) {
+	cur := reflect.ValueOf(current)
+	if cur.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("cannot append to %T: not a slice", current)
+	}
+
+	elemType := cur.Type().Elem()
+	result := reflect.MakeSlice(cur.Type(), 0, cur.Len()+len(values))
+	result = reflect.AppendSlice(result, cur)
+
+	for _, value := range values {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() {
+			if !isNillable(elemType.Kind()) {
+				return nil, fmt.Errorf("cannot append nil to %T", current)
+			}
+			v = reflect.Zero(elemType)
+		}
+		if !v.Type().AssignableTo(elemType) {
+			return nil, fmt.Errorf("cannot append %T to %T", value, current)
+		}
+		result = reflect.Append(result, v)
+	}
+
+	return result.Interface(), nil
+}
@@ -0,0 +1,256 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// AuditEntry is one line of a graph audit log: a single node visit.
+//
+// ExportAuditLog writes one entry per node visited, in execution order, as
+// newline-delimited JSON. Each line conforms to this JSON Schema:
+//
+//	{
+//	  "$schema": "https://json-schema.org/draft/2020-12/schema",
+//	  "title": "AuditEntry",
+//	  "type": "object",
+//	  "required": ["graph", "run_id", "sequence", "node", "iteration", "started_at", "events"],
+//	  "properties": {
+//	    "graph":        {"type": "string", "description": "graph name"},
+//	    "run_id":       {"type": "string", "description": "State.RunID of the execution"},
+//	    "sequence":     {"type": "integer", "minimum": 1, "description": "position in the execution path"},
+//	    "node":         {"type": "string"},
+//	    "iteration":    {"type": "integer", "minimum": 1},
+//	    "started_at":   {"type": "string", "format": "date-time"},
+//	    "completed_at": {"type": "string", "format": "date-time", "description": "absent if the node did not complete"},
+//	    "state":        {"type": "object", "description": "state data after the node, with sensitive keys redacted"},
+//	    "error":        {"type": "string", "description": "execution error, on the entry of the node that failed"},
+//	    "events": {
+//	      "type": "array",
+//	      "items": {
+//	        "type": "object",
+//	        "required": ["type", "timestamp", "source"],
+//	        "properties": {
+//	          "type":      {"type": "string", "description": "EventType value, e.g. \"node.start\""},
+//	          "timestamp": {"type": "string", "format": "date-time"},
+//	          "source":    {"type": "object", "properties": {"kind": {"type": "string"}, "name": {"type": "string"}}},
+//	          "data":      {"type": "object"}
+//	        }
+//	      }
+//	    }
+//	  }
+//	}
+//
+// Events are the observer events emitted while the node ran; events emitted
+// before the first node (graph.start) belong to the first entry and events
+// after the last node (checkpoint saves, graph.complete) to the last.
+type AuditEntry struct {
+	Graph       string         `json:"graph"`
+	RunID       string         `json:"run_id"`
+	Sequence    int            `json:"sequence"`
+	Node        string         `json:"node"`
+	Iteration   int            `json:"iteration"`
+	StartedAt   time.Time      `json:"started_at"`
+	CompletedAt time.Time      `json:"completed_at,omitzero"`
+	State       map[string]any `json:"state,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Events      []AuditEvent   `json:"events"`
+}
+
+// AuditEvent is an observer event recorded in an AuditEntry.
+type AuditEvent struct {
+	Type      observability.EventType   `json:"type"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Source    observability.EventSource `json:"source"`
+	Data      map[string]any            `json:"data,omitempty"`
+}
+
+// auditTrace accumulates the audit entries of one execution.
+type auditTrace struct {
+	graph string
+	runID string
+
+	mu      sync.Mutex
+	entries []AuditEntry
+	pending []AuditEvent
+}
+
+type auditTraceKey struct{}
+
+// record adds event to the trace, opening a new entry on EventNodeStart and
+// completing the current one on EventNodeComplete.
+func (t *auditTrace) record(event observability.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	recorded := AuditEvent{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Source:    event.Source,
+		Data:      event.Data,
+	}
+
+	switch event.Type {
+	case observability.EventNodeStart:
+		node, _ := event.Data["node"].(string)
+		iteration, _ := event.Data["iteration"].(int)
+		t.entries = append(t.entries, AuditEntry{
+			Graph:     t.graph,
+			RunID:     t.runID,
+			Sequence:  len(t.entries) + 1,
+			Node:      node,
+			Iteration: iteration,
+			StartedAt: event.Timestamp,
+			Events:    append(t.pending, recorded),
+		})
+		t.pending = nil
+		return
+	case observability.EventNodeComplete:
+		if current := t.current(); current != nil {
+			current.CompletedAt = event.Timestamp
+			current.State, _ = event.Data["output_snapshot"].(map[string]any)
+		}
+	}
+
+	if current := t.current(); current != nil {
+		current.Events = append(current.Events, recorded)
+	} else {
+		t.pending = append(t.pending, recorded)
+	}
+}
+
+func (t *auditTrace) current() *AuditEntry {
+	if len(t.entries) == 0 {
+		return nil
+	}
+	return &t.entries[len(t.entries)-1]
+}
+
+// finish attaches the execution error, if any, to the last entry.
+func (t *auditTrace) finish(err error) {
+	if err == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if current := t.current(); current != nil {
+		current.Error = err.Error()
+	}
+}
+
+// auditObserver forwards events to the graph's observer and records them in
+// the audit trace carried by the context, if any.
+type auditObserver struct {
+	observability.Observer
+}
+
+func (a auditObserver) OnEvent(ctx context.Context, event observability.Event) {
+	a.Observer.OnEvent(ctx, event)
+
+	if trace, ok := ctx.Value(auditTraceKey{}).(*auditTrace); ok {
+		trace.record(event)
+	}
+}
+
+// maxAuditRuns bounds the execution traces a graph retains for
+// ExportAuditLogFor; the oldest trace is dropped when a new run starts.
+const maxAuditRuns = 64
+
+// beginAudit starts the audit trace of an execution and returns the context
+// that carries it to the graph's observer. A new execution of a retained
+// RunID, such as a Resume, replaces that run's trace.
+func (g *stateGraph) beginAudit(ctx context.Context, runID string) (context.Context, *auditTrace) {
+	trace := &auditTrace{graph: g.name, runID: runID}
+
+	g.auditMutex.Lock()
+	g.audit = trace
+	if _, exists := g.audits[runID]; exists {
+		g.auditOrder = slices.DeleteFunc(g.auditOrder, func(id string) bool { return id == runID })
+	}
+	g.audits[runID] = trace
+	g.auditOrder = append(g.auditOrder, runID)
+	if len(g.auditOrder) > maxAuditRuns {
+		delete(g.audits, g.auditOrder[0])
+		g.auditOrder = slices.Delete(g.auditOrder, 0, 1)
+	}
+	g.auditMutex.Unlock()
+
+	return context.WithValue(ctx, auditTraceKey{}, trace), trace
+}
+
+// ExportAuditLog writes the audit log of the graph's most recently started
+// execution (Execute, ExecuteWithResult, Resume, or ResumeVersion) to w as
+// newline-delimited JSON, one AuditEntry per node visited.
+//
+// Requires GraphConfig.EnableAuditLog; capture is off by default to avoid its
+// overhead. An execution still in progress exports the entries recorded so
+// far. When runs execute concurrently the most recent one may belong to
+// another caller; use ExportAuditLogFor to export a specific run.
+func (g *stateGraph) ExportAuditLog(w io.Writer) error {
+	if !g.auditLog {
+		return fmt.Errorf("audit log not enabled for this graph")
+	}
+
+	g.auditMutex.Lock()
+	trace := g.audit
+	g.auditMutex.Unlock()
+
+	if trace == nil {
+		return fmt.Errorf("no execution recorded in audit log")
+	}
+	return trace.export(w)
+}
+
+// ExportAuditLogFor writes the audit log of the execution with runID to w as
+// newline-delimited JSON, one AuditEntry per node visited.
+//
+// The graph retains the traces of its 64 most recently started runs; older
+// runs return an error. Requires GraphConfig.EnableAuditLog.
+//
+// Example:
+//
+//	cfg.EnableAuditLog = true
+//	graph, _ := state.NewGraph(cfg)
+//	_, err := graph.Execute(ctx, initial)
+//
+//	f, _ := os.Create("audit-" + initial.RunID + ".ndjson")
+//	defer f.Close()
+//	graph.ExportAuditLogFor(initial.RunID, f)
+func (g *stateGraph) ExportAuditLogFor(runID string, w io.Writer) error {
+	if !g.auditLog {
+		return fmt.Errorf("audit log not enabled for this graph")
+	}
+
+	g.auditMutex.Lock()
+	trace, exists := g.audits[runID]
+	g.auditMutex.Unlock()
+
+	if !exists {
+		return fmt.Errorf("no execution recorded in audit log for run %s", runID)
+	}
+	return trace.export(w)
+}
+
+// export writes the entries recorded so far as newline-delimited JSON.
+func (t *auditTrace) export(w io.Writer) error {
+	t.mu.Lock()
+	entries := slices.Clone(t.entries)
+	t.mu.Unlock()
+
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write audit entry %d: %w", entry.Sequence, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// StateBuilder converts between State and a schema struct T.
+//
+// Each exported field of T maps to the State key named by its json tag, or the
+// field name when untagged. Fields tagged "-" are skipped, "omitempty" fields
+// are not written when zero, and untagged embedded structs are flattened. Nodes
+// that know a workflow's schema use a StateBuilder for typed access; nodes that
+// do not keep using Get and Set on the same State.
+//
+// The zero value is ready to use. Observer is attached to States created by
+// From; nil uses NoOpObserver.
+//
+// Example:
+//
+//	type Review struct {
+//	    DocumentID string   `json:"document_id"`
+//	    Score      int      `json:"score"`
+//	    Tags       []string `json:"tags,omitempty"`
+//	}
+//
+//	var reviews state.StateBuilder[Review]
+//
+//	s := reviews.From(Review{DocumentID: "doc-1"})
+//
+//	review, err := reviews.To(s)
+//	review.Score = 90
+//	s = reviews.Apply(s, review)
+type StateBuilder[T any] struct {
+	Observer observability.Observer
+}
+
+// From creates a new State holding the fields of schema as keys.
+//
+// Values are stored with their Go types, so GetAs works on them directly.
+// Panics if T is not a struct type.
+func (b StateBuilder[T]) From(schema T) State {
+	return NewFromMap(b.Observer, b.encode(schema))
+}
+
+// Apply returns s with the fields of schema set as keys, leaving other keys
+// untouched. The update behaves like SetMany, including its frozen-key
+// handling and single EventStateSet.
+//
+// Panics if T is not a struct type.
+func (b StateBuilder[T]) Apply(s State, schema T) State {
+	return s.SetMany(b.encode(schema))
+}
+
+// To reads the schema fields of T from state.
+//
+// Missing keys leave their fields at the zero value. Values whose type is
+// assignable to the field are used as-is; others are converted through JSON,
+// which recovers typed values after a JSONCodec resume (float64 to int,
+// map[string]any to struct). Returns an error naming the key when a value
+// cannot be converted, or when T is not a struct type.
+func (b StateBuilder[T]) To(state State) (T, error) {
+	var schema T
+
+	rv := reflect.ValueOf(&schema).Elem()
+	if rv.Kind() != reflect.Struct {
+		return schema, fmt.Errorf("state builder type %s is not a struct", rv.Type())
+	}
+
+	for _, field := range builderFields(rv.Type()) {
+		val, exists := state.Data[field.key]
+		if !exists || val == nil {
+			continue
+		}
+
+		if err := assignField(rv.FieldByIndex(field.index), val); err != nil {
+			return schema, fmt.Errorf("failed to read state key %q: %w", field.key, err)
+		}
+	}
+
+	return schema, nil
+}
+
+func (b StateBuilder[T]) encode(schema T) map[string]any {
+	rv := reflect.ValueOf(schema)
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("state builder type %s is not a struct", reflect.TypeFor[T]()))
+	}
+
+	values := make(map[string]any)
+	for _, field := range builderFields(rv.Type()) {
+		fv := rv.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+		values[field.key] = fv.Interface()
+	}
+	return values
+}
+
+// builderField is a struct field exposed as a State key.
+type builderField struct {
+	key       string
+	index     []int
+	omitEmpty bool
+}
+
+// builderFields lists the State keys of struct type t following encoding/json
+// naming: json tag name, else field name, with untagged embedded structs
+// flattened into the parent.
+func builderFields(t reflect.Type) []builderField {
+	var fields []builderField
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			for _, inner := range builderFields(sf.Type) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields = append(fields, builderField{
+			key:       name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+
+	return fields
+}
+
+// assignField stores val in field, converting through JSON when the stored
+// type is not assignable to the field type.
+func assignField(field reflect.Value, val any) error {
+	rv := reflect.ValueOf(val)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %w", val, field.Type(), err)
+	}
+
+	target := reflect.New(field.Type())
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %w", val, field.Type(), err)
+	}
+
+	field.Set(target.Elem())
+	return nil
+}
@@ -1,8 +1,13 @@
 package state
 
 import (
+	"container/list"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
 )
 
 // CheckpointStore provides persistence for workflow state during execution.
@@ -32,18 +37,41 @@ type CheckpointStore interface {
 	Delete(runID string) error
 
 	// List returns all RunIDs with stored checkpoints.
-	// Useful for monitoring and cleanup operations.
+	// Useful for monitoring and cleanup operations; ListCheckpointInfo
+	// adds each checkpoint's node, timestamp, and size.
 	List() ([]string, error)
+
+	// SaveIfAbsent persists State only if no checkpoint exists for its RunID.
+	// Returns true if the State was saved and false if a checkpoint already
+	// existed. The check and save are atomic, so when several processes race
+	// to initialize the same run exactly one of them saves.
+	SaveIfAbsent(state State) (bool, error)
 }
 
 // memoryCheckpointStore implements CheckpointStore with in-memory storage.
 //
 // Thread-safe implementation using sync.RWMutex. Checkpoints are lost when
 // process terminates - suitable for development and testing but not production
-// recovery scenarios.
+// recovery scenarios. Every Save is kept as a new version (see
+// VersionedCheckpointStore) until pruned or deleted.
 type memoryCheckpointStore struct {
-	states map[string]State
-	mu     sync.RWMutex
+	states    map[string][]checkpointVersion
+	histories map[string]StateHistory
+	graphs    map[string]map[string]bool
+	mu        sync.RWMutex
+
+	// Bounded stores (see NewMemoryCheckpointStoreWithCapacity) track runs
+	// from most to least recently saved; capacity is zero when unbounded.
+	capacity  int
+	recency   *list.List
+	elements  map[string]*list.Element
+	evictions uint64
+}
+
+// checkpointVersion is one saved State of a run.
+type checkpointVersion struct {
+	version int
+	state   State
 }
 
 // NewMemoryCheckpointStore creates a CheckpointStore with in-memory storage.
@@ -54,36 +82,58 @@ type memoryCheckpointStore struct {
 //	cfg := config.DefaultGraphConfig("workflow")
 //	cfg.Checkpoint.Store = "memory"
 //	cfg.Checkpoint.Interval = 5
+//
+// The returned store also implements VersionedCheckpointStore and
+// MemoryStatsReporter. It grows without bound; use
+// NewMemoryCheckpointStoreWithCapacity to cap the number of runs kept.
 func NewMemoryCheckpointStore() CheckpointStore {
-	return &memoryCheckpointStore{
-		states: make(map[string]State),
-	}
+	return NewMemoryCheckpointStoreWithCapacity(0)
 }
 
 func (m *memoryCheckpointStore) Save(state State) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.states[state.RunID] = state
+	versions := m.states[state.RunID]
+	next := 1
+	if len(versions) > 0 {
+		next = versions[len(versions)-1].version + 1
+	}
+	m.indexGraph(state.RunID, m.latestGraph(state.RunID), state.Graph)
+	m.states[state.RunID] = append(versions, checkpointVersion{version: next, state: state})
+	m.touch(state.RunID)
 	return nil
 }
 
+func (m *memoryCheckpointStore) SaveIfAbsent(state State) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.states[state.RunID]) > 0 {
+		return false, nil
+	}
+	m.states[state.RunID] = []checkpointVersion{{version: 1, state: state}}
+	m.indexGraph(state.RunID, state.Graph, state.Graph)
+	m.touch(state.RunID)
+	return true, nil
+}
+
 func (m *memoryCheckpointStore) Load(runID string) (State, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	state, exists := m.states[runID]
-	if !exists {
+	versions, exists := m.states[runID]
+	if !exists || len(versions) == 0 {
 		return State{}, fmt.Errorf("checkpoint not found: %s", runID)
 	}
-	return state, nil
+	return versions[len(versions)-1].state, nil
 }
 
 func (m *memoryCheckpointStore) Delete(runID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	delete(m.states, runID)
+	m.forget(runID)
 	return nil
 }
 
@@ -98,58 +148,203 @@ func (m *memoryCheckpointStore) List() ([]string, error) {
 	return ids, nil
 }
 
-// checkpointStores is the global registry of named CheckpointStore implementations.
+func (m *memoryCheckpointStore) SaveHistory(runID string, history StateHistory) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.histories[runID] = slices.Clone(history)
+	return nil
+}
+
+func (m *memoryCheckpointStore) LoadHistory(runID string) (StateHistory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history, exists := m.histories[runID]
+	if !exists {
+		return nil, fmt.Errorf("history not found: %s", runID)
+	}
+	return slices.Clone(history), nil
+}
+
+// CheckpointStoreFactory constructs a CheckpointStore from store-specific
+// options, typically CheckpointConfig.Options. Factories are called once per
+// graph, so each graph receives its own store instance.
+type CheckpointStoreFactory func(options map[string]any) (CheckpointStore, error)
+
+// checkpointStores is the global registry of named CheckpointStore factories.
 //
-// The "memory" store is registered by default. Custom stores can be added via
-// RegisterCheckpointStore before graph initialization.
+// The "memory" and "file" factories are registered by default. Custom stores
+// can be added via RegisterCheckpointStoreFactory before graph initialization.
 var (
-	checkpointStores = map[string]CheckpointStore{
-		"memory": NewMemoryCheckpointStore(),
+	checkpointStores = map[string]CheckpointStoreFactory{
+		"memory": newMemoryCheckpointStoreFromOptions,
+		"file":   newFileCheckpointStoreFromOptions,
 	}
 	mutex sync.RWMutex
 )
 
-// GetCheckpointStore retrieves a CheckpointStore by name from the registry.
+// GetCheckpointStore constructs a CheckpointStore by name from the registry,
+// passing options to the store's factory.
 //
-// Returns error if the requested store is not registered. Use
-// RegisterCheckpointStore to add custom implementations.
+// Each call returns a new store from the factory; the "memory" store, for
+// example, does not share checkpoints between calls. Returns error if the
+// requested store is not registered or the factory rejects options.
 //
 // This function is called by NewGraph during initialization to resolve the
-// store specified in CheckpointConfig.Store.
+// store specified in CheckpointConfig.Store with CheckpointConfig.Options.
 //
 // Example:
 //
-//	store, err := state.GetCheckpointStore("memory")
+//	store, err := state.GetCheckpointStore("file", map[string]any{
+//	    "dir":   "/var/lib/workflow/checkpoints",
+//	    "codec": "gob",
+//	})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func GetCheckpointStore(name string) (CheckpointStore, error) {
+func GetCheckpointStore(name string, options map[string]any) (CheckpointStore, error) {
 	mutex.RLock()
-	defer mutex.RUnlock()
+	factory, exists := checkpointStores[name]
+	mutex.RUnlock()
 
-	store, exists := checkpointStores[name]
 	if !exists {
 		return nil, fmt.Errorf("unknown checkpoint store: %s", name)
 	}
+
+	store, err := factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint store %s: %w", name, err)
+	}
 	return store, nil
 }
 
-// RegisterCheckpointStore adds a named CheckpointStore to the global registry.
+// resolveCheckpointStore constructs the store named by cfg.
+//
+// cfg.Dir, cfg.Codec, and cfg.Compression are passed to the factory as the
+// "dir", "codec", and "compression" options unless cfg.Options sets them. The
+// codec and compressor are resolved first so an unknown name fails graph
+// construction for every store.
+func resolveCheckpointStore(cfg config.CheckpointConfig) (CheckpointStore, error) {
+	if _, err := GetCodec(cfg.Codec); err != nil {
+		return nil, err
+	}
+	if cfg.Compression != "" {
+		if _, err := GetCompressor(cfg.Compression); err != nil {
+			return nil, err
+		}
+	}
+
+	options := maps.Clone(cfg.Options)
+	if options == nil {
+		options = make(map[string]any, 3)
+	}
+	if _, set := options["dir"]; !set && cfg.Dir != "" {
+		options["dir"] = cfg.Dir
+	}
+	if _, set := options["codec"]; !set && cfg.Codec != "" {
+		options["codec"] = cfg.Codec
+	}
+	if _, set := options["compression"]; !set && cfg.Compression != "" {
+		options["compression"] = cfg.Compression
+	}
+
+	return GetCheckpointStore(cfg.Store, options)
+}
+
+// newFileCheckpointStoreFromOptions is the "file" factory. It reads the
+// required "dir" option and the optional "codec" and "compression" options
+// (see CodecFromOptions).
+func newFileCheckpointStoreFromOptions(options map[string]any) (CheckpointStore, error) {
+	dir, err := stringOption(options, "dir")
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := CodecFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileCheckpointStore(dir, codec)
+}
+
+// newMemoryCheckpointStoreFromOptions builds the "memory" store, bounded to
+// the "max_entries" option when it is set.
+func newMemoryCheckpointStoreFromOptions(options map[string]any) (CheckpointStore, error) {
+	maxEntries, err := intOption(options, "max_entries")
+	if err != nil {
+		return nil, err
+	}
+	return NewMemoryCheckpointStoreWithCapacity(maxEntries), nil
+}
+
+// intOption returns the integer option key, or 0 when it is absent. Integral
+// float64 values are accepted, since JSON configuration decodes numbers as
+// float64.
+func intOption(options map[string]any, key string) (int, error) {
+	value, exists := options[key]
+	if !exists || value == nil {
+		return 0, nil
+	}
+
+	switch n := value.(type) {
+	case int:
+		return n, nil
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), nil
+		}
+	}
+	return 0, fmt.Errorf("option %q must be an integer, got %v", key, value)
+}
+
+// stringOption returns the string option key, or "" when it is absent.
+func stringOption(options map[string]any, key string) (string, error) {
+	value, exists := options[key]
+	if !exists || value == nil {
+		return "", nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("option %q must be a string, got %T", key, value)
+	}
+	return str, nil
+}
+
+// RegisterCheckpointStoreFactory adds a named CheckpointStoreFactory to the
+// global registry.
 //
 // Call this function before creating graphs that use the custom store. The
-// store name can then be referenced in CheckpointConfig.Store.
+// store name can then be referenced in CheckpointConfig.Store, with
+// CheckpointConfig.Options passed to fn.
 //
 // Example:
 //
-//	diskStore := NewDiskCheckpointStore("/var/checkpoints")
-//	state.RegisterCheckpointStore("disk", diskStore)
+//	state.RegisterCheckpointStoreFactory("sqlite", func(options map[string]any) (state.CheckpointStore, error) {
+//	    path, _ := options["path"].(string)
+//	    return checkpointsqlite.Open(path, checkpointsqlite.Options{})
+//	})
 //
 //	cfg := config.DefaultGraphConfig("workflow")
-//	cfg.Checkpoint.Store = "disk"
+//	cfg.Checkpoint.Store = "sqlite"
+//	cfg.Checkpoint.Options = map[string]any{"path": "/var/lib/workflow/review.db"}
 //	cfg.Checkpoint.Interval = 10
-func RegisterCheckpointStore(name string, store CheckpointStore) {
+func RegisterCheckpointStoreFactory(name string, fn CheckpointStoreFactory) {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	checkpointStores[name] = store
+	checkpointStores[name] = fn
+}
+
+// RegisterCheckpointStore adds a named CheckpointStore instance to the global
+// registry. Every graph using the name shares store and ignores
+// CheckpointConfig.Options.
+//
+// Deprecated: Use RegisterCheckpointStoreFactory, which constructs a store
+// per graph from its options.
+func RegisterCheckpointStore(name string, store CheckpointStore) {
+	RegisterCheckpointStoreFactory(name, func(map[string]any) (CheckpointStore, error) {
+		return store, nil
+	})
 }
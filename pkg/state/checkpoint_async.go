@@ -0,0 +1,170 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// Async checkpoint overflow behaviors (see CheckpointConfig.AsyncOverflow).
+const (
+	asyncOverflowBlock      = "block"
+	asyncOverflowDropOldest = "drop_oldest"
+)
+
+const defaultAsyncQueueSize = 16
+
+// asyncCheckpointConfig holds a graph's resolved async save settings.
+type asyncCheckpointConfig struct {
+	queueSize  int
+	dropOldest bool
+	onError    func(runID string, err error)
+}
+
+// resolveAsyncCheckpoints validates the async save settings, returning nil when
+// saves are synchronous.
+func resolveAsyncCheckpoints(cfg config.CheckpointConfig) (*asyncCheckpointConfig, error) {
+	if !cfg.Async {
+		return nil, nil
+	}
+
+	async := &asyncCheckpointConfig{
+		queueSize: cfg.AsyncQueueSize,
+		onError:   cfg.OnAsyncError,
+	}
+	if async.queueSize < 1 {
+		async.queueSize = defaultAsyncQueueSize
+	}
+
+	switch cfg.AsyncOverflow {
+	case "", asyncOverflowBlock:
+	case asyncOverflowDropOldest:
+		async.dropOldest = true
+	default:
+		return nil, fmt.Errorf("unknown async checkpoint overflow %q (want %q or %q)", cfg.AsyncOverflow, asyncOverflowBlock, asyncOverflowDropOldest)
+	}
+	return async, nil
+}
+
+// checkpointJob is a checkpoint waiting to be saved.
+type checkpointJob struct {
+	checkpoint State
+	node       string
+}
+
+// checkpointSaver saves one run's checkpoints on a background worker, in the
+// order they were taken. A nil saver is valid and does nothing.
+type checkpointSaver struct {
+	graph      *stateGraph
+	ctx        context.Context
+	queue      chan checkpointJob
+	dropOldest bool
+	onError    func(runID string, err error)
+	done       chan struct{}
+	flushOnce  sync.Once
+}
+
+// newCheckpointSaver starts the async save worker for one run, or returns nil
+// when the graph saves checkpoints synchronously.
+func (g *stateGraph) newCheckpointSaver(ctx context.Context) *checkpointSaver {
+	if g.asyncCheckpoints == nil || g.checkpointInterval == 0 {
+		return nil
+	}
+
+	s := &checkpointSaver{
+		graph:      g,
+		ctx:        ctx,
+		queue:      make(chan checkpointJob, g.asyncCheckpoints.queueSize),
+		dropOldest: g.asyncCheckpoints.dropOldest,
+		onError:    g.asyncCheckpoints.onError,
+		done:       make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *checkpointSaver) run() {
+	defer close(s.done)
+
+	for job := range s.queue {
+		if err := s.graph.saveCheckpoint(s.ctx, job.checkpoint, job.node, true); err != nil && s.onError != nil {
+			s.onError(job.checkpoint.RunID, err)
+		}
+	}
+}
+
+// enqueue queues a checkpoint for saving. When the queue is full it waits for
+// room, or with drop-oldest overflow discards the oldest queued checkpoint, as
+// the newer checkpoint supersedes it.
+func (s *checkpointSaver) enqueue(checkpoint State, node string) {
+	job := checkpointJob{checkpoint: checkpoint, node: node}
+	if !s.dropOldest {
+		s.queue <- job
+		return
+	}
+
+	for {
+		select {
+		case s.queue <- job:
+			return
+		default:
+		}
+
+		select {
+		case <-s.queue:
+		default:
+		}
+	}
+}
+
+// flush stops accepting checkpoints and waits for the queued saves to finish.
+// It is safe to call more than once.
+func (s *checkpointSaver) flush() {
+	if s == nil {
+		return
+	}
+
+	s.flushOnce.Do(func() {
+		close(s.queue)
+		<-s.done
+	})
+}
+
+// saveCheckpoint saves checkpoint to the graph's store, prunes old versions,
+// and emits EventCheckpointSave. Failures emit EventCheckpointError and are
+// returned ready to wrap in an ExecutionError.
+func (g *stateGraph) saveCheckpoint(ctx context.Context, checkpoint State, node string, async bool) error {
+	started := time.Now()
+	if err := checkpoint.Checkpoint(g.checkpointStore); err != nil {
+		g.checkpointError(ctx, "save", checkpoint.RunID, node, err)
+		return fmt.Errorf("checkpoint save failed: %w", err)
+	}
+
+	saveTime := time.Since(started)
+
+	if store, ok := g.checkpointStore.(VersionedCheckpointStore); ok {
+		if err := store.PruneVersions(checkpoint.RunID, g.maxVersions); err != nil {
+			g.checkpointError(ctx, "prune", checkpoint.RunID, node, err)
+			return fmt.Errorf("checkpoint prune failed: %w", err)
+		}
+	}
+
+	g.observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventCheckpointSave,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+		Data: map[string]any{
+			"node":     node,
+			"run_id":   checkpoint.RunID,
+			"duration": saveTime,
+			"size":     checkpoint.Size(),
+			"store":    g.checkpointStoreName,
+			"async":    async,
+		},
+	})
+	return nil
+}
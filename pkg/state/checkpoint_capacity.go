@@ -0,0 +1,108 @@
+package state
+
+import "container/list"
+
+// MemoryStoreStats reports the occupancy of a memory checkpoint store.
+type MemoryStoreStats struct {
+	// Runs is the number of runs with checkpoints in the store
+	Runs int `json:"runs"`
+
+	// Capacity is the maximum number of runs kept, or 0 when unbounded
+	Capacity int `json:"capacity"`
+
+	// Evictions counts runs discarded to stay within Capacity
+	Evictions uint64 `json:"evictions"`
+}
+
+// MemoryStatsReporter is implemented by the stores returned from
+// NewMemoryCheckpointStore and NewMemoryCheckpointStoreWithCapacity.
+type MemoryStatsReporter interface {
+	// Stats reports the store's current occupancy and evictions.
+	Stats() MemoryStoreStats
+}
+
+// NewMemoryCheckpointStoreWithCapacity creates an in-memory CheckpointStore
+// that keeps at most maxEntries runs, for long-lived services whose graphs
+// preserve checkpoints (CheckpointConfig.Preserve) and would otherwise grow
+// the store forever.
+//
+// Saving a checkpoint for a new run when the store is full evicts the least
+// recently saved run with all of its versions and history; Loading an evicted
+// run returns the standard not-found error. Loads do not affect eviction
+// order. maxEntries below 1 creates an unbounded store, like
+// NewMemoryCheckpointStore.
+//
+// The returned store also implements VersionedCheckpointStore and
+// MemoryStatsReporter. Configured graphs select it with the "memory" store and
+// the "max_entries" option.
+//
+// Example:
+//
+//	store := state.NewMemoryCheckpointStoreWithCapacity(10000)
+//	graph, err := state.NewGraphWithDeps(cfg, observer, store)
+//
+//	stats := store.(state.MemoryStatsReporter).Stats()
+//	log.Printf("%d runs checkpointed, %d evicted", stats.Runs, stats.Evictions)
+func NewMemoryCheckpointStoreWithCapacity(maxEntries int) CheckpointStore {
+	store := &memoryCheckpointStore{
+		states:    make(map[string][]checkpointVersion),
+		histories: make(map[string]StateHistory),
+		graphs:    make(map[string]map[string]bool),
+	}
+	if maxEntries > 0 {
+		store.capacity = maxEntries
+		store.recency = list.New()
+		store.elements = make(map[string]*list.Element)
+	}
+	return store
+}
+
+func (m *memoryCheckpointStore) Stats() MemoryStoreStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return MemoryStoreStats{
+		Runs:      len(m.states),
+		Capacity:  m.capacity,
+		Evictions: m.evictions,
+	}
+}
+
+// touch marks runID as the most recently saved run and evicts the least
+// recently saved runs beyond capacity. Callers must hold m.mu for writing.
+func (m *memoryCheckpointStore) touch(runID string) {
+	if m.capacity == 0 {
+		return
+	}
+
+	if element, exists := m.elements[runID]; exists {
+		m.recency.MoveToFront(element)
+		return
+	}
+	m.elements[runID] = m.recency.PushFront(runID)
+
+	for m.recency.Len() > m.capacity {
+		oldest := m.recency.Back().Value.(string)
+		m.forget(oldest)
+		m.evictions++
+	}
+}
+
+// forget removes runID's checkpoints, history, and graph index entry. Callers
+// must hold m.mu for writing.
+func (m *memoryCheckpointStore) forget(runID string) {
+	if _, exists := m.states[runID]; exists {
+		graph := m.latestGraph(runID)
+		delete(m.graphs[graph], runID)
+		if len(m.graphs[graph]) == 0 {
+			delete(m.graphs, graph)
+		}
+	}
+	delete(m.states, runID)
+	delete(m.histories, runID)
+
+	if element, exists := m.elements[runID]; exists {
+		m.recency.Remove(element)
+		delete(m.elements, runID)
+	}
+}
@@ -0,0 +1,178 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// CopyOptions controls CopyCheckpointsWithOptions.
+type CopyOptions struct {
+	// Filter selects the checkpoints to copy; nil copies every checkpoint
+	Filter func(CheckpointInfo) bool
+
+	// Force overwrites checkpoints already present in the destination instead
+	// of skipping them
+	Force bool
+}
+
+// CopyReport describes the outcome of a checkpoint copy. Run IDs appear in
+// the order they were copied, oldest checkpoint first.
+type CopyReport struct {
+	// Copied lists the runs saved to the destination
+	Copied []string
+
+	// Skipped lists the runs left alone because the destination already had
+	// a checkpoint for them
+	Skipped []string
+
+	// Failed maps each run that could not be copied to its error
+	Failed map[string]error
+}
+
+// Err joins the per-run errors of the copy, or returns nil if every selected
+// run was copied or skipped.
+func (r CopyReport) Err() error {
+	ids := make([]string, 0, len(r.Failed))
+	for id := range r.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, fmt.Errorf("run %s: %w", id, r.Failed[id]))
+	}
+	return errors.Join(errs...)
+}
+
+// CopyCheckpoints copies the latest checkpoint of each run in src that
+// matches filter (nil matches all) into dst, skipping runs dst already has.
+// See CopyCheckpointsWithOptions.
+//
+// Example:
+//
+//	// Move staging checkpoints of the review graph into production.
+//	report, err := state.CopyCheckpoints(ctx, fileStore, redisStore, func(info state.CheckpointInfo) bool {
+//	    return info.Graph == "document-review"
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("copied %d, skipped %d", len(report.Copied), len(report.Skipped))
+//	if err := report.Err(); err != nil {
+//	    log.Printf("rerun to retry failed runs: %v", err)
+//	}
+func CopyCheckpoints(ctx context.Context, src, dst CheckpointStore, filter func(CheckpointInfo) bool) (CopyReport, error) {
+	return CopyCheckpointsWithOptions(ctx, src, dst, CopyOptions{Filter: filter})
+}
+
+// CopyCheckpointsWithOptions lists the checkpoints in src and copies the
+// latest checkpoint of each selected run into dst.
+//
+// Runs are copied one at a time, oldest first. A run that fails to load or
+// save is recorded in CopyReport.Failed and the copy moves on to the next run.
+// Stores without CheckpointInfoLister are loaded run by run to describe each
+// checkpoint for the filter, so a run that cannot be loaded is reported as
+// failed even if the filter would have excluded it.
+// Without opts.Force, runs already in dst are skipped using SaveIfAbsent, so
+// an interrupted or partially failed copy can simply be run again. Only the
+// latest checkpoint of each run is copied; earlier versions and execution
+// history stay in src.
+//
+// Returns an error only if src cannot be listed or ctx ends, along with the
+// report of the runs processed so far.
+func CopyCheckpointsWithOptions(ctx context.Context, src, dst CheckpointStore, opts CopyOptions) (CopyReport, error) {
+	report := CopyReport{Failed: make(map[string]error)}
+
+	candidates, err := copyCandidates(src, report.Failed)
+	if err != nil {
+		return report, fmt.Errorf("failed to list source checkpoints: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if opts.Filter != nil && !opts.Filter(candidate.info) {
+			continue
+		}
+
+		runID := candidate.info.RunID
+		checkpoint := candidate.state
+		if checkpoint == nil {
+			loaded, err := src.Load(runID)
+			if err != nil {
+				report.Failed[runID] = fmt.Errorf("failed to load checkpoint: %w", err)
+				continue
+			}
+			checkpoint = &loaded
+		}
+
+		if opts.Force {
+			if err := dst.Save(*checkpoint); err != nil {
+				report.Failed[runID] = fmt.Errorf("failed to save checkpoint: %w", err)
+				continue
+			}
+			report.Copied = append(report.Copied, runID)
+			continue
+		}
+
+		saved, err := dst.SaveIfAbsent(*checkpoint)
+		switch {
+		case err != nil:
+			report.Failed[runID] = fmt.Errorf("failed to save checkpoint: %w", err)
+		case saved:
+			report.Copied = append(report.Copied, runID)
+		default:
+			report.Skipped = append(report.Skipped, runID)
+		}
+	}
+	return report, nil
+}
+
+// copyCandidate is a run selected for copying. state is set when the run was
+// already loaded to describe it.
+type copyCandidate struct {
+	info  CheckpointInfo
+	state *State
+}
+
+// copyCandidates lists the runs in src, oldest first. Stores implementing
+// CheckpointInfoLister are listed directly; for other stores each run is
+// loaded to describe it, and runs that fail to load are recorded in failed
+// rather than failing the listing as ListCheckpointInfo would.
+func copyCandidates(src CheckpointStore, failed map[string]error) ([]copyCandidate, error) {
+	var candidates []copyCandidate
+
+	if lister, ok := src.(CheckpointInfoLister); ok {
+		infos, err := lister.ListInfo()
+		if err != nil {
+			return nil, err
+		}
+		candidates = make([]copyCandidate, 0, len(infos))
+		for _, info := range infos {
+			candidates = append(candidates, copyCandidate{info: info})
+		}
+	} else {
+		ids, err := src.List()
+		if err != nil {
+			return nil, err
+		}
+		candidates = make([]copyCandidate, 0, len(ids))
+		for _, id := range ids {
+			s, err := src.Load(id)
+			if err != nil {
+				failed[id] = fmt.Errorf("failed to load checkpoint: %w", err)
+				continue
+			}
+			candidates = append(candidates, copyCandidate{info: infoFor(s, s.Size()), state: &s})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b copyCandidate) int {
+		return compareCheckpointInfo(a.info, b.info)
+	})
+	return candidates, nil
+}
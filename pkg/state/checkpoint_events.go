@@ -0,0 +1,41 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// checkpointError emits EventCheckpointError for a failed checkpoint store
+// operation: "save", "prune", "load", or "delete". node is empty for loads,
+// which happen before execution resumes at a node.
+//
+// Save, prune, and load failures also fail the graph operation; a failed
+// delete after successful execution is reported only through this event, as
+// the run itself completed.
+func (g *stateGraph) checkpointError(ctx context.Context, operation, runID, node string, err error) {
+	g.observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventCheckpointError,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+		Data: map[string]any{
+			"operation": operation,
+			"node":      node,
+			"run_id":    runID,
+			"store":     g.checkpointStoreName,
+			"error":     err.Error(),
+		},
+	})
+}
+
+// storeName identifies a checkpoint store injected with NewGraphWithDeps in
+// checkpoint events, using its type name (for example "state.fileStore").
+func storeName(store CheckpointStore) string {
+	if store == nil {
+		return ""
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", store), "*")
+}
@@ -0,0 +1,261 @@
+package state
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// GraphCheckpointLister is an optional CheckpointStore extension that lists
+// the checkpoints taken by one graph.
+//
+// Implementations should index checkpoints by State.Graph so the listing does
+// not load every stored checkpoint. ListCheckpointsByGraph falls back to
+// filtering ListCheckpointInfo for stores that do not implement it.
+type GraphCheckpointLister interface {
+	// ListByGraph returns metadata for the checkpoints whose latest State
+	// was taken by graphName, oldest first.
+	ListByGraph(graphName string) ([]CheckpointInfo, error)
+}
+
+// ListCheckpointsByGraph returns metadata for the checkpoints in store taken
+// by the named graph, oldest first.
+//
+// Checkpoints are matched on State.Graph, which graphs set on every State
+// they checkpoint, so graphs sharing a store can be told apart. Stores
+// implementing GraphCheckpointLister answer from their index; for other
+// stores every checkpoint is listed with ListCheckpointInfo and filtered.
+//
+// Example:
+//
+//	// Resume every interrupted run of the review graph.
+//	infos, err := state.ListCheckpointsByGraph(store, "document-review")
+//	if err != nil {
+//	    return err
+//	}
+//	for _, info := range infos {
+//	    if _, err := graph.Resume(ctx, info.RunID); err != nil {
+//	        log.Printf("resume %s: %v", info.RunID, err)
+//	    }
+//	}
+func ListCheckpointsByGraph(store CheckpointStore, graphName string) ([]CheckpointInfo, error) {
+	if lister, ok := store.(GraphCheckpointLister); ok {
+		return lister.ListByGraph(graphName)
+	}
+
+	infos, err := ListCheckpointInfo(store)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(infos, func(info CheckpointInfo) bool {
+		return info.Graph != graphName
+	}), nil
+}
+
+// ListByGraph reads the runs of graphName from the store's graph index.
+func (m *memoryCheckpointStore) ListByGraph(graphName string) ([]CheckpointInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	runs := m.graphs[graphName]
+	infos := make([]CheckpointInfo, 0, len(runs))
+	for runID := range runs {
+		versions := m.states[runID]
+		latest := versions[len(versions)-1]
+		info := infoFor(latest.state, latest.state.Size())
+		info.Version = latest.version
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, compareCheckpointInfo)
+	return infos, nil
+}
+
+// indexGraph moves runID from the graph of its previous latest checkpoint to
+// graph. Callers must hold m.mu for writing.
+func (m *memoryCheckpointStore) indexGraph(runID, previous, graph string) {
+	if runs := m.graphs[previous]; runs != nil {
+		delete(runs, runID)
+		if len(runs) == 0 {
+			delete(m.graphs, previous)
+		}
+	}
+
+	runs := m.graphs[graph]
+	if runs == nil {
+		runs = make(map[string]bool)
+		m.graphs[graph] = runs
+	}
+	runs[runID] = true
+}
+
+// latestGraph returns the graph of runID's latest checkpoint, or "" when the
+// run has none. Callers must hold m.mu.
+func (m *memoryCheckpointStore) latestGraph(runID string) string {
+	versions := m.states[runID]
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[len(versions)-1].state.Graph
+}
+
+// File store graph index
+//
+// The file store indexes runs in {dir}/.graphs/{graph}/{runID}, one empty
+// marker file per run, with graph names base64url-encoded so any name is a
+// safe directory name. Markers are written on save and removed on delete. The
+// index is built from the existing checkpoints on first use, recorded by the
+// .indexed file, so directories written before the index existed are listed
+// too. A marker can outlive its checkpoint or point at a run since saved by
+// another graph, so listings verify each run before reporting it.
+const (
+	graphIndexDirName = ".graphs"
+	graphIndexBuilt   = ".indexed"
+)
+
+// ListByGraph loads only the runs indexed under graphName. Runs saved outside
+// a graph (empty graphName) are not indexed and are found by reading every
+// checkpoint.
+func (f *fileCheckpointStore) ListByGraph(graphName string) ([]CheckpointInfo, error) {
+	if graphName == "" {
+		infos, err := f.ListInfo()
+		if err != nil {
+			return nil, err
+		}
+		return slices.DeleteFunc(infos, func(info CheckpointInfo) bool {
+			return info.Graph != ""
+		}), nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.buildGraphIndex(); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(f.graphIndexDir(graphName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []CheckpointInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read graph index: %w", err)
+	}
+
+	infos := make([]CheckpointInfo, 0, len(entries))
+	for _, entry := range entries {
+		runID := entry.Name()
+		path, err := f.path(runID)
+		if err != nil {
+			continue
+		}
+
+		data, err := readCheckpointFile(path, runID, 0)
+		if errors.Is(err, fs.ErrNotExist) {
+			os.Remove(filepath.Join(f.graphIndexDir(graphName), runID))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := f.codec.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint %s: %w", runID, err)
+		}
+		if s.Graph != graphName {
+			continue
+		}
+		infos = append(infos, payloadInfo(s, data))
+	}
+	slices.SortFunc(infos, compareCheckpointInfo)
+	return infos, nil
+}
+
+func (f *fileCheckpointStore) graphIndexDir(graph string) string {
+	return filepath.Join(f.dir, graphIndexDirName, base64.RawURLEncoding.EncodeToString([]byte(graph)))
+}
+
+// indexGraph records runID under graph. Callers must hold f.mu for writing.
+func (f *fileCheckpointStore) indexGraph(runID, graph string) error {
+	if graph == "" {
+		return nil
+	}
+
+	dir := f.graphIndexDir(graph)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to index checkpoint: %w", err)
+	}
+
+	marker, err := os.OpenFile(filepath.Join(dir, runID), os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to index checkpoint: %w", err)
+	}
+	return marker.Close()
+}
+
+// unindexRun removes runID from every graph. Callers must hold f.mu for
+// writing.
+func (f *fileCheckpointStore) unindexRun(runID string) error {
+	graphs, err := os.ReadDir(filepath.Join(f.dir, graphIndexDirName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read graph index: %w", err)
+	}
+
+	for _, graph := range graphs {
+		if !graph.IsDir() {
+			continue
+		}
+		marker := filepath.Join(f.dir, graphIndexDirName, graph.Name(), runID)
+		if err := os.Remove(marker); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to unindex checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildGraphIndex indexes every existing checkpoint the first time the index
+// is used. Checkpoints that cannot be read are left unindexed. Callers must
+// hold f.mu for writing.
+func (f *fileCheckpointStore) buildGraphIndex() error {
+	built := filepath.Join(f.dir, graphIndexDirName, graphIndexBuilt)
+	if _, err := os.Stat(built); err == nil {
+		return nil
+	}
+
+	ids, err := f.listIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		path, err := f.path(id)
+		if err != nil {
+			continue
+		}
+		data, err := readCheckpointFile(path, id, 0)
+		if err != nil {
+			continue
+		}
+		s, err := f.codec.Decode(data)
+		if err != nil {
+			continue
+		}
+		if err := f.indexGraph(id, s.Graph); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(built), 0o755); err != nil {
+		return fmt.Errorf("failed to build graph index: %w", err)
+	}
+	if err := os.WriteFile(built, nil, 0o644); err != nil {
+		return fmt.Errorf("failed to build graph index: %w", err)
+	}
+	return nil
+}
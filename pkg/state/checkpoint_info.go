@@ -0,0 +1,167 @@
+package state
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+)
+
+// CheckpointInfo summarizes a stored checkpoint without its data.
+type CheckpointInfo struct {
+	// RunID identifies the checkpointed run
+	RunID string `json:"run_id"`
+
+	// Node is the node the run was checkpointed at; Resume continues after it
+	Node string `json:"node"`
+
+	// Graph names the graph that took the checkpoint (empty for States saved
+	// outside a graph)
+	Graph string `json:"graph,omitempty"`
+
+	// Timestamp is when the checkpoint was taken
+	Timestamp time.Time `json:"timestamp"`
+
+	// Size is the approximate checkpoint size in bytes. Stores report their
+	// encoded payload size where they know it, otherwise State.Size.
+	Size int `json:"size"`
+
+	// UncompressedSize is the encoded size before compression for stores
+	// using a compressed codec (see NewCompressedCodec); zero when the
+	// checkpoint is not compressed
+	UncompressedSize int `json:"uncompressed_size,omitempty"`
+
+	// Version numbers the checkpoint within its run for stores implementing
+	// VersionedCheckpointStore; zero otherwise
+	Version int `json:"version,omitempty"`
+}
+
+// CheckpointInfoLister is an optional CheckpointStore extension that lists
+// checkpoint metadata without returning the stored states.
+//
+// Implementations should avoid loading full checkpoints where their storage
+// allows it. ListCheckpointInfo falls back to loading each checkpoint for
+// stores that do not implement it.
+type CheckpointInfoLister interface {
+	ListInfo() ([]CheckpointInfo, error)
+}
+
+// ListCheckpointInfo returns metadata for every checkpoint in store, oldest
+// first.
+//
+// Stores implementing CheckpointInfoLister answer directly. For other stores
+// each listed run is loaded to read its node and timestamp, and Size is the
+// State.Size estimate.
+//
+// Example:
+//
+//	infos, _ := state.ListCheckpointInfo(store)
+//	for _, info := range infos {
+//	    if time.Since(info.Timestamp) > 24*time.Hour {
+//	        fmt.Printf("%s interrupted at %s since %s\n", info.RunID, info.Node, info.Timestamp)
+//	    }
+//	}
+func ListCheckpointInfo(store CheckpointStore) ([]CheckpointInfo, error) {
+	var infos []CheckpointInfo
+
+	if lister, ok := store.(CheckpointInfoLister); ok {
+		listed, err := lister.ListInfo()
+		if err != nil {
+			return nil, err
+		}
+		infos = listed
+	} else {
+		ids, err := store.List()
+		if err != nil {
+			return nil, err
+		}
+
+		infos = make([]CheckpointInfo, 0, len(ids))
+		for _, id := range ids {
+			s, err := store.Load(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load checkpoint %s: %w", id, err)
+			}
+			infos = append(infos, infoFor(s, s.Size()))
+		}
+	}
+
+	slices.SortFunc(infos, compareCheckpointInfo)
+	return infos, nil
+}
+
+// infoFor returns the CheckpointInfo of a stored State of the given size.
+func infoFor(s State, size int) CheckpointInfo {
+	return CheckpointInfo{
+		RunID:     s.RunID,
+		Node:      s.CheckpointNode,
+		Graph:     s.Graph,
+		Timestamp: s.Timestamp,
+		Size:      size,
+	}
+}
+
+// payloadInfo returns the CheckpointInfo of a State decoded from payload,
+// reporting the payload's stored and uncompressed sizes.
+func payloadInfo(s State, payload []byte) CheckpointInfo {
+	info := infoFor(s, len(payload))
+	info.UncompressedSize, _ = UncompressedSize(payload)
+	return info
+}
+
+func compareCheckpointInfo(a, b CheckpointInfo) int {
+	return cmp.Or(a.Timestamp.Compare(b.Timestamp), cmp.Compare(a.RunID, b.RunID))
+}
+
+func (m *memoryCheckpointStore) ListInfo() ([]CheckpointInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	infos := make([]CheckpointInfo, 0, len(m.states))
+	for _, versions := range m.states {
+		latest := versions[len(versions)-1]
+		info := infoFor(latest.state, latest.state.Size())
+		info.Version = latest.version
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, compareCheckpointInfo)
+	return infos, nil
+}
+
+// ListInfo decodes each checkpoint file for its node and timestamp and reports
+// the file size.
+func (f *fileCheckpointStore) ListInfo() ([]CheckpointInfo, error) {
+	ids, err := f.List()
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	infos := make([]CheckpointInfo, 0, len(ids))
+	for _, id := range ids {
+		path, err := f.path(id)
+		if err != nil {
+			continue
+		}
+
+		data, err := readCheckpointFile(path, id, 0)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := f.codec.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint %s: %w", id, err)
+		}
+		infos = append(infos, payloadInfo(s, data))
+	}
+	slices.SortFunc(infos, compareCheckpointInfo)
+	return infos, nil
+}
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// LoadLatest returns the most recent checkpoint taken by the named graph
+// across all runs in store.
+//
+// Checkpoints are matched on State.Graph, which graphs set on every State
+// they checkpoint. The newest checkpoint by timestamp wins; ties are broken by
+// the greater run ID so the choice is deterministic. Returns an error if the
+// graph has no checkpoints.
+//
+// Example:
+//
+//	latest, err := state.LoadLatest(store, "document-review")
+//	if err != nil {
+//	    return err
+//	}
+//	final, err := graph.Resume(ctx, latest.RunID)
+func LoadLatest(store CheckpointStore, graphName string) (State, error) {
+	infos, err := ListCheckpointsByGraph(store, graphName)
+	if err != nil {
+		return State{}, err
+	}
+	if len(infos) == 0 {
+		return State{}, fmt.Errorf("no checkpoint found for graph: %s", graphName)
+	}
+	return store.Load(infos[len(infos)-1].RunID)
+}
+
+// LoadAtNode returns the most recent checkpoint of a run taken at node.
+//
+// For a VersionedCheckpointStore the run's retained versions are searched
+// newest first (by timestamp, then version), so a run that looped through
+// node returns its last visit. Other stores hold only the latest checkpoint,
+// which is returned if it was taken at node. Returns an error if no retained
+// checkpoint of the run was taken at node; raise CheckpointConfig.MaxVersions
+// to keep more history.
+//
+// Example:
+//
+//	// Rerun everything after the last successful classification.
+//	checkpoint, err := state.LoadAtNode(store, runID, "classify")
+func LoadAtNode(store CheckpointStore, runID, node string) (State, error) {
+	versioned, ok := store.(VersionedCheckpointStore)
+	if !ok {
+		latest, err := store.Load(runID)
+		if err != nil {
+			return State{}, err
+		}
+		if latest.CheckpointNode != node {
+			return State{}, fmt.Errorf("no checkpoint of run %s found at node %s", runID, node)
+		}
+		return latest, nil
+	}
+
+	versions, err := versioned.Versions(runID)
+	if err != nil {
+		return State{}, err
+	}
+	slices.SortFunc(versions, func(a, b CheckpointInfo) int {
+		return cmp.Or(a.Timestamp.Compare(b.Timestamp), cmp.Compare(a.Version, b.Version))
+	})
+
+	for _, info := range slices.Backward(versions) {
+		if info.Node == node {
+			return versioned.LoadVersion(runID, info.Version)
+		}
+	}
+	return State{}, fmt.Errorf("no checkpoint of run %s found at node %s", runID, node)
+}
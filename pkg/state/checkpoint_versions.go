@@ -0,0 +1,228 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VersionedCheckpointStore is an optional CheckpointStore extension that keeps
+// every saved checkpoint of a run instead of overwriting it.
+//
+// Save appends a new version and Load returns the latest one. Versions are
+// numbered from 1 and keep their numbers when older versions are pruned, so a
+// version number identifies the same checkpoint for the life of the run.
+// Delete removes all versions.
+//
+// Graphs call PruneVersions after every checkpoint with
+// CheckpointConfig.MaxVersions, and ResumeVersion restarts a run from an
+// earlier version when the latest checkpoint is corrupt or took a bad path.
+type VersionedCheckpointStore interface {
+	CheckpointStore
+
+	// LoadVersion retrieves a specific checkpoint version of a run.
+	LoadVersion(runID string, version int) (State, error)
+
+	// Versions describes the retained versions of a run, oldest first.
+	Versions(runID string) ([]CheckpointInfo, error)
+
+	// PruneVersions discards all but the newest keep versions of a run.
+	// keep values below 1 are treated as 1.
+	PruneVersions(runID string, keep int) error
+}
+
+func (m *memoryCheckpointStore) LoadVersion(runID string, version int) (State, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, v := range m.states[runID] {
+		if v.version == version {
+			return v.state, nil
+		}
+	}
+	return State{}, fmt.Errorf("checkpoint version not found: %s@%d", runID, version)
+}
+
+func (m *memoryCheckpointStore) Versions(runID string) ([]CheckpointInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	versions, exists := m.states[runID]
+	if !exists {
+		return nil, fmt.Errorf("checkpoint not found: %s", runID)
+	}
+
+	infos := make([]CheckpointInfo, 0, len(versions))
+	for _, v := range versions {
+		info := infoFor(v.state, v.state.Size())
+		info.Version = v.version
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (m *memoryCheckpointStore) PruneVersions(runID string, keep int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keep = max(keep, 1)
+	versions := m.states[runID]
+	if len(versions) > keep {
+		m.states[runID] = slices.Clone(versions[len(versions)-keep:])
+	}
+	return nil
+}
+
+// checkpointVersionsExt suffixes the per-run directory of checkpoint versions
+// written by the file store.
+const checkpointVersionsExt = ".versions"
+
+func (f *fileCheckpointStore) versionsDir(runID string) string {
+	return filepath.Join(f.dir, runID+checkpointVersionsExt)
+}
+
+// versionNumbers returns the version numbers stored for runID in ascending
+// order. Callers must hold f.mu.
+func (f *fileCheckpointStore) versionNumbers(runID string) ([]int, error) {
+	entries, err := os.ReadDir(f.versionsDir(runID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoint versions: %w", err)
+	}
+
+	numbers := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), checkpointFileExt)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		if n, err := strconv.Atoi(name); err == nil && n > 0 {
+			numbers = append(numbers, n)
+		}
+	}
+	slices.Sort(numbers)
+	return numbers, nil
+}
+
+// saveVersion writes data as the next version of runID. Callers must hold
+// f.mu for writing.
+func (f *fileCheckpointStore) saveVersion(runID string, data []byte) error {
+	numbers, err := f.versionNumbers(runID)
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(numbers) > 0 {
+		next = numbers[len(numbers)-1] + 1
+	}
+
+	if err := os.MkdirAll(f.versionsDir(runID), 0o755); err != nil {
+		return fmt.Errorf("failed to create checkpoint versions directory: %w", err)
+	}
+	if err := writeCheckpointFile(f.versionPath(runID, next), data); err != nil {
+		return fmt.Errorf("failed to write checkpoint version: %w", err)
+	}
+	return nil
+}
+
+func (f *fileCheckpointStore) versionPath(runID string, version int) string {
+	return filepath.Join(f.versionsDir(runID), strconv.Itoa(version)+checkpointFileExt)
+}
+
+func (f *fileCheckpointStore) LoadVersion(runID string, version int) (State, error) {
+	if _, err := f.path(runID); err != nil {
+		return State{}, err
+	}
+
+	f.mu.RLock()
+	data, err := readCheckpointFile(f.versionPath(runID, version), runID, version)
+	f.mu.RUnlock()
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{}, fmt.Errorf("checkpoint version not found: %s@%d", runID, version)
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	state, err := f.codec.Decode(data)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to decode checkpoint %s@%d: %w", runID, version, err)
+	}
+	return state, nil
+}
+
+// Versions decodes each retained version for its node and timestamp and
+// reports the file size. Checkpoints written before versioning have no
+// versions. Versions that fail checksum verification are omitted, so the
+// result lists only versions ResumeVersion can restore.
+func (f *fileCheckpointStore) Versions(runID string) ([]CheckpointInfo, error) {
+	path, err := f.path(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("checkpoint not found: %s", runID)
+	}
+
+	numbers, err := f.versionNumbers(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]CheckpointInfo, 0, len(numbers))
+	for _, n := range numbers {
+		data, err := readCheckpointFile(f.versionPath(runID, n), runID, n)
+		var corrupt *CorruptCheckpointError
+		if errors.As(err, &corrupt) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := f.codec.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint %s@%d: %w", runID, n, err)
+		}
+
+		info := payloadInfo(state, data)
+		info.Version = n
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (f *fileCheckpointStore) PruneVersions(runID string, keep int) error {
+	if _, err := f.path(runID); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	numbers, err := f.versionNumbers(runID)
+	if err != nil {
+		return err
+	}
+
+	keep = max(keep, 1)
+	for _, n := range numbers[:max(len(numbers)-keep, 0)] {
+		if err := os.Remove(f.versionPath(runID, n)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to prune checkpoint version: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package checkpointobject provides a state.CheckpointStore backed by an
+// object storage bucket, writing each run's State to {prefix}/{runID}.json.
+//
+// The store talks to storage through the minimal Client interface rather than
+// a specific SDK, so AWS S3, GCS, and MinIO are supported by small adapters
+// in the calling program and none of those SDKs are linked into this module.
+// MemoryClient is an in-process Client for tests.
+//
+// # Usage
+//
+//	client := s3Adapter{client: s3.NewFromConfig(awsCfg), bucket: "workflows"}
+//	state.RegisterCheckpointStoreFactory("s3", func(options map[string]any) (state.CheckpointStore, error) {
+//	    prefix, _ := options["prefix"].(string)
+//	    codec, err := state.CodecFromOptions(options)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return checkpointobject.New(client, checkpointobject.Options{Prefix: prefix, Codec: codec})
+//	})
+//
+//	cfg := config.DefaultGraphConfig("review")
+//	cfg.Checkpoint.Store = "s3"
+//	cfg.Checkpoint.Options = map[string]any{"prefix": "checkpoints/review"}
+//	cfg.Checkpoint.Interval = 1
+//	graph, err := state.NewGraph(cfg)
+//
+// # Adapters
+//
+// A Client adapter maps four operations onto its SDK. Get must return an
+// error wrapping ErrNotFound for a missing key (for S3, a NoSuchKey error) so
+// that Load can tell a missing checkpoint apart from a transport failure.
+// List returns one page of keys and a continuation token, which the store
+// follows until it is empty.
+package checkpointobject
@@ -0,0 +1,143 @@
+package checkpointobject
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPageSize is the MemoryClient page size when PageSize is not positive.
+const DefaultPageSize = 1000
+
+// MemoryClient is an in-memory Client for tests.
+//
+// Listing is lexicographic and paginated by PageSize, with the continuation
+// token being the offset of the next key, so small page sizes exercise a
+// Store's pagination.
+type MemoryClient struct {
+	// PageSize caps the keys returned per List call (default DefaultPageSize)
+	PageSize int
+
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+}
+
+// NewMemoryClient returns an empty MemoryClient.
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		objects: make(map[string]memoryObject),
+	}
+}
+
+func (m *MemoryClient) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[key] = memoryObject{data: slices.Clone(data), contentType: contentType}
+	return nil
+}
+
+// PutIfAbsent implements ConditionalClient.
+func (m *MemoryClient) PutIfAbsent(ctx context.Context, key string, data []byte, contentType string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.objects[key]; exists {
+		return false, nil
+	}
+	m.objects[key] = memoryObject{data: slices.Clone(data), contentType: contentType}
+	return true, nil
+}
+
+func (m *MemoryClient) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	obj, exists := m.objects[key]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return slices.Clone(obj.data), nil
+}
+
+func (m *MemoryClient) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *MemoryClient) List(ctx context.Context, prefix, token string) (Page, error) {
+	if err := ctx.Err(); err != nil {
+		return Page{}, err
+	}
+
+	start := 0
+	if token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 {
+			return Page{}, fmt.Errorf("invalid continuation token: %q", token)
+		}
+		start = n
+	}
+
+	m.mu.RLock()
+	keys := make([]string, 0, len(m.objects))
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	m.mu.RUnlock()
+	slices.Sort(keys)
+
+	size := m.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+
+	if start >= len(keys) {
+		return Page{Keys: []string{}}, nil
+	}
+	end := min(start+size, len(keys))
+
+	page := Page{Keys: keys[start:end]}
+	if end < len(keys) {
+		page.NextToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// ContentType returns the content type key was stored with, or "" if the key
+// does not exist.
+func (m *MemoryClient) ContentType(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.objects[key].contentType
+}
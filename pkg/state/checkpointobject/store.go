@@ -0,0 +1,252 @@
+package checkpointobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// ErrNotFound is returned (wrapped) by Client.Get when the key does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Page is one page of a Client.List result.
+type Page struct {
+	// Keys are the full object keys in this page
+	Keys []string
+
+	// NextToken continues the listing; empty when this is the last page
+	NextToken string
+}
+
+// Client is the subset of an object storage API the store needs.
+//
+// Implementations must be safe for concurrent use.
+type Client interface {
+	// Put writes data to key with the given content type, replacing any
+	// existing object.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Get reads the object at key. A missing key returns an error wrapping
+	// ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Delete removes the object at key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns one page of keys beginning with prefix, starting after
+	// token. An empty token requests the first page.
+	List(ctx context.Context, prefix, token string) (Page, error)
+}
+
+// ConditionalClient is implemented by clients that can create an object only
+// when its key does not exist, such as an S3 PutObject with If-None-Match: *.
+// Store.SaveIfAbsent requires it.
+type ConditionalClient interface {
+	Client
+
+	// PutIfAbsent writes data to key unless an object already exists there.
+	// Returns true if the object was written.
+	PutIfAbsent(ctx context.Context, key string, data []byte, contentType string) (bool, error)
+}
+
+// Options configures a Store.
+type Options struct {
+	// Prefix is prepended to every object key, e.g. "checkpoints/review".
+	// Leading and trailing slashes are ignored; empty stores at the bucket root.
+	Prefix string
+
+	// Codec serializes State payloads (default state.JSONCodec)
+	Codec state.Codec
+}
+
+// objectExt is the suffix of checkpoint object keys.
+const objectExt = ".json"
+
+// Store is a state.CheckpointStore writing one object per run through a
+// Client. It holds no state of its own, so consistency across processes is
+// whatever the underlying bucket provides.
+type Store struct {
+	client      Client
+	prefix      string
+	codec       state.Codec
+	contentType string
+}
+
+// New returns a Store writing through client.
+//
+// Example:
+//
+//	store, err := checkpointobject.New(client, checkpointobject.Options{Prefix: "checkpoints"})
+//	if err != nil {
+//	    return err
+//	}
+//	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+func New(client Client, opts Options) (*Store, error) {
+	if client == nil {
+		return nil, fmt.Errorf("object storage client cannot be nil")
+	}
+
+	codec := opts.Codec
+	if codec == nil {
+		codec = state.JSONCodec{}
+	}
+
+	prefix := strings.Trim(opts.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &Store{
+		client:      client,
+		prefix:      prefix,
+		codec:       codec,
+		contentType: contentType(codec),
+	}, nil
+}
+
+// contentType returns the MIME type recorded on objects encoded by codec.
+func contentType(codec state.Codec) string {
+	switch codec.(type) {
+	case state.JSONCodec, *state.JSONCodec:
+		return "application/json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func (s *Store) Save(st state.State) error {
+	key, err := s.key(st.RunID)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.codec.Encode(st)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	if err := s.client.Put(context.Background(), key, data, s.contentType); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// SaveIfAbsent writes the checkpoint with a conditional put. The check must be
+// atomic in the bucket itself, so SaveIfAbsent fails unless the client
+// implements ConditionalClient.
+func (s *Store) SaveIfAbsent(st state.State) (bool, error) {
+	conditional, ok := s.client.(ConditionalClient)
+	if !ok {
+		return false, fmt.Errorf("object storage client does not support conditional writes")
+	}
+
+	key, err := s.key(st.RunID)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := s.codec.Encode(st)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	saved, err := conditional.PutIfAbsent(context.Background(), key, data, s.contentType)
+	if err != nil {
+		return false, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *Store) Load(runID string) (state.State, error) {
+	key, err := s.key(runID)
+	if err != nil {
+		return state.State{}, err
+	}
+
+	data, err := s.client.Get(context.Background(), key)
+	if errors.Is(err, ErrNotFound) {
+		return state.State{}, fmt.Errorf("checkpoint not found: %s", runID)
+	}
+	if err != nil {
+		return state.State{}, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	st, err := s.codec.Decode(data)
+	if err != nil {
+		return state.State{}, fmt.Errorf("failed to decode checkpoint %s: %w", runID, err)
+	}
+	return st, nil
+}
+
+func (s *Store) Delete(runID string) error {
+	key, err := s.key(runID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Delete(context.Background(), key); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// List follows Client.List pages until the listing is exhausted. Keys below
+// nested prefixes and keys without the checkpoint suffix are skipped.
+func (s *Store) List() ([]string, error) {
+	ids := make([]string, 0)
+	token := ""
+
+	for {
+		page, err := s.client.List(context.Background(), s.prefix, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+		}
+
+		for _, key := range page.Keys {
+			name, ok := strings.CutPrefix(key, s.prefix)
+			if !ok || strings.Contains(name, "/") || !strings.HasSuffix(name, objectExt) {
+				continue
+			}
+			ids = append(ids, strings.TrimSuffix(name, objectExt))
+		}
+
+		if page.NextToken == "" {
+			return ids, nil
+		}
+		if page.NextToken == token {
+			return nil, fmt.Errorf("failed to list checkpoints: continuation token %q did not advance", token)
+		}
+		token = page.NextToken
+	}
+}
+
+// Ping checks that the bucket is reachable, implementing state.HealthChecker.
+//
+// Clients that implement Ping(ctx) error are asked directly; otherwise Ping
+// requests the first page of keys under the store prefix, which exercises
+// connectivity and list permissions.
+func (s *Store) Ping(ctx context.Context) error {
+	var err error
+	if pinger, ok := s.client.(interface{ Ping(context.Context) error }); ok {
+		err = pinger.Ping(ctx)
+	} else {
+		_, err = s.client.List(ctx, s.prefix, "")
+	}
+
+	if err != nil {
+		return fmt.Errorf("object storage unreachable: %w", err)
+	}
+	return nil
+}
+
+// key returns the object key for runID, rejecting IDs that would nest below
+// the store prefix.
+func (s *Store) key(runID string) (string, error) {
+	if runID == "" || strings.Contains(runID, "/") {
+		return "", fmt.Errorf("invalid run ID for object checkpoint: %q", runID)
+	}
+	return s.prefix + runID + objectExt, nil
+}
@@ -0,0 +1,40 @@
+// Package checkpointsqlite provides a state.CheckpointStore backed by SQLite,
+// giving single-binary deployments durable checkpoints without a separate
+// database service.
+//
+// The SQLite driver (modernc.org/sqlite, pure Go) is only linked into programs
+// that import this package; the state package itself has no database
+// dependency.
+//
+// # Usage
+//
+//	store, err := checkpointsqlite.Open("checkpoints.db", checkpointsqlite.Options{
+//	    Table: "review_checkpoints",
+//	    Codec: state.GobCodec{},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer store.Close()
+//
+//	cfg := config.DefaultGraphConfig("review")
+//	cfg.Checkpoint.Interval = 1
+//	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+//
+// # Schema
+//
+// Open and New create the table on first use:
+//
+//	CREATE TABLE checkpoints (
+//	    run_id          TEXT PRIMARY KEY,
+//	    node            TEXT NOT NULL,
+//	    graph           TEXT NOT NULL,     -- State.Graph, empty outside a graph
+//	    created_at      INTEGER NOT NULL,  -- Unix nanoseconds of the first save
+//	    checkpointed_at INTEGER NOT NULL,  -- Unix nanoseconds of State.Timestamp
+//	    payload         BLOB NOT NULL      -- State encoded with Options.Codec
+//	)
+//
+// with indexes on created_at and (graph, created_at), the latter serving
+// ListByGraph. Tables created by earlier versions gain the checkpointed_at and
+// graph columns, and the graph index, on Open.
+package checkpointsqlite
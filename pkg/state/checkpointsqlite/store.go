@@ -0,0 +1,315 @@
+package checkpointsqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	_ "modernc.org/sqlite"
+)
+
+// DefaultTable is the table used when Options.Table is empty.
+const DefaultTable = "checkpoints"
+
+// tableName restricts table names to plain SQL identifiers, since they are
+// interpolated into statements.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Options configures a Store.
+type Options struct {
+	// Table names the checkpoint table (default DefaultTable)
+	Table string
+
+	// Codec serializes State payloads (default state.JSONCodec)
+	Codec state.Codec
+}
+
+// Store is a state.CheckpointStore backed by a SQLite table with the columns
+// (run_id, node, graph, created_at, checkpointed_at, payload).
+//
+// Save upserts by run ID. created_at records when a run was first
+// checkpointed and is kept across later saves, so List returns runs in the
+// order they started. Store also implements state.CheckpointInfoLister,
+// answering from the metadata columns without decoding payloads. Store is
+// safe for concurrent use.
+type Store struct {
+	db    *sql.DB
+	owned bool
+	codec state.Codec
+
+	upsert      string
+	insert      string
+	load        string
+	delete      string
+	list        string
+	listInfo    string
+	listByGraph string
+}
+
+// Open opens (creating if needed) the SQLite database at path and returns a
+// Store using it.
+//
+// The database is accessed through a single connection so that concurrent
+// graph executions serialize their writes instead of failing with
+// SQLITE_BUSY. Close releases the database.
+//
+// Example:
+//
+//	store, err := checkpointsqlite.Open("/var/lib/workflow/checkpoints.db", checkpointsqlite.Options{})
+//	if err != nil {
+//	    return err
+//	}
+//	defer store.Close()
+//	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+func Open(path string, opts Options) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	store, err := New(db, opts)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	store.owned = true
+	return store, nil
+}
+
+// New returns a Store using an already opened SQLite database.
+//
+// The checkpoint table is created if it does not exist. The caller keeps
+// ownership of db; Close does not close it.
+func New(db *sql.DB, opts Options) (*Store, error) {
+	table := opts.Table
+	if table == "" {
+		table = DefaultTable
+	}
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid checkpoint table name: %q", table)
+	}
+
+	codec := opts.Codec
+	if codec == nil {
+		codec = state.JSONCodec{}
+	}
+
+	if err := migrate(db, table); err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db:    db,
+		codec: codec,
+		upsert: fmt.Sprintf(`INSERT INTO %s (run_id, node, graph, created_at, checkpointed_at, payload) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(run_id) DO UPDATE SET node = excluded.node, graph = excluded.graph,
+				checkpointed_at = excluded.checkpointed_at, payload = excluded.payload`, table),
+		insert: fmt.Sprintf(`INSERT INTO %s (run_id, node, graph, created_at, checkpointed_at, payload) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(run_id) DO NOTHING`, table),
+		load:   fmt.Sprintf(`SELECT payload FROM %s WHERE run_id = ?`, table),
+		delete: fmt.Sprintf(`DELETE FROM %s WHERE run_id = ?`, table),
+		list:   fmt.Sprintf(`SELECT run_id FROM %s ORDER BY created_at, run_id`, table),
+		listInfo: fmt.Sprintf(`SELECT run_id, node, graph, COALESCE(NULLIF(checkpointed_at, 0), created_at), length(payload), substr(payload, 1, 64)
+			FROM %s ORDER BY created_at, run_id`, table),
+		listByGraph: fmt.Sprintf(`SELECT run_id, node, graph, COALESCE(NULLIF(checkpointed_at, 0), created_at), length(payload), substr(payload, 1, 64)
+			FROM %s WHERE graph = ? ORDER BY created_at, run_id`, table),
+	}, nil
+}
+
+// addedColumns lists columns introduced after the original schema, with the
+// definitions used to add them to existing tables.
+var addedColumns = []struct{ name, definition string }{
+	{"checkpointed_at", "INTEGER NOT NULL DEFAULT 0"},
+	{"graph", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// migrate creates the checkpoint table and its indexes, adding the columns in
+// addedColumns to tables created before they existed.
+func migrate(db *sql.DB, table string) error {
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			run_id          TEXT PRIMARY KEY,
+			node            TEXT NOT NULL,
+			graph           TEXT NOT NULL DEFAULT '',
+			created_at      INTEGER NOT NULL,
+			checkpointed_at INTEGER NOT NULL DEFAULT 0,
+			payload         BLOB NOT NULL
+		)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_created_at ON %s (created_at)`, table, table),
+	}
+
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to migrate checkpoint table %s: %w", table, err)
+		}
+	}
+
+	for _, column := range addedColumns {
+		var exists int
+		err := db.QueryRow(
+			`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column.name,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to migrate checkpoint table %s: %w", table, err)
+		}
+		if exists == 0 {
+			stmt := fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column.name, column.definition)
+			if _, err := db.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to migrate checkpoint table %s: %w", table, err)
+			}
+		}
+	}
+
+	stmt := fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_graph ON %s (graph, created_at)`, table, table)
+	if _, err := db.Exec(stmt); err != nil {
+		return fmt.Errorf("failed to migrate checkpoint table %s: %w", table, err)
+	}
+	return nil
+}
+
+func (s *Store) Save(st state.State) error {
+	if st.RunID == "" {
+		return fmt.Errorf("cannot save checkpoint without run ID")
+	}
+
+	payload, err := s.codec.Encode(st)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	if _, err := s.db.Exec(s.upsert, st.RunID, st.CheckpointNode, st.Graph, time.Now().UnixNano(), st.Timestamp.UnixNano(), payload); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// SaveIfAbsent inserts the checkpoint unless a row for the run ID exists,
+// leaving an existing row untouched.
+func (s *Store) SaveIfAbsent(st state.State) (bool, error) {
+	if st.RunID == "" {
+		return false, fmt.Errorf("cannot save checkpoint without run ID")
+	}
+
+	payload, err := s.codec.Encode(st)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	now := time.Now().UnixNano()
+	result, err := s.db.Exec(s.insert, st.RunID, st.CheckpointNode, st.Graph, now, st.Timestamp.UnixNano(), payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return inserted == 1, nil
+}
+
+func (s *Store) Load(runID string) (state.State, error) {
+	var payload []byte
+	err := s.db.QueryRow(s.load, runID).Scan(&payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return state.State{}, fmt.Errorf("checkpoint not found: %s", runID)
+	}
+	if err != nil {
+		return state.State{}, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	st, err := s.codec.Decode(payload)
+	if err != nil {
+		return state.State{}, fmt.Errorf("failed to decode checkpoint %s: %w", runID, err)
+	}
+	return st, nil
+}
+
+func (s *Store) Delete(runID string) error {
+	if _, err := s.db.Exec(s.delete, runID); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) List() ([]string, error) {
+	rows, err := s.db.Query(s.list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+	return ids, nil
+}
+
+// ListInfo returns checkpoint metadata in List order. Timestamp is the saved
+// State's Timestamp (the first save time for rows written before the column
+// existed), Size is the encoded payload length, and UncompressedSize is read
+// from the header of payloads written with a compressed codec.
+func (s *Store) ListInfo() ([]state.CheckpointInfo, error) {
+	return s.queryInfo(s.listInfo)
+}
+
+// ListByGraph returns the metadata of graphName's checkpoints in List order,
+// implementing state.GraphCheckpointLister with the table's graph index.
+func (s *Store) ListByGraph(graphName string) ([]state.CheckpointInfo, error) {
+	return s.queryInfo(s.listByGraph, graphName)
+}
+
+// queryInfo runs a metadata query selecting the columns of listInfo.
+func (s *Store) queryInfo(query string, args ...any) ([]state.CheckpointInfo, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	infos := make([]state.CheckpointInfo, 0)
+	for rows.Next() {
+		var info state.CheckpointInfo
+		var nanos int64
+		var header []byte
+		if err := rows.Scan(&info.RunID, &info.Node, &info.Graph, &nanos, &info.Size, &header); err != nil {
+			return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+		}
+		info.Timestamp = time.Unix(0, nanos)
+		info.UncompressedSize, _ = state.UncompressedSize(header)
+		infos = append(infos, info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+	return infos, nil
+}
+
+// Ping verifies the database connection, implementing state.HealthChecker.
+func (s *Store) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("checkpoint database unreachable: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database if the Store opened it with Open.
+func (s *Store) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.db.Close()
+}
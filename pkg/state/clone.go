@@ -0,0 +1,139 @@
+package state
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// Cloner is implemented by values that know how to deep copy themselves.
+//
+// CloneDeep calls Clone instead of copying the value reflectively, allowing
+// custom types with unexported or pointer fields to provide correct copies.
+type Cloner interface {
+	Clone() any
+}
+
+// CloneDeep creates a copy of the State with nested maps and slices copied recursively.
+//
+// Clone shares nested reference values between the original and the copy, so a
+// node that mutates a nested map in place also mutates earlier states and saved
+// checkpoints. CloneDeep prevents this at the cost of copying every nested
+// collection.
+//
+// Copy rules:
+//   - Values implementing Cloner are replaced with the result of Clone()
+//   - Maps and slices of any type are copied recursively
+//   - All other values (scalars, structs, pointers, channels, funcs) are copied as-is
+//
+// Emits EventStateClone with deep=true through the observer.
+//
+// Example:
+//
+//	original := state.New(observer).Set("config", map[string]any{"retries": 3})
+//	copied := original.CloneDeep()
+//	cfg, _ := state.GetAs[map[string]any](copied, "config")
+//	cfg["retries"] = 5 // original is unaffected
+func (s State) CloneDeep() State {
+	data := make(map[string]any, len(s.Data))
+	for key, value := range s.Data {
+		data[key] = deepCopy(value)
+	}
+
+	newState := State{
+		Data:           data,
+		Observer:       s.Observer,
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		size:           newSizeCache(),
+		frozen:         s.frozen,
+		provenance:     s.provenance,
+		ctx:            s.ctx,
+	}
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateClone,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      map[string]any{"keys": len(newState.Data), "deep": true},
+	})
+
+	return newState
+}
+
+// deepCopy recursively copies maps and slices, deferring to Cloner when implemented.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case Cloner:
+		return v.Clone()
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	}
+
+	return deepCopyValue(reflect.ValueOf(value)).Interface()
+}
+
+// deepCopyValue handles typed maps and slices (e.g., []string, map[string]int).
+func deepCopyValue(v reflect.Value) reflect.Value {
+	if v.CanInterface() {
+		if cloner, ok := v.Interface().(Cloner); ok {
+			return reflect.ValueOf(cloner.Clone())
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopyElem(iter.Value(), v.Type().Elem()))
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			copied.Index(i).Set(deepCopyElem(v.Index(i), v.Type().Elem()))
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// deepCopyElem copies a map or slice element, converting the result back to the
+// element type (required for interface-typed elements).
+func deepCopyElem(v reflect.Value, elemType reflect.Type) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Zero(elemType)
+		}
+		v = v.Elem()
+	}
+
+	copied := deepCopyValue(v)
+	if !copied.Type().AssignableTo(elemType) {
+		return v
+	}
+	return copied
+}
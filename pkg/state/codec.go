@@ -0,0 +1,159 @@
+package state
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// Codec serializes State for checkpoint stores that persist bytes.
+//
+// Decoded States use NoOpObserver; Resume attaches the graph's observer.
+type Codec interface {
+	Encode(state State) ([]byte, error)
+	Decode(data []byte) (State, error)
+}
+
+// JSONCodec encodes State with MarshalJSON.
+//
+// JSON is human-readable and portable but does not preserve Go types: numbers
+// decode as float64, time.Time as a string, and structs as map[string]any.
+// Use GobCodec when predicates or nodes type-assert values after a resume.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(state State) ([]byte, error) {
+	return json.Marshal(state)
+}
+
+func (JSONCodec) Decode(data []byte) (State, error) {
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+// GobCodec encodes State with encoding/gob, preserving the concrete Go type of
+// every value.
+//
+// Because State.Data holds values as any, gob must know each concrete type in
+// advance. Common types (basic kinds, time.Time, time.Duration, []any, and
+// map[string]any) are registered by this package; register custom types with
+// RegisterType before saving or loading checkpoints that contain them.
+type GobCodec struct{}
+
+// stateGob is the gob wire format for State.
+type stateGob struct {
+	Data           map[string]any
+	RunID          string
+	CheckpointNode string
+	Graph          string
+	Timestamp      time.Time
+	Frozen         []string
+	Provenance     map[string]KeyProvenance
+}
+
+func (GobCodec) Encode(state State) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(stateGob{
+		Data:           state.Data,
+		RunID:          state.RunID,
+		CheckpointNode: state.CheckpointNode,
+		Graph:          state.Graph,
+		Timestamp:      state.Timestamp,
+		Frozen:         state.FrozenKeys(),
+		Provenance:     state.provenance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to gob encode state (register custom types with state.RegisterType): %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Decode(data []byte) (State, error) {
+	var in stateGob
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&in); err != nil {
+		return State{}, fmt.Errorf("failed to gob decode state: %w", err)
+	}
+
+	if in.Data == nil {
+		in.Data = make(map[string]any)
+	}
+
+	return State{
+		Data:           in.Data,
+		Observer:       observability.NoOpObserver{},
+		RunID:          in.RunID,
+		CheckpointNode: in.CheckpointNode,
+		Graph:          in.Graph,
+		Timestamp:      in.Timestamp,
+		size:           newSizeCache(),
+		frozen:         frozenSet(in.Frozen),
+		provenance:     in.Provenance,
+	}, nil
+}
+
+// RegisterType registers T with encoding/gob so values of type T stored in
+// State survive GobCodec checkpoints with their type intact.
+//
+// Call during initialization, before checkpoints are saved or loaded.
+//
+// Example:
+//
+//	func init() {
+//	    state.RegisterType[Invoice]()
+//	    state.RegisterType[[]Invoice]()
+//	}
+func RegisterType[T any]() {
+	var zero T
+	gob.Register(zero)
+}
+
+func init() {
+	RegisterType[time.Time]()
+	RegisterType[time.Duration]()
+	RegisterType[[]any]()
+	RegisterType[map[string]any]()
+}
+
+// codecs is the global registry of named Codec implementations.
+var (
+	codecs = map[string]Codec{
+		"json": JSONCodec{},
+		"gob":  GobCodec{},
+	}
+	codecsMutex sync.RWMutex
+)
+
+// GetCodec retrieves a Codec by name from the registry.
+//
+// "json" and "gob" are registered by default. An empty name resolves to
+// "json".
+func GetCodec(name string) (Codec, error) {
+	if name == "" {
+		name = "json"
+	}
+
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+
+	codec, exists := codecs[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown state codec: %s", name)
+	}
+	return codec, nil
+}
+
+// RegisterCodec adds a named Codec to the global registry so it can be
+// selected with CheckpointConfig.Codec.
+func RegisterCodec(name string, codec Codec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+
+	codecs[name] = codec
+}
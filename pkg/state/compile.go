@@ -0,0 +1,187 @@
+package state
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Compile validates the graph, checks that every node can reach an exit
+// point, and locks the graph against further changes.
+//
+// After Compile, AddNode, AddNodeFunc, AddEdge, SetEntryPoint, SetExitPoint,
+// and SetReducer return ErrGraphCompiled. Compile runs Validate and then a
+// structural analysis that fails if a node other than an exit point has no
+// outgoing edges, or if no exit point is reachable from a node (edge
+// predicates are ignored, as in Validate). Such graphs would otherwise only
+// fail when a run reached the node.
+//
+// Compile also builds a per-node dispatch table that execution uses in place
+// of the raw edge lists. Each node's predicate edges are separated from its
+// first unconditional edge, and edges after that one are dropped because they
+// can never be taken, so a node whose first edge is unconditional transitions
+// without evaluating anything. Compile is idempotent; a failed Compile leaves
+// the graph unlocked so it can be fixed.
+//
+// Execute, ExecuteWithResult, Resume, and ResumeVersion compile an uncompiled
+// graph automatically unless GraphConfig.RequireCompile is set, in which case
+// they return ErrGraphNotCompiled. Call Compile in main() to surface graph
+// construction errors at startup.
+//
+// Example:
+//
+//	graph.AddNode("fetch", fetch)
+//	graph.AddNode("store", store)
+//	graph.AddEdge("fetch", "store", nil)
+//	graph.SetEntryPoint("fetch")
+//	graph.SetExitPoint("store")
+//	if err := graph.Compile(); err != nil {
+//	    log.Fatalf("invalid workflow: %v", err)
+//	}
+func (g *stateGraph) Compile() error {
+	g.compileMutex.Lock()
+	defer g.compileMutex.Unlock()
+
+	if g.compiled.Load() {
+		return nil
+	}
+
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("graph validation failed: %w", err)
+	}
+
+	names := slices.Sorted(maps.Keys(g.nodes))
+	for _, name := range names {
+		if !g.exitPoints[name] && len(g.edges[name]) == 0 {
+			return fmt.Errorf("node %s has no outgoing edges and is not an exit point", name)
+		}
+	}
+
+	if stuck := g.findExitUnreachable(); len(stuck) > 0 {
+		return fmt.Errorf("no exit point reachable from nodes: %s", strings.Join(stuck, ", "))
+	}
+
+	g.dispatch = buildDispatch(g.edges)
+
+	g.compiled.Store(true)
+	return nil
+}
+
+// dispatchEdge is an edge in a dispatch table, with its index among the
+// node's edges for observer events.
+type dispatchEdge struct {
+	Edge
+	index int
+}
+
+// nodeDispatch holds a node's precomputed outgoing transitions. Predicate
+// edges are evaluated in order; fallback, the node's first unconditional
+// edge, is taken when none of them match.
+type nodeDispatch struct {
+	conditional []dispatchEdge
+	fallback    *dispatchEdge
+}
+
+// buildDispatch precomputes the dispatch table for every node with outgoing
+// edges. Edges after a node's first unconditional edge are unreachable and
+// omitted.
+func buildDispatch(edges map[string][]Edge) map[string]*nodeDispatch {
+	table := make(map[string]*nodeDispatch, len(edges))
+	for from, list := range edges {
+		d := &nodeDispatch{}
+		for i, edge := range list {
+			if edge.Predicate == nil {
+				d.fallback = &dispatchEdge{Edge: edge, index: i}
+				break
+			}
+			d.conditional = append(d.conditional, dispatchEdge{Edge: edge, index: i})
+		}
+		table[from] = d
+	}
+	return table
+}
+
+// next returns the edge to follow from state, calling evaluate, when non-nil,
+// for each edge considered. Returns false when no edge applies.
+func (d *nodeDispatch) next(state State, evaluate func(dispatchEdge)) (dispatchEdge, bool) {
+	for _, edge := range d.conditional {
+		if evaluate != nil {
+			evaluate(edge)
+		}
+		if edge.Predicate(state) {
+			return edge, true
+		}
+	}
+
+	if d.fallback == nil {
+		return dispatchEdge{}, false
+	}
+	if evaluate != nil {
+		evaluate(*d.fallback)
+	}
+	return *d.fallback, true
+}
+
+// IsCompiled reports whether Compile has succeeded for the graph.
+func (g *stateGraph) IsCompiled() bool {
+	return g.compiled.Load()
+}
+
+// ensureCompiled compiles the graph before a run, or refuses the run when
+// GraphConfig.RequireCompile is set.
+func (g *stateGraph) ensureCompiled() error {
+	if g.compiled.Load() {
+		return nil
+	}
+	if g.requireCompile {
+		return ErrGraphNotCompiled
+	}
+	return g.Compile()
+}
+
+// checkMutable returns ErrGraphCompiled once the graph has been compiled.
+func (g *stateGraph) checkMutable() error {
+	if g.compiled.Load() {
+		return ErrGraphCompiled
+	}
+	return nil
+}
+
+// findExitUnreachable walks edges backwards from every exit point and returns
+// the sorted names of nodes that cannot reach any exit.
+func (g *stateGraph) findExitUnreachable() []string {
+	incoming := make(map[string][]string, len(g.nodes))
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			incoming[edge.To] = append(incoming[edge.To], from)
+		}
+	}
+
+	reaches := make(map[string]bool, len(g.nodes))
+	queue := make([]string, 0, len(g.exitPoints))
+	for exit := range g.exitPoints {
+		reaches[exit] = true
+		queue = append(queue, exit)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, from := range incoming[node] {
+			if !reaches[from] {
+				reaches[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+
+	stuck := make([]string, 0)
+	for _, name := range slices.Sorted(maps.Keys(g.nodes)) {
+		if !reaches[name] {
+			stuck = append(stuck, name)
+		}
+	}
+	return stuck
+}
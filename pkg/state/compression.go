@@ -0,0 +1,230 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Compressor compresses encoded checkpoint payloads for NewCompressedCodec.
+//
+// Name identifies the algorithm in each compressed payload, so payloads can
+// be decompressed by any codec that has the compressor registered with
+// RegisterCompressor. Implementations must be safe for concurrent use.
+type Compressor interface {
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// GzipCompressor compresses with compress/gzip. It is registered as "gzip".
+type GzipCompressor struct {
+	// Level is the gzip compression level; zero uses gzip.DefaultCompression
+	Level int
+}
+
+func (GzipCompressor) Name() string {
+	return "gzip"
+}
+
+func (g GzipCompressor) Compress(data []byte) ([]byte, error) {
+	level := g.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// compressedMagic prefixes payloads written by a compressed codec. The
+// leading zero byte cannot begin JSON or gob output, so uncompressed
+// payloads are never mistaken for compressed ones.
+//
+// The full header is the magic, a one-byte compressor name length, the
+// name, and the uncompressed size as a uvarint.
+const compressedMagic = "\x00cmp"
+
+// compressedCodec wraps a Codec, compressing its output.
+type compressedCodec struct {
+	inner      Codec
+	compressor Compressor
+}
+
+// NewCompressedCodec returns a Codec that compresses inner's output with
+// compressor.
+//
+// Encoded payloads begin with a marker naming the compressor and recording the
+// uncompressed size. Decode reads payloads from any registered compressor and
+// passes payloads without the marker, such as checkpoints saved before
+// compression was enabled, straight to inner. A nil inner uses JSONCodec.
+//
+// Compression is usually enabled through CheckpointConfig.Compression, which
+// the "file" store applies to its codec. Stores constructed directly take the
+// wrapped codec:
+//
+//	codec := state.NewCompressedCodec(state.GobCodec{}, state.GzipCompressor{})
+//	store, err := state.NewFileCheckpointStore("/var/lib/workflow/checkpoints", codec)
+func NewCompressedCodec(inner Codec, compressor Compressor) Codec {
+	if inner == nil {
+		inner = JSONCodec{}
+	}
+	return &compressedCodec{
+		inner:      inner,
+		compressor: compressor,
+	}
+}
+
+func (c *compressedCodec) Encode(state State) ([]byte, error) {
+	data, err := c.inner.Encode(state)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := c.compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress checkpoint: %w", err)
+	}
+
+	name := c.compressor.Name()
+	if len(name) > 255 {
+		return nil, fmt.Errorf("compressor name too long: %s", name)
+	}
+
+	out := make([]byte, 0, len(compressedMagic)+1+len(name)+binary.MaxVarintLen64+len(compressed))
+	out = append(out, compressedMagic...)
+	out = append(out, byte(len(name)))
+	out = append(out, name...)
+	out = binary.AppendUvarint(out, uint64(len(data)))
+	return append(out, compressed...), nil
+}
+
+func (c *compressedCodec) Decode(data []byte) (State, error) {
+	name, _, body, ok := parseCompressedHeader(data)
+	if !ok {
+		return c.inner.Decode(data)
+	}
+
+	compressor := c.compressor
+	if name != compressor.Name() {
+		var err error
+		if compressor, err = GetCompressor(name); err != nil {
+			return State{}, err
+		}
+	}
+
+	decompressed, err := compressor.Decompress(body)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to decompress checkpoint: %w", err)
+	}
+	return c.inner.Decode(decompressed)
+}
+
+// UncompressedSize reports the size before compression recorded in a payload
+// written by a compressed codec. Only the header is read, so a prefix of the
+// payload (64 bytes covers compressor names up to 48 bytes) is enough.
+// Returns false for payloads that are not compressed.
+func UncompressedSize(payload []byte) (int, bool) {
+	_, size, _, ok := parseCompressedHeader(payload)
+	return size, ok
+}
+
+// parseCompressedHeader splits a compressed payload into its compressor name,
+// uncompressed size, and compressed body.
+func parseCompressedHeader(data []byte) (name string, size int, body []byte, ok bool) {
+	rest, found := bytes.CutPrefix(data, []byte(compressedMagic))
+	if !found || len(rest) == 0 {
+		return "", 0, nil, false
+	}
+
+	n := int(rest[0])
+	if len(rest) < 1+n {
+		return "", 0, nil, false
+	}
+	name, rest = string(rest[1:1+n]), rest[1+n:]
+
+	length, read := binary.Uvarint(rest)
+	if read <= 0 {
+		return "", 0, nil, false
+	}
+	return name, int(length), rest[read:], true
+}
+
+// compressors is the global registry of named Compressor implementations.
+var (
+	compressors = map[string]Compressor{
+		"gzip": GzipCompressor{},
+	}
+	compressorsMutex sync.RWMutex
+)
+
+// GetCompressor retrieves a Compressor by name from the registry.
+//
+// "gzip" is registered by default.
+func GetCompressor(name string) (Compressor, error) {
+	compressorsMutex.RLock()
+	defer compressorsMutex.RUnlock()
+
+	compressor, exists := compressors[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown checkpoint compressor: %s", name)
+	}
+	return compressor, nil
+}
+
+// RegisterCompressor adds a Compressor to the global registry under its Name
+// so it can be selected with CheckpointConfig.Compression, for example a zstd
+// implementation.
+func RegisterCompressor(compressor Compressor) {
+	compressorsMutex.Lock()
+	defer compressorsMutex.Unlock()
+
+	compressors[compressor.Name()] = compressor
+}
+
+// CodecFromOptions resolves the Codec named by the "codec" option, wrapped
+// with the compressor named by the "compression" option when it is set.
+// Checkpoint store factories use it to honor CheckpointConfig.Codec and
+// CheckpointConfig.Compression.
+func CodecFromOptions(options map[string]any) (Codec, error) {
+	name, err := stringOption(options, "codec")
+	if err != nil {
+		return nil, err
+	}
+	codec, err := GetCodec(name)
+	if err != nil {
+		return nil, err
+	}
+
+	compression, err := stringOption(options, "compression")
+	if err != nil || compression == "" {
+		return codec, err
+	}
+	compressor, err := GetCompressor(compression)
+	if err != nil {
+		return nil, err
+	}
+	return NewCompressedCodec(codec, compressor), nil
+}
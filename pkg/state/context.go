@@ -0,0 +1,35 @@
+package state
+
+import "context"
+
+// WithContext returns a copy of the State whose observer events carry ctx.
+//
+// State operations such as Set, Merge, and Clone do not take a context, so by
+// default their events are emitted with context.Background(). Binding a context
+// lets observers correlate those events with the request that produced them
+// (trace spans, request IDs, deadlines). The binding is carried by every State
+// derived from the returned State.
+//
+// Graph execution binds each node's input State to the node's context, so
+// nodes get this behavior without calling WithContext themselves. The State
+// returned by Execute is unbound. A nil ctx removes the binding.
+//
+// The copy shares its data with s; no observer event is emitted.
+//
+// Example:
+//
+//	s = s.WithContext(r.Context())
+//	s = s.Set("user", user) // EventStateSet carries the request context
+func (s State) WithContext(ctx context.Context) State {
+	s.ctx = ctx
+	return s
+}
+
+// Context returns the context bound with WithContext, or context.Background()
+// when the State is unbound.
+func (s State) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
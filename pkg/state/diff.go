@@ -0,0 +1,131 @@
+package state
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StateDiff describes the differences between two states.
+//
+// Added holds keys present only in the after state with their new values.
+// Removed holds keys present only in the before state with their old values.
+// Changed holds keys present in both states whose values differ according to
+// reflect.DeepEqual. Empty sections are omitted when marshaled to JSON.
+type StateDiff struct {
+	Added   map[string]any         `json:"added,omitempty"`
+	Removed map[string]any         `json:"removed,omitempty"`
+	Changed map[string]ValueChange `json:"changed,omitempty"`
+}
+
+// ValueChange records the old and new value of a changed key.
+type ValueChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Diff compares two states and reports added, removed, and changed keys.
+//
+// Values are compared with reflect.DeepEqual, so nested maps and slices with
+// equal contents are considered unchanged. Diff does not emit observer events.
+//
+// Example:
+//
+//	diff := state.Diff(before, after)
+//	for key, change := range diff.Changed {
+//	    fmt.Printf("%s: %v -> %v\n", key, change.Old, change.New)
+//	}
+func Diff(before, after State) StateDiff {
+	diff := StateDiff{
+		Added:   make(map[string]any),
+		Removed: make(map[string]any),
+		Changed: make(map[string]ValueChange),
+	}
+
+	for key, newValue := range after.Data {
+		oldValue, exists := before.Data[key]
+		if !exists {
+			diff.Added[key] = newValue
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Changed[key] = ValueChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	for key, oldValue := range before.Data {
+		if _, exists := after.Data[key]; !exists {
+			diff.Removed[key] = oldValue
+		}
+	}
+
+	return diff
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d StateDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Summary returns the sorted keys of each section without values.
+//
+// Graph execution includes the summary in NodeComplete events when
+// GraphConfig.NodeDiff is enabled, keeping events small while still showing
+// which keys each node mutated.
+func (d StateDiff) Summary() map[string][]string {
+	return map[string][]string{
+		"added":   slices.Sorted(maps.Keys(d.Added)),
+		"removed": slices.Sorted(maps.Keys(d.Removed)),
+		"changed": slices.Sorted(maps.Keys(d.Changed)),
+	}
+}
+
+// Equal reports whether s and other hold the same data.
+//
+// Only Data is compared, key by key with reflect.DeepEqual. RunID,
+// CheckpointNode, Timestamp, and Observer are ignored, so two States produced
+// by separate runs compare equal when their data matches. A nil and an empty
+// data map are equal. Numeric types must match exactly: int(1) and float64(1)
+// are different values, which matters for States loaded from JSON.
+//
+// Example:
+//
+//	if !got.Equal(want) {
+//	    t.Errorf("state mismatch:\n%s", want.EqualDiff(got))
+//	}
+func (s State) Equal(other State) bool {
+	return Diff(s, other).IsEmpty()
+}
+
+// EqualDiff describes how other's data differs from s, or returns "" when the
+// States are Equal.
+//
+// Each differing key is reported on its own line, sorted by key: "+" marks keys
+// only in other, "-" keys only in s, and "~" keys whose values differ. Intended
+// for test failure messages; the format is not stable for parsing.
+func (s State) EqualDiff(other State) string {
+	diff := Diff(s, other)
+	if diff.IsEmpty() {
+		return ""
+	}
+
+	lines := make([]string, 0, len(diff.Added)+len(diff.Removed)+len(diff.Changed))
+	for key, value := range diff.Added {
+		lines = append(lines, fmt.Sprintf("+ %s: %#v", key, value))
+	}
+	for key, value := range diff.Removed {
+		lines = append(lines, fmt.Sprintf("- %s: %#v", key, value))
+	}
+	for key, change := range diff.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s: %#v -> %#v", key, change.Old, change.New))
+	}
+
+	slices.SortFunc(lines, func(a, b string) int {
+		return strings.Compare(a[2:], b[2:])
+	})
+
+	return strings.Join(lines, "\n")
+}
@@ -26,6 +26,29 @@
 //
 //	value, exists := s.Get("user")  // "alice", true
 //
+// # Plain Map Interop
+//
+// ToMap and FromMap (equivalently NewFromMap) convert between State and
+// map[string]any for APIs that work with plain maps, such as templates, REST
+// handlers, and json.Unmarshal:
+//
+//	var payload map[string]any
+//	json.Unmarshal(body, &payload)
+//	s := state.FromMap(observer, payload) // new RunID
+//
+//	tmpl.Execute(w, s.ToMap())
+//
+// Both copy the top-level map, so neither side observes the other's changes.
+//
+// # Key Namespaces
+//
+// Namespaced gives a node a view of the State whose keys are prefixed with
+// the namespace and "/", so independently written nodes can both use "result"
+// without colliding:
+//
+//	ocr := s.Namespaced("ocr").Set("result", text) // writes "ocr/result"
+//	s = ocr.State()
+//
 // # Immutability
 //
 // State operations never modify the original state. This enables:
@@ -45,6 +68,10 @@
 //
 // When observability is not needed, use NoOpObserver for zero overhead.
 //
+// State events are emitted with context.Background() unless a context is bound
+// with WithContext. Graph execution binds each node's context automatically, so
+// events from state operations inside nodes carry the request context.
+//
 // # Usage with Patterns
 //
 // State is designed to work as the TContext type for workflow patterns:
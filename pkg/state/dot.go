@@ -0,0 +1,88 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ExportDOT renders the graph structure in Graphviz DOT format.
+//
+// Nodes are emitted in sorted order for deterministic output. The entry point is
+// drawn as an oval and exit points as double circles. Predicate edges are dashed
+// and labeled with the edge Name when present. Nodes assigned to a group with
+// SetNodeGroup are drawn inside a "subgraph cluster_<n>" per group, in sorted
+// group order, labeled with the group name.
+//
+// Example:
+//
+//	os.WriteFile("graph.dot", []byte(graph.ExportDOT()), 0o644)
+//	// dot -Tsvg graph.dot -o graph.svg
+func (g *stateGraph) ExportDOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.name))
+	b.WriteString("    node [shape=box];\n")
+
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	writeNode := func(name, indent string) {
+		switch {
+		case name == g.entryPoint:
+			fmt.Fprintf(&b, "%s%s [shape=oval];\n", indent, dotQuote(name))
+		case g.exitPoints[name]:
+			fmt.Fprintf(&b, "%s%s [shape=doublecircle];\n", indent, dotQuote(name))
+		default:
+			fmt.Fprintf(&b, "%s%s;\n", indent, dotQuote(name))
+		}
+	}
+
+	for _, name := range names {
+		if _, grouped := g.groups[name]; !grouped {
+			writeNode(name, "    ")
+		}
+	}
+
+	groups, members := g.groupMembers(names)
+	for i, group := range groups {
+		fmt.Fprintf(&b, "    subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "        label=%s;\n", dotQuote(group))
+		for _, name := range members[group] {
+			writeNode(name, "        ")
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, name := range names {
+		for _, edge := range g.edges[name] {
+			var attrs []string
+			if edge.Predicate != nil {
+				attrs = append(attrs, "style=dashed")
+			}
+			if edge.Name != "" {
+				attrs = append(attrs, "label="+dotQuote(edge.Name))
+			}
+
+			if len(attrs) > 0 {
+				fmt.Fprintf(&b, "    %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+			} else {
+				fmt.Fprintf(&b, "    %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns s as a DOT quoted string, escaping backslashes, quotes, and
+// newlines.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
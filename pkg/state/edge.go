@@ -66,6 +66,31 @@ func KeyEquals(key string, value any) TransitionPredicate {
 	}
 }
 
+// KeyExistsIn returns a predicate that checks if a key exists in namespace ns.
+//
+// Example:
+//
+//	predicate := state.KeyExistsIn("legal", "summary")
+func KeyExistsIn(ns, key string) TransitionPredicate {
+	return func(state State) bool {
+		_, exists := state.GetIn(ns, key)
+		return exists
+	}
+}
+
+// KeyEqualsIn returns a predicate that checks if a key in namespace ns has a
+// specific value.
+//
+// Example:
+//
+//	predicate := state.KeyEqualsIn("finance", "status", "approved")
+func KeyEqualsIn(ns, key string, value any) TransitionPredicate {
+	return func(state State) bool {
+		val, exists := state.GetIn(ns, key)
+		return exists && val == value
+	}
+}
+
 // Not inverts a predicate.
 //
 // Example:
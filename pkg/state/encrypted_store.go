@@ -0,0 +1,169 @@
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrCheckpointIntegrity is returned by an encrypted store's Load when a
+// checkpoint's ciphertext fails authentication under every configured key,
+// indicating tampering, corruption, or an unknown key.
+var ErrCheckpointIntegrity = errors.New("checkpoint integrity check failed")
+
+// encryptedPayloadKey is the State key holding the ciphertext of an
+// encrypted checkpoint in the inner store.
+const encryptedPayloadKey = "encrypted_checkpoint"
+
+// encryptedCheckpointStore wraps a CheckpointStore, encrypting each State
+// before it reaches the inner store.
+type encryptedCheckpointStore struct {
+	inner CheckpointStore
+	aeads []cipher.AEAD
+	codec Codec
+}
+
+// NewEncryptedStore returns a CheckpointStore that encrypts checkpoints with
+// AES-GCM before saving them to inner.
+//
+// Save serializes the State with JSONCodec and seals it under key with a
+// random nonce, binding the ciphertext to the run ID. The inner store
+// receives a State carrying only the run ID, checkpoint node, timestamp, and
+// the base64 ciphertext, so any store, including one using JSONCodec,
+// persists it unchanged. Load decrypts and decodes; values therefore follow
+// JSONCodec typing after a round trip. Delete and List pass straight through.
+//
+// Keys must be 16, 24, or 32 bytes (AES-128, AES-192, or AES-256). For key
+// rotation, pass the retired keys as previousKeys: new checkpoints are always
+// sealed with key, while Load tries key and then each previous key in order.
+// A checkpoint no key can authenticate fails with ErrCheckpointIntegrity.
+//
+// The wrapper does not expose optional store interfaces such as HistoryStore
+// or VersionedCheckpointStore, so execution history is never written
+// unencrypted.
+//
+// Example:
+//
+//	inner, err := state.NewFileCheckpointStore("/var/lib/workflow/checkpoints", nil)
+//	if err != nil {
+//	    return err
+//	}
+//	store, err := state.NewEncryptedStore(inner, currentKey, retiredKey)
+//	if err != nil {
+//	    return err
+//	}
+//	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+func NewEncryptedStore(inner CheckpointStore, key []byte, previousKeys ...[]byte) (CheckpointStore, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("inner checkpoint store cannot be nil")
+	}
+
+	keys := append([][]byte{key}, previousKeys...)
+	aeads := make([]cipher.AEAD, 0, len(keys))
+	for i, k := range keys {
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint encryption key %d: must be 16, 24, or 32 bytes, got %d", i, len(k))
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize checkpoint encryption: %w", err)
+		}
+		aeads = append(aeads, aead)
+	}
+
+	return &encryptedCheckpointStore{
+		inner: inner,
+		aeads: aeads,
+		codec: JSONCodec{},
+	}, nil
+}
+
+func (e *encryptedCheckpointStore) Save(state State) error {
+	envelope, err := e.seal(state)
+	if err != nil {
+		return err
+	}
+	return e.inner.Save(envelope)
+}
+
+func (e *encryptedCheckpointStore) SaveIfAbsent(state State) (bool, error) {
+	envelope, err := e.seal(state)
+	if err != nil {
+		return false, err
+	}
+	return e.inner.SaveIfAbsent(envelope)
+}
+
+// seal encrypts state into the envelope State saved to the inner store.
+func (e *encryptedCheckpointStore) seal(state State) (State, error) {
+	plaintext, err := e.codec.Encode(state)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	aead := e.aeads[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return State{}, fmt.Errorf("failed to generate checkpoint nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(state.RunID))
+
+	return State{
+		Data: map[string]any{
+			encryptedPayloadKey: base64.StdEncoding.EncodeToString(sealed),
+		},
+		Observer:       state.Observer,
+		RunID:          state.RunID,
+		CheckpointNode: state.CheckpointNode,
+		Graph:          state.Graph,
+		Timestamp:      state.Timestamp,
+		size:           newSizeCache(),
+	}, nil
+}
+
+func (e *encryptedCheckpointStore) Load(runID string) (State, error) {
+	envelope, err := e.inner.Load(runID)
+	if err != nil {
+		return State{}, err
+	}
+
+	encoded, ok := envelope.Data[encryptedPayloadKey].(string)
+	if !ok {
+		return State{}, fmt.Errorf("checkpoint %s is not encrypted", runID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return State{}, fmt.Errorf("%w: %s: malformed ciphertext", ErrCheckpointIntegrity, runID)
+	}
+
+	for _, aead := range e.aeads {
+		if len(sealed) < aead.NonceSize() {
+			break
+		}
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+		plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(runID))
+		if err != nil {
+			continue
+		}
+
+		state, err := e.codec.Decode(plaintext)
+		if err != nil {
+			return State{}, fmt.Errorf("failed to decode checkpoint %s: %w", runID, err)
+		}
+		return state, nil
+	}
+
+	return State{}, fmt.Errorf("%w: %s", ErrCheckpointIntegrity, runID)
+}
+
+func (e *encryptedCheckpointStore) Delete(runID string) error {
+	return e.inner.Delete(runID)
+}
+
+func (e *encryptedCheckpointStore) List() ([]string, error) {
+	return e.inner.List()
+}
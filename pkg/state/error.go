@@ -1,6 +1,20 @@
 package state
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrExecutionTimeout is wrapped by the ExecutionError returned when a run
+// exceeds GraphConfig.ExecutionTimeout.
+var ErrExecutionTimeout = errors.New("execution timeout exceeded")
+
+// ErrGraphCompiled is returned when a compiled graph is modified.
+var ErrGraphCompiled = errors.New("graph is compiled")
+
+// ErrGraphNotCompiled is returned when a graph configured with
+// GraphConfig.RequireCompile is executed before Compile.
+var ErrGraphNotCompiled = errors.New("graph is not compiled")
 
 // ExecutionError captures rich context when graph execution fails.
 //
@@ -9,11 +23,13 @@ import "fmt"
 //   - State: State snapshot at failure
 //   - Path: Full execution path leading to failure
 //   - Err: Underlying error from node or graph execution
+//   - MessageID: Hub message involved, when Err wraps a messaging.MessageError
 type ExecutionError struct {
-	NodeName string
-	State    State
-	Path     []string
-	Err      error
+	NodeName  string
+	State     State
+	Path      []string
+	Err       error
+	MessageID string
 }
 
 // Error implements the error interface.
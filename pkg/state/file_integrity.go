@@ -0,0 +1,190 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkpointFileMagic begins the header line of checkpoint files written by
+// the file store. The header is followed by the hex SHA-256 of the payload and
+// a newline; the payload is the codec output.
+const checkpointFileMagic = "checkpoint-v1 sha256:"
+
+// CorruptCheckpointError is returned when a checkpoint file fails its
+// integrity check, typically because the process died while writing it or
+// the file was modified on disk.
+//
+// Resume and ResumeVersion return it wrapped; use errors.As to detect it and
+// fall back to an earlier version with ResumeVersion.
+type CorruptCheckpointError struct {
+	// RunID identifies the checkpointed run
+	RunID string
+
+	// Version is the corrupt checkpoint version, or zero for the latest
+	// checkpoint
+	Version int
+
+	// Path is the corrupt file
+	Path string
+
+	// Reason describes the failed check
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *CorruptCheckpointError) Error() string {
+	name := e.RunID
+	if e.Version > 0 {
+		name = fmt.Sprintf("%s@%d", e.RunID, e.Version)
+	}
+	return fmt.Sprintf("checkpoint %s is corrupt (%s); resume from an earlier version with ResumeVersion", name, e.Reason)
+}
+
+// writeCheckpointFile atomically replaces path with payload and its checksum
+// header. The data is written to a temporary file in the same directory,
+// synced, and renamed over path, so a crash leaves either the old file or the
+// new one, never a partial write.
+func writeCheckpointFile(path string, payload []byte) error {
+	tmp, err := writeTempCheckpoint(path, payload)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// createCheckpointFile writes payload and its checksum header to path only if
+// path does not exist. Returns false without writing when the file already
+// exists.
+//
+// The data is written and synced to a temporary file first, then published
+// with a hard link, which fails if path exists. The create is exclusive across
+// processes and atomic: readers and crashes see either no file or the
+// complete one.
+func createCheckpointFile(path string, payload []byte) (bool, error) {
+	if _, err := os.Lstat(path); err == nil {
+		return false, nil
+	}
+
+	tmp, err := writeTempCheckpoint(path, payload)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Link(tmp, path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	syncDir(filepath.Dir(path))
+	return true, nil
+}
+
+// writeTempCheckpoint writes payload and its checksum header to a synced
+// temporary file beside path and returns the temporary file's name. Callers
+// must remove it.
+func writeTempCheckpoint(path string, payload []byte) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeChecksummed(tmp, payload); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// writeChecksummed writes the checksum header and payload to file, syncs it,
+// and closes it.
+func writeChecksummed(file *os.File, payload []byte) error {
+	sum := sha256.Sum256(payload)
+	header := checkpointFileMagic + hex.EncodeToString(sum[:]) + "\n"
+
+	if _, err := file.WriteString(header); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(payload); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir flushes a directory entry update to disk where the platform
+// supports it. Failures are ignored: the rename itself has succeeded.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
+
+// readCheckpointFile reads path and returns its verified payload.
+//
+// Files written before checksums were introduced have no header and are
+// returned unverified. Read failures are wrapped, so callers can still test
+// for fs.ErrNotExist; integrity failures return *CorruptCheckpointError.
+func readCheckpointFile(path, runID string, version int) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return verifyCheckpoint(data, path, runID, version)
+}
+
+// verifyCheckpoint strips and checks the checksum header of a checkpoint
+// file's contents.
+func verifyCheckpoint(data []byte, path, runID string, version int) ([]byte, error) {
+	corrupt := func(reason string) error {
+		return &CorruptCheckpointError{RunID: runID, Version: version, Path: path, Reason: reason}
+	}
+
+	if len(data) == 0 {
+		return nil, corrupt("empty file")
+	}
+	if len(data) < len(checkpointFileMagic) && strings.HasPrefix(checkpointFileMagic, string(data)) {
+		return nil, corrupt("truncated header")
+	}
+	if !bytes.HasPrefix(data, []byte(checkpointFileMagic)) {
+		return data, nil
+	}
+
+	header, payload, found := bytes.Cut(data, []byte("\n"))
+	if !found {
+		return nil, corrupt("truncated header")
+	}
+
+	want, err := hex.DecodeString(string(header[len(checkpointFileMagic):]))
+	if err != nil || len(want) != sha256.Size {
+		return nil, corrupt("malformed checksum header")
+	}
+
+	sum := sha256.Sum256(payload)
+	if !bytes.Equal(sum[:], want) {
+		return nil, corrupt("checksum mismatch")
+	}
+	return payload, nil
+}
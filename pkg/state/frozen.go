@@ -0,0 +1,176 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// ErrFrozenKey is returned when a write targets a key frozen with Freeze.
+var ErrFrozenKey = errors.New("key is frozen")
+
+// Freeze creates a new State in which keys are read-only.
+//
+// Frozen keys protect values that downstream nodes must never overwrite, such
+// as the original input document. Once frozen, a key stays frozen for the rest
+// of the run: the frozen set is preserved by Clone, CloneDeep, Merge, and JSON
+// checkpoints, and cannot be cleared.
+//
+// Writes to frozen keys behave as follows:
+//   - TrySet and TrySetMany return ErrFrozenKey without modifying the State
+//   - Update returns an error wrapping ErrFrozenKey
+//   - Set, SetMany, and SetPath leave the frozen value in place and list the
+//     skipped keys under "frozen" in the EventStateSet data
+//   - Merge and MergeWith keep the receiver's value and list the skipped keys
+//     under "frozen" in the EventStateMerge data
+//
+// Graph execution rejects a node whose output changes a frozen key, for example
+// by building a fresh State, with an ExecutionError wrapping ErrFrozenKey.
+//
+// Keys may be frozen before they are set; the first write is then rejected
+// like any other. Namespaced keys are not supported individually; freeze whole
+// top-level keys.
+//
+// Example:
+//
+//	s = s.Set("document", original).Freeze("document")
+//	_, err := s.TrySet("document", edited) // errors.Is(err, state.ErrFrozenKey)
+func (s State) Freeze(keys ...string) State {
+	frozen := maps.Clone(s.frozen)
+	if frozen == nil {
+		frozen = make(map[string]bool, len(keys))
+	}
+	for _, key := range keys {
+		frozen[key] = true
+	}
+
+	newState := s.Clone()
+	newState.frozen = frozen
+	return newState
+}
+
+// IsFrozen reports whether key is frozen.
+func (s State) IsFrozen(key string) bool {
+	return s.frozen[key]
+}
+
+// FrozenKeys returns the frozen keys in sorted order.
+func (s State) FrozenKeys() []string {
+	return slices.Sorted(maps.Keys(s.frozen))
+}
+
+// TrySet is Set for callers that need to know when a write is rejected.
+//
+// Returns the original State and an error wrapping ErrFrozenKey when key is
+// frozen; otherwise behaves exactly like Set.
+func (s State) TrySet(key string, value any) (State, error) {
+	if s.frozen[key] {
+		return s, fmt.Errorf("cannot set %q: %w", key, ErrFrozenKey)
+	}
+	return s.Set(key, value), nil
+}
+
+// TrySetMany is SetMany for callers that need to know when a write is rejected.
+//
+// If any key in values is frozen, no values are written and the error wraps
+// ErrFrozenKey and names every frozen key. Otherwise behaves exactly like
+// SetMany.
+func (s State) TrySetMany(values map[string]any) (State, error) {
+	if frozen := s.frozenIn(values); len(frozen) > 0 {
+		return s, fmt.Errorf("cannot set %s: %w", strings.Join(frozen, ", "), ErrFrozenKey)
+	}
+	return s.SetMany(values), nil
+}
+
+// frozenIn returns the sorted keys of data that are frozen in s.
+func (s State) frozenIn(data map[string]any) []string {
+	if len(s.frozen) == 0 {
+		return nil
+	}
+
+	frozen := make([]string, 0)
+	for key := range data {
+		if s.frozen[key] {
+			frozen = append(frozen, key)
+		}
+	}
+	slices.Sort(frozen)
+	return frozen
+}
+
+// withoutFrozen returns data minus the keys frozen in s, and the skipped keys.
+// data is returned as-is when nothing is skipped.
+func (s State) withoutFrozen(data map[string]any) (map[string]any, []string) {
+	frozen := s.frozenIn(data)
+	if len(frozen) == 0 {
+		return data, nil
+	}
+
+	allowed := maps.Clone(data)
+	for _, key := range frozen {
+		delete(allowed, key)
+	}
+	return allowed, frozen
+}
+
+// frozenSet builds a frozen set from a key list, returning nil when empty.
+func frozenSet(keys []string) map[string]bool {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	frozen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		frozen[key] = true
+	}
+	return frozen
+}
+
+// unionFrozen returns the union of two frozen sets without modifying either.
+func unionFrozen(a, b map[string]bool) map[string]bool {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+
+	union := maps.Clone(a)
+	maps.Copy(union, b)
+	return union
+}
+
+// frozenViolations returns the frozen keys of before whose presence or value
+// differs in after, sorted.
+func frozenViolations(before, after State) []string {
+	violations := make([]string, 0)
+	for key := range before.frozen {
+		old, hadOld := before.Data[key]
+		current, hasCurrent := after.Data[key]
+		if hadOld != hasCurrent || !reflect.DeepEqual(old, current) {
+			violations = append(violations, key)
+		}
+	}
+	slices.Sort(violations)
+	return violations
+}
+
+// emitSet reports an EventStateSet, listing any frozen keys that were skipped.
+func (s State) emitSet(data map[string]any, skipped []string) {
+	if len(skipped) > 0 {
+		data["frozen"] = skipped
+	}
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateSet,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      data,
+	})
+}
@@ -2,11 +2,18 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
 )
 
@@ -29,6 +36,11 @@ import (
 //	graph.SetEntryPoint("analyze")
 //	graph.SetExitPoint("approve")
 //	result, err := graph.Execute(ctx, initialState)
+//
+// Once built, a graph may be executed from many goroutines: per-run
+// execution state is local to each call. Construction methods must not run
+// concurrently with executions; IsolatedRunner enforces this for concurrent
+// workloads.
 type StateGraph interface {
 	// Name returns the graph identifier for event metadata
 	Name() string
@@ -36,6 +48,9 @@ type StateGraph interface {
 	// AddNode registers a computation step in the graph
 	AddNode(name string, node StateNode) error
 
+	// AddNodeFunc registers a function as a computation step in the graph
+	AddNodeFunc(name string, fn func(context.Context, State) (State, error)) error
+
 	// AddEdge creates a transition between nodes (predicate can be nil for unconditional)
 	AddEdge(from, to string, predicate TransitionPredicate) error
 
@@ -45,10 +60,52 @@ type StateGraph interface {
 	// SetExitPoint defines a terminal node (execution stops here)
 	SetExitPoint(node string) error
 
+	// SetReducer registers how a key's updates are folded into the flowing state
+	SetReducer(key string, reducer Reducer) error
+
+	// SetNodeGroup assigns a node to a named group for visualization and metrics
+	SetNodeGroup(node, group string) error
+
+	// MarkSensitive redacts the given keys' values from events, history, and checkpoints
+	MarkSensitive(keys ...string)
+
+	// Validate checks graph structure for configuration errors
+	Validate() error
+
+	// Compile validates and analyzes the graph, then locks it against changes
+	Compile() error
+
+	// IsCompiled reports whether Compile has succeeded
+	IsCompiled() bool
+
 	// Execute runs the graph from entry point with initial state
 	Execute(ctx context.Context, initialState State) (State, error)
 
+	// ExecuteWithResult runs the graph and returns the final state with its path and history
+	ExecuteWithResult(ctx context.Context, initialState State) (ExecutionResult, error)
+
 	Resume(ctx context.Context, runID string) (State, error)
+
+	// ResumeVersion continues execution from an earlier checkpoint version
+	ResumeVersion(ctx context.Context, runID string, version int) (State, error)
+
+	// PingCheckpointStore checks that the graph's checkpoint store is reachable
+	PingCheckpointStore(ctx context.Context) error
+
+	// ExportAuditLog writes the most recent execution's audit log as NDJSON
+	ExportAuditLog(w io.Writer) error
+
+	// ExportAuditLogFor writes the audit log of the execution with runID as NDJSON
+	ExportAuditLogFor(runID string, w io.Writer) error
+
+	// ExportMermaid renders the graph structure as a Mermaid flowchart
+	ExportMermaid() string
+
+	// ExportMermaidWithPath renders the graph with an execution path highlighted
+	ExportMermaidWithPath(path []string) string
+
+	// ExportDOT renders the graph structure in Graphviz DOT format
+	ExportDOT() string
 }
 
 // stateGraph implements StateGraph interface with concrete execution engine.
@@ -61,8 +118,31 @@ type stateGraph struct {
 	maxIterations       int
 	observer            observability.Observer
 	checkpointStore     CheckpointStore
+	checkpointStoreName string
 	checkpointInterval  int
 	preserveCheckpoints bool
+	maxVersions         int
+	asyncCheckpoints    *asyncCheckpointConfig
+	executionTimeout    time.Duration
+	acyclic             bool
+	deepClone           bool
+	nodeDiff            bool
+	trackHistory        bool
+	trackProvenance     bool
+	maxHistory          int
+	schema              *Schema
+	reducers            map[string]Reducer
+	groups              map[string]string
+	sensitive           map[string]bool
+	auditLog            bool
+	audit               *auditTrace
+	audits              map[string]*auditTrace
+	auditOrder          []string
+	auditMutex          sync.Mutex
+	requireCompile      bool
+	dispatch            map[string]*nodeDispatch
+	compiled            atomic.Bool
+	compileMutex        sync.Mutex
 }
 
 // Name returns the graph identifier for event metadata.
@@ -73,7 +153,9 @@ func (g *stateGraph) Name() string {
 // NewGraph creates a new state graph from configuration.
 //
 // The constructor resolves the observer from the configuration registry
-// and initializes the graph with empty node/edge collections.
+// and initializes the graph with empty node/edge collections. When
+// checkpointing is enabled, a store implementing HealthChecker is pinged and
+// an unreachable store fails construction.
 //
 // Example:
 //
@@ -91,13 +173,29 @@ func NewGraph(cfg config.GraphConfig) (StateGraph, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve observer: %w", err)
 	}
+	if cfg.EnableAuditLog {
+		observer = auditObserver{observer}
+	}
+
+	schema, err := resolveSchema(cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	asyncCheckpoints, err := resolveAsyncCheckpoints(cfg.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
 
 	var checkpointStore CheckpointStore
 	if cfg.Checkpoint.Interval > 0 {
-		checkpointStore, err = GetCheckpointStore(cfg.Checkpoint.Store)
+		checkpointStore, err = resolveCheckpointStore(cfg.Checkpoint)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve checkpoint store: %w", err)
 		}
+		if err := pingOnConstruction(checkpointStore); err != nil {
+			return nil, err
+		}
 	}
 
 	return &stateGraph{
@@ -106,10 +204,27 @@ func NewGraph(cfg config.GraphConfig) (StateGraph, error) {
 		edges:               make(map[string][]Edge),
 		exitPoints:          make(map[string]bool),
 		maxIterations:       cfg.MaxIterations,
+		executionTimeout:    cfg.ExecutionTimeout,
 		observer:            observer,
 		checkpointStore:     checkpointStore,
+		checkpointStoreName: cfg.Checkpoint.Store,
 		checkpointInterval:  cfg.Checkpoint.Interval,
 		preserveCheckpoints: cfg.Checkpoint.Preserve,
+		maxVersions:         cfg.Checkpoint.MaxVersions,
+		asyncCheckpoints:    asyncCheckpoints,
+		acyclic:             cfg.Acyclic,
+		deepClone:           cfg.DeepClone,
+		nodeDiff:            cfg.NodeDiff,
+		trackHistory:        cfg.TrackHistory,
+		trackProvenance:     cfg.TrackProvenance,
+		maxHistory:          cfg.MaxHistory,
+		schema:              schema,
+		reducers:            make(map[string]Reducer),
+		groups:              make(map[string]string),
+		sensitive:           sensitiveKeys(cfg.SensitiveKeys),
+		auditLog:            cfg.EnableAuditLog,
+		audits:              make(map[string]*auditTrace),
+		requireCompile:      cfg.RequireCompile,
 	}, nil
 }
 
@@ -117,6 +232,25 @@ func NewGraphWithDeps(cfg config.GraphConfig, observer observability.Observer, c
 	if observer == nil {
 		observer = observability.NoOpObserver{}
 	}
+	if cfg.EnableAuditLog {
+		observer = auditObserver{observer}
+	}
+
+	schema, err := resolveSchema(cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	asyncCheckpoints, err := resolveAsyncCheckpoints(cfg.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Checkpoint.Interval > 0 && checkpointStore != nil {
+		if err := pingOnConstruction(checkpointStore); err != nil {
+			return nil, err
+		}
+	}
 
 	return &stateGraph{
 		name:                cfg.Name,
@@ -124,17 +258,62 @@ func NewGraphWithDeps(cfg config.GraphConfig, observer observability.Observer, c
 		edges:               make(map[string][]Edge),
 		exitPoints:          make(map[string]bool),
 		maxIterations:       cfg.MaxIterations,
+		executionTimeout:    cfg.ExecutionTimeout,
 		observer:            observer,
 		checkpointStore:     checkpointStore,
+		checkpointStoreName: storeName(checkpointStore),
 		checkpointInterval:  cfg.Checkpoint.Interval,
 		preserveCheckpoints: cfg.Checkpoint.Preserve,
+		maxVersions:         cfg.Checkpoint.MaxVersions,
+		asyncCheckpoints:    asyncCheckpoints,
+		acyclic:             cfg.Acyclic,
+		deepClone:           cfg.DeepClone,
+		nodeDiff:            cfg.NodeDiff,
+		trackHistory:        cfg.TrackHistory,
+		trackProvenance:     cfg.TrackProvenance,
+		maxHistory:          cfg.MaxHistory,
+		schema:              schema,
+		reducers:            make(map[string]Reducer),
+		groups:              make(map[string]string),
+		sensitive:           sensitiveKeys(cfg.SensitiveKeys),
+		auditLog:            cfg.EnableAuditLog,
+		audits:              make(map[string]*auditTrace),
+		requireCompile:      cfg.RequireCompile,
 	}, nil
 }
 
+// sensitiveKeys builds the graph's sensitive key set from configuration.
+func sensitiveKeys(keys []string) map[string]bool {
+	sensitive := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			sensitive[key] = true
+		}
+	}
+	return sensitive
+}
+
+// resolveSchema looks up the named schema, returning nil when name is empty.
+func resolveSchema(name string) (*Schema, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	schema, err := GetSchema(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema: %w", err)
+	}
+	return schema, nil
+}
+
 // AddNode registers a computation step in the graph.
 //
 // Nodes must have unique names. Adding a duplicate node returns an error.
 func (g *stateGraph) AddNode(name string, node StateNode) error {
+	if err := g.checkMutable(); err != nil {
+		return err
+	}
+
 	if name == "" {
 		return fmt.Errorf("node name cannot be empty")
 	}
@@ -151,11 +330,31 @@ func (g *stateGraph) AddNode(name string, node StateNode) error {
 	return nil
 }
 
+// AddNodeFunc registers fn as a computation step in the graph.
+//
+// Shorthand for AddNode(name, FuncNode(fn)); the same naming rules apply.
+//
+// Example:
+//
+//	graph.AddNodeFunc("normalize", func(ctx context.Context, s state.State) (state.State, error) {
+//	    return s.Set("normalized", true), nil
+//	})
+func (g *stateGraph) AddNodeFunc(name string, fn func(context.Context, State) (State, error)) error {
+	if fn == nil {
+		return fmt.Errorf("node function cannot be nil")
+	}
+	return g.AddNode(name, FuncNode(fn))
+}
+
 // AddEdge creates a transition between nodes.
 //
 // Both nodes must exist before adding an edge. Predicate can be nil for
 // unconditional transitions. Multiple edges from the same node are allowed.
 func (g *stateGraph) AddEdge(from, to string, predicate TransitionPredicate) error {
+	if err := g.checkMutable(); err != nil {
+		return err
+	}
+
 	if from == "" {
 		return fmt.Errorf("from node cannot be empty")
 	}
@@ -186,6 +385,10 @@ func (g *stateGraph) AddEdge(from, to string, predicate TransitionPredicate) err
 //
 // The entry point node must exist. Only one entry point is allowed.
 func (g *stateGraph) SetEntryPoint(node string) error {
+	if err := g.checkMutable(); err != nil {
+		return err
+	}
+
 	if node == "" {
 		return fmt.Errorf("entry point cannot be empty")
 	}
@@ -207,6 +410,10 @@ func (g *stateGraph) SetEntryPoint(node string) error {
 // Multiple exit points are supported - call this method multiple times
 // to register different termination conditions. The exit point node must exist.
 func (g *stateGraph) SetExitPoint(node string) error {
+	if err := g.checkMutable(); err != nil {
+		return err
+	}
+
 	if node == "" {
 		return fmt.Errorf("exit point cannot be empty")
 	}
@@ -219,6 +426,37 @@ func (g *stateGraph) SetExitPoint(node string) error {
 	return nil
 }
 
+// SetReducer registers a reducer that folds node updates for key into the state.
+//
+// Without a reducer, a node's returned value for a key replaces the previous
+// value. With a reducer, the graph stores reducer(previous, returned) for each
+// node that writes the key. A key counts as written when its value differs from
+// the node's input (slices and maps by identity, other values by equality), so
+// nodes that pass state through unchanged do not re-apply reducers.
+//
+// Registering a reducer for a key that already has one replaces it.
+//
+// Example:
+//
+//	graph.SetReducer("messages", state.AppendReducer)
+//	graph.SetReducer("tokens", state.SumReducer)
+func (g *stateGraph) SetReducer(key string, reducer Reducer) error {
+	if err := g.checkMutable(); err != nil {
+		return err
+	}
+
+	if key == "" {
+		return fmt.Errorf("reducer key cannot be empty")
+	}
+
+	if reducer == nil {
+		return fmt.Errorf("reducer for key %s cannot be nil", key)
+	}
+
+	g.reducers[key] = reducer
+	return nil
+}
+
 // Validate checks graph structure for common configuration errors.
 //
 // Validation ensures:
@@ -226,9 +464,20 @@ func (g *stateGraph) SetExitPoint(node string) error {
 //   - Entry point is set and exists
 //   - At least one exit point is set
 //   - All exit points exist as nodes
+//   - No cycles exist when the graph is configured as acyclic
+//   - Every node is reachable from the entry point
 //
-// This method is called internally by Execute but can be called explicitly
-// to validate graph structure before execution.
+// Reachability follows every edge regardless of its predicate, so a node that
+// is only reachable through a conditional edge passes validation. An
+// unreachable node usually means an AddEdge call is missing; the error lists
+// every unreachable node in sorted order.
+//
+// In acyclic mode, conditional edges are treated the same as unconditional edges,
+// and the error message includes the offending cycle's node sequence.
+//
+// This method is called by Compile, which Execute runs on an uncompiled
+// graph, but can be called explicitly to validate graph structure before
+// execution.
 func (g *stateGraph) Validate() error {
 	if len(g.nodes) == 0 {
 		return fmt.Errorf("graph has no nodes")
@@ -252,13 +501,23 @@ func (g *stateGraph) Validate() error {
 		}
 	}
 
+	if g.acyclic {
+		if cycle := g.findCycle(); cycle != nil {
+			return fmt.Errorf("cycle detected in acyclic graph: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	if unreachable := g.findUnreachable(); len(unreachable) > 0 {
+		return fmt.Errorf("nodes unreachable from entry point %s: %s", g.entryPoint, strings.Join(unreachable, ", "))
+	}
+
 	return nil
 }
 
 // Execute runs the graph from entry point with initial state.
 //
 // Execution follows this algorithm:
-//  1. Validate graph structure
+//  1. Compile the graph if needed (see Compile)
 //  2. Start at entry point node
 //  3. Execute current node with state
 //  4. Check if current node is an exit point
@@ -266,24 +525,56 @@ func (g *stateGraph) Validate() error {
 //  6. Repeat from step 3 with next node
 //  7. Return final state when exit point reached
 //
-// Cycle detection and iteration limits prevent infinite loops.
-// Observer receives events for all execution milestones.
+// Cycle detection and iteration limits prevent infinite loops. When
+// GraphConfig.ExecutionTimeout is set, the whole run shares one deadline: nodes
+// receive a context that expires with it, and the run fails with an
+// ExecutionError wrapping ErrExecutionTimeout once it passes.
+// Observer receives events for all execution milestones. Each node's input
+// State is bound to ctx (see State.WithContext), so state events emitted by
+// nodes carry the request context. Checkpoint saves emit EventCheckpointSave
+// with the save duration, estimated payload size, and store name; failed
+// checkpoint operations emit EventCheckpointError, including a failed delete of
+// the run's checkpoint after success, which does not fail the run. A run that
+// fails after starting emits EventGraphError with the error and failing node.
+// With CheckpointConfig.Async, saves run on a background worker, a failed save
+// is reported to CheckpointConfig.OnAsyncError instead of failing the run, and
+// queued saves are flushed before Execute returns.
 //
 // Returns ExecutionError with full context on failure.
 func (g *stateGraph) Execute(ctx context.Context, initialState State) (State, error) {
-	return g.execute(ctx, g.entryPoint, initialState)
+	return g.execute(ctx, g.entryPoint, initialState, nil)
+}
+
+// ExecuteWithResult runs the graph like Execute and also reports the executed
+// path and, when GraphConfig.TrackHistory is enabled, the state history.
+//
+// The result is populated on failure as well, so history can show what the
+// state looked like leading up to the failing node.
+//
+// Example:
+//
+//	result, err := graph.ExecuteWithResult(ctx, initialState)
+//	before, _ := result.History.Before("approve")
+func (g *stateGraph) ExecuteWithResult(ctx context.Context, initialState State) (ExecutionResult, error) {
+	var result ExecutionResult
+	_, err := g.execute(ctx, g.entryPoint, initialState, &result)
+	return result, err
 }
 
 // Resume continues graph execution from a saved checkpoint.
 //
 // Loads the checkpoint identified by runID and resumes execution from the next
 // node after the checkpoint. The checkpoint State preserves all execution context
-// including data transformations and metadata.
+// including data transformations and metadata. The loaded State is bound to the
+// graph's observer, so state operations in resumed nodes are observed like
+// those of a fresh run regardless of the observer the checkpoint was saved
+// with.
 //
 // Resume algorithm:
 //  1. Verify checkpointing is enabled for this graph
-//  2. Load checkpoint State from store
-//  3. Emit EventCheckpointLoad
+//  2. Load checkpoint State from store and attach the graph's observer
+//  3. Emit EventCheckpointLoad with the load duration, or EventCheckpointError
+//     if the load fails
 //  4. Find next valid node transition from checkpoint
 //  5. Emit EventCheckpointResume
 //  6. Continue execution from next node
@@ -291,6 +582,7 @@ func (g *stateGraph) Execute(ctx context.Context, initialState State) (State, er
 // Returns error if:
 //   - Checkpointing not enabled (Interval=0)
 //   - Checkpoint not found
+//   - Checkpoint fails integrity verification (*CorruptCheckpointError)
 //   - No valid transition from checkpoint node
 //   - Checkpoint is at exit point (execution already complete)
 //
@@ -306,18 +598,74 @@ func (g *stateGraph) Resume(ctx context.Context, runID string) (State, error) {
 		return State{}, fmt.Errorf("checkpointing not enabled for this graph")
 	}
 
+	started := time.Now()
 	state, err := g.checkpointStore.Load(runID)
 	if err != nil {
+		g.checkpointError(ctx, "load", runID, "", err)
 		return State{}, fmt.Errorf("failed to load checkpoint: %w", err)
 	}
 
+	return g.resume(ctx, runID, state, 0, time.Since(started))
+}
+
+// ResumeVersion continues graph execution from a specific checkpoint version,
+// restarting a run from an earlier good point when its latest checkpoint is
+// corrupt or the run went down a bad path.
+//
+// The graph's checkpoint store must implement VersionedCheckpointStore, and the
+// version must still be retained (see CheckpointConfig.MaxVersions). Checkpoints
+// saved by the resumed run are appended after the run's existing versions.
+// Otherwise ResumeVersion behaves like Resume.
+//
+// Example:
+//
+//	store := state.NewMemoryCheckpointStore().(state.VersionedCheckpointStore)
+//	versions, _ := store.Versions(runID)
+//	finalState, err := graph.ResumeVersion(ctx, runID, versions[0].Version)
+func (g *stateGraph) ResumeVersion(ctx context.Context, runID string, version int) (State, error) {
+	if g.checkpointStore == nil {
+		return State{}, fmt.Errorf("checkpointing not enabled for this graph")
+	}
+
+	store, ok := g.checkpointStore.(VersionedCheckpointStore)
+	if !ok {
+		return State{}, fmt.Errorf("checkpoint store does not support versions")
+	}
+
+	started := time.Now()
+	state, err := store.LoadVersion(runID, version)
+	if err != nil {
+		g.checkpointError(ctx, "load", runID, "", err)
+		return State{}, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	return g.resume(ctx, runID, state, version, time.Since(started))
+}
+
+// resume continues execution after a loaded checkpoint. version is zero when
+// the latest checkpoint was loaded; loadTime is how long the store took to
+// load it.
+func (g *stateGraph) resume(ctx context.Context, runID string, state State, version int, loadTime time.Duration) (State, error) {
+	if err := g.ensureCompiled(); err != nil {
+		return State{}, err
+	}
+
+	if keys := redactedKeys(state.Data, ""); len(keys) > 0 {
+		return State{}, fmt.Errorf("%w: %s", ErrRedactedCheckpoint, strings.Join(keys, ", "))
+	}
+
+	state = state.WithObserver(g.observer)
+
 	g.observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventCheckpointLoad,
 		Timestamp: time.Now(),
-		Source:    g.name,
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 		Data: map[string]any{
-			"node":   state.CheckpointNode,
-			"run_id": runID,
+			"node":     state.CheckpointNode,
+			"run_id":   runID,
+			"version":  version,
+			"duration": loadTime,
+			"store":    g.checkpointStoreName,
 		},
 	})
 
@@ -329,7 +677,7 @@ func (g *stateGraph) Resume(ctx context.Context, runID string) (State, error) {
 	g.observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventCheckpointResume,
 		Timestamp: time.Now(),
-		Source:    g.name,
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 		Data: map[string]any{
 			"checkpoint_node": state.CheckpointNode,
 			"resume_node":     nextNode,
@@ -337,18 +685,31 @@ func (g *stateGraph) Resume(ctx context.Context, runID string) (State, error) {
 		},
 	})
 
-	return g.execute(ctx, nextNode, state)
+	return g.execute(ctx, nextNode, state, nil)
 }
 
-func (g *stateGraph) execute(ctx context.Context, startNode string, initialState State) (State, error) {
-	if err := g.Validate(); err != nil {
-		return initialState, fmt.Errorf("graph validation failed: %w", err)
+func (g *stateGraph) execute(ctx context.Context, startNode string, initialState State, result *ExecutionResult) (final State, err error) {
+	if err := g.ensureCompiled(); err != nil {
+		return initialState, err
+	}
+
+	if g.auditLog {
+		var trace *auditTrace
+		ctx, trace = g.beginAudit(ctx, initialState.RunID)
+		defer func() { trace.finish(err) }()
+	}
+
+	started := time.Now()
+	if g.executionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, g.executionTimeout, ErrExecutionTimeout)
+		defer cancel()
 	}
 
 	g.observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventGraphStart,
 		Timestamp: time.Now(),
-		Source:    g.name,
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 		Data: map[string]any{
 			"entry_point": g.entryPoint,
 			"run_id":      initialState.RunID,
@@ -356,13 +717,42 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 		},
 	})
 
+	defer func() {
+		if err != nil {
+			g.graphError(ctx, initialState.RunID, err)
+		}
+	}()
+
+	saver := g.newCheckpointSaver(ctx)
+	defer saver.flush()
+
 	current := startNode
 	state := initialState
 	iterations := 0
 	visited := make(map[string]int)
 	path := make([]string, 0, g.maxIterations)
 
+	var history StateHistory
+	if g.trackHistory {
+		history = g.recordHistory(history, "", 0, initialState)
+	}
+
+	groupDurations := make(map[string]time.Duration)
+
+	if result != nil {
+		defer func() {
+			result.State = state
+			result.Path = path
+			result.History = history
+			result.GroupDurations = groupDurations
+		}()
+	}
+
 	for {
+		if errors.Is(context.Cause(ctx), ErrExecutionTimeout) {
+			return state, g.timeoutError(current, state, path, started)
+		}
+
 		if err := ctx.Err(); err != nil {
 			return state, &ExecutionError{
 				NodeName: current,
@@ -382,14 +772,17 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 			}
 		}
 
-		visited[current]++
 		path = append(path, current)
 
+		if !g.acyclic {
+			visited[current]++
+		}
+
 		if visited[current] > 1 {
 			g.observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventCycleDetected,
 				Timestamp: time.Now(),
-				Source:    g.name,
+				Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 				Data: map[string]any{
 					"node":        current,
 					"visit_count": visited[current],
@@ -409,83 +802,168 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 			}
 		}
 
+		group := g.groups[current]
+
+		startData := map[string]any{
+			"node":           current,
+			"run_id":         state.RunID,
+			"iteration":      iterations,
+			"input_snapshot": g.redactData(state.Data),
+		}
+		if group != "" {
+			startData["group"] = group
+		}
+
 		g.observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventNodeStart,
 			Timestamp: time.Now(),
-			Source:    g.name,
-			Data: map[string]any{
-				"node":           current,
-				"iteration":      iterations,
-				"input_snapshot": maps.Clone(state.Data),
-			},
+			Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+			Data:      startData,
 		})
 
-		newState, err := node.Execute(ctx, state)
+		input := state.WithContext(ctx)
+		if g.deepClone {
+			input = input.CloneDeep()
+		}
+
+		nodeStarted := time.Now()
+		newState, err := node.Execute(ctx, input)
+		if group != "" {
+			groupDurations[group] += time.Since(nodeStarted)
+		}
+
+		completeData := map[string]any{
+			"node":            current,
+			"run_id":          state.RunID,
+			"iteration":       iterations,
+			"error":           err != nil,
+			"output_snapshot": g.redactData(newState.Data),
+		}
+		if group != "" {
+			completeData["group"] = group
+		}
+		if g.nodeDiff && err == nil {
+			completeData["diff"] = Diff(state, newState).Summary()
+		}
 
 		g.observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventNodeComplete,
 			Timestamp: time.Now(),
-			Source:    g.name,
-			Data: map[string]any{
-				"node":            current,
-				"iteration":       iterations,
-				"error":           err != nil,
-				"output_snapshot": maps.Clone(newState.Data),
-			},
+			Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+			Data:      completeData,
 		})
 
+		if err != nil {
+			if errors.Is(context.Cause(ctx), ErrExecutionTimeout) {
+				return state, g.timeoutError(current, state, path, started)
+			}
+			return state, &ExecutionError{
+				NodeName:  current,
+				State:     state,
+				Path:      path,
+				Err:       fmt.Errorf("node execution failed: %w", err),
+				MessageID: messaging.MessageIDOf(err),
+			}
+		}
+
+		if violations := frozenViolations(input, newState); len(violations) > 0 {
+			return state, &ExecutionError{
+				NodeName: current,
+				State:    state,
+				Path:     path,
+				Err:      fmt.Errorf("node modified %s: %w", strings.Join(violations, ", "), ErrFrozenKey),
+			}
+		}
+		newState.frozen = unionFrozen(input.frozen, newState.frozen)
+
+		newState, err = applyReducers(g.reducers, input, newState)
 		if err != nil {
 			return state, &ExecutionError{
 				NodeName: current,
 				State:    state,
 				Path:     path,
-				Err:      fmt.Errorf("node execution failed: %w", err),
+				Err:      err,
+			}
+		}
+
+		if g.schema != nil {
+			if err := g.schema.Validate(newState); err != nil {
+				var schemaErr *SchemaError
+				if errors.As(err, &schemaErr) {
+					schemaErr.Node = current
+				}
+				return state, &ExecutionError{
+					NodeName: current,
+					State:    newState,
+					Path:     path,
+					Err:      err,
+				}
 			}
 		}
 
-		state = newState.SetCheckpointNode(current)
+		if g.trackProvenance {
+			newState = newState.recordProvenance(state, current, iterations)
+		}
+
+		state = newState.SetCheckpointNode(current).WithContext(nil)
+		state.Graph = g.name
+
+		if g.trackHistory {
+			history = g.recordHistory(history, current, iterations, state)
+		}
 
 		if g.checkpointInterval > 0 && iterations%g.checkpointInterval == 0 {
-			if err := state.Checkpoint(g.checkpointStore); err != nil {
+			checkpoint := g.redact(state)
+			if saver != nil {
+				saver.enqueue(checkpoint, current)
+			} else if err := g.saveCheckpoint(ctx, checkpoint, current, false); err != nil {
 				return state, &ExecutionError{
 					NodeName: current,
 					State:    state,
 					Path:     path,
-					Err:      fmt.Errorf("checkpoint save failed: %w", err),
+					Err:      err,
 				}
 			}
-
-			g.observer.OnEvent(ctx, observability.Event{
-				Type:      observability.EventCheckpointSave,
-				Timestamp: time.Now(),
-				Source:    g.name,
-				Data: map[string]any{
-					"node":   current,
-					"run_id": state.RunID,
-				},
-			})
 		}
 
 		if g.exitPoints[current] {
 			g.observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventGraphComplete,
 				Timestamp: time.Now(),
-				Source:    g.name,
+				Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 				Data: map[string]any{
 					"exit_point":  current,
+					"run_id":      state.RunID,
 					"iterations":  iterations,
 					"path_length": len(path),
 				},
 			})
 
+			saver.flush()
+
 			if !g.preserveCheckpoints && g.checkpointInterval > 0 {
-				g.checkpointStore.Delete(state.RunID)
+				if err := g.checkpointStore.Delete(state.RunID); err != nil {
+					g.checkpointError(ctx, "delete", state.RunID, current, err)
+				}
+			}
+
+			if g.trackHistory && g.preserveCheckpoints {
+				if store, ok := g.checkpointStore.(HistoryStore); ok {
+					if err := store.SaveHistory(state.RunID, history); err != nil {
+						return state, &ExecutionError{
+							NodeName: current,
+							State:    state,
+							Path:     path,
+							Err:      fmt.Errorf("history save failed: %w", err),
+						}
+					}
+				}
 			}
 
 			return state, nil
 		}
 
-		edges, hasEdges := g.edges[current]
+		route, hasEdges := g.dispatch[current]
 		if !hasEdges {
 			return state, &ExecutionError{
 				NodeName: current,
@@ -495,41 +973,20 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 			}
 		}
 
-		nextNode := ""
-		for i, edge := range edges {
+		edge, found := route.next(state, func(edge dispatchEdge) {
 			g.observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventEdgeEvaluate,
 				Timestamp: time.Now(),
-				Source:    g.name,
+				Source:    observability.NewEventSource(observability.SourceGraph, g.name),
 				Data: map[string]any{
 					"from":          edge.From,
 					"to":            edge.To,
-					"edge_index":    i,
+					"edge_index":    edge.index,
 					"has_predicate": edge.Predicate != nil,
 				},
 			})
-
-			if edge.Predicate == nil || edge.Predicate(state) {
-				nextNode = edge.To
-
-				g.observer.OnEvent(ctx, observability.Event{
-					Type:      observability.EventEdgeTransition,
-					Timestamp: time.Now(),
-					Source:    g.name,
-					Data: map[string]any{
-						"from":             edge.From,
-						"to":               edge.To,
-						"edge_index":       i,
-						"predicate_name":   edge.Name,
-						"predicate_result": true,
-					},
-				})
-
-				break
-			}
-		}
-
-		if nextNode == "" {
+		})
+		if !found {
 			return state, &ExecutionError{
 				NodeName: current,
 				State:    state,
@@ -538,7 +995,20 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 			}
 		}
 
-		current = nextNode
+		g.observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventEdgeTransition,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+			Data: map[string]any{
+				"from":             edge.From,
+				"to":               edge.To,
+				"edge_index":       edge.index,
+				"predicate_name":   edge.Name,
+				"predicate_result": true,
+			},
+		})
+
+		current = edge.To
 	}
 }
 
@@ -556,7 +1026,7 @@ func (g *stateGraph) execute(ctx context.Context, startNode string, initialState
 // Called by Resume to determine where execution should continue after loading
 // a checkpoint.
 func (g *stateGraph) findNextNode(fromNode string, state State) (string, error) {
-	edges, hasEdges := g.edges[fromNode]
+	route, hasEdges := g.dispatch[fromNode]
 	if !hasEdges {
 		if g.exitPoints[fromNode] {
 			return "", fmt.Errorf("checkpoint was at exit point, execution already complete")
@@ -564,12 +1034,117 @@ func (g *stateGraph) findNextNode(fromNode string, state State) (string, error)
 		return "", fmt.Errorf("no outgoing edges from checkpoint node: %s", fromNode)
 	}
 
-	for i := range edges {
-		edge := &edges[i]
-		if edge.Predicate == nil || edge.Predicate(state) {
-			return edge.To, nil
+	if edge, found := route.next(state, nil); found {
+		return edge.To, nil
+	}
+	return "", fmt.Errorf("no valid edge transition from checkpoint node: %s", fromNode)
+}
+
+// timeoutError reports that the run exceeded GraphConfig.ExecutionTimeout.
+func (g *stateGraph) timeoutError(node string, state State, path []string, started time.Time) *ExecutionError {
+	return &ExecutionError{
+		NodeName: node,
+		State:    state,
+		Path:     path,
+		Err:      fmt.Errorf("%w: %v elapsed (limit %v)", ErrExecutionTimeout, time.Since(started).Round(time.Millisecond), g.executionTimeout),
+	}
+}
+
+// graphError emits EventGraphError for a run that ended without reaching an
+// exit point, naming the failing node when err is an ExecutionError.
+func (g *stateGraph) graphError(ctx context.Context, runID string, err error) {
+	data := map[string]any{
+		"run_id": runID,
+		"error":  err.Error(),
+	}
+	var execErr *ExecutionError
+	if errors.As(err, &execErr) {
+		data["node"] = execErr.NodeName
+	}
+
+	g.observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventGraphError,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceGraph, g.name),
+		Data:      data,
+	})
+}
+
+// findUnreachable performs a breadth-first search from the entry point and
+// returns the sorted names of nodes it never visits. Edge predicates are
+// ignored; any edge is considered traversable.
+func (g *stateGraph) findUnreachable() []string {
+	reachable := map[string]bool{g.entryPoint: true}
+	queue := []string{g.entryPoint}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range g.edges[node] {
+			if !reachable[edge.To] {
+				reachable[edge.To] = true
+				queue = append(queue, edge.To)
+			}
 		}
 	}
 
-	return "", fmt.Errorf("no valid edge transition from checkpoint node: %s", fromNode)
+	unreachable := make([]string, 0)
+	for _, name := range slices.Sorted(maps.Keys(g.nodes)) {
+		if !reachable[name] {
+			unreachable = append(unreachable, name)
+		}
+	}
+	return unreachable
+}
+
+// findCycle performs a depth-first search over all edges and returns the first
+// cycle found as a node sequence that starts and ends with the same node.
+//
+// Nodes are traversed in sorted order so the reported cycle is deterministic.
+// Edge predicates are ignored; any edge is considered traversable. Returns nil
+// when the graph is acyclic.
+func (g *stateGraph) findCycle() []string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	status := make(map[string]int, len(g.nodes))
+	stack := make([]string, 0, len(g.nodes))
+
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		status[node] = inProgress
+		stack = append(stack, node)
+
+		for _, edge := range g.edges[node] {
+			switch status[edge.To] {
+			case inProgress:
+				start := slices.Index(stack, edge.To)
+				cycle := slices.Clone(stack[start:])
+				return append(cycle, edge.To)
+			case unvisited:
+				if cycle := visit(edge.To); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		status[node] = done
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(g.nodes))
+	for _, name := range names {
+		if status[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,81 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// checkpointPingTimeout bounds the store ping performed at graph construction.
+const checkpointPingTimeout = 5 * time.Second
+
+// HealthChecker is an optional CheckpointStore extension for stores that
+// depend on a filesystem or remote service that can become unreachable.
+//
+// NewGraph and NewGraphWithDeps ping the store when checkpointing is enabled,
+// so an unreachable store fails graph construction rather than the first
+// checkpoint of a run. The file, SQLite, and object storage stores implement
+// it; the encrypting wrapper delegates to its inner store.
+type HealthChecker interface {
+	// Ping reports whether the store's backing storage is reachable.
+	Ping(ctx context.Context) error
+}
+
+// PingCheckpointStore pings store if it implements HealthChecker. Stores
+// without a health check are reported healthy.
+//
+// Example:
+//
+//	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+//	    if err := state.PingCheckpointStore(r.Context(), store); err != nil {
+//	        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//	    }
+//	})
+func PingCheckpointStore(ctx context.Context, store CheckpointStore) error {
+	checker, ok := store.(HealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.Ping(ctx)
+}
+
+// PingCheckpointStore pings the graph's checkpoint store. Returns nil when
+// checkpointing is disabled or the store has no health check.
+//
+// Use it from service health endpoints when the store was constructed from
+// configuration and is not otherwise reachable.
+func (g *stateGraph) PingCheckpointStore(ctx context.Context) error {
+	if g.checkpointStore == nil {
+		return nil
+	}
+	return PingCheckpointStore(ctx, g.checkpointStore)
+}
+
+// pingOnConstruction fails graph construction if store is unreachable.
+func pingOnConstruction(store CheckpointStore) error {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointPingTimeout)
+	defer cancel()
+
+	if err := PingCheckpointStore(ctx, store); err != nil {
+		return fmt.Errorf("checkpoint store unreachable: %w", err)
+	}
+	return nil
+}
+
+// Ping checks that the checkpoint directory still exists and is a directory.
+func (f *fileCheckpointStore) Ping(ctx context.Context) error {
+	info, err := os.Stat(f.dir)
+	if err != nil {
+		return fmt.Errorf("checkpoint directory unavailable: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("checkpoint directory unavailable: %s is not a directory", f.dir)
+	}
+	return nil
+}
+
+// Ping delegates to the inner store.
+func (e *encryptedCheckpointStore) Ping(ctx context.Context) error {
+	return PingCheckpointStore(ctx, e.inner)
+}
@@ -0,0 +1,105 @@
+package state
+
+import (
+	"time"
+)
+
+// HistoryEntry is a snapshot of the flowing state recorded during execution.
+//
+// Node is the node that produced the state. The first entry of a run has an
+// empty Node and holds the initial state passed to Execute.
+type HistoryEntry struct {
+	Node      string    `json:"node"`
+	Iteration int       `json:"iteration"`
+	Timestamp time.Time `json:"timestamp"`
+	State     State     `json:"state"`
+}
+
+// StateHistory is the ordered record of state snapshots for a single run.
+//
+// The graph maintains history when GraphConfig.TrackHistory is enabled,
+// appending a snapshot after every node. When GraphConfig.MaxHistory is
+// reached the oldest entries are discarded. Snapshots are shallow clones, or
+// deep clones when GraphConfig.DeepClone is set.
+type StateHistory []HistoryEntry
+
+// Before returns the state as it was immediately before the most recent
+// execution of node. Returns false if node does not appear in the history or
+// the preceding snapshot has been discarded by the MaxHistory cap.
+//
+// Example:
+//
+//	result, err := graph.ExecuteWithResult(ctx, initialState)
+//	if before, ok := result.History.Before("review"); ok {
+//	    fmt.Println(state.Diff(before, result.State))
+//	}
+func (h StateHistory) Before(node string) (State, bool) {
+	for i := len(h) - 1; i > 0; i-- {
+		if h[i].Node == node {
+			return h[i-1].State, true
+		}
+	}
+	return State{}, false
+}
+
+// Nodes returns the node names in history order, excluding the initial entry.
+func (h StateHistory) Nodes() []string {
+	nodes := make([]string, 0, len(h))
+	for _, entry := range h {
+		if entry.Node != "" {
+			nodes = append(nodes, entry.Node)
+		}
+	}
+	return nodes
+}
+
+// HistoryStore is implemented by CheckpointStores that can persist StateHistory.
+//
+// When GraphConfig.TrackHistory and Checkpoint.Preserve are both enabled, the
+// graph saves the run's history alongside the final checkpoint if its store
+// implements HistoryStore. The built-in memory store implements it.
+type HistoryStore interface {
+	// SaveHistory persists history for the given RunID, replacing any existing history.
+	SaveHistory(runID string, history StateHistory) error
+
+	// LoadHistory retrieves history for the given RunID.
+	// Returns error if no history is stored.
+	LoadHistory(runID string) (StateHistory, error)
+}
+
+// ExecutionResult is the outcome of a graph run returned by ExecuteWithResult.
+//
+// State is the final state (or the last successful state when execution
+// fails), Path lists the executed nodes in order, and History holds state
+// snapshots when GraphConfig.TrackHistory is enabled. GroupDurations totals
+// the time spent executing the nodes of each group (see
+// StateGraph.SetNodeGroup); ungrouped nodes are not counted.
+type ExecutionResult struct {
+	State          State
+	Path           []string
+	History        StateHistory
+	GroupDurations map[string]time.Duration
+}
+
+// recordHistory appends a snapshot of s to history, discarding the oldest
+// entries beyond the graph's cap.
+func (g *stateGraph) recordHistory(history StateHistory, node string, iteration int, s State) StateHistory {
+	snapshot := s.Clone()
+	if g.deepClone {
+		snapshot = s.CloneDeep()
+	}
+	snapshot = g.redact(snapshot)
+
+	history = append(history, HistoryEntry{
+		Node:      node,
+		Iteration: iteration,
+		Timestamp: time.Now(),
+		State:     snapshot,
+	})
+
+	if g.maxHistory > 0 && len(history) > g.maxHistory {
+		history = history[len(history)-g.maxHistory:]
+	}
+
+	return history
+}
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// IsolatedRunner executes a StateGraph from many goroutines at once.
+//
+// A StateGraph is already safe for concurrent Execute calls once its
+// structure is fixed: visited nodes, the execution path, and the current
+// node are local to each run, and the observer and checkpoint store are
+// required to be safe for concurrent use. What a graph does not protect is
+// its structure, so building it while runs are in flight is a data race, and
+// runs started from the same initial State share a RunID and overwrite each
+// other's checkpoints.
+//
+// IsolatedRunner enforces that contract. NewIsolatedRunner compiles the
+// graph, locking nodes, edges, entry and exit points, and reducers (see
+// Compile); MarkSensitive must still be called before the runner is created.
+// Run deep-copies the initial State so nested maps and slices are never
+// shared between runs, and gives every run its own RunID.
+//
+// Example:
+//
+//	runner := state.NewIsolatedRunner(graph)
+//	seed := state.New(observer).Set("tenant", "acme")
+//
+//	var wg sync.WaitGroup
+//	for _, doc := range docs {
+//	    wg.Go(func() {
+//	        final, err := runner.Run(ctx, seed.Set("document", doc))
+//	        report(doc, final, err)
+//	    })
+//	}
+//	wg.Wait()
+type IsolatedRunner struct {
+	graph      StateGraph
+	compileErr error
+}
+
+// NewIsolatedRunner compiles graph and returns a runner for it.
+//
+// A compile failure is reported by every Run rather than here, so a runner
+// can be constructed unconditionally during setup.
+func NewIsolatedRunner(graph StateGraph) *IsolatedRunner {
+	return &IsolatedRunner{
+		graph:      graph,
+		compileErr: graph.Compile(),
+	}
+}
+
+// Graph returns the compiled graph the runner executes.
+func (r *IsolatedRunner) Graph() StateGraph {
+	return r.graph
+}
+
+// Run executes the graph on an isolated copy of initial and returns the
+// final State. The run is assigned a new RunID; read it from the returned
+// State, or from ExecutionError.State on failure, to Resume the run.
+//
+// Returns an error if the graph failed to compile, otherwise the result of
+// StateGraph.Execute.
+func (r *IsolatedRunner) Run(ctx context.Context, initial State) (State, error) {
+	if r.compileErr != nil {
+		return initial, fmt.Errorf("graph %s cannot run: %w", r.graph.Name(), r.compileErr)
+	}
+
+	run := initial.CloneDeep()
+	run.RunID = uuid.New().String()
+
+	return r.graph.Execute(ctx, run)
+}
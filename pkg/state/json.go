@@ -0,0 +1,127 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// stateJSON is the wire format for State.
+type stateJSON struct {
+	Data           map[string]json.RawMessage `json:"data"`
+	RunID          string                     `json:"run_id"`
+	CheckpointNode string                     `json:"checkpoint_node"`
+	Graph          string                     `json:"graph,omitempty"`
+	Timestamp      time.Time                  `json:"timestamp"`
+	Frozen         []string                   `json:"frozen,omitempty"`
+	Provenance     map[string]KeyProvenance   `json:"provenance,omitempty"`
+}
+
+// MarshalJSON encodes the State's data and run metadata.
+//
+// The observer is not serialized. Each value is encoded individually so that an
+// unsupported value (channel, function, cyclic structure) produces an error
+// naming the offending key.
+//
+// Example:
+//
+//	data, err := json.Marshal(s)
+func (s State) MarshalJSON() ([]byte, error) {
+	out := stateJSON{
+		Data:           make(map[string]json.RawMessage, len(s.Data)),
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		Frozen:         s.FrozenKeys(),
+		Provenance:     s.provenance,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.Data)) {
+		raw, err := json.Marshal(s.Data[key])
+		if err != nil {
+			return nil, fmt.Errorf("state key %q is not JSON serializable: %w", key, err)
+		}
+		out.Data[key] = raw
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes State data and run metadata produced by MarshalJSON.
+//
+// Values decode using encoding/json defaults (numbers become float64, objects
+// become map[string]any). The decoded State uses NoOpObserver; attach an
+// observer with WithObserver.
+//
+// Example:
+//
+//	var s state.State
+//	if err := json.Unmarshal(data, &s); err != nil {
+//	    return err
+//	}
+//	s = s.WithObserver(observer)
+func (s *State) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Data           map[string]any           `json:"data"`
+		RunID          string                   `json:"run_id"`
+		CheckpointNode string                   `json:"checkpoint_node"`
+		Graph          string                   `json:"graph"`
+		Timestamp      time.Time                `json:"timestamp"`
+		Frozen         []string                 `json:"frozen"`
+		Provenance     map[string]KeyProvenance `json:"provenance"`
+	}
+
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+
+	if in.Data == nil {
+		in.Data = make(map[string]any)
+	}
+
+	*s = State{
+		Data:           in.Data,
+		Observer:       observability.NoOpObserver{},
+		RunID:          in.RunID,
+		CheckpointNode: in.CheckpointNode,
+		Graph:          in.Graph,
+		Timestamp:      in.Timestamp,
+		size:           newSizeCache(),
+		frozen:         frozenSet(in.Frozen),
+		provenance:     in.Provenance,
+	}
+
+	return nil
+}
+
+// WithObserver returns a copy of the State that reports to observer.
+//
+// Data and run metadata are preserved. If observer is nil, NoOpObserver is used.
+// Typically called after unmarshaling a State from storage.
+//
+// Example:
+//
+//	s = s.WithObserver(observability.NewSlogObserver(logger))
+func (s State) WithObserver(observer observability.Observer) State {
+	if observer == nil {
+		observer = observability.NoOpObserver{}
+	}
+
+	return State{
+		Data:           maps.Clone(s.Data),
+		Observer:       observer,
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		size:           newSizeCache(),
+		frozen:         s.frozen,
+		provenance:     s.provenance,
+		ctx:            s.ctx,
+	}
+}
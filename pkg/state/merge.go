@@ -0,0 +1,133 @@
+package state
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// TakeFirst returns a MergeResolver that keeps the receiver's value for every
+// conflicting key.
+//
+// Example:
+//
+//	// Keep the first branch's classification
+//	merged := first.MergeWith(second, state.TakeFirst())
+func TakeFirst() MergeResolver {
+	return func(key string, ours, theirs any) any {
+		return ours
+	}
+}
+
+// TakeLast returns a MergeResolver that takes the merged-in State's value for
+// every conflicting key, matching Merge.
+func TakeLast() MergeResolver {
+	return func(key string, ours, theirs any) any {
+		return theirs
+	}
+}
+
+// TakeMax returns a MergeResolver that keeps the larger of two numeric values.
+//
+// Supports every integer and floating-point kind, including named numeric
+// types and mixed pairs such as int64 against float64; the winning value keeps
+// its type. Integers are compared exactly, so large int64 and uint64 values do
+// not lose precision. Non-numeric conflicts take the merged-in State's value,
+// as with Merge.
+//
+// Example:
+//
+//	// Both branches scored the document; keep the higher confidence
+//	merged := left.MergeWith(right, state.TakeMax())
+func TakeMax() MergeResolver {
+	return func(key string, ours, theirs any) any {
+		if c, ok := compareNumbers(ours, theirs); ok && c > 0 {
+			return ours
+		}
+		return theirs
+	}
+}
+
+// Concatenate returns a MergeResolver that appends the merged-in State's slice
+// to the receiver's.
+//
+// Values are combined like AppendReducer: the incoming value may be a slice of
+// the same type or a single element of it, and a new slice is returned.
+// Conflicts that cannot be appended take the merged-in State's value, as with
+// Merge.
+//
+// Example:
+//
+//	// Join node: collect findings from both branches
+//	merged := left.MergeWith(right, state.Concatenate())
+func Concatenate() MergeResolver {
+	return func(key string, ours, theirs any) any {
+		combined, err := AppendReducer(ours, theirs)
+		if err != nil {
+			return theirs
+		}
+		return combined
+	}
+}
+
+// numberClass groups reflect kinds by how they are compared.
+type numberClass int
+
+const (
+	notNumber numberClass = iota
+	signedNumber
+	unsignedNumber
+	floatNumber
+)
+
+func classifyNumber(v reflect.Value) numberClass {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return signedNumber
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return unsignedNumber
+	case reflect.Float32, reflect.Float64:
+		return floatNumber
+	}
+	return notNumber
+}
+
+// compareNumbers compares two values of any integer or floating-point kind,
+// returning -1, 0, or +1. Integer pairs are compared exactly; pairs involving
+// a float are compared as float64. Returns false if either value is not
+// numeric.
+func compareNumbers(a, b any) (int, bool) {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	cx, cy := classifyNumber(x), classifyNumber(y)
+	if cx == notNumber || cy == notNumber {
+		return 0, false
+	}
+
+	switch {
+	case cx == signedNumber && cy == signedNumber:
+		return cmp.Compare(x.Int(), y.Int()), true
+	case cx == unsignedNumber && cy == unsignedNumber:
+		return cmp.Compare(x.Uint(), y.Uint()), true
+	case cx == signedNumber && cy == unsignedNumber:
+		if x.Int() < 0 {
+			return -1, true
+		}
+		return cmp.Compare(uint64(x.Int()), y.Uint()), true
+	case cx == unsignedNumber && cy == signedNumber:
+		if y.Int() < 0 {
+			return 1, true
+		}
+		return cmp.Compare(x.Uint(), uint64(y.Int())), true
+	}
+	return cmp.Compare(floatOf(x), floatOf(y)), true
+}
+
+// floatOf converts a numeric reflect.Value to float64.
+func floatOf(v reflect.Value) float64 {
+	switch classifyNumber(v) {
+	case signedNumber:
+		return float64(v.Int())
+	case unsignedNumber:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,225 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ExportMermaid renders the graph structure as a Mermaid flowchart.
+//
+// Nodes are emitted in sorted order for deterministic output. Unconditional edges
+// render as solid arrows, predicate edges as dashed arrows labeled with the edge
+// Name when present. The entry point is rendered as a stadium shape and exit points
+// as double circles. Nodes assigned to a group with SetNodeGroup are rendered inside
+// a subgraph per group, in sorted group order.
+//
+// Example:
+//
+//	fmt.Println(graph.ExportMermaid())
+func (g *stateGraph) ExportMermaid() string {
+	return g.renderMermaid(nil)
+}
+
+// ExportMermaidWithPath renders the graph structure with an execution path highlighted.
+//
+// The path is typically taken from ExecutionError.Path after a failed run. Visited
+// nodes are styled and annotated with the 1-based step numbers at which they executed,
+// so repeated visits in cycles show every step (e.g., "review<br/>#2, #4"). Traversed
+// edges are styled and labeled with the step numbers of each traversal.
+//
+// Path entries that do not exist in the graph (e.g., a path recorded against an older
+// graph version) are rendered as dangling annotated nodes, and transitions with no
+// matching edge are rendered as dotted links, rather than causing an error.
+//
+// Example:
+//
+//	_, err := graph.Execute(ctx, initial)
+//	var execErr *state.ExecutionError
+//	if errors.As(err, &execErr) {
+//	    fmt.Println(graph.ExportMermaidWithPath(execErr.Path))
+//	}
+func (g *stateGraph) ExportMermaidWithPath(path []string) string {
+	return g.renderMermaid(path)
+}
+
+// renderMermaid builds the flowchart, highlighting path when it is non-nil.
+func (g *stateGraph) renderMermaid(path []string) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	ids := make(map[string]string, len(names))
+	for i, name := range names {
+		ids[name] = "n" + strconv.Itoa(i)
+	}
+
+	visits := make(map[string][]int)
+	for i, name := range path {
+		visits[name] = append(visits[name], i+1)
+	}
+
+	unknown := make([]string, 0)
+	for _, name := range path {
+		if _, exists := ids[name]; exists {
+			continue
+		}
+		ids[name] = "u" + strconv.Itoa(len(unknown))
+		unknown = append(unknown, name)
+	}
+
+	writeNode := func(name, indent string) {
+		label := mermaidLabel(name, visits[name])
+		switch {
+		case name == g.entryPoint:
+			fmt.Fprintf(&b, "%s%s([\"%s\"])\n", indent, ids[name], label)
+		case g.exitPoints[name]:
+			fmt.Fprintf(&b, "%s%s((\"%s\"))\n", indent, ids[name], label)
+		default:
+			fmt.Fprintf(&b, "%s%s[\"%s\"]\n", indent, ids[name], label)
+		}
+	}
+
+	for _, name := range names {
+		if _, grouped := g.groups[name]; !grouped {
+			writeNode(name, "    ")
+		}
+	}
+
+	groups, members := g.groupMembers(names)
+	for i, group := range groups {
+		fmt.Fprintf(&b, "    subgraph g%d[\"%s\"]\n", i, mermaidEscape(group))
+		for _, name := range members[group] {
+			writeNode(name, "        ")
+		}
+		b.WriteString("    end\n")
+	}
+
+	for _, name := range unknown {
+		label := mermaidLabel(name+" (unknown)", visits[name])
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[name], label)
+	}
+
+	type transition struct{ from, to string }
+	traversals := make(map[transition][]int)
+	for i := 1; i < len(path); i++ {
+		t := transition{path[i-1], path[i]}
+		traversals[t] = append(traversals[t], i)
+	}
+
+	linkIndex := 0
+	var visitedLinks []string
+	rendered := make(map[transition]bool)
+
+	for _, name := range names {
+		for _, edge := range g.edges[name] {
+			t := transition{edge.From, edge.To}
+			steps := traversals[t]
+			if rendered[t] {
+				steps = nil
+			}
+			rendered[t] = true
+
+			label := edge.Name
+			if len(steps) > 0 {
+				label = strings.TrimSpace(label + " " + mermaidSteps(steps))
+			}
+
+			arrow := "-->"
+			if edge.Predicate != nil {
+				arrow = "-.->"
+			}
+
+			if label != "" {
+				fmt.Fprintf(&b, "    %s %s|\"%s\"| %s\n", ids[edge.From], arrow, mermaidEscape(label), ids[edge.To])
+			} else {
+				fmt.Fprintf(&b, "    %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+			}
+
+			if len(steps) > 0 {
+				visitedLinks = append(visitedLinks, strconv.Itoa(linkIndex))
+			}
+			linkIndex++
+		}
+	}
+
+	var untracked []int
+	for i := 1; i < len(path); i++ {
+		t := transition{path[i-1], path[i]}
+		if rendered[t] {
+			continue
+		}
+		rendered[t] = true
+		fmt.Fprintf(&b, "    %s -.-|\"%s\"| %s\n", ids[t.from], mermaidSteps(traversals[t]), ids[t.to])
+		untracked = append(untracked, linkIndex)
+		linkIndex++
+	}
+
+	if path == nil {
+		return b.String()
+	}
+
+	b.WriteString("    classDef visited fill:#d4edda,stroke:#28a745,stroke-width:2px\n")
+	b.WriteString("    classDef unknown fill:#f8d7da,stroke:#dc3545,stroke-dasharray:5 5\n")
+
+	var visitedNodes []string
+	for _, name := range names {
+		if len(visits[name]) > 0 {
+			visitedNodes = append(visitedNodes, ids[name])
+		}
+	}
+	if len(visitedNodes) > 0 {
+		fmt.Fprintf(&b, "    class %s visited\n", strings.Join(visitedNodes, ","))
+	}
+
+	if len(unknown) > 0 {
+		unknownIDs := make([]string, len(unknown))
+		for i, name := range unknown {
+			unknownIDs[i] = ids[name]
+		}
+		fmt.Fprintf(&b, "    class %s unknown\n", strings.Join(unknownIDs, ","))
+	}
+
+	if len(visitedLinks) > 0 {
+		fmt.Fprintf(&b, "    linkStyle %s stroke:#28a745,stroke-width:3px\n", strings.Join(visitedLinks, ","))
+	}
+
+	if len(untracked) > 0 {
+		indices := make([]string, len(untracked))
+		for i, idx := range untracked {
+			indices[i] = strconv.Itoa(idx)
+		}
+		fmt.Fprintf(&b, "    linkStyle %s stroke:#dc3545,stroke-width:2px\n", strings.Join(indices, ","))
+	}
+
+	return b.String()
+}
+
+// mermaidLabel formats a node label, appending visit step numbers when present.
+func mermaidLabel(name string, steps []int) string {
+	label := mermaidEscape(name)
+	if len(steps) == 0 {
+		return label
+	}
+	return label + "<br/>" + mermaidSteps(steps)
+}
+
+// mermaidSteps formats step numbers as "#1, #3".
+func mermaidSteps(steps []int) string {
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = "#" + strconv.Itoa(step)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mermaidEscape replaces characters that terminate Mermaid quoted labels.
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
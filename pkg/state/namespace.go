@@ -0,0 +1,186 @@
+package state
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// namespacePrefix marks top-level keys that hold namespaced sub-states.
+//
+// A namespace "legal" is stored as a map[string]any under the key "ns:legal".
+// Keys with this prefix are reserved; use the namespace accessors rather than
+// writing them directly.
+const namespacePrefix = "ns:"
+
+func namespaceKey(ns string) string {
+	return namespacePrefix + ns
+}
+
+// namespaceData returns the map stored for ns, or nil if the namespace is unset.
+func (s State) namespaceData(ns string) map[string]any {
+	data, _ := s.Data[namespaceKey(ns)].(map[string]any)
+	return data
+}
+
+// SetIn creates a new State with key set inside namespace ns.
+//
+// Namespaces keep keys written by different agents or subsystems from
+// colliding in the flat key space. Other keys in the namespace are preserved.
+// The original State is not modified.
+//
+// Emits EventStateSet with the namespace and key.
+//
+// Example:
+//
+//	s = s.SetIn("legal", "summary", legalSummary)
+//	s = s.SetIn("finance", "summary", financeSummary)
+func (s State) SetIn(ns, key string, value any) State {
+	newState := s.Clone()
+
+	data := maps.Clone(s.namespaceData(ns))
+	if data == nil {
+		data = make(map[string]any)
+	}
+	data[key] = value
+	newState.Data[namespaceKey(ns)] = data
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateSet,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      map[string]any{"namespace": ns, "key": key},
+	})
+
+	return newState
+}
+
+// GetIn retrieves key from namespace ns.
+//
+// Returns the value and true if the namespace and key exist, nil and false
+// otherwise.
+func (s State) GetIn(ns, key string) (any, bool) {
+	value, exists := s.namespaceData(ns)[key]
+	return value, exists
+}
+
+// Namespace returns the contents of namespace ns as a standalone State.
+//
+// The returned State shares the observer and execution metadata of the parent
+// but has its own copy of the namespace data, so changes are not visible in the
+// parent until written back with MergeNamespace. An unset namespace yields an
+// empty State.
+//
+// Example:
+//
+//	legal := s.Namespace("legal")
+//	legal = legal.Set("reviewed", true)
+//	s = s.MergeNamespace("legal", legal)
+func (s State) Namespace(ns string) State {
+	data := maps.Clone(s.namespaceData(ns))
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	return State{
+		Data:           data,
+		Observer:       s.Observer,
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		size:           newSizeCache(),
+		ctx:            s.ctx,
+	}
+}
+
+// Namespaces returns the names of all namespaces in the State, sorted.
+func (s State) Namespaces() []string {
+	namespaces := make([]string, 0)
+	for key, value := range s.Data {
+		if _, ok := value.(map[string]any); ok && strings.HasPrefix(key, namespacePrefix) {
+			namespaces = append(namespaces, strings.TrimPrefix(key, namespacePrefix))
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// MergeNamespace creates a new State with other's keys merged into namespace ns.
+//
+// Keys from other overwrite keys with the same name in the namespace; nested
+// namespaces in other are merged recursively. The original States are not
+// modified.
+//
+// Emits EventStateMerge with the namespace and number of keys merged.
+func (s State) MergeNamespace(ns string, other State) State {
+	newState := s.Clone()
+
+	data := maps.Clone(s.namespaceData(ns))
+	if data == nil {
+		data = make(map[string]any, len(other.Data))
+	}
+	mergeData(data, other.Data, "", nil, nil)
+	newState.Data[namespaceKey(ns)] = data
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateMerge,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      map[string]any{"namespace": ns, "keys": len(other.Data)},
+	})
+
+	return newState
+}
+
+// mergeConflict records a key present in both merged States and the value
+// chosen for it.
+type mergeConflict struct {
+	key      string
+	ours     any
+	theirs   any
+	resolved any
+}
+
+// mergeData copies src into dst. Namespaces present in both maps are merged
+// recursively into a new map instead of being replaced. For other keys present
+// in both, resolve picks the value (nil means src wins) and the conflict, keyed
+// by the namespace-qualified key, is appended to conflicts when non-nil.
+func mergeData(dst, src map[string]any, path string, resolve MergeResolver, conflicts *[]mergeConflict) {
+	for key, theirs := range src {
+		ours, exists := dst[key]
+		if !exists {
+			dst[key] = theirs
+			continue
+		}
+
+		if strings.HasPrefix(key, namespacePrefix) {
+			oursNS, oursOK := ours.(map[string]any)
+			theirsNS, theirsOK := theirs.(map[string]any)
+			if oursOK && theirsOK {
+				merged := maps.Clone(oursNS)
+				mergeData(merged, theirsNS, path+strings.TrimPrefix(key, namespacePrefix)+".", resolve, conflicts)
+				dst[key] = merged
+				continue
+			}
+		}
+
+		resolved := theirs
+		if resolve != nil {
+			resolved = resolve(key, ours, theirs)
+		}
+		dst[key] = resolved
+
+		if conflicts != nil {
+			*conflicts = append(*conflicts, mergeConflict{
+				key:      path + key,
+				ours:     ours,
+				theirs:   theirs,
+				resolved: resolved,
+			})
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package state
+
+import "strings"
+
+// NamespacedState is a view of a State that prefixes every key with
+// ns + "/".
+//
+// It lets modular nodes use short key names such as "result" without
+// coordinating with other nodes: a node working in Namespaced("ocr") reads and
+// writes "ocr/result". The keys live in the State's flat key space, so they
+// are checkpointed, diffed, and observed like any other key. Unlike SetIn and
+// Namespace, which store a namespace as a nested map under one key, no new
+// data layout is involved.
+//
+// NamespacedState is immutable like State: Set and Delete return a new view
+// over a new State. Use State to get the underlying State back.
+//
+// Example:
+//
+//	func ocrNode(ctx context.Context, s state.State) (state.State, error) {
+//	    ns := s.Namespaced("ocr")
+//	    pages, _ := ns.Get("pages")
+//	    ns = ns.Set("result", extract(pages))
+//	    return ns.State(), nil
+//	}
+type NamespacedState struct {
+	state  State
+	ns     string
+	prefix string
+}
+
+// Namespaced returns a view of s whose key operations are prefixed with
+// ns + "/".
+func (s State) Namespaced(ns string) NamespacedState {
+	return NamespacedState{state: s, ns: ns, prefix: ns + "/"}
+}
+
+// Namespaced returns a nested view prefixed with n's prefix, ns, and "/".
+func (n NamespacedState) Namespaced(ns string) NamespacedState {
+	return NamespacedState{state: n.state, ns: n.prefix + ns, prefix: n.prefix + ns + "/"}
+}
+
+// Namespace returns the full namespace of the view, without the trailing "/".
+func (n NamespacedState) Namespace() string {
+	return n.ns
+}
+
+// State returns the underlying State, including keys outside the namespace.
+func (n NamespacedState) State() State {
+	return n.state
+}
+
+// Get retrieves key from the namespace.
+func (n NamespacedState) Get(key string) (any, bool) {
+	return n.state.Get(n.prefix + key)
+}
+
+// Has reports whether key exists in the namespace.
+func (n NamespacedState) Has(key string) bool {
+	return n.state.Has(n.prefix + key)
+}
+
+// Set returns a view over a new State with key set in the namespace.
+// See State.Set.
+func (n NamespacedState) Set(key string, value any) NamespacedState {
+	n.state = n.state.Set(n.prefix+key, value)
+	return n
+}
+
+// Delete returns a view over a new State without key in the namespace.
+// See State.Delete.
+func (n NamespacedState) Delete(key string) NamespacedState {
+	n.state = n.state.Delete(n.prefix + key)
+	return n
+}
+
+// Keys returns the namespace's keys with the prefix stripped, sorted. Keys of
+// nested namespaces are included with their remaining prefix, e.g. "sub/key".
+func (n NamespacedState) Keys() []string {
+	keys := make([]string, 0)
+	for _, key := range n.state.Keys() {
+		if rest, ok := strings.CutPrefix(key, n.prefix); ok {
+			keys = append(keys, rest)
+		}
+	}
+	return keys
+}
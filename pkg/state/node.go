@@ -42,3 +42,21 @@ func NewFunctionNode(fn func(context.Context, State) (State, error)) StateNode {
 func (n *FunctionNode) Execute(ctx context.Context, state State) (State, error) {
 	return n.fn(ctx, state)
 }
+
+// FuncNode adapts an ordinary function to a StateNode.
+//
+// Like http.HandlerFunc, FuncNode is a conversion rather than a constructor,
+// so node functions can be declared once and registered in several graphs:
+//
+//	func normalize(ctx context.Context, s state.State) (state.State, error) {
+//	    return s.Set("normalized", true), nil
+//	}
+//
+//	graphA.AddNode("normalize", state.FuncNode(normalize))
+//	graphB.AddNode("normalize", state.FuncNode(normalize))
+type FuncNode func(ctx context.Context, state State) (State, error)
+
+// Execute calls f(ctx, state).
+func (f FuncNode) Execute(ctx context.Context, state State) (State, error) {
+	return f(ctx, state)
+}
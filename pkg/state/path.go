@@ -0,0 +1,112 @@
+package state
+
+import (
+	"maps"
+	"strings"
+)
+
+// GetPath retrieves a nested value by dot-separated path.
+//
+// Each segment but the last must name a map[string]any, as produced by
+// decoding JSON into State. Returns false when any segment is missing or a
+// non-map value is encountered before the end of the path. An empty path
+// returns false.
+//
+// A literal dot in a key is escaped as `\.` and a literal backslash as `\\`.
+// Any other backslash is kept as-is.
+//
+// Example:
+//
+//	risk, ok := s.GetPath("analysis.scores.risk")
+//	version, ok := s.GetPath(`deps.golang\.org/x/time`)
+func (s State) GetPath(path string) (any, bool) {
+	segments := splitPath(path)
+	if len(segments) == 0 {
+		return nil, false
+	}
+
+	current := s.Data
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	value, exists := current[segments[len(segments)-1]]
+	return value, exists
+}
+
+// SetPath creates a new State with a nested value set by dot-separated path.
+//
+// Missing intermediate maps are created. An intermediate value that is not a
+// map[string]any is replaced by a new map. Every map along the path is copied
+// rather than modified, so maps shared with the original State are left
+// untouched. Paths use the same escaping as GetPath. An empty path returns the
+// State unchanged.
+//
+// Emits EventStateSet with the path.
+//
+// Example:
+//
+//	s = s.SetPath("analysis.scores.risk", 0.8)
+func (s State) SetPath(path string, value any) State {
+	segments := splitPath(path)
+	if len(segments) == 0 {
+		return s
+	}
+
+	newState := s.Clone()
+
+	var skipped []string
+	if s.frozen[segments[0]] {
+		skipped = []string{segments[0]}
+	} else {
+		newState.Data[segments[0]] = setPathValue(s.Data[segments[0]], segments[1:], value)
+	}
+
+	s.emitSet(map[string]any{"path": path}, skipped)
+
+	return newState
+}
+
+// setPathValue returns current with value set at segments, copying maps.
+func setPathValue(current any, segments []string, value any) any {
+	if len(segments) == 0 {
+		return value
+	}
+
+	existing, _ := current.(map[string]any)
+	next := make(map[string]any, len(existing)+1)
+	maps.Copy(next, existing)
+
+	next[segments[0]] = setPathValue(existing[segments[0]], segments[1:], value)
+	return next
+}
+
+// splitPath splits path on unescaped dots, resolving `\.` and `\\` escapes.
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var segments []string
+	var segment strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path) && (path[i+1] == '.' || path[i+1] == '\\'):
+			segment.WriteByte(path[i+1])
+			i++
+		case c == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(c)
+		}
+	}
+
+	return append(segments, segment.String())
+}
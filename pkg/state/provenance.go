@@ -0,0 +1,68 @@
+package state
+
+import (
+	"maps"
+	"time"
+)
+
+// KeyProvenance records which node last wrote a key and when.
+type KeyProvenance struct {
+	Node      string    `json:"node"`
+	Iteration int       `json:"iteration"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Provenance reports which node last added or changed key.
+//
+// Provenance is recorded only by graphs with GraphConfig.TrackProvenance
+// enabled: after each node, every key the node added or changed (see Diff) is
+// attributed to that node, and keys it removed are forgotten. Keys merged in by
+// a node with Merge or MergeWith are attributed to the merging node. Keys that
+// were already present in the initial State and never changed have no
+// provenance.
+//
+// Provenance is preserved by State operations and checkpoints, so a resumed run
+// keeps the attribution of keys written before the failure.
+//
+// Example:
+//
+//	if p, ok := result.Provenance("classification"); ok {
+//	    fmt.Printf("set by %s at %v\n", p.Node, p.Timestamp)
+//	}
+func (s State) Provenance(key string) (KeyProvenance, bool) {
+	p, ok := s.provenance[key]
+	return p, ok
+}
+
+// recordProvenance returns s with the keys that differ from before attributed
+// to node. The provenance map is copied, never modified in place.
+func (s State) recordProvenance(before State, node string, iteration int) State {
+	diff := Diff(before, s)
+	if diff.IsEmpty() {
+		s.provenance = before.provenance
+		return s
+	}
+
+	provenance := maps.Clone(before.provenance)
+	if provenance == nil {
+		provenance = make(map[string]KeyProvenance, len(diff.Added)+len(diff.Changed))
+	}
+
+	written := KeyProvenance{
+		Node:      node,
+		Iteration: iteration,
+		Timestamp: time.Now(),
+	}
+	for key := range diff.Added {
+		provenance[key] = written
+	}
+	for key := range diff.Changed {
+		provenance[key] = written
+	}
+	for key := range diff.Removed {
+		delete(provenance, key)
+	}
+
+	s.provenance = provenance
+	return s
+}
@@ -0,0 +1,123 @@
+package state
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces the values of sensitive keys in observer events,
+// execution history, and saved checkpoints.
+const RedactedValue = "[REDACTED]"
+
+// ErrRedactedCheckpoint is returned by Resume when a loaded checkpoint contains
+// redacted values. Resuming would hand placeholder strings to downstream nodes,
+// so the graph refuses rather than continuing with corrupted state.
+var ErrRedactedCheckpoint = errors.New("checkpoint contains redacted values")
+
+// MarkSensitive marks state keys whose values must not leave the process.
+//
+// Sensitive values are replaced with RedactedValue in NodeStart and
+// NodeComplete snapshots, in recorded history, and in saved checkpoints. The
+// State flowing between nodes and returned from Execute is never redacted.
+// Keys inside a namespace are qualified with the namespace name
+// ("customer.email"); marking a namespace's name redacts the whole namespace.
+//
+// Because redacted checkpoints cannot be resumed, graphs that checkpoint
+// sensitive keys should treat Resume failures with ErrRedactedCheckpoint as
+// a signal to restart the run.
+//
+// Example:
+//
+//	graph.MarkSensitive("api_key", "customer.email")
+func (g *stateGraph) MarkSensitive(keys ...string) {
+	for _, key := range keys {
+		if key != "" {
+			g.sensitive[key] = true
+		}
+	}
+}
+
+// redact returns a copy of s with sensitive values replaced. The copy does not
+// emit observer events.
+func (g *stateGraph) redact(s State) State {
+	if len(g.sensitive) == 0 {
+		return s
+	}
+
+	return State{
+		Data:           g.redactData(s.Data),
+		Observer:       s.Observer,
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		size:           newSizeCache(),
+		frozen:         s.frozen,
+		provenance:     s.provenance,
+	}
+}
+
+// redactData returns a shallow copy of data with sensitive values replaced.
+func (g *stateGraph) redactData(data map[string]any) map[string]any {
+	if len(g.sensitive) == 0 {
+		return maps.Clone(data)
+	}
+	return redactMap(data, "", g.sensitive)
+}
+
+func redactMap(data map[string]any, path string, sensitive map[string]bool) map[string]any {
+	if data == nil {
+		return nil
+	}
+
+	redacted := make(map[string]any, len(data))
+	for key, value := range data {
+		name := key
+		nested, isNamespace := value.(map[string]any)
+		isNamespace = isNamespace && path == "" && strings.HasPrefix(key, namespacePrefix)
+		if isNamespace {
+			name = strings.TrimPrefix(key, namespacePrefix)
+		}
+		if path != "" {
+			name = path + "." + key
+		}
+
+		switch {
+		case sensitive[name]:
+			redacted[key] = RedactedValue
+		case isNamespace:
+			redacted[key] = redactMap(nested, name, sensitive)
+		default:
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// redactedKeys returns the qualified keys in data holding RedactedValue, sorted.
+func redactedKeys(data map[string]any, path string) []string {
+	keys := make([]string, 0)
+	for key, value := range data {
+		name := key
+		isNamespace := path == "" && strings.HasPrefix(key, namespacePrefix)
+		if isNamespace {
+			name = strings.TrimPrefix(key, namespacePrefix)
+		}
+		if path != "" {
+			name = path + "." + key
+		}
+
+		if value == RedactedValue {
+			keys = append(keys, name)
+			continue
+		}
+
+		if nested, ok := value.(map[string]any); ok && isNamespace {
+			keys = append(keys, redactedKeys(nested, name)...)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,211 @@
+package state
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// Reducer combines the current value of a state key with a node's update.
+//
+// Reducers are registered per key on a StateGraph with SetReducer. When a node
+// writes a key with a registered reducer, the graph stores
+// reducer(current, update) instead of replacing the value. current is nil when
+// the key did not exist before the node ran.
+//
+// Reducers return an error when the values cannot be combined (e.g., appending
+// a string to an int), which fails graph execution with an ExecutionError.
+type Reducer func(current, update any) (any, error)
+
+// OverwriteReducer replaces the current value with the update.
+//
+// This matches the default behavior for keys without a reducer and is useful
+// for documenting intent alongside accumulating keys.
+func OverwriteReducer(current, update any) (any, error) {
+	return update, nil
+}
+
+// AppendReducer appends the update to the current slice.
+//
+// The update may be a slice of the same type (its elements are appended) or a
+// single element assignable to the slice element type. When current is nil the
+// update is stored as-is. Returns an error when current is not a slice or the
+// update does not match its element type.
+//
+// Example:
+//
+//	graph.SetReducer("messages", state.AppendReducer)
+//	// node: return s.Set("messages", []string{"new message"}), nil
+func AppendReducer(current, update any) (any, error) {
+	if current == nil {
+		return update, nil
+	}
+
+	cur := reflect.ValueOf(current)
+	if cur.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("append reducer: current value is %T, not a slice", current)
+	}
+
+	upd := reflect.ValueOf(update)
+	if upd.IsValid() && upd.Type() == cur.Type() {
+		result := reflect.MakeSlice(cur.Type(), 0, cur.Len()+upd.Len())
+		result = reflect.AppendSlice(result, cur)
+		return reflect.AppendSlice(result, upd).Interface(), nil
+	}
+
+	elemType := cur.Type().Elem()
+	if !upd.IsValid() {
+		if !isNillable(elemType.Kind()) {
+			return nil, fmt.Errorf("append reducer: cannot append nil to %T", current)
+		}
+		upd = reflect.Zero(elemType)
+	}
+	if !upd.Type().AssignableTo(elemType) {
+		return nil, fmt.Errorf("append reducer: cannot append %T to %T", update, current)
+	}
+
+	result := reflect.MakeSlice(cur.Type(), 0, cur.Len()+1)
+	result = reflect.AppendSlice(result, cur)
+	return reflect.Append(result, upd).Interface(), nil
+}
+
+// SumReducer adds the update to the current numeric value.
+//
+// Supports int and float64 (the types produced by Go literals and encoding/json).
+// Mixing int and float64 produces a float64. When current is nil the update is
+// stored as-is. Returns an error for any other type combination.
+//
+// Example:
+//
+//	graph.SetReducer("tokens_used", state.SumReducer)
+func SumReducer(current, update any) (any, error) {
+	if current == nil {
+		return update, nil
+	}
+
+	switch c := current.(type) {
+	case int:
+		switch u := update.(type) {
+		case int:
+			return c + u, nil
+		case float64:
+			return float64(c) + u, nil
+		}
+	case float64:
+		switch u := update.(type) {
+		case int:
+			return c + float64(u), nil
+		case float64:
+			return c + u, nil
+		}
+	}
+
+	return nil, fmt.Errorf("sum reducer: cannot add %T to %T", update, current)
+}
+
+// MergeMapReducer merges the update map into the current map.
+//
+// Both values must be map[string]any. Keys in the update overwrite keys in the
+// current map. The result is a new map; neither input is modified. When current
+// is nil the update is stored as-is.
+//
+// Example:
+//
+//	graph.SetReducer("metadata", state.MergeMapReducer)
+func MergeMapReducer(current, update any) (any, error) {
+	if current == nil {
+		return update, nil
+	}
+
+	cur, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("merge map reducer: current value is %T, not map[string]any", current)
+	}
+
+	upd, ok := update.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("merge map reducer: update is %T, not map[string]any", update)
+	}
+
+	merged := maps.Clone(cur)
+	if merged == nil {
+		merged = make(map[string]any, len(upd))
+	}
+	maps.Copy(merged, upd)
+	return merged, nil
+}
+
+// applyReducers folds the keys a node wrote into the flowing state using the
+// graph's registered reducers. Keys without reducers keep the node's value.
+func applyReducers(reducers map[string]Reducer, input, output State) (State, error) {
+	if len(reducers) == 0 {
+		return output, nil
+	}
+
+	var reduced State
+	changed := false
+
+	for key, reducer := range reducers {
+		update, written := output.Data[key]
+		if !written {
+			continue
+		}
+
+		current, existed := input.Data[key]
+		if existed && sameValue(current, update) {
+			continue
+		}
+
+		if output.frozen[key] {
+			continue
+		}
+
+		value, err := reducer(current, update)
+		if err != nil {
+			return output, fmt.Errorf("reducer for key %q failed: %w", key, err)
+		}
+
+		if !changed {
+			reduced = output.Clone()
+			changed = true
+		}
+		reduced.Data[key] = value
+	}
+
+	if !changed {
+		return output, nil
+	}
+	return reduced, nil
+}
+
+// sameValue reports whether a node left a value untouched. Slices and maps are
+// compared by identity, other comparable values by equality.
+func sameValue(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return !va.IsValid() && !vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	switch va.Kind() {
+	case reflect.Slice:
+		return va.Pointer() == vb.Pointer() && va.Len() == vb.Len()
+	case reflect.Map, reflect.Func, reflect.Chan:
+		return va.Pointer() == vb.Pointer()
+	}
+
+	if va.Comparable() {
+		return va.Equal(vb)
+	}
+	return false
+}
+
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package state
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Kind is the expected shape of a State value in a Schema.
+type Kind string
+
+const (
+	// KindString matches string values.
+	KindString Kind = "string"
+
+	// KindInt matches signed and unsigned integer values.
+	KindInt Kind = "int"
+
+	// KindBool matches bool values.
+	KindBool Kind = "bool"
+
+	// KindFloat matches float32 and float64 values.
+	KindFloat Kind = "float"
+
+	// KindSlice matches slices and arrays of any element type.
+	KindSlice Kind = "slice"
+
+	// KindMap matches maps of any key and value type.
+	KindMap Kind = "map"
+
+	// KindAny matches any value, including nil.
+	KindAny Kind = "any"
+)
+
+// SchemaField declares the expected kind of a key and whether it must be present.
+type SchemaField struct {
+	Kind     Kind
+	Required bool
+}
+
+// Schema declares expected kinds for State keys.
+//
+// Schemas catch type drift between nodes (e.g., a node writing a float where
+// later nodes expect an int) at the node boundary instead of deep inside a
+// downstream type assertion. Keys not declared in the schema are not checked.
+//
+// Example:
+//
+//	schema := state.NewSchema().
+//	    Require("document_id", state.KindString).
+//	    Require("page_count", state.KindInt).
+//	    Optional("tags", state.KindSlice)
+//
+//	if err := schema.Validate(s); err != nil {
+//	    log.Fatal(err)
+//	}
+type Schema struct {
+	fields map[string]SchemaField
+}
+
+// NewSchema creates an empty Schema.
+func NewSchema() *Schema {
+	return &Schema{
+		fields: make(map[string]SchemaField),
+	}
+}
+
+// Require declares a key that must be present with the given kind.
+func (s *Schema) Require(key string, kind Kind) *Schema {
+	s.fields[key] = SchemaField{Kind: kind, Required: true}
+	return s
+}
+
+// Optional declares a key that, when present, must have the given kind.
+func (s *Schema) Optional(key string, kind Kind) *Schema {
+	s.fields[key] = SchemaField{Kind: kind}
+	return s
+}
+
+// Fields returns a copy of the declared fields.
+func (s *Schema) Fields() map[string]SchemaField {
+	return maps.Clone(s.fields)
+}
+
+// Validate checks the State against the schema.
+//
+// Returns a *SchemaError listing every violation (sorted by key), or nil if
+// the State conforms. Missing required keys and values of the wrong kind are
+// both reported. A nil value only satisfies KindAny.
+func (s *Schema) Validate(st State) error {
+	violations := make([]SchemaViolation, 0)
+
+	for _, key := range slices.Sorted(maps.Keys(s.fields)) {
+		field := s.fields[key]
+
+		value, exists := st.Data[key]
+		if !exists {
+			if field.Required {
+				violations = append(violations, SchemaViolation{
+					Key:      key,
+					Expected: field.Kind,
+					Missing:  true,
+				})
+			}
+			continue
+		}
+
+		if !matchesKind(value, field.Kind) {
+			violations = append(violations, SchemaViolation{
+				Key:      key,
+				Expected: field.Kind,
+				Actual:   fmt.Sprintf("%T", value),
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &SchemaError{Violations: violations}
+}
+
+func matchesKind(value any, kind Kind) bool {
+	if kind == KindAny {
+		return true
+	}
+
+	if value == nil {
+		return false
+	}
+
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.String:
+		return kind == KindString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kind == KindInt
+	case reflect.Bool:
+		return kind == KindBool
+	case reflect.Float32, reflect.Float64:
+		return kind == KindFloat
+	case reflect.Slice, reflect.Array:
+		return kind == KindSlice
+	case reflect.Map:
+		return kind == KindMap
+	}
+
+	return false
+}
+
+// SchemaViolation describes a single key that failed schema validation.
+type SchemaViolation struct {
+	Key      string
+	Expected Kind
+	Actual   string
+	Missing  bool
+}
+
+func (v SchemaViolation) String() string {
+	if v.Missing {
+		return fmt.Sprintf("%s: required %s is missing", v.Key, v.Expected)
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", v.Key, v.Expected, v.Actual)
+}
+
+// SchemaError reports all schema violations for a State.
+//
+// Node is set when the violation is detected during graph execution and names
+// the node whose output failed validation.
+type SchemaError struct {
+	Node       string
+	Violations []SchemaViolation
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	details := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		details[i] = v.String()
+	}
+
+	if e.Node != "" {
+		return fmt.Sprintf("schema validation failed after node %s: %s", e.Node, strings.Join(details, "; "))
+	}
+	return fmt.Sprintf("schema validation failed: %s", strings.Join(details, "; "))
+}
+
+// Keys returns the offending keys in order.
+func (e *SchemaError) Keys() []string {
+	keys := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		keys[i] = v.Key
+	}
+	return keys
+}
+
+// schemas is the global registry of named Schemas referenced by GraphConfig.Schema.
+var (
+	schemas      = map[string]*Schema{}
+	schemasMutex sync.RWMutex
+)
+
+// GetSchema retrieves a Schema by name from the registry.
+//
+// Returns error if the requested schema is not registered.
+func GetSchema(name string) (*Schema, error) {
+	schemasMutex.RLock()
+	defer schemasMutex.RUnlock()
+
+	schema, exists := schemas[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown schema: %s", name)
+	}
+	return schema, nil
+}
+
+// RegisterSchema adds a named Schema to the global registry.
+//
+// Register schemas before creating graphs that reference them through
+// GraphConfig.Schema. When configured, the graph validates State after every
+// node and fails execution with a *SchemaError naming the node.
+//
+// Example:
+//
+//	state.RegisterSchema("document", state.NewSchema().
+//	    Require("document_id", state.KindString).
+//	    Require("page_count", state.KindInt))
+//
+//	cfg := config.DefaultGraphConfig("workflow")
+//	cfg.Schema = "document"
+func RegisterSchema(name string, schema *Schema) {
+	schemasMutex.Lock()
+	defer schemasMutex.Unlock()
+
+	schemas[name] = schema
+}
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// KeySize reports the estimated encoded size of a single State key.
+type KeySize struct {
+	Key   string `json:"key"`
+	Bytes int    `json:"bytes"`
+}
+
+// sizeCache memoizes the last size computation for a State.
+//
+// States are immutable and every transformation allocates a fresh cache, so a
+// cache only transitions from dirty to clean once. The mutex guards concurrent
+// Size calls on the same State value.
+type sizeCache struct {
+	mu    sync.Mutex
+	dirty bool
+	total int
+	keys  []KeySize
+}
+
+func newSizeCache() *sizeCache {
+	return &sizeCache{dirty: true}
+}
+
+// Size returns the estimated size of the State's data in bytes.
+//
+// The estimate is the sum, over all keys, of the JSON-encoded key plus the
+// JSON-encoded value. It ignores object delimiters and run metadata, so it is
+// approximate: use it to gauge checkpoint payloads, not to enforce exact
+// limits. Byte slices are counted as their base64 encoding, matching how they
+// are serialized. Values that cannot be JSON-encoded (channels, functions,
+// NaN floats) contribute only their key.
+//
+// The result is memoized on the State. Data must not be mutated directly
+// after Size is called; use Set and related methods, which return a new State.
+//
+// Example:
+//
+//	if s.Size() > 1<<20 {
+//	    log.Printf("large checkpoint: %v", s.LargestKeys(3))
+//	}
+func (s State) Size() int {
+	total, _ := s.sizes()
+	return total
+}
+
+// LargestKeys returns up to n keys ordered by estimated size, largest first.
+//
+// Keys with equal sizes are ordered alphabetically. Returns an empty slice
+// when n <= 0. Sizes are computed as described for Size.
+//
+// Example:
+//
+//	for _, ks := range s.LargestKeys(5) {
+//	    fmt.Printf("%s: %d bytes\n", ks.Key, ks.Bytes)
+//	}
+func (s State) LargestKeys(n int) []KeySize {
+	if n <= 0 {
+		return []KeySize{}
+	}
+
+	_, keys := s.sizes()
+	return slices.Clone(keys[:min(n, len(keys))])
+}
+
+func (s State) sizes() (int, []KeySize) {
+	if s.size == nil {
+		return computeSizes(s.Data)
+	}
+
+	s.size.mu.Lock()
+	defer s.size.mu.Unlock()
+
+	if s.size.dirty {
+		s.size.total, s.size.keys = computeSizes(s.Data)
+		s.size.dirty = false
+	}
+
+	return s.size.total, s.size.keys
+}
+
+func computeSizes(data map[string]any) (int, []KeySize) {
+	total := 0
+	keys := make([]KeySize, 0, len(data))
+
+	for key, value := range data {
+		size := encodedSize(key) + encodedSize(value)
+		keys = append(keys, KeySize{Key: key, Bytes: size})
+		total += size
+	}
+
+	slices.SortFunc(keys, func(a, b KeySize) int {
+		if c := cmp.Compare(b.Bytes, a.Bytes); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return total, keys
+}
+
+func encodedSize(value any) int {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(encoded)
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// StateSnapshot is a named, in-memory copy of a State taken at a point in a
+// run, used to roll back when later work diverges.
+//
+// Unlike a checkpoint, a snapshot is never persisted and is scoped to the
+// execution holding it; unlike Clone, it is a distinct value that cannot be
+// mistaken for live state. Frozen keys and provenance are carried along so a
+// restored State enforces the same invariants as the original.
+type StateSnapshot struct {
+	// Data is a shallow copy of the State's data at snapshot time
+	Data map[string]any
+
+	// RunID identifies the run the snapshot was taken from
+	RunID string
+
+	// CheckpointNode is the State's checkpoint node at snapshot time
+	CheckpointNode string
+
+	// Graph is the graph that produced the State, if any
+	Graph string
+
+	// Timestamp is the State's own timestamp at snapshot time
+	Timestamp time.Time
+
+	// CreatedAt is when the snapshot was taken
+	CreatedAt time.Time
+
+	frozen     map[string]bool
+	provenance map[string]KeyProvenance
+}
+
+// Snapshot captures the State for a later RestoreSnapshot.
+//
+// The data map is copied shallowly, like Clone: nested maps and slices are
+// shared with the State. Take the snapshot from CloneDeep when nodes mutate
+// nested values in place.
+//
+// Example:
+//
+//	good := s.Snapshot()
+//	refined, err := critique(ctx, s)
+//	if err != nil || score(refined) < score(s) {
+//	    refined = state.RestoreSnapshot(good, observer)
+//	}
+func (s State) Snapshot() StateSnapshot {
+	return StateSnapshot{
+		Data:           maps.Clone(s.Data),
+		RunID:          s.RunID,
+		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
+		Timestamp:      s.Timestamp,
+		CreatedAt:      time.Now(),
+		frozen:         s.frozen,
+		provenance:     s.provenance,
+	}
+}
+
+// RestoreSnapshot reconstructs a State from snap that reports to observer.
+//
+// Each call returns a State with its own data map, so a snapshot can be
+// restored any number of times. If observer is nil, NoOpObserver is used.
+//
+// Emits EventStateCreate with the number of keys and the snapshot time.
+func RestoreSnapshot(snap StateSnapshot, observer observability.Observer) State {
+	if observer == nil {
+		observer = observability.NoOpObserver{}
+	}
+
+	data := maps.Clone(snap.Data)
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	s := State{
+		Data:           data,
+		Observer:       observer,
+		RunID:          snap.RunID,
+		CheckpointNode: snap.CheckpointNode,
+		Graph:          snap.Graph,
+		Timestamp:      snap.Timestamp,
+		size:           newSizeCache(),
+		frozen:         snap.frozen,
+		provenance:     snap.provenance,
+	}
+
+	observer.OnEvent(context.Background(), observability.Event{
+		Type:      observability.EventStateCreate,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data: map[string]any{
+			"keys":          len(s.Data),
+			"snapshot_time": snap.CreatedAt,
+		},
+	})
+
+	return s
+}
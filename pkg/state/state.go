@@ -2,7 +2,14 @@ package state
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
 	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
@@ -18,7 +25,7 @@ import (
 // Observer integration is built-in from Phase 2, enabling production-grade
 // observability without retrofit friction in later phases.
 //
-// Checkpoint metadata (runID, checkpointNode, timestamp) provides execution
+// Checkpoint metadata (runID, checkpointNode, graph, timestamp) provides execution
 // provenance for workflow persistence and recovery. This metadata flows through
 // all State transformations maintaining execution identity.
 type State struct {
@@ -26,7 +33,13 @@ type State struct {
 	Observer       observability.Observer `json:"-"`
 	RunID          string                 `json:"run_id"`
 	CheckpointNode string                 `json:"checkpoint_node"`
+	Graph          string                 `json:"graph,omitempty"`
 	Timestamp      time.Time              `json:"timestamp"`
+
+	size       *sizeCache
+	frozen     map[string]bool
+	provenance map[string]KeyProvenance
+	ctx        context.Context
 }
 
 // New creates a new empty State with the given observer.
@@ -49,18 +62,66 @@ func New(observer observability.Observer) State {
 		Observer:  observer,
 		RunID:     uuid.New().String(),
 		Timestamp: time.Now(),
+		size:      newSizeCache(),
 	}
 
 	observer.OnEvent(context.Background(), observability.Event{
 		Type:      observability.EventStateCreate,
 		Timestamp: s.Timestamp,
-		Source:    "state",
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
 		Data:      map[string]any{},
 	})
 
 	return s
 }
 
+// NewFromMap creates a new State populated with a copy of data.
+//
+// The input map is shallow-copied so later changes to it do not affect the
+// State. A nil map produces an empty State. If observer is nil, NoOpObserver
+// is used.
+//
+// Emits EventStateCreate with the number of keys.
+//
+// Example:
+//
+//	s := state.NewFromMap(observer, map[string]any{"user": "alice", "count": 3})
+func NewFromMap(observer observability.Observer, data map[string]any) State {
+	if observer == nil {
+		observer = observability.NoOpObserver{}
+	}
+
+	s := State{
+		Data:      make(map[string]any, len(data)),
+		Observer:  observer,
+		RunID:     uuid.New().String(),
+		Timestamp: time.Now(),
+		size:      newSizeCache(),
+	}
+	maps.Copy(s.Data, data)
+
+	observer.OnEvent(context.Background(), observability.Event{
+		Type:      observability.EventStateCreate,
+		Timestamp: s.Timestamp,
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      map[string]any{"keys": len(s.Data)},
+	})
+
+	return s
+}
+
+// FromMap creates a new State populated with a copy of data. It is
+// equivalent to NewFromMap.
+//
+// Example:
+//
+//	var payload map[string]any
+//	json.Unmarshal(body, &payload)
+//	s := state.FromMap(observer, payload)
+func FromMap(observer observability.Observer, data map[string]any) State {
+	return NewFromMap(observer, data)
+}
+
 // Clone creates an independent copy of the State.
 //
 // The returned State has its own data map (shallow clone) but preserves the
@@ -80,13 +141,18 @@ func (s State) Clone() State {
 		Observer:       s.Observer,
 		RunID:          s.RunID,
 		CheckpointNode: s.CheckpointNode,
+		Graph:          s.Graph,
 		Timestamp:      s.Timestamp,
+		size:           newSizeCache(),
+		frozen:         s.frozen,
+		provenance:     s.provenance,
+		ctx:            s.ctx,
 	}
 
-	s.Observer.OnEvent(context.Background(), observability.Event{
+	s.Observer.OnEvent(s.Context(), observability.Event{
 		Type:      observability.EventStateClone,
 		Timestamp: time.Now(),
-		Source:    "state",
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
 		Data:      map[string]any{"keys": len(newState.Data)},
 	})
 
@@ -110,6 +176,294 @@ func (s State) Get(key string) (any, bool) {
 	return val, exists
 }
 
+// Keys returns the State's keys in sorted order.
+//
+// The returned slice is a new allocation owned by the caller. An empty State
+// returns an empty, non-nil slice so it encodes to JSON as [] rather than null.
+//
+// Example:
+//
+//	for _, key := range s.Keys() {
+//	    fmt.Println(key)
+//	}
+func (s State) Keys() []string {
+	keys := make([]string, 0, len(s.Data))
+	for key := range s.Data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Len returns the number of keys in the State.
+func (s State) Len() int {
+	return len(s.Data)
+}
+
+// Has reports whether key exists in the State, including keys set to nil.
+func (s State) Has(key string) bool {
+	_, exists := s.Data[key]
+	return exists
+}
+
+// ToMap returns a shallow copy of the State's data.
+//
+// Use ToMap to hand state to APIs that expect a plain map, such as text/template
+// or JSON responses. The returned map is owned by the caller; modifying it does
+// not affect the State. Nested reference values (slices, maps, pointers) are
+// shared with the State and should not be mutated.
+//
+// Example:
+//
+//	tmpl.Execute(w, s.ToMap())
+func (s State) ToMap() map[string]any {
+	data := maps.Clone(s.Data)
+	if data == nil {
+		data = make(map[string]any)
+	}
+	return data
+}
+
+// All returns an iterator over the State's entries in sorted key order.
+//
+// The iterator walks a snapshot of the data taken when iteration begins, so
+// writes to the State's data map during iteration do not affect the entries
+// yielded. Sorted ordering keeps generated output, such as prompts built from
+// state, stable across runs.
+//
+// Example:
+//
+//	for key, value := range s.All() {
+//	    fmt.Fprintf(&prompt, "%s: %v\n", key, value)
+//	}
+func (s State) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		snapshot := maps.Clone(s.Data)
+		for _, key := range slices.Sorted(maps.Keys(snapshot)) {
+			if !yield(key, snapshot[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Range calls fn for each entry in sorted key order until fn returns false.
+//
+// Range iterates over a snapshot like All.
+//
+// Example:
+//
+//	s.Range(func(key string, value any) bool {
+//	    fmt.Println(key, value)
+//	    return true
+//	})
+func (s State) Range(fn func(key string, value any) bool) {
+	for key, value := range s.All() {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// GetAs retrieves a value from the State and asserts it to type T.
+//
+// Returns the zero value of T and false when the key is missing or the stored
+// value is not of type T. No conversions are performed: an int stored under the
+// key does not satisfy GetAs[float64], and vice versa.
+//
+// Example:
+//
+//	doc, ok := state.GetAs[string](s, "document")
+//	if !ok {
+//	    return s, fmt.Errorf("document missing from state")
+//	}
+func GetAs[T any](s State, key string) (T, bool) {
+	val, exists := s.Data[key]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+
+	typed, ok := val.(T)
+	return typed, ok
+}
+
+// MustGetAs retrieves a value from the State asserted to type T, panicking on failure.
+//
+// The panic message includes the key, the requested type, and the actual stored
+// type to make misconfigured workflows easy to diagnose. Use in nodes where a
+// missing or mistyped key indicates a programming error.
+//
+// Example:
+//
+//	count := state.MustGetAs[int](s, "count")
+func MustGetAs[T any](s State, key string) T {
+	val, exists := s.Data[key]
+	if !exists {
+		panic(fmt.Sprintf("state key %q not found (want %s)", key, reflect.TypeFor[T]()))
+	}
+
+	typed, ok := val.(T)
+	if !ok {
+		panic(fmt.Sprintf("state key %q has type %T, want %s", key, val, reflect.TypeFor[T]()))
+	}
+	return typed
+}
+
+// GetString retrieves a string value from the State.
+//
+// Accepts string and json.Number values. Returns "" and false when the key is
+// missing or holds any other type; no formatting of non-string values occurs.
+//
+// Example:
+//
+//	name, ok := s.GetString("user")
+func (s State) GetString(key string) (string, bool) {
+	switch v := s.Data[key].(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
+
+// GetInt retrieves an integer value from the State with numeric coercion.
+//
+// Coercions:
+//   - int, int8, int16, int32, int64: converted directly
+//   - uint, uint8, uint16, uint32, uint64: converted when within int range
+//   - float32, float64: converted only when the value is integral and within int range
+//   - json.Number: parsed as an integer, or as an integral float
+//
+// Returns 0 and false when the key is missing, the type is not numeric, or the
+// value cannot be represented as an int without loss (e.g., 2.5). Strings are
+// never parsed.
+//
+// Example:
+//
+//	// State loaded from JSON stores numbers as float64
+//	count, ok := s.GetInt("count")
+func (s State) GetInt(key string) (int, bool) {
+	switch v := s.Data[key].(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return uintToInt(uint64(v))
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return uintToInt(uint64(v))
+	case uint64:
+		return uintToInt(v)
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i), true
+		}
+		if f, err := v.Float64(); err == nil {
+			return floatToInt(f)
+		}
+		return 0, false
+	default:
+		return 0, false
+	}
+}
+
+// GetBool retrieves a bool value from the State.
+//
+// Only bool values are accepted. Strings such as "true" and numbers such as 1
+// are not coerced and return false.
+//
+// Example:
+//
+//	approved, _ := s.GetBool("approved")
+func (s State) GetBool(key string) (bool, bool) {
+	v, ok := s.Data[key].(bool)
+	return v, ok
+}
+
+// GetFloat64 retrieves a floating point value from the State with numeric coercion.
+//
+// Coercions:
+//   - float64: returned directly
+//   - float32: widened to float64
+//   - all signed and unsigned integer types: converted to float64
+//   - json.Number: parsed as a float
+//
+// Returns 0 and false when the key is missing or the value is not numeric.
+// Strings are never parsed.
+//
+// Example:
+//
+//	score, ok := s.GetFloat64("score")
+func (s State) GetFloat64(key string) (float64, bool) {
+	switch v := s.Data[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// uintToInt converts v to int when it fits.
+func uintToInt(v uint64) (int, bool) {
+	if v > math.MaxInt {
+		return 0, false
+	}
+	return int(v), true
+}
+
+// floatToInt converts f to int when it is integral and within int range.
+func floatToInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+	return int(f), true
+}
+
 // Set creates a new State with the key-value pair added or updated.
 //
 // The original State is not modified (immutability). The new State preserves
@@ -125,18 +479,131 @@ func (s State) Get(key string) (any, bool) {
 //	// s1 is empty, s2 has user, s3 has user+count
 func (s State) Set(key string, value any) State {
 	newState := s.Clone()
-	newState.Data[key] = value
 
-	s.Observer.OnEvent(context.Background(), observability.Event{
-		Type:      observability.EventStateSet,
+	var skipped []string
+	if s.frozen[key] {
+		skipped = []string{key}
+	} else {
+		newState.Data[key] = value
+	}
+
+	s.emitSet(map[string]any{"key": key}, skipped)
+
+	return newState
+}
+
+// Delete creates a new State without key.
+//
+// The original State is not modified. Deleting a missing key returns an
+// unchanged copy; a frozen key is kept and reported in the event.
+//
+// Emits EventStateDelete through the observer.
+//
+// Example:
+//
+//	s2 := s1.Delete("draft")
+func (s State) Delete(key string) State {
+	newState := s.Clone()
+
+	data := map[string]any{"key": key}
+	if s.frozen[key] {
+		data["frozen"] = []string{key}
+	} else {
+		delete(newState.Data, key)
+	}
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateDelete,
 		Timestamp: time.Now(),
-		Source:    "state",
-		Data:      map[string]any{"key": key},
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      data,
 	})
 
 	return newState
 }
 
+// SetMany creates a new State with all given key-value pairs added or updated.
+//
+// Equivalent to calling Set for each entry, but performs a single clone and emits
+// a single EventStateSet event listing the keys (sorted). Prefer SetMany when a
+// node writes several outputs at once.
+//
+// Example:
+//
+//	s2 := s1.SetMany(map[string]any{
+//	    "summary": summary,
+//	    "stage":   "summarized",
+//	})
+func (s State) SetMany(values map[string]any) State {
+	newState := s.Clone()
+	allowed, skipped := s.withoutFrozen(values)
+	maps.Copy(newState.Data, allowed)
+
+	s.emitSet(map[string]any{"keys": slices.Sorted(maps.Keys(values))}, skipped)
+
+	return newState
+}
+
+// Update creates a new State with key set to the result of fn.
+//
+// fn receives the current value and whether the key exists. If fn returns an
+// error, the original State is returned unchanged along with the error.
+// Otherwise the result is stored as with Set (emitting EventStateSet).
+//
+// Example:
+//
+//	s, err := s.Update("attempts", func(current any, exists bool) (any, error) {
+//	    if !exists {
+//	        return 1, nil
+//	    }
+//	    n, ok := current.(int)
+//	    if !ok {
+//	        return nil, fmt.Errorf("attempts is %T, not int", current)
+//	    }
+//	    return n + 1, nil
+//	})
+func (s State) Update(key string, fn func(current any, exists bool) (any, error)) (State, error) {
+	if s.frozen[key] {
+		return s, fmt.Errorf("update of key %q failed: %w", key, ErrFrozenKey)
+	}
+
+	current, exists := s.Data[key]
+
+	value, err := fn(current, exists)
+	if err != nil {
+		return s, fmt.Errorf("update of key %q failed: %w", key, err)
+	}
+
+	return s.Set(key, value), nil
+}
+
+// UpdateAs creates a new State with key set to fn applied to its typed value.
+//
+// When the key is missing, fn receives the zero value of T. Returns the
+// original State and an error when the key holds a value that is not of type T;
+// no conversions are performed (see GetAs).
+//
+// Example:
+//
+//	s, err := state.UpdateAs(s, "count", func(n int) int { return n + 1 })
+//	s, err = state.UpdateAs(s, "log", func(log []string) []string {
+//	    return append(slices.Clone(log), "reviewed")
+//	})
+func UpdateAs[T any](s State, key string, fn func(T) T) (State, error) {
+	return s.Update(key, func(current any, exists bool) (any, error) {
+		if !exists {
+			var zero T
+			return fn(zero), nil
+		}
+
+		typed, ok := current.(T)
+		if !ok {
+			return nil, fmt.Errorf("has type %T, want %s", current, reflect.TypeFor[T]())
+		}
+		return fn(typed), nil
+	})
+}
+
 // SetCheckpointNode creates a new State with updated checkpoint metadata.
 //
 // This method updates the checkpointNode field and refreshes the timestamp
@@ -161,9 +628,9 @@ func (s State) SetCheckpointNode(node string) State {
 // Merge creates a new State combining this State with another State.
 //
 // Keys from the other State are copied into the new State, overwriting any
-// existing keys with the same name. The original States are not modified.
-//
-// Uses maps.Copy for efficient merging.
+// existing keys with the same name. Namespaces (see SetIn) present in both
+// States are merged key by key rather than replaced. The original States are
+// not modified.
 //
 // Emits EventStateMerge through the observer.
 //
@@ -175,13 +642,104 @@ func (s State) SetCheckpointNode(node string) State {
 //	// merged has: user=alice, role=user (overwritten), count=42
 func (s State) Merge(other State) State {
 	newState := s.Clone()
-	maps.Copy(newState.Data, other.Data)
+	newState.frozen = unionFrozen(s.frozen, other.frozen)
+
+	src, skipped := s.withoutFrozen(other.Data)
+	mergeData(newState.Data, src, "", nil, nil)
+
+	data := map[string]any{"keys": len(other.Data)}
+	if len(skipped) > 0 {
+		data["frozen"] = skipped
+	}
+
+	s.Observer.OnEvent(s.Context(), observability.Event{
+		Type:      observability.EventStateMerge,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceState, s.RunID),
+		Data:      data,
+	})
+
+	return newState
+}
+
+// MergeResolver decides the merged value for a key present in both States.
+//
+// ours is the value from the receiver State, theirs the value from the State
+// being merged in. The returned value is stored under key.
+type MergeResolver func(key string, ours, theirs any) any
+
+// ConflictResolver is an alias for MergeResolver.
+type ConflictResolver = MergeResolver
+
+// MergeWith creates a new State combining this State with another State,
+// resolving conflicting keys with resolve.
+//
+// Keys present only in other are copied as-is. For keys present in both States,
+// resolve is called and its result is stored. A nil resolve behaves like Merge
+// (other wins). Namespaces present in both States are merged recursively, with
+// resolve called for conflicting keys inside them. The original States are not
+// modified.
+//
+// Emits one EventStateMergeConflict per conflicted key, carrying both values
+// and the resolved one, followed by EventStateMerge with the sorted list of
+// conflicted keys. Keys inside namespaces are reported qualified by namespace
+// (e.g., "legal.summary"). TakeFirst, TakeLast, TakeMax, and Concatenate
+// provide common resolvers.
+//
+// Example:
+//
+//	// Join node: concatenate findings from both branches
+//	merged := left.MergeWith(right, func(key string, ours, theirs any) any {
+//	    if key == "findings" {
+//	        return append(ours.([]string), theirs.([]string)...)
+//	    }
+//	    return theirs
+//	})
+func (s State) MergeWith(other State, resolve MergeResolver) State {
+	newState := s.Clone()
+	newState.frozen = unionFrozen(s.frozen, other.frozen)
+	var conflicts []mergeConflict
+
+	src, skipped := s.withoutFrozen(other.Data)
+	mergeData(newState.Data, src, "", resolve, &conflicts)
+
+	slices.SortFunc(conflicts, func(a, b mergeConflict) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	keys := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		keys = append(keys, conflict.key)
+	}
+
+	data := map[string]any{
+		"keys":      len(other.Data),
+		"conflicts": keys,
+	}
+	if len(skipped) > 0 {
+		data["frozen"] = skipped
+	}
+
+	source := observability.NewEventSource(observability.SourceState, s.RunID)
+	for _, conflict := range conflicts {
+		s.Observer.OnEvent(s.Context(), observability.Event{
+			Type:      observability.EventStateMergeConflict,
+			Timestamp: time.Now(),
+			Source:    source,
+			Data: map[string]any{
+				"key":      conflict.key,
+				"ours":     conflict.ours,
+				"theirs":   conflict.theirs,
+				"resolved": conflict.resolved,
+			},
+		})
+	}
 
-	s.Observer.OnEvent(context.Background(), observability.Event{
+	s.Observer.OnEvent(s.Context(), observability.Event{
 		Type:      observability.EventStateMerge,
 		Timestamp: time.Now(),
-		Source:    "state",
-		Data:      map[string]any{"keys": len(other.Data)},
+		Source:    source,
+		Data:      data,
 	})
 
 	return newState
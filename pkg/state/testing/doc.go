@@ -0,0 +1,16 @@
+// Package testing provides state graph nodes for testing routing, edges, and
+// checkpointing without running real computation or agent calls.
+//
+// The package name shadows the standard library, so import it under an alias:
+//
+//	import sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
+//
+// Example:
+//
+//	visits := 0
+//	graph.AddNode("classify", sttest.MockNode(state.New(nil).Set("category", "urgent"), nil))
+//	graph.AddNode("escalate", sttest.CountingNode(&visits))
+//	graph.AddNode("archive", sttest.EchoNode())
+//	graph.AddEdge("classify", "escalate", state.KeyEquals("category", "urgent"))
+//	graph.AddEdge("classify", "archive", nil)
+package testing
@@ -0,0 +1,38 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// MockNode returns a node that ignores its input and always returns response
+// and err.
+//
+// The same response is returned on every call; graphs treat it like any node
+// output, so run metadata such as RunID comes from response rather than the
+// graph's input State.
+func MockNode(response state.State, err error) state.StateNode {
+	return state.FuncNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return response, err
+	})
+}
+
+// EchoNode returns a node that returns its input State unchanged.
+func EchoNode() state.StateNode {
+	return state.FuncNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s, nil
+	})
+}
+
+// CountingNode returns a node that increments *counter on each call and
+// returns its input State unchanged.
+//
+// The counter is not synchronized; use it with graphs that run nodes
+// sequentially.
+func CountingNode(counter *int) state.StateNode {
+	return state.FuncNode(func(ctx context.Context, s state.State) (state.State, error) {
+		*counter++
+		return s, nil
+	})
+}
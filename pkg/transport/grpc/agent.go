@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JaimeStill/go-agents/pkg/agent"
+	"github.com/JaimeStill/go-agents/pkg/client"
+	"github.com/JaimeStill/go-agents/pkg/model"
+	"github.com/JaimeStill/go-agents/pkg/providers"
+	"github.com/JaimeStill/go-agents/pkg/response"
+)
+
+// remoteAgent stands in for an agent running in another process. It carries
+// only the agent's identity; the LLM itself is not reachable through the hub,
+// so protocol methods return an error.
+type remoteAgent struct {
+	id string
+}
+
+var _ agent.Agent = (*remoteAgent)(nil)
+
+func (a *remoteAgent) ID() string {
+	return a.id
+}
+
+func (a *remoteAgent) Client() client.Client {
+	return nil
+}
+
+func (a *remoteAgent) Provider() providers.Provider {
+	return nil
+}
+
+func (a *remoteAgent) Model() *model.Model {
+	return nil
+}
+
+func (a *remoteAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	return nil, a.notLocal()
+}
+
+func (a *remoteAgent) notLocal() error {
+	return fmt.Errorf("agent %s is remote: LLM protocols are not available through the hub", a.id)
+}
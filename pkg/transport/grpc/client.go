@@ -0,0 +1,537 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/agent"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hubpb"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"golang.org/x/time/rate"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// deadLetterBufferSize bounds the client's local dead letter queue.
+const deadLetterBufferSize = 100
+
+type subscription struct {
+	cancel       context.CancelFunc
+	done         chan struct{}
+	registeredAt time.Time
+	capabilities []string
+	messages     atomic.Int64
+}
+
+type hubClient struct {
+	addr string
+	conn *gogrpc.ClientConn
+	rpc  hubpb.HubServiceClient
+
+	agents      map[string]*subscription
+	agentsMutex sync.Mutex
+
+	middleware      []hub.MessageMiddleware
+	middlewareMutex sync.RWMutex
+
+	deadLetters chan hub.DeadLetter
+	handlers    sync.WaitGroup
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	shutdown atomic.Bool
+	done     chan struct{}
+	once     sync.Once
+
+	sent atomic.Int64
+	recv atomic.Int64
+}
+
+// NewGRPCHubClient connects to a hub served by NewGRPCHubServer at addr and
+// returns it as a Hub.
+//
+// Without dial options the connection uses insecure transport credentials;
+// pass options such as grpc.WithTransportCredentials to configure TLS.
+//
+// Agents registered with the client receive their messages over a stream from
+// the remote hub; a handler's returned response is sent back to the remote
+// hub addressed like an in-process reply. MessageContext.SendReply is not
+// available to remote handlers; return the reply instead.
+//
+// Subscribe, Unsubscribe, and Publish are forwarded to the remote hub.
+// Operations that manage the remote hub's agents are not available over the
+// transport and return an error wrapping errors.ErrUnsupported: Pause,
+// Resume, Replace, SetAgentRateLimit, SetAgentCircuitBreaker, AddRoutingRule,
+// AddTransformer, AddResponseTransformer, RegisterWithHealthCheck, and
+// SendToCapable. ListAgents and Metrics describe only the agents registered through this
+// client. Shutdown unregisters them and closes the connection; it does not
+// shut down the remote hub.
+func NewGRPCHubClient(addr string, opts ...gogrpc.DialOption) (hub.Hub, error) {
+	if len(opts) == 0 {
+		opts = []gogrpc.DialOption{gogrpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := gogrpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to hub at %s: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &hubClient{
+		addr:        addr,
+		conn:        conn,
+		rpc:         hubpb.NewHubServiceClient(conn),
+		agents:      make(map[string]*subscription),
+		deadLetters: make(chan hub.DeadLetter, deadLetterBufferSize),
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+func (c *hubClient) RegisterAgent(ag agent.Agent, handler hub.MessageHandler) error {
+	return c.RegisterWithCapabilities(ag, handler, nil)
+}
+
+// RegisterWithCapabilities opens a Subscribe stream for the agent and returns
+// once the remote hub has accepted the registration.
+func (c *hubClient) RegisterWithCapabilities(ag agent.Agent, handler hub.MessageHandler, capabilities []string) error {
+	if c.IsShutdown() {
+		return hub.ErrHubShutdown
+	}
+
+	agentID := ag.ID()
+	c.agentsMutex.Lock()
+	defer c.agentsMutex.Unlock()
+
+	if _, exists := c.agents[agentID]; exists {
+		return fmt.Errorf("agent already registered: %s", agentID)
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	stream, err := c.rpc.Subscribe(ctx, &hubpb.SubscribeRequest{
+		AgentId:      agentID,
+		Capabilities: capabilities,
+	})
+	if err != nil {
+		cancel()
+		return fromStatus(err)
+	}
+
+	// The server sends headers once the agent is registered. A stream that
+	// ends without headers carries the registration error in its status.
+	header, err := stream.Header()
+	if err == nil && header == nil {
+		_, err = stream.Recv()
+	}
+	if err != nil {
+		cancel()
+		return fromStatus(err)
+	}
+
+	sub := &subscription{
+		cancel:       cancel,
+		done:         make(chan struct{}),
+		registeredAt: time.Now(),
+		capabilities: slices.Clone(capabilities),
+	}
+	c.agents[agentID] = sub
+
+	go c.receive(ctx, ag, handler, stream, sub)
+
+	return nil
+}
+
+// receive dispatches streamed messages to handler until the stream ends.
+func (c *hubClient) receive(ctx context.Context, ag agent.Agent, handler hub.MessageHandler, stream hubpb.HubService_SubscribeClient, sub *subscription) {
+	defer close(sub.done)
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Default().WarnContext(
+					ctx,
+					"hub stream closed",
+					slog.String("addr", c.addr),
+					slog.String("agent_id", ag.ID()),
+					slog.String("error", err.Error()),
+				)
+			}
+			c.agentsMutex.Lock()
+			if c.agents[ag.ID()] == sub {
+				delete(c.agents, ag.ID())
+			}
+			c.agentsMutex.Unlock()
+			return
+		}
+
+		msg, err := fromProto(event.GetMessage())
+		if err != nil {
+			c.deadLetter(nil, ag.ID(), hub.DeadLetterDeliveryFailed)
+			continue
+		}
+
+		sub.messages.Add(1)
+		c.recv.Add(1)
+
+		if c.IsShutdown() {
+			continue
+		}
+		c.handlers.Go(func() {
+			c.handle(ctx, ag, handler, msg)
+		})
+	}
+}
+
+func (c *hubClient) handle(ctx context.Context, ag agent.Agent, handler hub.MessageHandler, msg *messaging.Message) {
+	if handler == nil {
+		return
+	}
+
+	msgCtx := &hub.MessageContext{
+		HubName: c.addr,
+		Agent:   ag,
+	}
+
+	response, err := c.wrapHandler(handler)(ctx, msg, msgCtx)
+	if err != nil {
+		c.deadLetter(msg, ag.ID(), hub.DeadLetterHandlerFailed)
+		return
+	}
+
+	if response == nil {
+		return
+	}
+
+	response = addressReply(msg, response)
+	if err := c.SendMessage(ctx, response); err != nil {
+		slog.Default().ErrorContext(
+			ctx,
+			"failed to send response",
+			slog.String("addr", c.addr),
+			slog.String("agent_id", ag.ID()),
+			slog.String("message_id", response.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// addressReply mirrors the hub's reply addressing: responses go to message's
+// reply target and are correlated with message's ID when ReplyTo is empty.
+func addressReply(message, response *messaging.Message) *messaging.Message {
+	to := response.To
+	if to == "" || (response.IsResponse() && message.ReplyToAgent != "") {
+		to = message.ReplyTarget()
+	}
+
+	replyTo := response.ReplyTo
+	if response.IsResponse() && replyTo == "" {
+		replyTo = message.ID
+	}
+
+	if to == response.To && replyTo == response.ReplyTo {
+		return response
+	}
+
+	addressed := response.Clone()
+	addressed.To = to
+	addressed.ReplyTo = replyTo
+	return addressed
+}
+
+func (c *hubClient) UnregisterAgent(agentID string) error {
+	c.agentsMutex.Lock()
+	sub, exists := c.agents[agentID]
+	delete(c.agents, agentID)
+	c.agentsMutex.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", hub.ErrAgentNotFound, agentID)
+	}
+
+	sub.cancel()
+	<-sub.done
+	return nil
+}
+
+func (c *hubClient) ListAgents() []hub.AgentInfo {
+	c.agentsMutex.Lock()
+	defer c.agentsMutex.Unlock()
+
+	agents := make([]hub.AgentInfo, 0, len(c.agents))
+	for id, sub := range c.agents {
+		agents = append(agents, hub.AgentInfo{
+			ID:           id,
+			RegisteredAt: sub.registeredAt,
+			MessageCount: sub.messages.Load(),
+			Status:       hub.AgentStatusActive,
+			Capabilities: slices.Clone(sub.capabilities),
+		})
+	}
+
+	slices.SortFunc(agents, func(a, b hub.AgentInfo) int {
+		return a.RegisteredAt.Compare(b.RegisteredAt)
+	})
+	return agents
+}
+
+func (c *hubClient) Replace(agentID string, newAgent agent.Agent) error {
+	return unsupported("Replace")
+}
+
+func (c *hubClient) Pause(agentID string) error {
+	return unsupported("Pause")
+}
+
+func (c *hubClient) Resume(agentID string) error {
+	return unsupported("Resume")
+}
+
+func (c *hubClient) SetAgentRateLimit(agentID string, limit rate.Limit, burst int) error {
+	return unsupported("SetAgentRateLimit")
+}
+
+func (c *hubClient) SetAgentCircuitBreaker(agentID string, cfg config.CircuitBreakerConfig) error {
+	return unsupported("SetAgentCircuitBreaker")
+}
+
+func (c *hubClient) RegisterWithHealthCheck(ag agent.Agent, handler hub.MessageHandler, probe hub.HealthProbe) error {
+	return unsupported("RegisterWithHealthCheck")
+}
+
+func (c *hubClient) AddRoutingRule(rule hub.RoutingRule) error {
+	return unsupported("AddRoutingRule")
+}
+
+func (c *hubClient) AddTransformer(transformer hub.MessageTransformer) error {
+	return unsupported("AddTransformer")
+}
+
+func (c *hubClient) AddResponseTransformer(transformer hub.MessageTransformer) error {
+	return unsupported("AddResponseTransformer")
+}
+
+// Use registers middleware applied to the handlers of agents registered
+// through this client.
+func (c *hubClient) Use(middleware hub.MessageMiddleware) {
+	if middleware == nil {
+		return
+	}
+
+	c.middlewareMutex.Lock()
+	defer c.middlewareMutex.Unlock()
+
+	c.middleware = append(c.middleware, middleware)
+}
+
+func (c *hubClient) wrapHandler(handler hub.MessageHandler) hub.MessageHandler {
+	c.middlewareMutex.RLock()
+	defer c.middlewareMutex.RUnlock()
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	return handler
+}
+
+func (c *hubClient) Send(ctx context.Context, from, to string, data any) error {
+	return c.SendMessage(ctx, messaging.NewNotification(from, to, data).Build())
+}
+
+func (c *hubClient) SendMessage(ctx context.Context, msg *messaging.Message) error {
+	_, err := c.send(ctx, msg, false)
+	return err
+}
+
+func (c *hubClient) Request(ctx context.Context, from, to string, data any) (*messaging.Message, error) {
+	return c.RequestMessage(ctx, messaging.NewRequest(from, to, data).Build())
+}
+
+func (c *hubClient) RequestMessage(ctx context.Context, msg *messaging.Message) (*messaging.Message, error) {
+	return c.send(ctx, msg, true)
+}
+
+func (c *hubClient) SendWithTimeout(ctx context.Context, agentID string, msg *messaging.Message, timeout time.Duration) (*messaging.Message, error) {
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive: %v", timeout)
+	}
+
+	if msg.To != agentID {
+		msg = msg.Clone()
+		msg.To = agentID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return c.RequestMessage(ctx, msg)
+}
+
+func (c *hubClient) send(ctx context.Context, msg *messaging.Message, awaitResponse bool) (*messaging.Message, error) {
+	if c.IsShutdown() {
+		return nil, hub.ErrHubShutdown
+	}
+
+	pb, err := toProto(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rpc.Send(ctx, &hubpb.MessageRequest{Message: pb, AwaitResponse: awaitResponse})
+	if err != nil {
+		return nil, messaging.WrapError(msg.ID, fromStatus(err))
+	}
+	c.sent.Add(1)
+
+	response, err := fromProto(resp.GetResponse())
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Broadcast reports agents the remote hub skipped through a *hub.BroadcastError.
+func (c *hubClient) Broadcast(ctx context.Context, msg *messaging.Message) error {
+	if c.IsShutdown() {
+		return hub.ErrHubShutdown
+	}
+
+	pb, err := toProto(msg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.rpc.Broadcast(ctx, &hubpb.MessageRequest{Message: pb})
+	if err != nil {
+		return messaging.WrapError(msg.ID, fromStatus(err))
+	}
+	c.sent.Add(1)
+
+	if skipped := resp.GetSkipped(); len(skipped) > 0 {
+		return &hub.BroadcastError{Skipped: skipped}
+	}
+	return nil
+}
+
+func (c *hubClient) SendToCapable(ctx context.Context, capability string, msg *messaging.Message) (*messaging.Message, error) {
+	return nil, unsupported("SendToCapable")
+}
+
+// Subscribe subscribes an agent registered with the remote hub, through this
+// client or any other, to topic.
+func (c *hubClient) Subscribe(agentID, topic string) error {
+	if c.IsShutdown() {
+		return hub.ErrHubShutdown
+	}
+
+	_, err := c.rpc.SubscribeTopic(c.ctx, &hubpb.TopicRequest{AgentId: agentID, Topic: topic})
+	return fromStatus(err)
+}
+
+func (c *hubClient) Unsubscribe(agentID, topic string) error {
+	if c.IsShutdown() {
+		return hub.ErrHubShutdown
+	}
+
+	_, err := c.rpc.UnsubscribeTopic(c.ctx, &hubpb.TopicRequest{AgentId: agentID, Topic: topic})
+	return fromStatus(err)
+}
+
+func (c *hubClient) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	if c.IsShutdown() {
+		return hub.ErrHubShutdown
+	}
+
+	pb, err := toProto(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.rpc.Publish(ctx, &hubpb.PublishRequest{Topic: topic, Message: pb}); err != nil {
+		return messaging.WrapError(msg.ID, fromStatus(err))
+	}
+	c.sent.Add(1)
+	return nil
+}
+
+// DeadLetterQueue reports messages whose local handler failed or that could
+// not be decoded. Undeliverable messages on the remote hub stay in its queue.
+func (c *hubClient) DeadLetterQueue() <-chan hub.DeadLetter {
+	return c.deadLetters
+}
+
+// AuditLog returns nil: operations are audited by the remote hub's own log.
+func (c *hubClient) AuditLog() hub.HubEventLog {
+	return nil
+}
+
+func (c *hubClient) deadLetter(message *messaging.Message, target, reason string) {
+	select {
+	case c.deadLetters <- hub.DeadLetter{
+		Message:     message,
+		Reason:      reason,
+		FailedAt:    time.Now(),
+		TargetAgent: target,
+	}:
+	default:
+	}
+}
+
+func (c *hubClient) Metrics() hub.HubMetrics {
+	c.agentsMutex.Lock()
+	agents := len(c.agents)
+	c.agentsMutex.Unlock()
+
+	return hub.HubMetrics{
+		LocalAgents:      int64(agents),
+		MessagesSent:     c.sent.Load(),
+		MessagesRecv:     c.recv.Load(),
+		ActiveAgentCount: agents,
+	}
+}
+
+// Shutdown closes every agent stream, waits for running handlers or ctx, and
+// closes the connection.
+func (c *hubClient) Shutdown(ctx context.Context) error {
+	var err error
+	c.once.Do(func() {
+		c.shutdown.Store(true)
+		c.cancel()
+
+		handlersDone := make(chan struct{})
+		go func() {
+			c.handlers.Wait()
+			close(handlersDone)
+		}()
+
+		select {
+		case <-handlersDone:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		if closeErr := c.conn.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		close(c.done)
+	})
+	return err
+}
+
+func (c *hubClient) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *hubClient) IsShutdown() bool {
+	return c.shutdown.Load()
+}
+
+var _ hub.Hub = (*hubClient)(nil)
@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"fmt"
+	"maps"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hubpb"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toProto converts a message to its wire form, encoding Data with the codec
+// registered for the message's content type.
+func toProto(msg *messaging.Message) (*hubpb.Message, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	data, err := messaging.DefaultContentRegistry.Marshal(msg.Data, msg.ContentType)
+	if err != nil {
+		return nil, messaging.WrapError(msg.ID, fmt.Errorf("failed to encode message data: %w", err))
+	}
+
+	return &hubpb.Message{
+		Id:           msg.ID,
+		From:         msg.From,
+		To:           msg.To,
+		Type:         string(msg.Type),
+		Data:         data,
+		ContentType:  msg.ContentType,
+		ReplyTo:      msg.ReplyTo,
+		ReplyToAgent: msg.ReplyToAgent,
+		Topic:        msg.Topic,
+		Timestamp:    toTimestamp(msg.Timestamp),
+		Priority:     int32(msg.Priority),
+		Headers:      maps.Clone(msg.Headers),
+		ExpiresAt:    toTimestamp(msg.ExpiresAt),
+	}, nil
+}
+
+// fromProto converts a wire message back to a message.
+//
+// JSON data (the default) is decoded into generic values, matching what a
+// message read from a JSON checkpoint or log would hold. Text data becomes a
+// string. Data of any other content type is left as raw bytes for the
+// receiver to decode with Message.DecodeContent.
+func fromProto(pb *hubpb.Message) (*messaging.Message, error) {
+	if pb == nil {
+		return nil, nil
+	}
+
+	msg := &messaging.Message{
+		ID:           pb.GetId(),
+		From:         pb.GetFrom(),
+		To:           pb.GetTo(),
+		Type:         messaging.MessageType(pb.GetType()),
+		ContentType:  pb.GetContentType(),
+		ReplyTo:      pb.GetReplyTo(),
+		ReplyToAgent: pb.GetReplyToAgent(),
+		Topic:        pb.GetTopic(),
+		Timestamp:    fromTimestamp(pb.GetTimestamp()),
+		Priority:     messaging.Priority(pb.GetPriority()),
+		Headers:      maps.Clone(pb.GetHeaders()),
+		ExpiresAt:    fromTimestamp(pb.GetExpiresAt()),
+	}
+
+	data, err := decodeData(pb.GetData(), msg.ContentType)
+	if err != nil {
+		return nil, messaging.WrapError(msg.ID, fmt.Errorf("failed to decode message data: %w", err))
+	}
+	msg.Data = data
+
+	return msg, nil
+}
+
+func decodeData(data []byte, contentType string) (any, error) {
+	switch mediaType(contentType) {
+	case messaging.ContentTypeJSON:
+		if len(data) == 0 {
+			return nil, nil
+		}
+		var value any
+		if err := messaging.DefaultContentRegistry.Unmarshal(data, contentType, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case messaging.ContentTypeText:
+		var value string
+		if err := messaging.DefaultContentRegistry.Unmarshal(data, contentType, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		return data, nil
+	}
+}
+
+// mediaType strips parameters from contentType, defaulting to JSON when empty.
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return messaging.ContentTypeJSON
+	}
+
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return parsed
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
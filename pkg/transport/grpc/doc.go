@@ -0,0 +1,61 @@
+// Package grpc provides a gRPC transport that connects agents in separate
+// processes through a single hub.
+//
+// One process owns the hub and serves it with NewGRPCHubServer. Other
+// processes connect with NewGRPCHubClient, which returns a hub.Hub whose
+// agents and messages are routed through the remote hub. The wire protocol is
+// defined in the hubpb package (pkg/hubpb/hub.proto).
+//
+// The package name matches the directory and shadows google.golang.org/grpc;
+// import it under an alias:
+//
+//	import grpctransport "github.com/JaimeStill/go-agents-orchestration/pkg/transport/grpc"
+//
+// # Serving a Hub
+//
+//	h := hub.New(ctx, config.DefaultHubConfig())
+//
+//	lis, _ := net.Listen("tcp", ":9090")
+//	srv := grpc.NewServer()
+//	hubpb.RegisterHubServiceServer(srv, grpctransport.NewGRPCHubServer(h))
+//	go srv.Serve(lis)
+//
+// # Connecting
+//
+//	remote, err := grpctransport.NewGRPCHubClient("hub-host:9090")
+//	if err != nil {
+//	    return err
+//	}
+//	defer remote.Shutdown(ctx)
+//
+//	remote.RegisterAgent(worker, handler)
+//	response, err := remote.Request(ctx, "worker", "coordinator", task)
+//
+// Registering an agent opens a server stream through which the hub delivers
+// the agent's messages; the hub sees a proxy agent with the same ID, so
+// in-process and remote agents address each other the same way. Closing the
+// stream, through UnregisterAgent, Shutdown, or a dropped connection,
+// unregisters the proxy.
+//
+// Subscribe, Unsubscribe, and Publish are forwarded to the remote hub, so
+// remote agents join topics and receive published messages over their
+// streams like any other message.
+//
+// # Message Data
+//
+// Message data is encoded with the codec registered for its content type
+// (see messaging.RegisterContentCodec). JSON data, the default, arrives
+// decoded into generic values such as map[string]any and float64. Text data
+// arrives as a string. Data of any other content type arrives as []byte for
+// the receiver to decode with Message.DecodeContent.
+//
+// # Errors
+//
+// Hub errors cross the transport as gRPC status codes and are restored on the
+// client, so errors.Is matches hub.ErrAgentNotFound, hub.ErrHubShutdown,
+// hub.ErrCircuitOpen, hub.ErrAgentUnhealthy, and context errors as it would
+// in-process. Broadcast skips are reported through *hub.BroadcastError.
+//
+// Operations that manage the remote hub's agents, such as Pause and Replace,
+// return an error wrapping errors.ErrUnsupported; see NewGRPCHubClient.
+package grpc
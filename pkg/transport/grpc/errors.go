@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unsupported reports a Hub operation the client cannot perform remotely.
+func unsupported(operation string) error {
+	return fmt.Errorf("grpc hub client: %s: %w", operation, errors.ErrUnsupported)
+}
+
+// toStatus converts a hub error into a gRPC status error.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, hub.ErrAgentNotFound):
+		code = codes.NotFound
+	case errors.Is(err, hub.ErrHubShutdown):
+		code = codes.Unavailable
+	case errors.Is(err, hub.ErrCircuitOpen):
+		code = codes.ResourceExhausted
+	case errors.Is(err, hub.ErrAgentUnhealthy):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	default:
+		code = codes.Unknown
+	}
+	return status.Error(code, err.Error())
+}
+
+// remoteError is a hub error received from the server. It unwraps to the hub
+// sentinel matching the status code, so callers can use errors.Is as they
+// would with an in-process hub.
+type remoteError struct {
+	status *status.Status
+	cause  error
+}
+
+func (e *remoteError) Error() string {
+	return e.status.Message()
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.cause
+}
+
+// GRPCStatus lets status.FromError recover the original status.
+func (e *remoteError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// fromStatus converts a gRPC status error back into a hub error.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var cause error
+	switch st.Code() {
+	case codes.NotFound:
+		cause = hub.ErrAgentNotFound
+	case codes.Unavailable:
+		cause = hub.ErrHubShutdown
+	case codes.ResourceExhausted:
+		cause = hub.ErrCircuitOpen
+	case codes.FailedPrecondition:
+		cause = hub.ErrAgentUnhealthy
+	case codes.DeadlineExceeded:
+		cause = context.DeadlineExceeded
+	case codes.Canceled:
+		cause = context.Canceled
+	default:
+		return err
+	}
+	return &remoteError{status: st, cause: cause}
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hubpb"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type hubServer struct {
+	hubpb.UnimplementedHubServiceServer
+	hub hub.Hub
+}
+
+// NewGRPCHubServer exposes h to other processes through the HubService.
+//
+// Register the returned server with a grpc.Server:
+//
+//	srv := grpc.NewServer()
+//	hubpb.RegisterHubServiceServer(srv, grpctransport.NewGRPCHubServer(h))
+//	srv.Serve(listener)
+//
+// Agents registered through Subscribe are unregistered from h when their
+// stream ends.
+func NewGRPCHubServer(h hub.Hub) hubpb.HubServiceServer {
+	return &hubServer{hub: h}
+}
+
+func (s *hubServer) Send(ctx context.Context, req *hubpb.MessageRequest) (*hubpb.MessageResponse, error) {
+	msg, err := fromProto(req.GetMessage())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if msg == nil {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	if !req.GetAwaitResponse() {
+		return &hubpb.MessageResponse{}, toStatus(s.hub.SendMessage(ctx, msg))
+	}
+
+	response, err := s.hub.RequestMessage(ctx, msg)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pb, err := toProto(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &hubpb.MessageResponse{Response: pb}, nil
+}
+
+func (s *hubServer) Broadcast(ctx context.Context, req *hubpb.MessageRequest) (*hubpb.BroadcastResponse, error) {
+	msg, err := fromProto(req.GetMessage())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if msg == nil {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	err = s.hub.Broadcast(ctx, msg)
+
+	var broadcastErr *hub.BroadcastError
+	if errors.As(err, &broadcastErr) {
+		return &hubpb.BroadcastResponse{Skipped: broadcastErr.Skipped}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &hubpb.BroadcastResponse{}, nil
+}
+
+func (s *hubServer) Publish(ctx context.Context, req *hubpb.PublishRequest) (*hubpb.PublishResponse, error) {
+	if req.GetTopic() == "" {
+		return nil, status.Error(codes.InvalidArgument, "topic is required")
+	}
+
+	msg, err := fromProto(req.GetMessage())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if msg == nil {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	return &hubpb.PublishResponse{}, toStatus(s.hub.Publish(ctx, req.GetTopic(), msg))
+}
+
+func (s *hubServer) SubscribeTopic(ctx context.Context, req *hubpb.TopicRequest) (*hubpb.TopicResponse, error) {
+	if req.GetAgentId() == "" || req.GetTopic() == "" {
+		return nil, status.Error(codes.InvalidArgument, "agent_id and topic are required")
+	}
+	return &hubpb.TopicResponse{}, toStatus(s.hub.Subscribe(req.GetAgentId(), req.GetTopic()))
+}
+
+func (s *hubServer) UnsubscribeTopic(ctx context.Context, req *hubpb.TopicRequest) (*hubpb.TopicResponse, error) {
+	if req.GetAgentId() == "" || req.GetTopic() == "" {
+		return nil, status.Error(codes.InvalidArgument, "agent_id and topic are required")
+	}
+	return &hubpb.TopicResponse{}, toStatus(s.hub.Unsubscribe(req.GetAgentId(), req.GetTopic()))
+}
+
+// Subscribe registers a proxy for the remote agent and forwards its messages
+// over the stream. Header metadata is sent once registration succeeds so the
+// client can report registration errors synchronously.
+func (s *hubServer) Subscribe(req *hubpb.SubscribeRequest, stream hubpb.HubService_SubscribeServer) error {
+	if req.GetAgentId() == "" {
+		return status.Error(codes.InvalidArgument, "agent_id is required")
+	}
+
+	ctx := stream.Context()
+	inbox := make(chan *hubpb.Message)
+
+	handler := func(handlerCtx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		pb, err := toProto(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case inbox <- pb:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-handlerCtx.Done():
+			return nil, handlerCtx.Err()
+		}
+	}
+
+	ag := &remoteAgent{id: req.GetAgentId()}
+	if err := s.hub.RegisterWithCapabilities(ag, handler, req.GetCapabilities()); err != nil {
+		return toStatus(err)
+	}
+	defer s.hub.UnregisterAgent(ag.id)
+
+	for _, topic := range req.GetTopics() {
+		if err := s.hub.Subscribe(ag.id, topic); err != nil {
+			return toStatus(err)
+		}
+	}
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.hub.Done():
+			return status.Error(codes.Unavailable, hub.ErrHubShutdown.Error())
+		case pb := <-inbox:
+			if err := stream.Send(&hubpb.MessageEvent{Message: pb}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -2,11 +2,17 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 // StepProcessor processes a single item and updates the accumulated context.
@@ -47,6 +53,15 @@ type StepProcessor[TItem, TContext any] func(
 	state TContext,
 ) (TContext, error)
 
+// Merger is implemented by chain context types that can combine independently
+// produced states. state.State implements Merger through State.Merge.
+//
+// Concurrent chains (ChainConfig.MaxConcurrency > 1) require TContext to
+// implement Merger[TContext].
+type Merger[T any] interface {
+	Merge(other T) T
+}
+
 // ChainResult contains the results of chain execution.
 //
 // The Final field always contains the result (either final state on success
@@ -83,14 +98,32 @@ type ChainResult[TContext any] struct {
 //   - EventStepComplete: After each step (success or failure)
 //   - EventChainComplete: When chain finishes
 //
+// Every event carries a "run_id" generated for the call, so observers can
+// correlate the events of concurrently running chains.
+//
 // Error Handling:
 //
 // Errors are wrapped in ChainError with complete context including:
-//   - Step index where failure occurred
+//   - Step index where failure occurred, and its name from cfg.StepNames
 //   - Item being processed
 //   - State at time of failure
 //   - Underlying error
 //
+// Concurrent Processing:
+//
+// When cfg.MaxConcurrency > 1, up to MaxConcurrency items are processed at once
+// with errgroup. Steps no longer see each other's output: every processor call
+// receives the initial state, and results are folded into the accumulated
+// state with Merge in item order, so TContext must implement Merger[TContext].
+// The progress callback and intermediate states follow item order rather than
+// completion order, and progress is never called concurrently.
+//
+// The first failure cancels the context passed to in-flight processors and
+// stops new items from starting. The returned ChainError reports the lowest
+// failed step, joins every error encountered, and lists each failure in
+// ChainError.Errors. Its State is the accumulation of the steps preceding the
+// first unfinished item.
+//
 // Empty Chain Behavior:
 //
 // When items slice is empty, returns immediately with:
@@ -155,19 +188,27 @@ func ProcessChain[TItem, TContext any](
 		return ChainResult[TContext]{}, fmt.Errorf("failed to resolve observer: %w", err)
 	}
 
+	concurrent := cfg.MaxConcurrency > 1
+	if _, ok := any(initial).(Merger[TContext]); concurrent && !ok {
+		return ChainResult[TContext]{}, fmt.Errorf("max concurrency %d requires %T to implement Merger", cfg.MaxConcurrency, initial)
+	}
+
 	result := ChainResult[TContext]{
 		Final: initial,
 		Steps: 0,
 	}
 
+	runID := uuid.NewString()
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessChain",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 		Data: map[string]any{
+			"run_id":                runID,
 			"item_count":            len(items),
 			"has_progress_callback": progress != nil,
 			"capture_intermediate":  cfg.CaptureIntermediateStates,
+			"max_concurrency":       max(cfg.MaxConcurrency, 1),
 		},
 	})
 
@@ -175,8 +216,9 @@ func ProcessChain[TItem, TContext any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventChainComplete,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessChain",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 			Data: map[string]any{
+				"run_id":          runID,
 				"steps_completed": 0,
 				"error":           false,
 			},
@@ -184,6 +226,10 @@ func ProcessChain[TItem, TContext any](
 		return result, nil
 	}
 
+	if concurrent {
+		return processChainConcurrent(ctx, cfg, observer, runID, items, initial, processor, progress)
+	}
+
 	var intermediate []TContext
 	if cfg.CaptureIntermediateStates {
 		intermediate = make([]TContext, 0, len(items)+1)
@@ -196,6 +242,7 @@ func ProcessChain[TItem, TContext any](
 		if err := ctx.Err(); err != nil {
 			chainErr := &ChainError[TItem, TContext]{
 				StepIndex: i,
+				StepName:  stepName(cfg, i),
 				Item:      item,
 				State:     state,
 				Err:       fmt.Errorf("processing cancelled: %w", err),
@@ -203,8 +250,9 @@ func ProcessChain[TItem, TContext any](
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventChainComplete,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessChain",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 				Data: map[string]any{
+					"run_id":          runID,
 					"steps_completed": i,
 					"error":           true,
 					"error_type":      "cancellation",
@@ -216,8 +264,9 @@ func ProcessChain[TItem, TContext any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventStepStart,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessChain",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 			Data: map[string]any{
+				"run_id":      runID,
 				"step_index":  i,
 				"total_steps": len(items),
 			},
@@ -227,15 +276,18 @@ func ProcessChain[TItem, TContext any](
 		if err != nil {
 			chainErr := &ChainError[TItem, TContext]{
 				StepIndex: i,
+				StepName:  stepName(cfg, i),
 				Item:      item,
 				State:     state,
 				Err:       err,
+				MessageID: messaging.MessageIDOf(err),
 			}
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventStepComplete,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessChain",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 				Data: map[string]any{
+					"run_id":      runID,
 					"step_index":  i,
 					"total_steps": len(items),
 					"error":       true,
@@ -244,8 +296,9 @@ func ProcessChain[TItem, TContext any](
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventChainComplete,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessChain",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 				Data: map[string]any{
+					"run_id":          runID,
 					"steps_completed": i,
 					"error":           true,
 					"error_type":      "processor",
@@ -263,8 +316,9 @@ func ProcessChain[TItem, TContext any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventStepComplete,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessChain",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 			Data: map[string]any{
+				"run_id":      runID,
 				"step_index":  i,
 				"total_steps": len(items),
 				"error":       false,
@@ -283,8 +337,9 @@ func ProcessChain[TItem, TContext any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventChainComplete,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessChain",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
 		Data: map[string]any{
+			"run_id":          runID,
 			"steps_completed": len(items),
 			"error":           false,
 		},
@@ -292,3 +347,275 @@ func ProcessChain[TItem, TContext any](
 
 	return result, nil
 }
+
+// stepName returns the configured name of step index, or "" when unnamed.
+func stepName(cfg config.ChainConfig, index int) string {
+	if index < 0 || index >= len(cfg.StepNames) {
+		return ""
+	}
+	return cfg.StepNames[index]
+}
+
+// processChainConcurrent implements ProcessChain for MaxConcurrency > 1.
+//
+// Each item is processed from the initial state; outputs are merged into the
+// accumulated state in item order as soon as every earlier item has finished.
+func processChainConcurrent[TItem, TContext any](
+	ctx context.Context,
+	cfg config.ChainConfig,
+	observer observability.Observer,
+	runID string,
+	items []TItem,
+	initial TContext,
+	processor StepProcessor[TItem, TContext],
+	progress ProgressFunc[TContext],
+) (ChainResult[TContext], error) {
+	var (
+		mu           sync.Mutex
+		state        = initial
+		next         int
+		outputs      = make([]TContext, len(items))
+		done         = make([]bool, len(items))
+		intermediate []TContext
+		failures     []TaskError[TItem]
+	)
+
+	if cfg.CaptureIntermediateStates {
+		intermediate = make([]TContext, 0, len(items)+1)
+		intermediate = append(intermediate, initial)
+	}
+
+	// advance folds completed outputs into state in item order. Callers hold mu.
+	advance := func() {
+		for next < len(items) && done[next] {
+			state = any(state).(Merger[TContext]).Merge(outputs[next])
+			var zero TContext
+			outputs[next] = zero
+
+			if cfg.CaptureIntermediateStates {
+				intermediate = append(intermediate, state)
+			}
+			next++
+
+			if progress != nil {
+				progress(next, len(items), state)
+			}
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.MaxConcurrency)
+
+	for i, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+
+		g.Go(func() error {
+			if gctx.Err() != nil {
+				return nil
+			}
+
+			observer.OnEvent(ctx, observability.Event{
+				Type:      observability.EventStepStart,
+				Timestamp: time.Now(),
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
+				Data: map[string]any{
+					"run_id":      runID,
+					"step_index":  i,
+					"total_steps": len(items),
+				},
+			})
+
+			updated, err := processor(gctx, item, initial)
+
+			observer.OnEvent(ctx, observability.Event{
+				Type:      observability.EventStepComplete,
+				Timestamp: time.Now(),
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
+				Data: map[string]any{
+					"run_id":      runID,
+					"step_index":  i,
+					"total_steps": len(items),
+					"error":       err != nil,
+				},
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failures = append(failures, TaskError[TItem]{Index: i, Item: item, Err: err})
+				return err
+			}
+
+			outputs[i] = updated
+			done[i] = true
+			advance()
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	var chainErr *ChainError[TItem, TContext]
+	errorType := "processor"
+
+	switch {
+	case len(failures) > 0:
+		slices.SortFunc(failures, func(a, b TaskError[TItem]) int {
+			return a.Index - b.Index
+		})
+
+		errs := make([]error, len(failures))
+		for i, failure := range failures {
+			errs[i] = failure.Err
+		}
+
+		chainErr = &ChainError[TItem, TContext]{
+			StepIndex: failures[0].Index,
+			StepName:  stepName(cfg, failures[0].Index),
+			Item:      failures[0].Item,
+			State:     state,
+			Err:       errors.Join(errs...),
+			MessageID: messaging.MessageIDOf(failures[0].Err),
+			Errors:    failures,
+		}
+	case next < len(items):
+		errorType = "cancellation"
+		chainErr = &ChainError[TItem, TContext]{
+			StepIndex: next,
+			StepName:  stepName(cfg, next),
+			Item:      items[next],
+			State:     state,
+			Err:       fmt.Errorf("processing cancelled: %w", ctx.Err()),
+		}
+	}
+
+	if chainErr != nil {
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventChainComplete,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
+			Data: map[string]any{
+				"run_id":          runID,
+				"steps_completed": next,
+				"error":           true,
+				"error_type":      errorType,
+			},
+		})
+		return ChainResult[TContext]{Final: initial}, chainErr
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventChainComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChain"),
+		Data: map[string]any{
+			"run_id":          runID,
+			"steps_completed": len(items),
+			"error":           false,
+		},
+	})
+
+	return ChainResult[TContext]{
+		Final:        state,
+		Intermediate: intermediate,
+		Steps:        len(items),
+	}, nil
+}
+
+// ChainStepResult reports the outcome of a single step in a streaming chain.
+//
+// On success, State contains the accumulated state after the step. On failure,
+// Err is set and State contains the accumulated state at the time of failure.
+type ChainStepResult[TItem, TContext any] struct {
+	// StepIndex is the 0-based index of the step
+	StepIndex int
+
+	// Item is the item processed by the step
+	Item TItem
+
+	// State is the accumulated state after the step (or at failure)
+	State TContext
+
+	// Err is the step failure, wrapped in ChainError (nil on success)
+	Err error
+}
+
+// ProcessChainStream executes a sequential chain and streams each step result.
+//
+// Behaves like ProcessChain but returns a channel that receives a ChainStepResult
+// after every completed step, enabling callers to consume results incrementally
+// for long-running pipelines. The channel is closed when all items are processed
+// or after the first error is delivered. Callers detect completion by channel close.
+//
+// The channel is unbuffered, so the chain applies backpressure: the next step does
+// not begin until the previous result has been received. If ctx is cancelled while
+// a result is pending delivery, the result is dropped and the stream closes.
+//
+// Observer events match ProcessChain. The returned error is only non-nil when the
+// chain cannot start (e.g., observer resolution fails).
+//
+// Example:
+//
+//	stream, err := workflows.ProcessChainStream(ctx, cfg, documents, initial, processor)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for step := range stream {
+//	    if step.Err != nil {
+//	        log.Printf("step %d failed: %v", step.StepIndex, step.Err)
+//	        break
+//	    }
+//	    fmt.Printf("processed %v\n", step.Item)
+//	}
+func ProcessChainStream[TItem, TContext any](
+	ctx context.Context,
+	cfg config.ChainConfig,
+	items []TItem,
+	initial TContext,
+	processor StepProcessor[TItem, TContext],
+) (<-chan ChainStepResult[TItem, TContext], error) {
+	if _, err := observability.GetObserver(cfg.Observer); err != nil {
+		return nil, fmt.Errorf("failed to resolve observer: %w", err)
+	}
+
+	stream := make(chan ChainStepResult[TItem, TContext])
+
+	send := func(result ChainStepResult[TItem, TContext]) {
+		select {
+		case stream <- result:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(stream)
+
+		progress := func(completed, total int, state TContext) {
+			send(ChainStepResult[TItem, TContext]{
+				StepIndex: completed - 1,
+				Item:      items[completed-1],
+				State:     state,
+			})
+		}
+
+		_, err := ProcessChain(ctx, cfg, items, initial, processor, progress)
+		if err != nil {
+			var chainErr *ChainError[TItem, TContext]
+			if errors.As(err, &chainErr) {
+				send(ChainStepResult[TItem, TContext]{
+					StepIndex: chainErr.StepIndex,
+					Item:      chainErr.Item,
+					State:     chainErr.State,
+					Err:       err,
+				})
+				return
+			}
+			send(ChainStepResult[TItem, TContext]{State: initial, Err: err})
+		}
+	}()
+
+	return stream, nil
+}
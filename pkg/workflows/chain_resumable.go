@@ -0,0 +1,188 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// Checkpoint keys used by ProcessChainResumable.
+const (
+	chainStepKey    = "chain_step"
+	chainContextKey = "chain_context"
+)
+
+// ProcessChainResumable executes a sequential chain like ProcessChain,
+// checkpointing the accumulated context to store after every step so a failed
+// run can continue where it stopped.
+//
+// The checkpoint is saved under cfg.RunID and records the number of completed
+// steps and the accumulated context. When a checkpoint for cfg.RunID exists,
+// the chain starts from the checkpointed context and skips the items already
+// processed; otherwise it behaves exactly like ProcessChain. The checkpoint is
+// deleted once every item has been processed.
+//
+// Resumed runs report absolute step positions: ChainResult.Steps and
+// ChainError.StepIndex count the skipped items, and StepNames apply to the
+// original item indices. ChainResult.Intermediate, when captured, begins with
+// the checkpointed context rather than initial. Callers must pass the same
+// items on every invocation of a run.
+//
+// The accumulated context is stored as JSON and decoded back into TContext on
+// resume, so TContext must round-trip through encoding/json. A state.State
+// context comes back with NoOpObserver.
+//
+// Resumable chains run sequentially; cfg.MaxConcurrency > 1 is rejected.
+//
+// Returns error if:
+//   - cfg.RunID is empty or store is nil
+//   - The existing checkpoint cannot be loaded or decoded, or covers more
+//     steps than there are items
+//   - A step fails or its checkpoint cannot be saved (ChainError)
+//
+// Example:
+//
+//	cfg := config.ResumableChainConfig{ChainConfig: config.DefaultChainConfig(), RunID: "ingest-" + batchID}
+//	store := state.NewMemoryCheckpointStore()
+//
+//	result, err := workflows.ProcessChainResumable(ctx, cfg, documents, Summary{}, summarize, store)
+//	if err != nil {
+//	    // Fix the cause and call again with the same RunID to continue
+//	    // from the failed document.
+//	}
+func ProcessChainResumable[TItem, TContext any](
+	ctx context.Context,
+	cfg config.ResumableChainConfig,
+	items []TItem,
+	initial TContext,
+	processor StepProcessor[TItem, TContext],
+	store state.CheckpointStore,
+) (ChainResult[TContext], error) {
+	if cfg.RunID == "" {
+		return ChainResult[TContext]{}, fmt.Errorf("resumable chain requires a run ID")
+	}
+	if store == nil {
+		return ChainResult[TContext]{}, fmt.Errorf("resumable chain requires a checkpoint store")
+	}
+	if cfg.MaxConcurrency > 1 {
+		return ChainResult[TContext]{}, fmt.Errorf("resumable chains run sequentially, got max concurrency %d", cfg.MaxConcurrency)
+	}
+
+	observer, err := observability.GetObserver(cfg.Observer)
+	if err != nil {
+		return ChainResult[TContext]{}, fmt.Errorf("failed to resolve observer: %w", err)
+	}
+
+	completed, resumed, found, err := loadChainCheckpoint[TContext](store, cfg.RunID)
+	if err != nil {
+		return ChainResult[TContext]{}, err
+	}
+	if completed > len(items) {
+		return ChainResult[TContext]{}, fmt.Errorf("checkpoint for run %s covers %d steps but chain has %d items", cfg.RunID, completed, len(items))
+	}
+	if found {
+		initial = resumed
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventCheckpointResume,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessChainResumable"),
+			Data: map[string]any{
+				"run_id":      cfg.RunID,
+				"resume_step": completed,
+				"total_steps": len(items),
+			},
+		})
+	}
+
+	chainCfg := cfg.ChainConfig
+	if completed < len(chainCfg.StepNames) {
+		chainCfg.StepNames = chainCfg.StepNames[completed:]
+	} else {
+		chainCfg.StepNames = nil
+	}
+
+	step := completed
+	checkpointed := func(ctx context.Context, item TItem, current TContext) (TContext, error) {
+		updated, err := processor(ctx, item, current)
+		if err != nil {
+			return updated, err
+		}
+		if err := saveChainCheckpoint(store, cfg.RunID, step+1, updated); err != nil {
+			return updated, fmt.Errorf("checkpoint save failed: %w", err)
+		}
+		step++
+		return updated, nil
+	}
+
+	result, err := ProcessChain(ctx, chainCfg, items[completed:], initial, checkpointed, nil)
+	result.Steps += completed
+	if err != nil {
+		var chainErr *ChainError[TItem, TContext]
+		if errors.As(err, &chainErr) {
+			chainErr.StepIndex += completed
+		}
+		return result, err
+	}
+
+	store.Delete(cfg.RunID)
+	return result, nil
+}
+
+// loadChainCheckpoint returns the completed step count and accumulated context
+// checkpointed for runID. found is false when the store has no checkpoint for
+// the run.
+func loadChainCheckpoint[TContext any](store state.CheckpointStore, runID string) (completed int, current TContext, found bool, err error) {
+	ids, err := store.List()
+	if err != nil {
+		return 0, current, false, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+	if !slices.Contains(ids, runID) {
+		return 0, current, false, nil
+	}
+
+	checkpoint, err := store.Load(runID)
+	if err != nil {
+		return 0, current, false, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	completed, ok := checkpoint.GetInt(chainStepKey)
+	if !ok || completed < 0 {
+		return 0, current, false, fmt.Errorf("checkpoint %s is not a chain checkpoint", runID)
+	}
+
+	value, _ := checkpoint.Get(chainContextKey)
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0, current, false, fmt.Errorf("failed to decode chain context: %w", err)
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return 0, current, false, fmt.Errorf("failed to decode chain context: %w", err)
+	}
+	return completed, current, true, nil
+}
+
+// saveChainCheckpoint stores the accumulated context after completed steps.
+func saveChainCheckpoint[TContext any](store state.CheckpointStore, runID string, completed int, current TContext) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode chain context: %w", err)
+	}
+	var encoded any
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("failed to encode chain context: %w", err)
+	}
+
+	checkpoint := state.New(nil).SetMany(map[string]any{
+		chainStepKey:    completed,
+		chainContextKey: encoded,
+	})
+	checkpoint.RunID = runID
+	return store.Save(checkpoint)
+}
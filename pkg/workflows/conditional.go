@@ -155,7 +155,7 @@ func ProcessConditional[TState any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventRouteEvaluate,
 		Timestamp: time.Now(),
-		Source:    "conditional",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessConditional"),
 		Data: map[string]any{
 			"route_count": len(routes.Handlers),
 		},
@@ -185,7 +185,7 @@ func ProcessConditional[TState any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventRouteSelect,
 		Timestamp: time.Now(),
-		Source:    "conditional",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessConditional"),
 		Data: map[string]any{
 			"route":       route,
 			"has_default": routes.Default != nil,
@@ -212,7 +212,7 @@ func ProcessConditional[TState any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventRouteExecute,
 		Timestamp: time.Now(),
-		Source:    "conditional",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessConditional"),
 		Data: map[string]any{
 			"route": route,
 			"error": false,
@@ -27,6 +27,11 @@
 //
 //	result, err := workflows.ProcessChain(ctx, config.DefaultChainConfig(), questions, initial, processor, nil)
 //
+// Chains whose items do not depend on each other can set ChainConfig.MaxConcurrency
+// to process several items at once. Each step then starts from the initial state and
+// results are merged in item order, so the context type must implement Merger
+// (state.State does).
+//
 // # Parallel Execution Pattern
 //
 // The parallel execution pattern processes items concurrently using a worker pool.
@@ -152,6 +157,7 @@
 //   - Item: Item being processed
 //   - State: Accumulated state at failure
 //   - Err: Underlying error (unwrappable)
+//   - Errors: Every failed step when MaxConcurrency > 1
 //
 // ParallelError (parallel execution):
 //   - Errors: All task failures with context
@@ -29,6 +29,10 @@ type ChainError[TItem, TContext any] struct {
 	// StepIndex is the 0-based index of the step that failed
 	StepIndex int
 
+	// StepName is the ChainConfig.StepNames entry for StepIndex (empty when
+	// the step is unnamed)
+	StepName string
+
 	// Item is the item being processed when the error occurred
 	Item TItem
 
@@ -37,11 +41,24 @@ type ChainError[TItem, TContext any] struct {
 
 	// Err is the underlying error that caused the failure
 	Err error
+
+	// MessageID identifies the hub message involved in the failure, when the
+	// processor's error wraps a messaging.MessageError (empty otherwise)
+	MessageID string
+
+	// Errors lists every failed step in item order when the chain runs with
+	// ChainConfig.MaxConcurrency > 1 (nil for sequential chains). StepIndex and
+	// Item describe the first entry, and Err joins all of them.
+	Errors []TaskError[TItem]
 }
 
-// Error returns a formatted error message with step index context.
+// Error returns a formatted error message with step index context, including
+// the step name when one is configured.
 // Implements the standard error interface.
 func (e *ChainError[TItem, TContext]) Error() string {
+	if e.StepName != "" {
+		return fmt.Sprintf("chain failed at step %d (%s): %v", e.StepIndex, e.StepName, e.Err)
+	}
 	return fmt.Sprintf("chain failed at step %d: %v", e.StepIndex, e.Err)
 }
 
@@ -226,3 +243,105 @@ func (e ConditionalError[TState]) Error() string {
 func (e ConditionalError[TState]) Unwrap() error {
 	return e.Err
 }
+
+// CompensationError captures a failed compensation during saga rollback.
+type CompensationError struct {
+	// StepIndex is the 0-based index of the step whose compensation failed
+	StepIndex int
+
+	// StepName is the name of the step whose compensation failed
+	StepName string
+
+	// Err is the error returned by the Compensate function
+	Err error
+}
+
+// SagaError provides rich error context for saga execution failures.
+//
+// SagaError reports which step failed, the state at the failure point, and any
+// compensations that failed during rollback. A non-empty CompensationErrors slice
+// indicates the saga could not be fully rolled back and may require manual intervention.
+//
+// Example:
+//
+//	_, err := workflows.ProcessSaga(ctx, cfg, steps, initial)
+//	var sagaErr *workflows.SagaError[Order]
+//	if errors.As(err, &sagaErr) {
+//	    fmt.Printf("Step %q failed: %v\n", sagaErr.StepName, sagaErr.Err)
+//	    for _, compErr := range sagaErr.CompensationErrors {
+//	        fmt.Printf("Rollback of %q failed: %v\n", compErr.StepName, compErr.Err)
+//	    }
+//	}
+type SagaError[TContext any] struct {
+	// StepIndex is the 0-based index of the step that failed
+	StepIndex int
+
+	// StepName is the name of the step that failed
+	StepName string
+
+	// State is the accumulated context at the time of failure
+	State TContext
+
+	// CompensationErrors contains all compensations that failed during rollback
+	CompensationErrors []CompensationError
+
+	// Err is the underlying error that caused the saga to fail
+	Err error
+}
+
+// Error returns a formatted error message with step and compensation context.
+func (e *SagaError[TContext]) Error() string {
+	msg := fmt.Sprintf("saga failed at step %d", e.StepIndex)
+	if e.StepName != "" {
+		msg = fmt.Sprintf("saga failed at step %d (%s)", e.StepIndex, e.StepName)
+	}
+
+	if len(e.CompensationErrors) > 0 {
+		return fmt.Sprintf("%s: %v (%d compensations failed)", msg, e.Err, len(e.CompensationErrors))
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+// Unwrap returns the underlying step error, enabling errors.Is and errors.As.
+func (e *SagaError[TContext]) Unwrap() error {
+	return e.Err
+}
+
+// PipelineError provides error context for pipeline stage failures.
+//
+// Identifies the failing stage by index and name, and preserves the value that
+// was passed into the failing stage.
+//
+// Example:
+//
+//	_, err := workflows.ProcessPipeline(ctx, cfg, stages, input)
+//	var pipeErr *workflows.PipelineError[Document]
+//	if errors.As(err, &pipeErr) {
+//	    fmt.Printf("Stage %q failed: %v\n", pipeErr.StageName, pipeErr.Err)
+//	}
+type PipelineError[T any] struct {
+	// StageIndex is the 0-based index of the stage that failed
+	StageIndex int
+
+	// StageName is the name of the stage that failed
+	StageName string
+
+	// Input is the value passed into the failing stage
+	Input T
+
+	// Err is the underlying error that caused the failure
+	Err error
+}
+
+// Error returns a formatted error message with stage index and name.
+func (e *PipelineError[T]) Error() string {
+	if e.StageName == "" {
+		return fmt.Sprintf("pipeline failed at stage %d: %v", e.StageIndex, e.Err)
+	}
+	return fmt.Sprintf("pipeline failed at stage %d (%s): %v", e.StageIndex, e.StageName, e.Err)
+}
+
+// Unwrap returns the underlying error, enabling errors.Is and errors.As.
+func (e *PipelineError[T]) Unwrap() error {
+	return e.Err
+}
@@ -77,6 +77,7 @@ type indexedResult[TResult any] struct {
 //
 // Auto-detection balances concurrency with resource usage. The 2x CPU multiplier is
 // optimal for I/O-bound work like agent API calls.
+// Use ProcessParallelWithPool to run workers on a WorkerPool shared across calls.
 //
 // Error Handling Modes:
 //
@@ -155,6 +156,55 @@ func ProcessParallel[TItem, TResult any](
 	items []TItem,
 	processor TaskProcessor[TItem, TResult],
 	progress ProgressFunc[TResult],
+) (ParallelResult[TItem, TResult], error) {
+	return processParallel(ctx, cfg, items, processor, progress, func(worker func()) error {
+		go worker()
+		return nil
+	})
+}
+
+// ProcessParallelWithPool executes concurrent processing like ProcessParallel,
+// running its workers on pool instead of starting goroutines.
+//
+// The worker count is determined from cfg as for ProcessParallel; the pool
+// further bounds how many of them run at once, so calls sharing a pool share
+// its concurrency. Workers waiting for a pool slot start as earlier calls
+// finish. Returns an error without processing any items when the pool cannot
+// accept a single worker, for example after Close or when ctx ends first.
+//
+// Example:
+//
+//	pool := workflows.NewWorkerPool(config.DefaultWorkerPoolConfig())
+//	defer pool.Close()
+//
+//	for _, batch := range batches {
+//	    result, err := workflows.ProcessParallelWithPool(ctx, pool, cfg, batch, processor, nil)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    save(result.Results)
+//	}
+func ProcessParallelWithPool[TItem, TResult any](
+	ctx context.Context,
+	pool WorkerPool,
+	cfg config.ParallelConfig,
+	items []TItem,
+	processor TaskProcessor[TItem, TResult],
+	progress ProgressFunc[TResult],
+) (ParallelResult[TItem, TResult], error) {
+	return processParallel(ctx, cfg, items, processor, progress, func(worker func()) error {
+		return pool.Submit(ctx, worker)
+	})
+}
+
+// processParallel implements ProcessParallel, starting each worker with spawn.
+func processParallel[TItem, TResult any](
+	ctx context.Context,
+	cfg config.ParallelConfig,
+	items []TItem,
+	processor TaskProcessor[TItem, TResult],
+	progress ProgressFunc[TResult],
+	spawn func(worker func()) error,
 ) (ParallelResult[TItem, TResult], error) {
 	observer, err := observability.GetObserver(cfg.Observer)
 	if err != nil {
@@ -165,7 +215,7 @@ func ProcessParallel[TItem, TResult any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventParallelStart,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessParallel",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 			Data: map[string]any{
 				"item_count":            0,
 				"worker_count":          0,
@@ -177,7 +227,7 @@ func ProcessParallel[TItem, TResult any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventParallelComplete,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessParallel",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 			Data: map[string]any{
 				"items_processed": 0,
 				"items_failed":    0,
@@ -196,7 +246,7 @@ func ProcessParallel[TItem, TResult any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventParallelStart,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessParallel",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 		Data: map[string]any{
 			"item_count":            len(items),
 			"worker_count":          workerCount,
@@ -228,16 +278,26 @@ func ProcessParallel[TItem, TResult any](
 		cancel = func() {}
 	}
 
+	// Queue every item before starting workers. Pool workers that start
+	// before later spawns return must find work, or they would hold their
+	// pool slots while Submit waits for one.
+	for i, item := range items {
+		workQueue <- indexedItem[TItem]{index: i, item: item}
+	}
+	close(workQueue)
+
 	var wg sync.WaitGroup
 	var completed atomic.Int32
 
+	started := 0
+	var spawnErr error
 	for i := range workerCount {
 		wg.Add(1)
-		go func(workerID int) {
+		spawnErr = spawn(func() {
 			defer wg.Done()
 			processWorker(
 				cancelCtx,
-				workerID,
+				i,
 				workQueue,
 				resultChannel,
 				processor,
@@ -248,13 +308,33 @@ func ProcessParallel[TItem, TResult any](
 				cfg.FailFast(),
 				cancel,
 			)
-		}(i)
+		})
+		if spawnErr != nil {
+			wg.Done()
+			break
+		}
+		started++
 	}
 
-	for i, item := range items {
-		workQueue <- indexedItem[TItem]{index: i, item: item}
+	if started == 0 {
+		close(resultChannel)
+		<-done
+
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventParallelComplete,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
+			Data: map[string]any{
+				"items_processed": 0,
+				"items_failed":    0,
+				"error":           true,
+			},
+		})
+		return ParallelResult[TItem, TResult]{
+			Results: []TResult{},
+			Errors:  []TaskError[TItem]{},
+		}, fmt.Errorf("failed to start workers: %w", spawnErr)
 	}
-	close(workQueue)
 
 	wg.Wait()
 	close(resultChannel)
@@ -264,7 +344,7 @@ func ProcessParallel[TItem, TResult any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventParallelComplete,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessParallel",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 			Data: map[string]any{
 				"items_processed": len(results),
 				"items_failed":    len(errors),
@@ -281,7 +361,7 @@ func ProcessParallel[TItem, TResult any](
 		observer.OnEvent(ctx, observability.Event{
 			Type:      observability.EventParallelComplete,
 			Timestamp: time.Now(),
-			Source:    "workflows.ProcessParallel",
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 			Data: map[string]any{
 				"items_processed": len(results),
 				"items_failed":    len(errors),
@@ -299,7 +379,7 @@ func ProcessParallel[TItem, TResult any](
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventParallelComplete,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessParallel",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 				Data: map[string]any{
 					"items_processed": len(results),
 					"items_failed":    len(errors),
@@ -316,7 +396,7 @@ func ProcessParallel[TItem, TResult any](
 	observer.OnEvent(ctx, observability.Event{
 		Type:      observability.EventParallelComplete,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessParallel",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 		Data: map[string]any{
 			"items_processed": len(results),
 			"items_failed":    len(errors),
@@ -393,7 +473,7 @@ func processWorker[TItem, TResult any](
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventWorkerStart,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessParallel",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 				Data: map[string]any{
 					"worker_id":   workerID,
 					"item_index":  work.index,
@@ -406,7 +486,7 @@ func processWorker[TItem, TResult any](
 			observer.OnEvent(ctx, observability.Event{
 				Type:      observability.EventWorkerComplete,
 				Timestamp: time.Now(),
-				Source:    "workflows.ProcessParallel",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 				Data: map[string]any{
 					"worker_id":   workerID,
 					"item_index":  work.index,
@@ -0,0 +1,179 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// PipelineStage is a named transformation within a pipeline.
+//
+// The Name is used in PipelineError messages and observer events so failures
+// can be traced to a meaningful stage rather than an index.
+//
+// Example:
+//
+//	stage := workflows.PipelineStage[Document]{
+//	    Name: "extract",
+//	    Fn: func(ctx context.Context, doc Document) (Document, error) {
+//	        return extractor.Extract(ctx, doc)
+//	    },
+//	}
+type PipelineStage[T any] struct {
+	// Name identifies the stage in errors and observer events
+	Name string
+
+	// Fn transforms the output of the previous stage
+	Fn func(ctx context.Context, input T) (T, error)
+}
+
+// ProcessPipeline executes stages in order, passing each stage's output to the next.
+//
+// Pipelines simplify composing independent transformation steps (including whole
+// ProcessChain or ProcessParallel invocations wrapped in a stage) without manually
+// threading results between calls. Processing stops at the first failing stage.
+// Context cancellation is checked before each stage. Stages are validated before
+// any runs: a stage with a nil Fn fails the pipeline with a PipelineError naming
+// that stage, and no events are emitted.
+//
+// Observer Integration:
+//
+// Emits events at key execution points:
+//   - EventPipelineStart: Before the first stage
+//   - EventStageStart: Before each stage
+//   - EventStageComplete: After each stage (success or failure)
+//   - EventPipelineComplete: When the pipeline finishes
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - cfg: Configuration with observer settings
+//   - stages: Stages to execute in order
+//   - initial: Input to the first stage
+//
+// Returns:
+//   - Output of the final stage (initial when stages is empty)
+//   - PipelineError identifying the failed stage on failure
+//
+// Example:
+//
+//	stages := []workflows.PipelineStage[Document]{
+//	    {Name: "extract", Fn: extract},
+//	    {Name: "classify", Fn: classify},
+//	    {Name: "summarize", Fn: summarize},
+//	}
+//
+//	doc, err := workflows.ProcessPipeline(ctx, config.DefaultPipelineConfig(), stages, doc)
+func ProcessPipeline[T any](
+	ctx context.Context,
+	cfg config.PipelineConfig,
+	stages []PipelineStage[T],
+	initial T,
+) (T, error) {
+	observer, err := observability.GetObserver(cfg.Observer)
+	if err != nil {
+		return initial, fmt.Errorf("failed to resolve observer: %w", err)
+	}
+
+	for i, stage := range stages {
+		if stage.Fn == nil {
+			return initial, &PipelineError[T]{
+				StageIndex: i,
+				StageName:  stage.Name,
+				Input:      initial,
+				Err:        errors.New("stage function cannot be nil"),
+			}
+		}
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventPipelineStart,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessPipeline"),
+		Data: map[string]any{
+			"stage_count": len(stages),
+		},
+	})
+
+	current := initial
+
+	for i, stage := range stages {
+		if err := ctx.Err(); err != nil {
+			return current, failPipeline(ctx, observer, i, stage.Name, current, fmt.Errorf("pipeline cancelled: %w", err))
+		}
+
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventStageStart,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessPipeline"),
+			Data: map[string]any{
+				"stage_index":  i,
+				"stage_name":   stage.Name,
+				"total_stages": len(stages),
+			},
+		})
+
+		output, err := stage.Fn(ctx, current)
+
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventStageComplete,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessPipeline"),
+			Data: map[string]any{
+				"stage_index":  i,
+				"stage_name":   stage.Name,
+				"total_stages": len(stages),
+				"error":        err != nil,
+			},
+		})
+
+		if err != nil {
+			return current, failPipeline(ctx, observer, i, stage.Name, current, err)
+		}
+
+		current = output
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventPipelineComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessPipeline"),
+		Data: map[string]any{
+			"stages_completed": len(stages),
+			"error":            false,
+		},
+	})
+
+	return current, nil
+}
+
+// failPipeline emits the pipeline completion event and builds a PipelineError.
+func failPipeline[T any](
+	ctx context.Context,
+	observer observability.Observer,
+	index int,
+	name string,
+	input T,
+	err error,
+) *PipelineError[T] {
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventPipelineComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessPipeline"),
+		Data: map[string]any{
+			"stages_completed": index,
+			"failed_stage":     name,
+			"error":            true,
+		},
+	})
+
+	return &PipelineError[T]{
+		StageIndex: index,
+		StageName:  name,
+		Input:      input,
+		Err:        err,
+	}
+}
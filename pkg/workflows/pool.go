@@ -0,0 +1,225 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+)
+
+// ErrPoolClosed is returned when submitting to a WorkerPool after Close.
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// ErrPoolQueueFull is returned when a submission waits longer than
+// WorkerPoolConfig.MaxQueueWait for room in the pool's queue.
+var ErrPoolQueueFull = errors.New("worker pool queue is full")
+
+// WorkerPool runs tasks on a bounded set of reusable worker goroutines.
+//
+// A pool can be shared by many ProcessParallelWithPool calls, bounding their
+// combined concurrency and reusing workers instead of starting goroutines per
+// call. Workers start on demand up to WorkerPoolConfig.Workers and, when
+// IdleTimeout is set, exit after sitting idle. Close shuts the pool down
+// gracefully: new submissions fail with ErrPoolClosed while queued and running
+// tasks finish.
+type WorkerPool interface {
+	io.Closer
+
+	// Submit queues task to run on a pool worker, waiting for queue room
+	Submit(ctx context.Context, task func()) error
+
+	// Workers reports the number of running worker goroutines
+	Workers() int
+
+	// Active reports the number of workers currently running a task
+	Active() int
+
+	// QueueDepth reports the number of tasks waiting for a worker
+	QueueDepth() int
+}
+
+type workerPool struct {
+	size         int
+	idleTimeout  time.Duration
+	maxQueueWait time.Duration
+
+	tasks   chan func()
+	closing chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	spawnMu sync.Mutex
+	workers int
+	active  atomic.Int32
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool creates a WorkerPool from cfg. Workers and QueueDepth below 1
+// use the DefaultWorkerPoolConfig values.
+//
+// Example:
+//
+//	pool := workflows.NewWorkerPool(config.DefaultWorkerPoolConfig())
+//	defer pool.Close()
+//
+//	for _, batch := range batches {
+//	    result, err := workflows.ProcessParallelWithPool(ctx, pool, cfg, batch, processor, nil)
+//	    ...
+//	}
+func NewWorkerPool(cfg config.WorkerPoolConfig) WorkerPool {
+	defaults := config.DefaultWorkerPoolConfig()
+	if cfg.Workers < 1 {
+		cfg.Workers = defaults.Workers
+	}
+	if cfg.QueueDepth < 1 {
+		cfg.QueueDepth = defaults.QueueDepth
+	}
+
+	return &workerPool{
+		size:         cfg.Workers,
+		idleTimeout:  cfg.IdleTimeout,
+		maxQueueWait: cfg.MaxQueueWait,
+		tasks:        make(chan func(), cfg.QueueDepth),
+		closing:      make(chan struct{}),
+	}
+}
+
+// Submit queues task and starts a worker when the queue has a backlog and the
+// pool is below its size. When the queue is full it waits for room until ctx
+// ends or MaxQueueWait passes (ErrPoolQueueFull).
+func (p *workerPool) Submit(ctx context.Context, task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	var timeout <-chan time.Time
+	if p.maxQueueWait > 0 {
+		timer := time.NewTimer(p.maxQueueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case p.tasks <- task:
+	case <-timeout:
+		return ErrPoolQueueFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	if p.workers < p.size && len(p.tasks) > 0 {
+		p.workers++
+		p.wg.Add(1)
+		go p.work()
+	}
+	return nil
+}
+
+func (p *workerPool) Workers() int {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	return p.workers
+}
+
+func (p *workerPool) Active() int {
+	return int(p.active.Load())
+}
+
+func (p *workerPool) QueueDepth() int {
+	return len(p.tasks)
+}
+
+// Close stops accepting tasks, then waits for queued and running tasks to
+// finish. Submissions waiting for queue room delay Close until they are
+// queued or give up. Calling Close more than once is safe.
+func (p *workerPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.closing)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	return nil
+}
+
+// work runs queued tasks until the pool closes or the worker retires idle.
+func (p *workerPool) work() {
+	defer p.wg.Done()
+
+	var idle *time.Timer
+	var expired <-chan time.Time
+	if p.idleTimeout > 0 {
+		idle = time.NewTimer(p.idleTimeout)
+		defer idle.Stop()
+		expired = idle.C
+	}
+
+	for {
+		select {
+		case task := <-p.tasks:
+			p.run(task)
+		case <-expired:
+			if p.retire() {
+				return
+			}
+		case <-p.closing:
+			p.drain()
+			return
+		}
+
+		if idle != nil {
+			idle.Reset(p.idleTimeout)
+		}
+	}
+}
+
+func (p *workerPool) run(task func()) {
+	p.active.Add(1)
+	defer p.active.Add(-1)
+	task()
+}
+
+// retire removes an idle worker from the pool unless tasks arrived meanwhile.
+func (p *workerPool) retire() bool {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+
+	if len(p.tasks) > 0 {
+		return false
+	}
+	p.workers--
+	return true
+}
+
+// drain runs the tasks left in the queue at Close. No tasks are queued after
+// the pool closes, so an empty queue means the worker is done.
+func (p *workerPool) drain() {
+	defer func() {
+		p.spawnMu.Lock()
+		p.workers--
+		p.spawnMu.Unlock()
+	}()
+
+	for {
+		select {
+		case task := <-p.tasks:
+			p.run(task)
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// SagaStep defines a single step of a saga with its compensating action.
+//
+// Execute performs the forward operation and returns the updated context.
+// Compensate undoes the effects of Execute and receives the context produced by
+// Execute. Compensate may be nil for steps that have nothing to undo (e.g., reads).
+//
+// Example:
+//
+//	step := workflows.SagaStep[Order]{
+//	    Name: "reserve-inventory",
+//	    Execute: func(ctx context.Context, o Order) (Order, error) {
+//	        o.ReservationID, err = inventory.Reserve(ctx, o.Items)
+//	        return o, err
+//	    },
+//	    Compensate: func(ctx context.Context, o Order) error {
+//	        return inventory.Release(ctx, o.ReservationID)
+//	    },
+//	}
+type SagaStep[TContext any] struct {
+	// Name identifies the step in errors and observer events
+	Name string
+
+	// Execute performs the forward operation
+	Execute func(ctx context.Context, state TContext) (TContext, error)
+
+	// Compensate undoes the forward operation (nil = nothing to undo)
+	Compensate func(ctx context.Context, state TContext) error
+}
+
+// ProcessSaga executes steps in order and compensates completed steps on failure.
+//
+// Implements the saga (compensating transaction) pattern for long-running workflows
+// that touch external systems. Steps execute sequentially with state accumulation.
+// When a step fails, Compensate is called in reverse order for every step that
+// previously completed, each receiving the state produced by its own Execute.
+//
+// Compensation errors are collected but never abort the compensation chain, ensuring
+// every completed step receives a rollback attempt. Compensation runs with a context
+// detached from cancellation so that rollback still occurs when the saga fails due to
+// context cancellation.
+//
+// Observer Integration:
+//
+// Emits events at key execution points:
+//   - EventSagaStart: Before the first step executes
+//   - EventStepStart, EventStepComplete: Around each forward step
+//   - EventCompensateStart, EventCompensateComplete: Around each compensation
+//   - EventSagaComplete: When the saga finishes (success or failure)
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - cfg: Configuration with observer settings
+//   - steps: Steps to execute in order
+//   - initial: Initial state for accumulation
+//
+// Returns:
+//   - Final state on success, or state at the failure point on error
+//   - SagaError describing the failed step and any compensation failures
+//
+// Example:
+//
+//	steps := []workflows.SagaStep[Order]{reserveInventory, chargePayment, scheduleShipping}
+//	order, err := workflows.ProcessSaga(ctx, config.DefaultSagaConfig(), steps, order)
+//	if err != nil {
+//	    var sagaErr *workflows.SagaError[Order]
+//	    if errors.As(err, &sagaErr) && len(sagaErr.CompensationErrors) > 0 {
+//	        log.Printf("manual intervention required: %v", sagaErr)
+//	    }
+//	}
+func ProcessSaga[TContext any](
+	ctx context.Context,
+	cfg config.SagaConfig,
+	steps []SagaStep[TContext],
+	initial TContext,
+) (TContext, error) {
+	observer, err := observability.GetObserver(cfg.Observer)
+	if err != nil {
+		return initial, fmt.Errorf("failed to resolve observer: %w", err)
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventSagaStart,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+		Data: map[string]any{
+			"step_count": len(steps),
+		},
+	})
+
+	states := make([]TContext, 0, len(steps))
+	state := initial
+
+	for i, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return state, compensateSaga(ctx, observer, steps, states, i, state, fmt.Errorf("saga cancelled: %w", err))
+		}
+
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventStepStart,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+			Data: map[string]any{
+				"step_index":  i,
+				"step_name":   step.Name,
+				"total_steps": len(steps),
+			},
+		})
+
+		updated, err := step.Execute(ctx, state)
+
+		observer.OnEvent(ctx, observability.Event{
+			Type:      observability.EventStepComplete,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+			Data: map[string]any{
+				"step_index":  i,
+				"step_name":   step.Name,
+				"total_steps": len(steps),
+				"error":       err != nil,
+			},
+		})
+
+		if err != nil {
+			return state, compensateSaga(ctx, observer, steps, states, i, state, err)
+		}
+
+		state = updated
+		states = append(states, state)
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventSagaComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+		Data: map[string]any{
+			"steps_completed": len(steps),
+			"compensated":     0,
+			"error":           false,
+		},
+	})
+
+	return state, nil
+}
+
+// compensateSaga runs compensations in reverse order for all completed steps.
+//
+// states[i] holds the state produced by steps[i].Execute. Compensation errors are
+// collected without stopping the remaining compensations. Returns a SagaError
+// describing the failure at failedIndex.
+func compensateSaga[TContext any](
+	ctx context.Context,
+	observer observability.Observer,
+	steps []SagaStep[TContext],
+	states []TContext,
+	failedIndex int,
+	state TContext,
+	cause error,
+) *SagaError[TContext] {
+	compensateCtx := context.WithoutCancel(ctx)
+	var compensationErrors []CompensationError
+	compensated := 0
+
+	for i := len(states) - 1; i >= 0; i-- {
+		step := steps[i]
+		if step.Compensate == nil {
+			continue
+		}
+
+		observer.OnEvent(compensateCtx, observability.Event{
+			Type:      observability.EventCompensateStart,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+			Data: map[string]any{
+				"step_index": i,
+				"step_name":  step.Name,
+			},
+		})
+
+		err := step.Compensate(compensateCtx, states[i])
+		if err != nil {
+			compensationErrors = append(compensationErrors, CompensationError{
+				StepIndex: i,
+				StepName:  step.Name,
+				Err:       err,
+			})
+		} else {
+			compensated++
+		}
+
+		observer.OnEvent(compensateCtx, observability.Event{
+			Type:      observability.EventCompensateComplete,
+			Timestamp: time.Now(),
+			Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+			Data: map[string]any{
+				"step_index": i,
+				"step_name":  step.Name,
+				"error":      err != nil,
+			},
+		})
+	}
+
+	observer.OnEvent(compensateCtx, observability.Event{
+		Type:      observability.EventSagaComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessSaga"),
+		Data: map[string]any{
+			"steps_completed":     len(states),
+			"compensated":         compensated,
+			"compensation_failed": len(compensationErrors),
+			"error":               true,
+		},
+	})
+
+	stepName := ""
+	if failedIndex < len(steps) {
+		stepName = steps[failedIndex].Name
+	}
+
+	return &SagaError[TContext]{
+		StepIndex:          failedIndex,
+		StepName:           stepName,
+		State:              state,
+		CompensationErrors: compensationErrors,
+		Err:                cause,
+	}
+}
@@ -0,0 +1,141 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+// GatherFunc merges the results of all scatter processors into a single output.
+//
+// Results are provided in processor order. When ScatterGatherConfig.FailFast is
+// false, results only contain the outputs of processors that succeeded.
+//
+// Example:
+//
+//	gather := func(answers []string) (string, error) {
+//	    return strings.Join(answers, "\n\n"), nil
+//	}
+type GatherFunc[TResult, TOutput any] func(results []TResult) (TOutput, error)
+
+// ProcessScatterGather distributes a single input to multiple processors and merges results.
+//
+// Every processor receives the same input and runs concurrently. Once all processors
+// finish, the collected results are passed to gather, and the gathered output is returned.
+// This is the natural shape of "ask multiple specialized agents the same question, then
+// synthesize their answers".
+//
+// The scatter phase is executed with ProcessParallel, so worker sizing, ordering, and
+// error handling follow the parallel execution semantics:
+//
+// FailFast=true (default):
+//   - First processor error cancels remaining processors
+//   - Gather is not called
+//   - Returns error wrapping ParallelError
+//
+// FailFast=false:
+//   - All processors run to completion
+//   - Gather receives only successful results (in processor order)
+//   - Returns error only if every processor failed
+//
+// Observer Integration:
+//
+// The scatter phase emits the parallel execution events. The gather phase emits:
+//   - EventGatherStart: Before gather is called
+//   - EventGatherComplete: After gather returns (success or failure)
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - cfg: Configuration including worker count, fail-fast mode, and observer
+//   - input: Input distributed to every processor
+//   - processors: Processors that each produce a result from the input
+//   - gather: Function that merges processor results into the output
+//
+// Returns:
+//   - Gathered output on success
+//   - Error wrapping the scatter or gather failure
+//
+// Example:
+//
+//	processors := []workflows.TaskProcessor[string, string]{
+//	    func(ctx context.Context, q string) (string, error) { return legalAgent.Ask(ctx, q) },
+//	    func(ctx context.Context, q string) (string, error) { return financeAgent.Ask(ctx, q) },
+//	}
+//
+//	gather := func(answers []string) (string, error) {
+//	    return synthesizer.Ask(ctx, strings.Join(answers, "\n\n"))
+//	}
+//
+//	answer, err := workflows.ProcessScatterGather(ctx, config.DefaultScatterGatherConfig(), question, processors, gather)
+func ProcessScatterGather[TInput, TResult, TOutput any](
+	ctx context.Context,
+	cfg config.ScatterGatherConfig,
+	input TInput,
+	processors []TaskProcessor[TInput, TResult],
+	gather GatherFunc[TResult, TOutput],
+) (TOutput, error) {
+	var zero TOutput
+
+	observer, err := observability.GetObserver(cfg.Observer)
+	if err != nil {
+		return zero, fmt.Errorf("failed to resolve observer: %w", err)
+	}
+
+	workers := cfg.MaxWorkers
+	if workers <= 0 {
+		workers = len(processors)
+	}
+
+	parallelCfg := config.ParallelConfig{
+		MaxWorkers:  workers,
+		WorkerCap:   len(processors),
+		FailFastNil: cfg.FailFastNil,
+		Observer:    cfg.Observer,
+	}
+
+	indices := make([]int, len(processors))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	scatter := func(ctx context.Context, index int) (TResult, error) {
+		return processors[index](ctx, input)
+	}
+
+	result, err := ProcessParallel(ctx, parallelCfg, indices, scatter, nil)
+	if err != nil {
+		return zero, fmt.Errorf("scatter failed: %w", err)
+	}
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventGatherStart,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessScatterGather"),
+		Data: map[string]any{
+			"processor_count": len(processors),
+			"result_count":    len(result.Results),
+			"failed_count":    len(result.Errors),
+		},
+	})
+
+	output, err := gather(result.Results)
+
+	observer.OnEvent(ctx, observability.Event{
+		Type:      observability.EventGatherComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessScatterGather"),
+		Data: map[string]any{
+			"result_count": len(result.Results),
+			"error":        err != nil,
+		},
+	})
+
+	if err != nil {
+		return zero, fmt.Errorf("gather failed: %w", err)
+	}
+
+	return output, nil
+}
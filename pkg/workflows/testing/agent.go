@@ -0,0 +1,165 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/JaimeStill/go-agents/pkg/agent"
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents/pkg/response"
+)
+
+// scriptedAgent implements agent.Agent by answering text prompts with respond.
+//
+// The embedded go-agents MockAgent supplies the ID, client, provider, and model.
+type scriptedAgent struct {
+	*mock.MockAgent
+	respond func(prompt string) (string, error)
+}
+
+func newScriptedAgent(id string, respond func(prompt string) (string, error)) scriptedAgent {
+	return scriptedAgent{
+		MockAgent: mock.NewMockAgent(mock.WithID(id)),
+		respond:   respond,
+	}
+}
+
+// Chat answers prompt with a single-choice chat response.
+func (a scriptedAgent) Chat(ctx context.Context, prompt string, opts ...map[string]any) (*response.ChatResponse, error) {
+	content, err := a.respond(prompt)
+	if err != nil {
+		return nil, err
+	}
+	return mock.NewSimpleChatAgent(a.ID(), content).Chat(ctx, prompt)
+}
+
+// ChatStream answers prompt with a single streaming chunk.
+func (a scriptedAgent) ChatStream(ctx context.Context, prompt string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	content, err := a.respond(prompt)
+	if err != nil {
+		return nil, err
+	}
+	return mock.NewStreamingChatAgent(a.ID(), []string{content}).ChatStream(ctx, prompt)
+}
+
+// Vision answers prompt like Chat; images are ignored.
+func (a scriptedAgent) Vision(ctx context.Context, prompt string, images []string, opts ...map[string]any) (*response.ChatResponse, error) {
+	return a.Chat(ctx, prompt, opts...)
+}
+
+// VisionStream answers prompt like ChatStream; images are ignored.
+func (a scriptedAgent) VisionStream(ctx context.Context, prompt string, images []string, opts ...map[string]any) (<-chan *response.StreamingChunk, error) {
+	return a.ChatStream(ctx, prompt, opts...)
+}
+
+// Tools is not supported by scripted agents.
+func (a scriptedAgent) Tools(ctx context.Context, prompt string, tools []agent.Tool, opts ...map[string]any) (*response.ToolsResponse, error) {
+	return nil, fmt.Errorf("agent %s does not support tools", a.ID())
+}
+
+// Embed is not supported by scripted agents.
+func (a scriptedAgent) Embed(ctx context.Context, input string, opts ...map[string]any) (*response.EmbeddingsResponse, error) {
+	return nil, fmt.Errorf("agent %s does not support embeddings", a.ID())
+}
+
+// NewMockAgent returns an agent.Agent that answers each prompt with the
+// matching entry in responses. Prompts must match exactly; an unknown prompt
+// fails the call with an error naming it. responses is copied.
+//
+// Example:
+//
+//	agent := wftest.NewMockAgent(map[string]string{"What is AI?": "Artificial intelligence."})
+//	resp, _ := agent.Chat(ctx, "What is AI?")
+//	resp.Content() // "Artificial intelligence."
+func NewMockAgent(responses map[string]string) agent.Agent {
+	responses = maps.Clone(responses)
+	return newScriptedAgent("mock-agent", func(prompt string) (string, error) {
+		content, ok := responses[prompt]
+		if !ok {
+			return "", fmt.Errorf("mock agent has no response for prompt %q", prompt)
+		}
+		return content, nil
+	})
+}
+
+// RecordingAgent is an agent.Agent that records every prompt it receives.
+//
+// By default it answers every prompt with an empty response; configure
+// answers with RespondWith or Respond. RecordingAgent is safe for concurrent
+// use, so it can stand in for agents called from parallel workflows.
+type RecordingAgent struct {
+	scriptedAgent
+
+	mu        sync.Mutex
+	prompts   []string
+	responder func(prompt string) (string, error)
+}
+
+// NewRecordingAgent returns a RecordingAgent with no recorded prompts.
+func NewRecordingAgent() *RecordingAgent {
+	r := &RecordingAgent{}
+	r.scriptedAgent = newScriptedAgent("recording-agent", r.record)
+	return r
+}
+
+// RespondWith answers every later prompt with content and returns r.
+func (r *RecordingAgent) RespondWith(content string) *RecordingAgent {
+	return r.Respond(func(string) (string, error) {
+		return content, nil
+	})
+}
+
+// Respond answers every later prompt with fn and returns r. Errors from fn are
+// returned by the agent call; the prompt is still recorded.
+//
+// Example:
+//
+//	recorder := wftest.NewRecordingAgent().Respond(func(prompt string) (string, error) {
+//	    if strings.Contains(prompt, "invalid") {
+//	        return "", errors.New("rate limited")
+//	    }
+//	    return "ok", nil
+//	})
+func (r *RecordingAgent) Respond(fn func(prompt string) (string, error)) *RecordingAgent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.responder = fn
+	return r
+}
+
+// Prompts returns the prompts received so far, in call order.
+func (r *RecordingAgent) Prompts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.prompts)
+}
+
+// Reset discards the recorded prompts.
+func (r *RecordingAgent) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prompts = nil
+}
+
+func (r *RecordingAgent) record(prompt string) (string, error) {
+	r.mu.Lock()
+	r.prompts = append(r.prompts, prompt)
+	responder := r.responder
+	r.mu.Unlock()
+
+	if responder == nil {
+		return "", nil
+	}
+	return responder(prompt)
+}
+
+var (
+	_ agent.Agent = scriptedAgent{}
+	_ agent.Agent = (*RecordingAgent)(nil)
+)
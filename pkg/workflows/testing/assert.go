@@ -0,0 +1,73 @@
+package testing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+// ChainAssertion checks one aspect of a chain result, reporting failures
+// through t.
+type ChainAssertion[T any] func(t testing.TB, result workflows.ChainResult[T])
+
+// AssertChainResult runs every assertion against result. Assertions report
+// with t.Errorf, so all failures are listed rather than only the first.
+func AssertChainResult[T any](t testing.TB, result workflows.ChainResult[T], assertions ...ChainAssertion[T]) {
+	t.Helper()
+
+	for _, assert := range assertions {
+		assert(t, result)
+	}
+}
+
+// HasSteps asserts that the chain completed n steps.
+func HasSteps[T any](n int) ChainAssertion[T] {
+	return func(t testing.TB, result workflows.ChainResult[T]) {
+		t.Helper()
+		if result.Steps != n {
+			t.Errorf("chain completed %d steps, want %d", result.Steps, n)
+		}
+	}
+}
+
+// FinalEquals asserts that the final state deeply equals want.
+func FinalEquals[T any](want T) ChainAssertion[T] {
+	return func(t testing.TB, result workflows.ChainResult[T]) {
+		t.Helper()
+		if !reflect.DeepEqual(result.Final, want) {
+			t.Errorf("final state = %v, want %v", result.Final, want)
+		}
+	}
+}
+
+// FinalSatisfies asserts that check accepts the final state. check returns a
+// description of the problem, or "" when the state is acceptable.
+//
+// Example:
+//
+//	wftest.FinalSatisfies(func(s state.State) string {
+//	    if !s.Has("summary") {
+//	        return "summary missing"
+//	    }
+//	    return ""
+//	})
+func FinalSatisfies[T any](check func(final T) string) ChainAssertion[T] {
+	return func(t testing.TB, result workflows.ChainResult[T]) {
+		t.Helper()
+		if problem := check(result.Final); problem != "" {
+			t.Errorf("final state %v: %s", result.Final, problem)
+		}
+	}
+}
+
+// HasIntermediateStates asserts that n intermediate states were captured,
+// including the initial state.
+func HasIntermediateStates[T any](n int) ChainAssertion[T] {
+	return func(t testing.TB, result workflows.ChainResult[T]) {
+		t.Helper()
+		if len(result.Intermediate) != n {
+			t.Errorf("captured %d intermediate states, want %d", len(result.Intermediate), n)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package testing provides test doubles and assertions for workflows built
+// on this module, so tests of chains, graph nodes, and processors share one
+// vocabulary instead of hand-rolled agent mocks.
+//
+// The package name shadows the standard library, so import it under an alias:
+//
+//	import (
+//	    "testing"
+//
+//	    wftest "github.com/JaimeStill/go-agents-orchestration/pkg/workflows/testing"
+//	)
+//
+// # Mock Agents
+//
+// NewMockAgent answers prompts from a fixed table, failing on prompts it does
+// not know:
+//
+//	reviewer := wftest.NewMockAgent(map[string]string{
+//	    "Summarize: intro": "An introduction.",
+//	    "Summarize: body":  "The main argument.",
+//	})
+//
+// NewRecordingAgent records every prompt it receives and answers with a
+// configurable response:
+//
+//	recorder := wftest.NewRecordingAgent().RespondWith("approved")
+//	// ... run the workflow ...
+//	if got := recorder.Prompts(); len(got) != 3 {
+//	    t.Errorf("agent called %d times, want 3", len(got))
+//	}
+//
+// Both implement agent.Agent from go-agents and answer Chat, ChatStream,
+// Vision, and VisionStream; Tools and Embed return an error.
+//
+// # Chain Assertions
+//
+// AssertChainResult checks a ChainResult against declarative assertions,
+// reporting every failure:
+//
+//	result, err := workflows.ProcessChain(ctx, cfg, items, initial, processor, nil)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	wftest.AssertChainResult(t, result,
+//	    wftest.HasSteps[string](3),
+//	    wftest.FinalEquals("start->a->b->c"),
+//	)
+package testing
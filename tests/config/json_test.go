@@ -0,0 +1,168 @@
+package config_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"golang.org/x/time/rate"
+)
+
+func TestNewHubConfigFromJSON_InheritsDefaults(t *testing.T) {
+	cfg, err := config.NewHubConfigFromJSON([]byte(`{
+		"name": "processing-hub",
+		"topic_buffer_size": 25,
+		"handler_retry": {"max_attempts": 3}
+	}`))
+	if err != nil {
+		t.Fatalf("NewHubConfigFromJSON() error = %v", err)
+	}
+
+	defaults := config.DefaultHubConfig()
+
+	if cfg.Name != "processing-hub" {
+		t.Errorf("Name = %q, want processing-hub", cfg.Name)
+	}
+	if cfg.TopicBufferSize != 25 {
+		t.Errorf("TopicBufferSize = %d, want 25", cfg.TopicBufferSize)
+	}
+	if cfg.ChannelBufferSize != defaults.ChannelBufferSize {
+		t.Errorf("ChannelBufferSize = %d, want default %d", cfg.ChannelBufferSize, defaults.ChannelBufferSize)
+	}
+	if cfg.DefaultTimeout != defaults.DefaultTimeout {
+		t.Errorf("DefaultTimeout = %v, want default %v", cfg.DefaultTimeout, defaults.DefaultTimeout)
+	}
+	if cfg.PerAgentRateLimit != rate.Inf {
+		t.Errorf("PerAgentRateLimit = %v, want rate.Inf", cfg.PerAgentRateLimit)
+	}
+	if cfg.HandlerRetry.MaxAttempts != 3 {
+		t.Errorf("HandlerRetry.MaxAttempts = %d, want 3", cfg.HandlerRetry.MaxAttempts)
+	}
+	if cfg.HandlerRetry.InitialBackoff != defaults.HandlerRetry.InitialBackoff {
+		t.Errorf("HandlerRetry.InitialBackoff = %v, want default %v",
+			cfg.HandlerRetry.InitialBackoff, defaults.HandlerRetry.InitialBackoff)
+	}
+	if cfg.Logger != slog.Default() {
+		t.Error("Logger should default to slog.Default()")
+	}
+}
+
+func TestNewHubConfigFromJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `{"name":`},
+		{"negative buffer", `{"channel_buffer_size": -1}`},
+		{"negative health check interval", `{"health_check_interval": -1}`},
+		{"zero timeout", `{"default_timeout": 0}`},
+		{"unparseable duration", `{"default_timeout": "soon"}`},
+		{"non-numeric duration", `{"default_timeout": true}`},
+		{"unparseable backoff", `{"handler_retry": {"max_backoff": "5 seconds"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := config.NewHubConfigFromJSON([]byte(tt.data)); err == nil {
+				t.Error("NewHubConfigFromJSON() should fail")
+			}
+		})
+	}
+}
+
+func TestNewHubConfigFromJSON_DurationStrings(t *testing.T) {
+	cfg, err := config.NewHubConfigFromJSON([]byte(`{
+		"default_timeout": "30s",
+		"default_message_ttl": "1m30s",
+		"health_check_interval": 2000000000,
+		"handler_retry": {"initial_backoff": "250ms", "max_backoff": "10s"}
+	}`))
+	if err != nil {
+		t.Fatalf("NewHubConfigFromJSON() error = %v", err)
+	}
+
+	if cfg.DefaultTimeout != 30*time.Second {
+		t.Errorf("DefaultTimeout = %v, want 30s", cfg.DefaultTimeout)
+	}
+	if cfg.DefaultMessageTTL != 90*time.Second {
+		t.Errorf("DefaultMessageTTL = %v, want 1m30s", cfg.DefaultMessageTTL)
+	}
+	if cfg.HealthCheckInterval != 2*time.Second {
+		t.Errorf("HealthCheckInterval = %v, want 2s from nanoseconds", cfg.HealthCheckInterval)
+	}
+	if cfg.HandlerRetry.InitialBackoff != 250*time.Millisecond {
+		t.Errorf("HandlerRetry.InitialBackoff = %v, want 250ms", cfg.HandlerRetry.InitialBackoff)
+	}
+	if cfg.HandlerRetry.MaxBackoff != 10*time.Second {
+		t.Errorf("HandlerRetry.MaxBackoff = %v, want 10s", cfg.HandlerRetry.MaxBackoff)
+	}
+	if cfg.HandlerRetry.Multiplier != config.DefaultRetryPolicy().Multiplier {
+		t.Errorf("HandlerRetry.Multiplier = %v, want default", cfg.HandlerRetry.Multiplier)
+	}
+}
+
+func TestNewGraphConfigFromJSON_InheritsDefaults(t *testing.T) {
+	cfg, err := config.NewGraphConfigFromJSON([]byte(`{
+		"name": "review",
+		"execution_timeout": 60000000000,
+		"checkpoint": {"interval": 2}
+	}`))
+	if err != nil {
+		t.Fatalf("NewGraphConfigFromJSON() error = %v", err)
+	}
+
+	if cfg.Name != "review" {
+		t.Errorf("Name = %q, want review", cfg.Name)
+	}
+	if cfg.ExecutionTimeout != time.Minute {
+		t.Errorf("ExecutionTimeout = %v, want 1m", cfg.ExecutionTimeout)
+	}
+	if cfg.Observer != "slog" {
+		t.Errorf("Observer = %q, want default slog", cfg.Observer)
+	}
+	if cfg.MaxIterations != 1000 {
+		t.Errorf("MaxIterations = %d, want default 1000", cfg.MaxIterations)
+	}
+	if cfg.MaxHistory != 100 {
+		t.Errorf("MaxHistory = %d, want default 100", cfg.MaxHistory)
+	}
+	if cfg.Checkpoint.Interval != 2 {
+		t.Errorf("Checkpoint.Interval = %d, want 2", cfg.Checkpoint.Interval)
+	}
+	if cfg.Checkpoint.Store != "memory" || cfg.Checkpoint.Codec != "json" {
+		t.Errorf("Checkpoint = %+v, want default store and codec", cfg.Checkpoint)
+	}
+}
+
+func TestNewGraphConfigFromJSON_DurationString(t *testing.T) {
+	cfg, err := config.NewGraphConfigFromJSON([]byte(`{"name": "review", "execution_timeout": "5m"}`))
+	if err != nil {
+		t.Fatalf("NewGraphConfigFromJSON() error = %v", err)
+	}
+	if cfg.ExecutionTimeout != 5*time.Minute {
+		t.Errorf("ExecutionTimeout = %v, want 5m", cfg.ExecutionTimeout)
+	}
+}
+
+func TestNewGraphConfigFromJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `[]`},
+		{"missing name", `{"observer": "noop"}`},
+		{"zero max iterations", `{"name": "g", "max_iterations": 0}`},
+		{"negative interval", `{"name": "g", "checkpoint": {"interval": -1}}`},
+		{"negative max versions", `{"name": "g", "checkpoint": {"max_versions": -1}}`},
+		{"unparseable timeout", `{"name": "g", "execution_timeout": "5"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := config.NewGraphConfigFromJSON([]byte(tt.data)); err == nil {
+				t.Error("NewGraphConfigFromJSON() should fail")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"golang.org/x/time/rate"
 )
 
 func TestGraphConfig_DefaultGraphConfig(t *testing.T) {
@@ -20,6 +21,12 @@ func TestGraphConfig_DefaultGraphConfig(t *testing.T) {
 	if cfg.MaxIterations != 1000 {
 		t.Errorf("DefaultGraphConfig().MaxIterations = %v, want %v", cfg.MaxIterations, 1000)
 	}
+	if cfg.TrackHistory {
+		t.Error("DefaultGraphConfig().TrackHistory = true, want false")
+	}
+	if cfg.MaxHistory != 100 {
+		t.Errorf("DefaultGraphConfig().MaxHistory = %v, want %v", cfg.MaxHistory, 100)
+	}
 }
 
 func TestGraphConfig_JSONMarshaling(t *testing.T) {
@@ -97,6 +104,38 @@ func TestGraphConfig_JSONUnmarshalFromString(t *testing.T) {
 	}
 }
 
+func TestCheckpointConfig_MergeOptions(t *testing.T) {
+	base := config.DefaultCheckpointConfig()
+	base.Options = map[string]any{"dir": "/tmp/base", "codec": "json"}
+	shared := base.Options
+
+	base.Merge(&config.CheckpointConfig{Options: map[string]any{"dir": "/tmp/override"}})
+
+	if base.Options["dir"] != "/tmp/override" || base.Options["codec"] != "json" {
+		t.Errorf("Options = %v, want dir overridden and codec kept", base.Options)
+	}
+	if shared["dir"] != "/tmp/base" {
+		t.Error("Merge() modified the original Options map")
+	}
+}
+
+func TestCheckpointConfig_MergeCompression(t *testing.T) {
+	base := config.DefaultCheckpointConfig()
+	if base.Compression != "" {
+		t.Errorf("default Compression = %q, want none", base.Compression)
+	}
+
+	base.Merge(&config.CheckpointConfig{Compression: "gzip"})
+	if base.Compression != "gzip" {
+		t.Errorf("Compression = %q, want gzip", base.Compression)
+	}
+
+	base.Merge(&config.CheckpointConfig{})
+	if base.Compression != "gzip" {
+		t.Errorf("Compression = %q after empty merge, want gzip kept", base.Compression)
+	}
+}
+
 func TestGraphConfig_ObserverAsString(t *testing.T) {
 	cfg := config.GraphConfig{
 		Name:          "test",
@@ -123,7 +162,109 @@ func TestHubConfig_DefaultHubConfig(t *testing.T) {
 		t.Errorf("DefaultHubConfig().DefaultTimeout = %v, want %v",
 			cfg.DefaultTimeout, 30*time.Second)
 	}
+	if cfg.TopicBufferSize != 100 {
+		t.Errorf("DefaultHubConfig().TopicBufferSize = %v, want %v", cfg.TopicBufferSize, 100)
+	}
+	if cfg.PriorityChannelBufferSize != 10 {
+		t.Errorf("DefaultHubConfig().PriorityChannelBufferSize = %v, want %v",
+			cfg.PriorityChannelBufferSize, 10)
+	}
+	if cfg.PerAgentRateLimit != rate.Inf {
+		t.Errorf("DefaultHubConfig().PerAgentRateLimit = %v, want rate.Inf", cfg.PerAgentRateLimit)
+	}
+	if cfg.DeadLetterBufferSize != 100 {
+		t.Errorf("DefaultHubConfig().DeadLetterBufferSize = %v, want %v",
+			cfg.DeadLetterBufferSize, 100)
+	}
+	if cfg.EnableDeduplication {
+		t.Error("DefaultHubConfig().EnableDeduplication = true, want false")
+	}
+	if cfg.DeduplicationWindowSize != 1000 {
+		t.Errorf("DefaultHubConfig().DeduplicationWindowSize = %v, want %v",
+			cfg.DeduplicationWindowSize, 1000)
+	}
 	if cfg.Logger == nil {
 		t.Error("DefaultHubConfig().Logger should not be nil")
 	}
+	if cfg.AuditLog != "" {
+		t.Errorf("DefaultHubConfig().AuditLog = %q, want auditing disabled", cfg.AuditLog)
+	}
+
+	cfg.Merge(&config.HubConfig{AuditLog: "memory"})
+	if cfg.AuditLog != "memory" {
+		t.Errorf("Merge() AuditLog = %q, want memory", cfg.AuditLog)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := config.RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     500 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 3, want: 400 * time.Millisecond},
+		{attempt: 4, want: 500 * time.Millisecond},
+		{attempt: 10, want: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_DefaultDisablesRetry(t *testing.T) {
+	if cfg := config.DefaultHubConfig(); cfg.HandlerRetry.MaxAttempts != 0 {
+		t.Errorf("DefaultHubConfig().HandlerRetry.MaxAttempts = %d, want 0", cfg.HandlerRetry.MaxAttempts)
+	}
+}
+
+func TestChainConfig_WithStepNames(t *testing.T) {
+	names := []string{"a", "b"}
+	base := config.DefaultChainConfig()
+	cfg := base.WithStepNames(names...)
+	names[0] = "changed"
+
+	if len(base.StepNames) != 0 {
+		t.Errorf("WithStepNames() modified the receiver: %v", base.StepNames)
+	}
+	if cfg.StepNames[0] != "a" {
+		t.Errorf("StepNames = %v, want copy of names", cfg.StepNames)
+	}
+}
+
+func TestCheckpointConfig_MergeAsync(t *testing.T) {
+	base := config.DefaultCheckpointConfig()
+	if base.Async || base.AsyncQueueSize != 16 || base.AsyncOverflow != "block" {
+		t.Errorf("defaults = %+v, want sync saves with a 16 save blocking queue", base)
+	}
+
+	called := false
+	base.Merge(&config.CheckpointConfig{
+		Async:          true,
+		AsyncQueueSize: 4,
+		AsyncOverflow:  "drop_oldest",
+		OnAsyncError:   func(string, error) { called = true },
+	})
+	if !base.Async || base.AsyncQueueSize != 4 || base.AsyncOverflow != "drop_oldest" {
+		t.Errorf("merged = %+v, want async settings overridden", base)
+	}
+	base.OnAsyncError("run", nil)
+	if !called {
+		t.Error("OnAsyncError not merged")
+	}
+
+	base.Merge(&config.CheckpointConfig{})
+	if !base.Async || base.AsyncOverflow != "drop_oldest" || base.OnAsyncError == nil {
+		t.Errorf("empty merge = %+v, want async settings kept", base)
+	}
 }
@@ -0,0 +1,20 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+)
+
+func TestWorkerPoolConfig_Merge(t *testing.T) {
+	base := config.DefaultWorkerPoolConfig()
+	if base.Workers < 1 || base.Workers > 16 || base.QueueDepth != 100 || base.IdleTimeout != 30*time.Second {
+		t.Errorf("defaults = %+v", base)
+	}
+
+	base.Merge(&config.WorkerPoolConfig{Workers: 3, MaxQueueWait: time.Second})
+	if base.Workers != 3 || base.MaxQueueWait != time.Second || base.QueueDepth != 100 {
+		t.Errorf("merged = %+v, want Workers and MaxQueueWait overridden", base)
+	}
+}
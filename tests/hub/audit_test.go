@@ -0,0 +1,185 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func createAuditedHub(t *testing.T, auditLog string) hub.Hub {
+	t.Helper()
+
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "audited"
+	cfg.AuditLog = auditLog
+	return hub.New(context.Background(), cfg)
+}
+
+func operations(entries []hub.HubAuditEntry) []string {
+	ops := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ops = append(ops, entry.Operation)
+	}
+	return ops
+}
+
+func TestHub_AuditLog_RecordsOperations(t *testing.T) {
+	h := createAuditedHub(t, "memory")
+	defer shutdownHub(h)
+
+	noop := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), noop)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "b"), noop)
+
+	msg := messaging.NewNotification("agent-a", "agent-b", "hello").Build()
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if err := h.Send(context.Background(), "agent-a", "missing", "hello"); err == nil {
+		t.Fatal("Send() to a missing agent should fail")
+	}
+	h.Pause("agent-b")
+	h.Subscribe("agent-a", "alerts")
+
+	entries, err := h.AuditLog().Query(hub.AuditFilter{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	want := []string{hub.AuditRegister, hub.AuditRegister, hub.AuditSend, hub.AuditSend, hub.AuditPause, hub.AuditSubscribe}
+	if got := operations(entries); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("operations = %v, want %v", got, want)
+	}
+
+	sent := entries[2]
+	if sent.AgentID != "agent-a" || sent.Target != "agent-b" || sent.MessageID != msg.ID || !sent.Success || sent.Hub != "audited" {
+		t.Errorf("send entry = %+v, want successful agent-a -> agent-b for %s", sent, msg.ID)
+	}
+
+	failed := entries[3]
+	if failed.Success || !strings.Contains(failed.Error, "missing") {
+		t.Errorf("failed send entry = %+v, want unsuccessful with error", failed)
+	}
+
+	if subscribed := entries[5]; subscribed.Target != "alerts" {
+		t.Errorf("subscribe Target = %q, want alerts", subscribed.Target)
+	}
+}
+
+func TestHub_AuditLog_Filter(t *testing.T) {
+	h := createAuditedHub(t, "memory")
+	defer shutdownHub(h)
+
+	noop := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), noop)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "b"), noop)
+	h.Send(context.Background(), "agent-a", "agent-b", "first")
+
+	time.Sleep(5 * time.Millisecond)
+	boundary := time.Now()
+	h.Send(context.Background(), "agent-b", "agent-a", "second")
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-c", "c"), noop)
+
+	log := h.AuditLog()
+
+	involvingB, _ := log.Query(hub.AuditFilter{AgentID: "agent-b"})
+	if got := operations(involvingB); strings.Join(got, ",") != "register,send,send" {
+		t.Errorf("agent-b operations = %v, want register and both sends", got)
+	}
+
+	recent, _ := log.Query(hub.AuditFilter{Since: boundary})
+	if got := operations(recent); strings.Join(got, ",") != "send,register" {
+		t.Errorf("operations since boundary = %v, want send, register", got)
+	}
+
+	earlier, _ := log.Query(hub.AuditFilter{Until: boundary, Operation: hub.AuditSend})
+	if len(earlier) != 1 || earlier[0].AgentID != "agent-a" {
+		t.Errorf("sends before boundary = %+v, want the first send", earlier)
+	}
+}
+
+func TestHub_AuditLog_Disabled(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	if h.AuditLog() != nil {
+		t.Error("AuditLog() should be nil when auditing is not configured")
+	}
+
+	unknown := createAuditedHub(t, "not-registered")
+	defer shutdownHub(unknown)
+
+	if unknown.AuditLog() != nil {
+		t.Error("AuditLog() should be nil for an unregistered audit log name")
+	}
+}
+
+func TestFileAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+	hub.RegisterAuditLog("file-audit-test", hub.NewFileAuditLog(path))
+
+	h := createAuditedHub(t, "file-audit-test")
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	})
+	h.Broadcast(context.Background(), messaging.NewNotification("agent-a", "", "news").Build())
+	shutdownHub(h)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], `"operation":"register"`) {
+		t.Fatalf("audit file = %q, want register, broadcast, shutdown lines", data)
+	}
+
+	// A second log over the same file reads the appended entries back.
+	entries, err := hub.NewFileAuditLog(path).Query(hub.AuditFilter{Operation: hub.AuditBroadcast})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].AgentID != "agent-a" || !entries[0].Success {
+		t.Errorf("broadcast entries = %+v, want one successful broadcast from agent-a", entries)
+	}
+
+	missing, err := hub.NewFileAuditLog(filepath.Join(t.TempDir(), "none.ndjson")).Query(hub.AuditFilter{})
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Query() of missing file = %v, %v; want empty", missing, err)
+	}
+}
+
+// failingAuditLog rejects every entry.
+type failingAuditLog struct {
+	hub.HubEventLog
+}
+
+func (failingAuditLog) Append(entry hub.HubAuditEntry) error {
+	return errors.New("audit storage full")
+}
+
+func TestHub_AuditLog_AppendFailureDoesNotFailOperation(t *testing.T) {
+	hub.RegisterAuditLog("failing-audit-test", failingAuditLog{hub.NewMemoryAuditLog()})
+
+	h := createAuditedHub(t, "failing-audit-test")
+	defer shutdownHub(h)
+
+	err := h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Errorf("RegisterAgent() error = %v, want audit failure ignored", err)
+	}
+}
@@ -0,0 +1,125 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func respondAs(id string) hub.MessageHandler {
+	return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse(id, msg.From, msg.ID, id).Build(), nil
+	}
+}
+
+func TestHub_RegisterWithCapabilities(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	agent := mock.NewSimpleChatAgent("worker", "response")
+	err := h.RegisterWithCapabilities(agent, nil, []string{"summarize", "translate", "summarize"})
+	if err != nil {
+		t.Fatalf("RegisterWithCapabilities() error = %v", err)
+	}
+
+	agents := h.ListAgents()
+	if len(agents) != 1 {
+		t.Fatalf("ListAgents() returned %d agents, want 1", len(agents))
+	}
+
+	want := []string{"summarize", "translate"}
+	if !slices.Equal(agents[0].Capabilities, want) {
+		t.Errorf("Capabilities = %v, want %v", agents[0].Capabilities, want)
+	}
+
+	if err := h.RegisterWithCapabilities(agent, nil, nil); err == nil {
+		t.Error("expected error for duplicate registration")
+	}
+}
+
+func TestHub_SendToCapable(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("client", "response"), nil)
+	h.RegisterWithCapabilities(mock.NewSimpleChatAgent("translator", "response"), respondAs("translator"), []string{"translate"})
+	h.RegisterWithCapabilities(mock.NewSimpleChatAgent("summarizer", "response"), respondAs("summarizer"), []string{"summarize"})
+
+	msg := messaging.NewRequest("client", "", "hello").Build()
+
+	response, err := h.SendToCapable(context.Background(), "summarize", msg)
+	if err != nil {
+		t.Fatalf("SendToCapable() error = %v", err)
+	}
+
+	if response.From != "summarizer" {
+		t.Errorf("response from %s, want summarizer", response.From)
+	}
+}
+
+func TestHub_SendToCapable_SpreadsLoad(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("client", "response"), nil)
+	for _, id := range []string{"worker-a", "worker-b"} {
+		h.RegisterWithCapabilities(mock.NewSimpleChatAgent(id, "response"), respondAs(id), []string{"work"})
+	}
+
+	counts := make(map[string]int)
+	for range 4 {
+		msg := messaging.NewRequest("client", "", "task").Build()
+		response, err := h.SendToCapable(context.Background(), "work", msg)
+		if err != nil {
+			t.Fatalf("SendToCapable() error = %v", err)
+		}
+		counts[response.From]++
+
+		// Allow message counts to settle before the next selection
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if counts["worker-a"] != 2 || counts["worker-b"] != 2 {
+		t.Errorf("expected requests spread evenly, got %v", counts)
+	}
+}
+
+func TestHub_SendToCapable_SkipsPausedAndSender(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterWithCapabilities(mock.NewSimpleChatAgent("self", "response"), respondAs("self"), []string{"work"})
+	h.RegisterWithCapabilities(mock.NewSimpleChatAgent("paused", "response"), respondAs("paused"), []string{"work"})
+	h.Pause("paused")
+
+	msg := messaging.NewRequest("self", "", "task").Build()
+	_, err := h.SendToCapable(context.Background(), "work", msg)
+	if err == nil {
+		t.Fatal("expected error when no eligible agent exists")
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterNoCapableAgent {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterNoCapableAgent)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dead letter")
+	}
+}
+
+func TestHub_SendToCapable_AfterShutdown(t *testing.T) {
+	h := createTestHub(t)
+	shutdownHub(h)
+
+	msg := messaging.NewRequest("client", "", "task").Build()
+	if _, err := h.SendToCapable(context.Background(), "work", msg); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("SendToCapable() error = %v, want ErrHubShutdown", err)
+	}
+}
@@ -0,0 +1,222 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func waitForCircuit(t *testing.T, h hub.Hub, agentID string, want hub.CircuitState) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, info := range h.ListAgents() {
+			if info.ID == agentID && info.Circuit == want {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("circuit for %s did not reach %s", agentID, want)
+}
+
+func drainDeadLetters(h hub.Hub) {
+	for {
+		select {
+		case <-h.DeadLetterQueue():
+		default:
+			return
+		}
+	}
+}
+
+func TestHub_CircuitBreaker(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("circuit-capture", observer)
+
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "circuit-hub"
+	cfg.Observer = "circuit-capture"
+	h := hub.New(context.Background(), cfg)
+	defer shutdownHub(h)
+
+	var failing atomic.Bool
+	failing.Store(true)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		if failing.Load() {
+			return nil, errors.New("downstream unavailable")
+		}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("flaky", "response"), handler)
+
+	err := h.SetAgentCircuitBreaker("flaky", config.CircuitBreakerConfig{
+		Threshold:        2,
+		Timeout:          200 * time.Millisecond,
+		HalfOpenAttempts: 2,
+	})
+	if err != nil {
+		t.Fatalf("SetAgentCircuitBreaker() error = %v", err)
+	}
+
+	ctx := context.Background()
+	waitForCircuit(t, h, "flaky", hub.CircuitClosed)
+
+	for range 2 {
+		if err := h.Send(ctx, "sender", "flaky", "task"); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	waitForCircuit(t, h, "flaky", hub.CircuitOpen)
+	drainDeadLetters(h)
+
+	err = h.Send(ctx, "sender", "flaky", "task")
+	if !errors.Is(err, hub.ErrCircuitOpen) {
+		t.Fatalf("Send() error = %v, want ErrCircuitOpen", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterCircuitOpen {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterCircuitOpen)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected circuit open dead letter")
+	}
+
+	failing.Store(false)
+	time.Sleep(250 * time.Millisecond)
+
+	if err := h.Send(ctx, "sender", "flaky", "task"); err != nil {
+		t.Fatalf("Send() after timeout error = %v", err)
+	}
+	waitForCircuit(t, h, "flaky", hub.CircuitHalfOpen)
+
+	if err := h.Send(ctx, "sender", "flaky", "task"); err != nil {
+		t.Fatalf("Send() in half-open error = %v", err)
+	}
+	waitForCircuit(t, h, "flaky", hub.CircuitClosed)
+
+	var transitions []observability.EventType
+	for _, event := range observer.Events() {
+		switch event.Type {
+		case observability.EventHubCircuitOpen, observability.EventHubCircuitHalfOpen, observability.EventHubCircuitClose:
+			transitions = append(transitions, event.Type)
+		}
+	}
+
+	expected := []observability.EventType{
+		observability.EventHubCircuitOpen,
+		observability.EventHubCircuitHalfOpen,
+		observability.EventHubCircuitClose,
+	}
+	if len(transitions) != len(expected) {
+		t.Fatalf("transitions = %v, want %v", transitions, expected)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("transition[%d] = %s, want %s", i, transitions[i], expected[i])
+		}
+	}
+}
+
+func TestHub_CircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, errors.New("still failing")
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("flaky", "response"), handler)
+	h.SetAgentCircuitBreaker("flaky", config.CircuitBreakerConfig{
+		Threshold:        1,
+		Timeout:          200 * time.Millisecond,
+		HalfOpenAttempts: 1,
+	})
+
+	ctx := context.Background()
+	h.Send(ctx, "sender", "flaky", "task")
+	waitForCircuit(t, h, "flaky", hub.CircuitOpen)
+
+	time.Sleep(250 * time.Millisecond)
+	if err := h.Send(ctx, "sender", "flaky", "task"); err != nil {
+		t.Fatalf("Send() after timeout error = %v", err)
+	}
+	waitForCircuit(t, h, "flaky", hub.CircuitOpen)
+
+	if err := h.Send(ctx, "sender", "flaky", "task"); !errors.Is(err, hub.ErrCircuitOpen) {
+		t.Errorf("Send() error = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestHub_CircuitBreaker_Broadcast(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, errors.New("failure")
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("flaky", "response"), handler)
+	h.SetAgentCircuitBreaker("flaky", config.CircuitBreakerConfig{
+		Threshold:        1,
+		Timeout:          time.Minute,
+		HalfOpenAttempts: 1,
+	})
+
+	h.Send(context.Background(), "sender", "flaky", "task")
+	waitForCircuit(t, h, "flaky", hub.CircuitOpen)
+
+	err := h.Broadcast(context.Background(), messaging.NewBroadcast("sender", "announcement").Build())
+
+	var broadcastErr *hub.BroadcastError
+	if !errors.As(err, &broadcastErr) {
+		t.Fatalf("Broadcast() error = %v, want BroadcastError", err)
+	}
+	if len(broadcastErr.Skipped) != 1 || broadcastErr.Skipped[0] != "flaky" {
+		t.Errorf("Skipped = %v, want [flaky]", broadcastErr.Skipped)
+	}
+}
+
+func TestHub_SetAgentCircuitBreaker_Errors(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent", "response"), nil)
+
+	if err := h.SetAgentCircuitBreaker("missing", config.DefaultCircuitBreakerConfig()); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+
+	invalid := []config.CircuitBreakerConfig{
+		{Threshold: 0, Timeout: time.Second, HalfOpenAttempts: 1},
+		{Threshold: 1, Timeout: 0, HalfOpenAttempts: 1},
+		{Threshold: 1, Timeout: time.Second, HalfOpenAttempts: 0},
+	}
+	for _, cfg := range invalid {
+		if err := h.SetAgentCircuitBreaker("agent", cfg); err == nil {
+			t.Errorf("expected error for config %+v", cfg)
+		}
+	}
+
+	for _, info := range h.ListAgents() {
+		if info.Circuit != "" {
+			t.Errorf("Circuit = %s, want empty without a breaker", info.Circuit)
+		}
+	}
+}
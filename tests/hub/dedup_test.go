@@ -0,0 +1,134 @@
+package hub_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func createDedupHub(t *testing.T, observerName string, enabled bool, windowSize int) hub.Hub {
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "dedup-hub"
+	cfg.Observer = observerName
+	cfg.EnableDeduplication = enabled
+	cfg.DeduplicationWindowSize = windowSize
+	return hub.New(context.Background(), cfg)
+}
+
+func countingHandler(count *atomic.Int32) hub.MessageHandler {
+	return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		count.Add(1)
+		return nil, nil
+	}
+}
+
+func TestHub_Deduplication(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("dedup-capture", observer)
+
+	h := createDedupHub(t, "dedup-capture", true, 100)
+	defer shutdownHub(h)
+
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+
+	ctx := context.Background()
+	msg := messaging.NewNotification("sender", "receiver", "payload").Build()
+
+	for range 3 {
+		if err := h.SendMessage(ctx, msg.Clone()); err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if got := count.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+
+	duplicates := 0
+	for _, event := range observer.Events() {
+		if event.Type == observability.EventMessageDuplicate {
+			duplicates++
+			if event.Data["message_id"] != msg.ID {
+				t.Errorf("message_id = %v, want %s", event.Data["message_id"], msg.ID)
+			}
+		}
+	}
+	if duplicates != 2 {
+		t.Errorf("duplicate events = %d, want 2", duplicates)
+	}
+}
+
+func TestHub_Deduplication_PerRecipient(t *testing.T) {
+	h := createDedupHub(t, "noop", true, 100)
+	defer shutdownHub(h)
+
+	var countA, countB atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), countingHandler(&countA))
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), countingHandler(&countB))
+
+	msg := messaging.NewBroadcast("sender", "announcement").Build()
+	if err := h.Broadcast(context.Background(), msg); err != nil {
+		t.Fatalf("Broadcast() error = %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if countA.Load() != 1 || countB.Load() != 1 {
+		t.Errorf("broadcast copies deduplicated across recipients: a=%d b=%d", countA.Load(), countB.Load())
+	}
+}
+
+func TestHub_Deduplication_WindowEviction(t *testing.T) {
+	h := createDedupHub(t, "noop", true, 1)
+	defer shutdownHub(h)
+
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+
+	ctx := context.Background()
+	first := messaging.NewNotification("sender", "receiver", "first").Build()
+	second := messaging.NewNotification("sender", "receiver", "second").Build()
+
+	for _, msg := range []*messaging.Message{first, second, first} {
+		h.SendMessage(ctx, msg.Clone())
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := count.Load(); got != 3 {
+		t.Errorf("handler called %d times, want 3 (first evicted from window)", got)
+	}
+}
+
+func TestHub_Deduplication_Disabled(t *testing.T) {
+	h := createDedupHub(t, "noop", false, 100)
+	defer shutdownHub(h)
+
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+
+	msg := messaging.NewNotification("sender", "receiver", "payload").Build()
+	h.SendMessage(context.Background(), msg.Clone())
+	h.SendMessage(context.Background(), msg.Clone())
+
+	time.Sleep(100 * time.Millisecond)
+
+	if got := count.Load(); got != 2 {
+		t.Errorf("handler called %d times, want 2", got)
+	}
+}
@@ -0,0 +1,136 @@
+package hub_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func createExpiryHub(t *testing.T, observerName string, defaultTTL time.Duration) hub.Hub {
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "expiry-hub"
+	cfg.Observer = observerName
+	cfg.DefaultMessageTTL = defaultTTL
+	return hub.New(context.Background(), cfg)
+}
+
+func expectDeadLetter(t *testing.T, h hub.Hub, reason string) hub.DeadLetter {
+	t.Helper()
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != reason {
+			t.Errorf("dead letter reason = %q, want %q", letter.Reason, reason)
+		}
+		return letter
+	case <-time.After(time.Second):
+		t.Fatalf("no dead letter with reason %q", reason)
+		return hub.DeadLetter{}
+	}
+}
+
+func TestHub_MessageExpiry(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("expiry-capture", observer)
+
+	h := createExpiryHub(t, "expiry-capture", 0)
+	defer shutdownHub(h)
+
+	// The receiver's queue is held back while a slow handler would be running,
+	// so the message waits past its TTL before dispatch.
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+	h.Pause("receiver")
+
+	msg := messaging.NewMessageWithTTL("sender", "receiver", "payload", 20*time.Millisecond).Build()
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	h.Resume("receiver")
+
+	letter := expectDeadLetter(t, h, hub.DeadLetterExpired)
+	if letter.Message.ID != msg.ID {
+		t.Errorf("dead letter message ID = %s, want %s", letter.Message.ID, msg.ID)
+	}
+	if letter.TargetAgent != "receiver" {
+		t.Errorf("dead letter target = %s, want receiver", letter.TargetAgent)
+	}
+
+	if got := count.Load(); got != 0 {
+		t.Errorf("handler called %d times for expired message, want 0", got)
+	}
+
+	found := false
+	for _, event := range observer.Events() {
+		if event.Type == observability.EventMessageExpired && event.Data["message_id"] == msg.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("EventMessageExpired not emitted")
+	}
+}
+
+func TestHub_MessageExpiry_NotExpired(t *testing.T) {
+	h := createExpiryHub(t, "noop", 0)
+	defer shutdownHub(h)
+
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+
+	msg := messaging.NewMessageWithTTL("sender", "receiver", "payload", time.Minute).Build()
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := count.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+}
+
+func TestHub_DefaultMessageTTL(t *testing.T) {
+	h := createExpiryHub(t, "noop", 20*time.Millisecond)
+	defer shutdownHub(h)
+
+	var count atomic.Int32
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), countingHandler(&count))
+	h.Pause("receiver")
+
+	if err := h.Send(context.Background(), "sender", "receiver", "stale"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	h.Resume("receiver")
+
+	expectDeadLetter(t, h, hub.DeadLetterExpired)
+
+	// An explicit ExpiresAt takes precedence over the hub default.
+	h.Pause("receiver")
+	msg := messaging.NewMessageWithTTL("sender", "receiver", "fresh", time.Minute).Build()
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	h.Resume("receiver")
+	time.Sleep(50 * time.Millisecond)
+
+	if got := count.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+}
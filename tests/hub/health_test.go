@@ -0,0 +1,141 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func agentInfo(h hub.Hub, id string) hub.AgentInfo {
+	for _, info := range h.ListAgents() {
+		if info.ID == id {
+			return info
+		}
+	}
+	return hub.AgentInfo{}
+}
+
+func waitForHealth(t *testing.T, h hub.Hub, id string, healthy bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if info := agentInfo(h, id); info.Healthy == healthy && !info.LastHealthCheck.IsZero() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("agent %s did not become healthy=%v", id, healthy)
+}
+
+func TestHub_RegisterWithHealthCheck(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("health-capture", observer)
+
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "health-hub"
+	cfg.Observer = "health-capture"
+	cfg.HealthCheckInterval = 10 * time.Millisecond
+	h := hub.New(context.Background(), cfg)
+	defer shutdownHub(h)
+
+	var failing atomic.Bool
+	probe := func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("backend unreachable")
+		}
+		return nil
+	}
+
+	received := make(chan string, 4)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg.Data.(string)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	if err := h.RegisterWithHealthCheck(mock.NewSimpleChatAgent("worker", "response"), handler, probe); err != nil {
+		t.Fatalf("RegisterWithHealthCheck() error = %v", err)
+	}
+
+	if info := agentInfo(h, "sender"); !info.Healthy || !info.LastHealthCheck.IsZero() {
+		t.Errorf("agent without probe = healthy %v, last check %v", info.Healthy, info.LastHealthCheck)
+	}
+
+	waitForHealth(t, h, "worker", true)
+
+	ctx := context.Background()
+	failing.Store(true)
+	waitForHealth(t, h, "worker", false)
+
+	err := h.Send(ctx, "sender", "worker", "while-down")
+	if !errors.Is(err, hub.ErrAgentUnhealthy) {
+		t.Errorf("Send() to unhealthy agent error = %v, want ErrAgentUnhealthy", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterUnhealthy || letter.TargetAgent != "worker" {
+			t.Errorf("dead letter = %+v, want unhealthy for worker", letter)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no dead letter for unhealthy agent")
+	}
+
+	failing.Store(false)
+	waitForHealth(t, h, "worker", true)
+
+	if err := h.Send(ctx, "sender", "worker", "recovered"); err != nil {
+		t.Fatalf("Send() after recovery error = %v", err)
+	}
+	select {
+	case data := <-received:
+		if data != "recovered" {
+			t.Errorf("received %q, want recovered", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered after recovery")
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	var unhealthy, healthy int
+	for _, event := range observer.events {
+		switch event.Type {
+		case observability.EventHubAgentUnhealthy:
+			unhealthy++
+			if event.Data["error"] != "backend unreachable" {
+				t.Errorf("unhealthy event error = %v", event.Data["error"])
+			}
+		case observability.EventHubAgentHealthy:
+			healthy++
+		}
+	}
+	if unhealthy != 1 || healthy != 1 {
+		t.Errorf("events unhealthy=%d healthy=%d, want 1 each", unhealthy, healthy)
+	}
+}
+
+func TestHub_RegisterWithHealthCheck_ProbeTimeout(t *testing.T) {
+	cfg := config.DefaultHubConfig()
+	cfg.HealthCheckInterval = 10 * time.Millisecond
+	h := hub.New(context.Background(), cfg)
+	defer shutdownHub(h)
+
+	probe := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	h.RegisterWithHealthCheck(mock.NewSimpleChatAgent("stuck", "response"), nil, probe)
+
+	waitForHealth(t, h, "stuck", false)
+}
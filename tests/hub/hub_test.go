@@ -3,6 +3,9 @@ package hub_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,8 +13,34 @@ import (
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"golang.org/x/time/rate"
 )
 
+type captureObserver struct {
+	mu     sync.Mutex
+	events []observability.Event
+}
+
+func (o *captureObserver) OnEvent(ctx context.Context, event observability.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *captureObserver) Events() []observability.Event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]observability.Event(nil), o.events...)
+}
+
+// Helper function to shut down a test hub with a bounded wait
+func shutdownHub(h hub.Hub) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	h.Shutdown(ctx)
+}
+
 // Helper function to create a test hub
 func createTestHub(t *testing.T) hub.Hub {
 	ctx := context.Background()
@@ -22,7 +51,7 @@ func createTestHub(t *testing.T) hub.Hub {
 
 func TestHub_RegisterAgent(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("test-agent", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -43,7 +72,7 @@ func TestHub_RegisterAgent(t *testing.T) {
 
 func TestHub_RegisterAgent_Duplicate(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("test-agent", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -65,7 +94,7 @@ func TestHub_RegisterAgent_Duplicate(t *testing.T) {
 
 func TestHub_UnregisterAgent(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("test-agent", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -93,7 +122,7 @@ func TestHub_UnregisterAgent(t *testing.T) {
 
 func TestHub_UnregisterAgent_NotFound(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	err := h.UnregisterAgent("nonexistent-agent")
 	if err == nil {
@@ -101,9 +130,109 @@ func TestHub_UnregisterAgent_NotFound(t *testing.T) {
 	}
 }
 
+func TestHub_ListAgents(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	if agents := h.ListAgents(); len(agents) != 0 {
+		t.Fatalf("ListAgents() = %v, want empty", agents)
+	}
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+
+	before := time.Now()
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response-b"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response-a"), handler)
+
+	agents := h.ListAgents()
+	if len(agents) != 2 {
+		t.Fatalf("ListAgents() returned %d agents, want 2", len(agents))
+	}
+	if agents[0].ID != "agent-a" || agents[1].ID != "agent-b" {
+		t.Errorf("ListAgents() order = [%s %s], want [agent-a agent-b]", agents[0].ID, agents[1].ID)
+	}
+
+	for _, info := range agents {
+		if info.Status != hub.AgentStatusActive {
+			t.Errorf("%s Status = %s, want %s", info.ID, info.Status, hub.AgentStatusActive)
+		}
+		if info.RegisteredAt.Before(before) {
+			t.Errorf("%s RegisteredAt = %v, want after %v", info.ID, info.RegisteredAt, before)
+		}
+		if info.MessageCount != 0 || !info.LastMessageAt.IsZero() {
+			t.Errorf("%s has message activity before any messages", info.ID)
+		}
+	}
+
+	h.UnregisterAgent("agent-b")
+
+	agents = h.ListAgents()
+	if len(agents) != 1 || agents[0].ID != "agent-a" {
+		t.Errorf("ListAgents() after unregister = %v, want only agent-a", agents)
+	}
+}
+
+func TestHub_ListAgents_MessageCount(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handled := make(chan struct{}, 3)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		handled <- struct{}{}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), handler)
+
+	ctx := context.Background()
+	for range 3 {
+		if err := h.Send(ctx, "sender", "receiver", "ping"); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	for range 3 {
+		select {
+		case <-handled:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for messages")
+		}
+	}
+
+	var receiver, sender hub.AgentInfo
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, info := range h.ListAgents() {
+			switch info.ID {
+			case "receiver":
+				receiver = info
+			case "sender":
+				sender = info
+			}
+		}
+		if receiver.MessageCount == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if receiver.MessageCount != 3 {
+		t.Errorf("receiver MessageCount = %d, want 3", receiver.MessageCount)
+	}
+	if receiver.LastMessageAt.IsZero() {
+		t.Error("receiver LastMessageAt should be set")
+	}
+	if sender.MessageCount != 0 {
+		t.Errorf("sender MessageCount = %d, want 0", sender.MessageCount)
+	}
+}
+
 func TestHub_Send(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	received := make(chan string, 1)
 
@@ -154,9 +283,108 @@ func TestHub_Send(t *testing.T) {
 	}
 }
 
+func TestHub_SendMessage_Priority(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	type delivery struct {
+		data     string
+		priority bool
+	}
+	received := make(chan delivery, 2)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- delivery{data: msg.Data.(string), priority: msgCtx.IsPriority()}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), handler)
+
+	ctx := context.Background()
+	normal := messaging.NewNotification("sender", "receiver", "normal").Build()
+	critical := messaging.NewNotification("sender", "receiver", "critical").
+		Priority(messaging.PriorityCritical).
+		Build()
+
+	if err := h.SendMessage(ctx, normal); err != nil {
+		t.Fatalf("SendMessage(normal) error = %v", err)
+	}
+	if err := h.SendMessage(ctx, critical); err != nil {
+		t.Fatalf("SendMessage(critical) error = %v", err)
+	}
+
+	results := make(map[string]bool)
+	for range 2 {
+		select {
+		case d := <-received:
+			results[d.data] = d.priority
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for messages")
+		}
+	}
+
+	if results["normal"] {
+		t.Error("normal message should not be delivered as priority")
+	}
+	if !results["critical"] {
+		t.Error("critical message should be delivered as priority")
+	}
+}
+
+func TestHub_MessageContext_Headers(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	received := make(chan string, 1)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		headers := msgCtx.Headers()
+		received <- headers["tenant"]
+		headers["tenant"] = "modified"
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), handler)
+
+	msg := messaging.NewNotification("agent-a", "agent-b", "data").
+		Headers(map[string]string{"tenant": "acme"}).
+		Build()
+
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	select {
+	case tenant := <-received:
+		if tenant != "acme" {
+			t.Errorf("Headers()[tenant] = %q, want acme", tenant)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+
+	if msg.Headers["tenant"] != "acme" {
+		t.Errorf("message header modified through Headers(): %q", msg.Headers["tenant"])
+	}
+}
+
+func TestHub_SendMessage_AgentNotFound(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	msg := messaging.NewNotification("sender", "missing", "data").
+		Priority(messaging.PriorityCritical).
+		Build()
+
+	if err := h.SendMessage(context.Background(), msg); err == nil {
+		t.Error("SendMessage() should fail when destination agent not found")
+	}
+}
+
 func TestHub_Send_AgentNotFound(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("agent-a", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -172,9 +400,64 @@ func TestHub_Send_AgentNotFound(t *testing.T) {
 	}
 }
 
+func TestHub_DeadLetterQueue_AgentNotFound(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	ctx := context.Background()
+	if err := h.Send(ctx, "sender", "missing-agent", "lost"); err == nil {
+		t.Fatal("Send() should fail when destination agent not found")
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.TargetAgent != "missing-agent" {
+			t.Errorf("TargetAgent = %s, want missing-agent", letter.TargetAgent)
+		}
+		if letter.Reason != hub.DeadLetterAgentNotFound {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterAgentNotFound)
+		}
+		if letter.Message == nil || letter.Message.Data != "lost" {
+			t.Errorf("Message = %v, want data 'lost'", letter.Message)
+		}
+		if letter.FailedAt.IsZero() {
+			t.Error("FailedAt should be set")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dead letter for unknown agent")
+	}
+}
+
+func TestHub_DeadLetterQueue_ChannelFull(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "test-hub"
+	cfg.ChannelBufferSize = 0
+
+	h := hub.New(ctx, cfg)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("busy", "response"), handler)
+
+	h.Broadcast(ctx, messaging.NewBroadcast("sender", "alert").Build())
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.TargetAgent != "busy" || letter.Reason != hub.DeadLetterChannelFull {
+			t.Errorf("DeadLetter = %s/%s, want busy/%s", letter.TargetAgent, letter.Reason, hub.DeadLetterChannelFull)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dead letter for full channel")
+	}
+}
+
 func TestHub_Request(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agentA := mock.NewSimpleChatAgent("agent-a", "response-a")
 	agentB := mock.NewSimpleChatAgent("agent-b", "response-b")
@@ -208,7 +491,7 @@ func TestHub_Request(t *testing.T) {
 
 func TestHub_Request_Timeout(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agentA := mock.NewSimpleChatAgent("agent-a", "response-a")
 	agentB := mock.NewSimpleChatAgent("agent-b", "response-b")
@@ -235,9 +518,68 @@ func TestHub_Request_Timeout(t *testing.T) {
 	}
 }
 
+func TestHub_SendWithTimeout(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	})
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "b"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("agent-b", msg.From, msg.ID, "done").Build(), nil
+	})
+
+	// The message is addressed by the agentID argument.
+	msg := messaging.NewRequest("agent-a", "", "task").Build()
+	response, err := h.SendWithTimeout(context.Background(), "agent-b", msg, time.Second)
+	if err != nil {
+		t.Fatalf("SendWithTimeout() error = %v", err)
+	}
+	if response.Data != "done" {
+		t.Errorf("Response data = %v, want done", response.Data)
+	}
+	if msg.To != "" {
+		t.Errorf("SendWithTimeout() modified the caller's message: To = %q", msg.To)
+	}
+}
+
+func TestHub_SendWithTimeout_DeadlineExceeded(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "a"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	})
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "b"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		<-release
+		return nil, nil
+	})
+
+	start := time.Now()
+	msg := messaging.NewRequest("agent-a", "agent-b", "task").Build()
+	_, err := h.SendWithTimeout(context.Background(), "agent-b", msg, 50*time.Millisecond)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SendWithTimeout() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SendWithTimeout() returned after %v, want about 50ms (default timeout is 30s)", elapsed)
+	}
+	if messaging.MessageIDOf(err) != msg.ID {
+		t.Errorf("MessageIDOf() = %q, want %q", messaging.MessageIDOf(err), msg.ID)
+	}
+
+	if _, err := h.SendWithTimeout(context.Background(), "agent-b", msg, 0); err == nil {
+		t.Error("SendWithTimeout() with zero timeout should fail")
+	}
+}
+
 func TestHub_Request_AgentNotFound(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("agent-a", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -251,11 +593,15 @@ func TestHub_Request_AgentNotFound(t *testing.T) {
 	if err == nil {
 		t.Error("Request() should fail when destination agent not found")
 	}
+
+	if messaging.MessageIDOf(err) == "" {
+		t.Error("Request() error should carry the request message ID")
+	}
 }
 
 func TestHub_Broadcast(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	received := make(chan string, 3)
 
@@ -282,7 +628,7 @@ func TestHub_Broadcast(t *testing.T) {
 
 	// Broadcast message
 	ctx := context.Background()
-	err := h.Broadcast(ctx, "agent-a", "broadcast-message")
+	err := h.Broadcast(ctx, messaging.NewBroadcast("agent-a", "broadcast-message").Build())
 	if err != nil {
 		t.Fatalf("Broadcast() error = %v", err)
 	}
@@ -307,9 +653,243 @@ func TestHub_Broadcast(t *testing.T) {
 	}
 }
 
+func TestHub_Broadcast_SkipsFullChannels(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("hub-broadcast-capture", observer)
+
+	ctx := context.Background()
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "test-hub"
+	cfg.ChannelBufferSize = 0
+	cfg.Observer = "hub-broadcast-capture"
+
+	h := hub.New(ctx, cfg)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response-a"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-c", "response-c"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response-b"), handler)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Broadcast(ctx, messaging.NewBroadcast("agent-a", "busy").Build())
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Broadcast() blocked on busy agents")
+	}
+
+	var broadcastErr *hub.BroadcastError
+	if !errors.As(err, &broadcastErr) {
+		t.Fatalf("Broadcast() error = %v, want BroadcastError", err)
+	}
+
+	if len(broadcastErr.Skipped) != 2 || broadcastErr.Skipped[0] != "agent-b" || broadcastErr.Skipped[1] != "agent-c" {
+		t.Errorf("Skipped = %v, want [agent-b agent-c]", broadcastErr.Skipped)
+	}
+
+	if len(observer.Events()) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(observer.Events()))
+	}
+
+	event := observer.Events()[0]
+	if event.Type != observability.EventHubBroadcast {
+		t.Errorf("Event type = %s, want %s", event.Type, observability.EventHubBroadcast)
+	}
+	if event.Data["delivered"] != 0 || event.Data["skipped"] != 2 {
+		t.Errorf("Event data = %v, want delivered=0 skipped=2", event.Data)
+	}
+}
+
+func TestHub_PauseResume(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("hub-pause-capture", observer)
+
+	ctx := context.Background()
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "test-hub"
+	cfg.Observer = "hub-pause-capture"
+
+	h := hub.New(ctx, cfg)
+	defer shutdownHub(h)
+
+	var mu sync.Mutex
+	var order []string
+	received := make(chan struct{}, 3)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		mu.Lock()
+		order = append(order, msg.Data.(string))
+		mu.Unlock()
+		received <- struct{}{}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+
+	if err := h.Pause("worker"); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+
+	if info := h.ListAgents()[1]; info.ID != "worker" || info.Status != hub.AgentStatusPaused {
+		t.Errorf("worker status = %s, want %s", info.Status, hub.AgentStatusPaused)
+	}
+
+	h.Send(ctx, "sender", "worker", "first")
+	h.Send(ctx, "sender", "worker", "second")
+
+	select {
+	case <-received:
+		t.Fatal("paused agent should not receive messages")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := h.Resume("worker"); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("held messages not delivered after resume")
+		}
+	}
+
+	if info := h.ListAgents()[1]; info.Status != hub.AgentStatusActive {
+		t.Errorf("worker status = %s, want %s", info.Status, hub.AgentStatusActive)
+	}
+
+	mu.Lock()
+	if len(order) != 2 {
+		t.Errorf("received %v, want [first second]", order)
+	}
+	mu.Unlock()
+
+	if len(observer.Events()) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(observer.Events()))
+	}
+	if observer.Events()[0].Type != observability.EventHubAgentPause {
+		t.Errorf("event 0 = %s, want %s", observer.Events()[0].Type, observability.EventHubAgentPause)
+	}
+	if observer.Events()[1].Type != observability.EventHubAgentResume {
+		t.Errorf("event 1 = %s, want %s", observer.Events()[1].Type, observability.EventHubAgentResume)
+	}
+	if observer.Events()[1].Data["held_messages"] != 2 {
+		t.Errorf("resume held_messages = %v, want 2", observer.Events()[1].Data["held_messages"])
+	}
+}
+
+func TestHub_PauseResume_Errors(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	if err := h.Pause("missing"); err == nil {
+		t.Error("Pause() should fail for unknown agent")
+	}
+	if err := h.Resume("missing"); err == nil {
+		t.Error("Resume() should fail for unknown agent")
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), nil)
+
+	if err := h.Resume("worker"); err == nil {
+		t.Error("Resume() should fail for active agent")
+	}
+	if err := h.Pause("worker"); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := h.Pause("worker"); err == nil {
+		t.Error("Pause() should fail for already paused agent")
+	}
+}
+
+func TestHub_SetAgentRateLimit(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	if err := h.SetAgentRateLimit("missing", rate.Limit(10), 1); err == nil {
+		t.Error("SetAgentRateLimit() should fail for unknown agent")
+	}
+
+	received := make(chan struct{}, 5)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- struct{}{}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("slow", "response"), handler)
+
+	if err := h.SetAgentRateLimit("slow", rate.Limit(20), 1); err != nil {
+		t.Fatalf("SetAgentRateLimit() error = %v", err)
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+	for range 5 {
+		if err := h.Send(ctx, "sender", "slow", "work"); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("5 sends at 20/s completed in %v, want >= 150ms", elapsed)
+	}
+
+	for range 5 {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("rate limited messages should still be delivered")
+		}
+	}
+}
+
+func TestHub_RateLimit_Broadcast(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("limited", "response"), handler)
+	h.SetAgentRateLimit("limited", rate.Limit(0.01), 1)
+
+	ctx := context.Background()
+	if err := h.Broadcast(ctx, messaging.NewBroadcast("sender", "first").Build()); err != nil {
+		t.Fatalf("first Broadcast() error = %v", err)
+	}
+
+	err := h.Broadcast(ctx, messaging.NewBroadcast("sender", "second").Build())
+	var broadcastErr *hub.BroadcastError
+	if !errors.As(err, &broadcastErr) || len(broadcastErr.Skipped) != 1 {
+		t.Fatalf("second Broadcast() error = %v, want BroadcastError skipping limited", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterRateLimited {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterRateLimited)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dead letter for rate limited broadcast")
+	}
+}
+
 func TestHub_Subscribe_Publish(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	received := make(chan string, 2)
 
@@ -347,7 +927,7 @@ func TestHub_Subscribe_Publish(t *testing.T) {
 
 	// Publish message
 	ctx := context.Background()
-	err = h.Publish(ctx, "agent-a", "test-topic", "topic-message")
+	err = h.Publish(ctx, "test-topic", messaging.NewNotification("agent-a", "", "topic-message").Build())
 	if err != nil {
 		t.Fatalf("Publish() error = %v", err)
 	}
@@ -374,7 +954,7 @@ func TestHub_Subscribe_Publish(t *testing.T) {
 
 func TestHub_Subscribe_AgentNotFound(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	err := h.Subscribe("nonexistent-agent", "test-topic")
 	if err == nil {
@@ -384,7 +964,7 @@ func TestHub_Subscribe_AgentNotFound(t *testing.T) {
 
 func TestHub_Publish_NoSubscribers(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agent := mock.NewSimpleChatAgent("agent-a", "response")
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
@@ -395,15 +975,126 @@ func TestHub_Publish_NoSubscribers(t *testing.T) {
 
 	// Publish to topic with no subscribers (should not error)
 	ctx := context.Background()
-	err := h.Publish(ctx, "agent-a", "empty-topic", "message")
+	err := h.Publish(ctx, "empty-topic", messaging.NewNotification("agent-a", "", "message").Build())
 	if err != nil {
 		t.Errorf("Publish() error = %v, should succeed with no subscribers", err)
 	}
 }
 
+func TestHub_Unsubscribe(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("hub-publish-capture", observer)
+
+	ctx := context.Background()
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "test-hub"
+	cfg.Observer = "hub-publish-capture"
+
+	h := hub.New(ctx, cfg)
+	defer shutdownHub(h)
+
+	received := make(chan string, 4)
+	makeSubscriber := func(id string) hub.MessageHandler {
+		return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+			if msg.Topic != "alerts" {
+				t.Errorf("msg.Topic = %s, want alerts", msg.Topic)
+			}
+			received <- id
+			return nil, nil
+		}
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("publisher", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("sub-a", "response"), makeSubscriber("sub-a"))
+	h.RegisterAgent(mock.NewSimpleChatAgent("sub-b", "response"), makeSubscriber("sub-b"))
+
+	h.Subscribe("sub-a", "alerts")
+	h.Subscribe("sub-b", "alerts")
+
+	if err := h.Unsubscribe("sub-b", "alerts"); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+
+	if err := h.Publish(ctx, "alerts", messaging.NewNotification("publisher", "", "alert").Build()); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case id := <-received:
+		if id != "sub-a" {
+			t.Errorf("received by %s, want sub-a", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber did not receive published message")
+	}
+
+	select {
+	case id := <-received:
+		t.Errorf("unexpected delivery to %s", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	events := observer.Events()
+	if len(events) != 1 || events[0].Type != observability.EventHubPublish {
+		t.Fatalf("events = %v, want single publish event", events)
+	}
+	if events[0].Data["topic"] != "alerts" || events[0].Data["subscribers"] != 1 {
+		t.Errorf("publish event data = %v, want topic=alerts subscribers=1", events[0].Data)
+	}
+}
+
+func TestHub_Unsubscribe_Errors(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+
+	if err := h.Unsubscribe("agent-a", "missing-topic"); err == nil {
+		t.Error("Unsubscribe() should fail for unknown topic")
+	}
+
+	h.Subscribe("agent-a", "topic")
+	if err := h.Unsubscribe("agent-b", "topic"); err == nil {
+		t.Error("Unsubscribe() should fail for agent not subscribed")
+	}
+}
+
+func TestHub_Publish_PointToPointCoexist(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	received := make(chan string, 2)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg.Data.(string)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+	h.Subscribe("worker", "jobs")
+
+	ctx := context.Background()
+	h.Send(ctx, "sender", "worker", "direct")
+	h.Publish(ctx, "jobs", messaging.NewNotification("sender", "", "published").Build())
+
+	got := make(map[string]bool)
+	for range 2 {
+		select {
+		case data := <-received:
+			got[data] = true
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for messages")
+		}
+	}
+
+	if !got["direct"] || !got["published"] {
+		t.Errorf("received %v, want direct and published", got)
+	}
+}
+
 func TestHub_UnregisterAgent_CleansUpSubscriptions(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	received := make(chan string, 1)
 
@@ -438,7 +1129,7 @@ func TestHub_UnregisterAgent_CleansUpSubscriptions(t *testing.T) {
 
 	// Publish to topic (agent-b should not receive)
 	ctx := context.Background()
-	err = h.Publish(ctx, "agent-a", "test-topic", "message")
+	err = h.Publish(ctx, "test-topic", messaging.NewNotification("agent-a", "", "message").Build())
 	if err != nil {
 		t.Fatalf("Publish() error = %v", err)
 	}
@@ -455,7 +1146,7 @@ func TestHub_UnregisterAgent_CleansUpSubscriptions(t *testing.T) {
 
 func TestHub_MessageContext(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	contextReceived := make(chan *hub.MessageContext, 1)
 
@@ -499,7 +1190,7 @@ func TestHub_MessageContext(t *testing.T) {
 
 func TestHub_HandlerError(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	agentA := mock.NewSimpleChatAgent("agent-a", "response-a")
 	agentB := mock.NewSimpleChatAgent("agent-b", "response-b")
@@ -532,9 +1223,130 @@ func TestHub_HandlerError(t *testing.T) {
 	}
 }
 
+type transientError struct {
+	retryable bool
+}
+
+func (e transientError) Error() string   { return "transient failure" }
+func (e transientError) Retryable() bool { return e.retryable }
+
+func createRetryHub(t *testing.T, observerName string, maxAttempts int) hub.Hub {
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "test-hub"
+	cfg.Observer = observerName
+	cfg.HandlerRetry.MaxAttempts = maxAttempts
+	cfg.HandlerRetry.InitialBackoff = 5 * time.Millisecond
+	cfg.HandlerRetry.MaxBackoff = 20 * time.Millisecond
+	return hub.New(context.Background(), cfg)
+}
+
+func TestHub_HandlerRetry(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("hub-retry-capture", observer)
+
+	h := createRetryHub(t, "hub-retry-capture", 3)
+	defer shutdownHub(h)
+
+	var calls atomic.Int32
+	done := make(chan struct{})
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		if calls.Add(1) < 3 {
+			return nil, transientError{retryable: true}
+		}
+		close(done)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("flaky", "response"), handler)
+	h.Send(context.Background(), "sender", "flaky", "work")
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("handler did not succeed after retries, calls = %d", calls.Load())
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("handler calls = %d, want 3", calls.Load())
+	}
+
+	retries := 0
+	for _, event := range observer.Events() {
+		if event.Type == observability.EventMessageRetry {
+			retries++
+		}
+	}
+	if retries != 2 {
+		t.Errorf("retry events = %d, want 2", retries)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		t.Errorf("unexpected dead letter: %s", letter.Reason)
+	default:
+	}
+}
+
+func TestHub_HandlerRetry_Exhausted(t *testing.T) {
+	h := createRetryHub(t, "noop", 2)
+	defer shutdownHub(h)
+
+	var calls atomic.Int32
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		calls.Add(1)
+		return nil, fmt.Errorf("wrapped: %w", transientError{retryable: true})
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("broken", "response"), handler)
+	h.Send(context.Background(), "sender", "broken", "work")
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterRetryExhausted {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterRetryExhausted)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected dead letter after retries exhausted")
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("handler calls = %d, want 3 (initial + 2 retries)", calls.Load())
+	}
+}
+
+func TestHub_HandlerRetry_NonRetryable(t *testing.T) {
+	h := createRetryHub(t, "noop", 3)
+	defer shutdownHub(h)
+
+	var calls atomic.Int32
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		calls.Add(1)
+		return nil, transientError{retryable: false}
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("broken", "response"), handler)
+	h.Send(context.Background(), "sender", "broken", "work")
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterHandlerFailed {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterHandlerFailed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected dead letter for non-retryable error")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("handler calls = %d, want 1", calls.Load())
+	}
+}
+
 func TestHub_Metrics(t *testing.T) {
 	h := createTestHub(t)
-	defer h.Shutdown(5 * time.Second)
+	defer shutdownHub(h)
 
 	// Initial metrics should be zero
 	metrics := h.Metrics()
@@ -594,28 +1406,152 @@ func TestHub_Shutdown(t *testing.T) {
 	h.RegisterAgent(agent, handler)
 
 	// Shutdown should complete successfully
-	err := h.Shutdown(5 * time.Second)
+	err := h.Shutdown(context.Background())
 	if err != nil {
 		t.Errorf("Shutdown() error = %v", err)
 	}
+
+	select {
+	case <-h.Done():
+	default:
+		t.Error("Done() should be closed after Shutdown")
+	}
+
+	if !h.IsShutdown() {
+		t.Error("IsShutdown() = false after Shutdown")
+	}
+}
+
+func TestHub_Shutdown_RejectsNewMessages(t *testing.T) {
+	h := createTestHub(t)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), nil)
+
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := h.Send(ctx, "agent-a", "agent-b", "late"); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Send() error = %v, want ErrHubShutdown", err)
+	}
+	if _, err := h.Request(ctx, "agent-a", "agent-b", "late"); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Request() error = %v, want ErrHubShutdown", err)
+	}
+	if err := h.Broadcast(ctx, messaging.NewBroadcast("agent-a", "late").Build()); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Broadcast() error = %v, want ErrHubShutdown", err)
+	}
+	if err := h.RegisterAgent(mock.NewSimpleChatAgent("agent-c", "response"), nil); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("RegisterAgent() error = %v, want ErrHubShutdown", err)
+	}
+
+	if err := h.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown() error = %v", err)
+	}
+}
+
+func TestHub_Shutdown_DrainsInFlight(t *testing.T) {
+	h := createTestHub(t)
+
+	var handled atomic.Int32
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		time.Sleep(20 * time.Millisecond)
+		handled.Add(1)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+	h.Pause("worker")
+
+	ctx := context.Background()
+	for range 5 {
+		h.Send(ctx, "sender", "worker", "work")
+	}
+
+	if err := h.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if handled.Load() != 5 {
+		t.Errorf("handled = %d, want 5 (queued messages should drain)", handled.Load())
+	}
+}
+
+func TestHub_Shutdown_ReportsDraining(t *testing.T) {
+	h := createTestHub(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		close(started)
+		<-release
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+
+	if err := h.Send(context.Background(), "sender", "worker", "work"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- h.Shutdown(context.Background()) }()
+
+	deadline := time.Now().Add(time.Second)
+	for agentInfo(h, "worker").Status != hub.AgentStatusDraining {
+		if time.Now().After(deadline) {
+			t.Fatalf("Status = %s during shutdown, want draining", agentInfo(h, "worker").Status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := agentInfo(h, "sender").Status; got != hub.AgentStatusDraining {
+		t.Errorf("idle agent Status = %s during shutdown, want draining", got)
+	}
+	if err := h.Pause("worker"); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Pause() during shutdown error = %v, want ErrHubShutdown", err)
+	}
+
+	close(release)
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
 }
 
 func TestHub_Shutdown_Timeout(t *testing.T) {
 	h := createTestHub(t)
 
-	agent := mock.NewSimpleChatAgent("test-agent", "response")
+	release := make(chan struct{})
+	defer close(release)
+
 	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
-		// Block forever to test timeout
-		<-make(chan struct{})
+		<-release
 		return nil, nil
 	}
 
-	h.RegisterAgent(agent, handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+	h.Pause("worker")
 
-	// This test would timeout if the shutdown doesn't handle it properly
-	// We'll use a very short timeout to test the timeout path
-	err := h.Shutdown(1 * time.Nanosecond)
-	if err == nil {
-		t.Error("Shutdown() should timeout with very short duration")
+	for range 3 {
+		h.Send(context.Background(), "sender", "worker", "work")
+	}
+	h.Resume("worker")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := h.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	select {
+	case <-h.Done():
+	case <-time.After(time.Second):
+		t.Error("Done() should close after forced shutdown")
 	}
 }
@@ -0,0 +1,79 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func TestHub_Metrics_HandlerOutcomes(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		time.Sleep(10 * time.Millisecond)
+		if msg.Data == "fail" {
+			return nil, errors.New("handler failure")
+		}
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), handler)
+	h.RegisterAgent(mock.NewSimpleChatAgent("idle", "response"), nil)
+	h.Pause("idle")
+
+	ctx := context.Background()
+	for _, data := range []string{"ok", "ok", "fail"} {
+		if err := h.Send(ctx, "sender", "worker", data); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	h.Send(ctx, "sender", "missing", "lost")
+
+	time.Sleep(200 * time.Millisecond)
+
+	metrics := h.Metrics()
+
+	if metrics.TotalMessagesDelivered != 2 {
+		t.Errorf("TotalMessagesDelivered = %d, want 2", metrics.TotalMessagesDelivered)
+	}
+	if metrics.TotalMessagesFailed != 1 {
+		t.Errorf("TotalMessagesFailed = %d, want 1", metrics.TotalMessagesFailed)
+	}
+	if metrics.TotalDeadLettered != 2 {
+		t.Errorf("TotalDeadLettered = %d, want 2 (handler failure + unknown agent)", metrics.TotalDeadLettered)
+	}
+	if metrics.ActiveAgentCount != 2 {
+		t.Errorf("ActiveAgentCount = %d, want 2", metrics.ActiveAgentCount)
+	}
+	if metrics.AverageHandlerLatency < 10*time.Millisecond {
+		t.Errorf("AverageHandlerLatency = %v, want >= 10ms", metrics.AverageHandlerLatency)
+	}
+	if metrics.PeakHandlerLatency < metrics.AverageHandlerLatency {
+		t.Errorf("PeakHandlerLatency = %v, want >= average %v", metrics.PeakHandlerLatency, metrics.AverageHandlerLatency)
+	}
+
+	worker, exists := metrics.AgentMetrics["worker"]
+	if !exists {
+		t.Fatal("AgentMetrics missing worker")
+	}
+	if worker.MessagesDelivered != 2 || worker.MessagesFailed != 1 || worker.DeadLettered != 1 {
+		t.Errorf("worker metrics = %+v, want 2 delivered, 1 failed, 1 dead-lettered", worker)
+	}
+
+	if len(metrics.AgentMetrics) != 3 {
+		t.Errorf("AgentMetrics has %d agents, want 3", len(metrics.AgentMetrics))
+	}
+
+	for _, info := range h.ListAgents() {
+		if info.ID == "worker" && info.Metrics != worker {
+			t.Errorf("AgentInfo.Metrics = %+v, want %+v", info.Metrics, worker)
+		}
+	}
+}
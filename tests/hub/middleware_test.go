@@ -0,0 +1,240 @@
+package hub_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func TestHub_Use_Order(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	tracing := func(name string) hub.MessageMiddleware {
+		return func(next hub.MessageHandler) hub.MessageHandler {
+			return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+				record(name + ":before")
+				response, err := next(ctx, msg, msgCtx)
+				record(name + ":after")
+				return response, err
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		record("handler")
+		close(done)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), handler)
+
+	// Middleware registered after the agent still applies at dispatch
+	h.Use(tracing("outer"))
+	h.Use(tracing("inner"))
+
+	if err := h.Send(context.Background(), "agent-a", "agent-b", "test"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+
+	// Allow middleware to finish unwinding
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	got := strings.Join(calls, ",")
+	mu.Unlock()
+
+	want := "outer:before,inner:before,handler,inner:after,outer:after"
+	if got != want {
+		t.Errorf("call order = %s, want %s", got, want)
+	}
+}
+
+func TestHub_Use_ShortCircuit(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	handlerCalled := make(chan struct{}, 1)
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		handlerCalled <- struct{}{}
+		return nil, nil
+	}
+
+	h.Use(func(next hub.MessageHandler) hub.MessageHandler {
+		return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+			if msg.From != "trusted" {
+				return nil, nil
+			}
+			return next(ctx, msg, msgCtx)
+		}
+	})
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("untrusted", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), handler)
+
+	h.Send(context.Background(), "untrusted", "agent-b", "test")
+
+	select {
+	case <-handlerCalled:
+		t.Error("handler should not be called when middleware short-circuits")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.Use(hub.RecoveryMiddleware())
+
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		panic("boom")
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), handler)
+
+	if err := h.Send(context.Background(), "agent-a", "agent-b", "test"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Reason != hub.DeadLetterHandlerFailed {
+			t.Errorf("Reason = %s, want %s", letter.Reason, hub.DeadLetterHandlerFailed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected recovered panic to be dead-lettered")
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	h.Use(hub.LoggingMiddleware(logger))
+
+	done := make(chan struct{})
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		defer close(done)
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), handler)
+
+	h.Send(context.Background(), "agent-a", "agent-b", "test")
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	output := buf.String()
+	for _, want := range []string{"message handled", "agent_id=agent-b", "from=agent-a", "duration="} {
+		if !strings.Contains(output, want) {
+			t.Errorf("log output missing %q: %s", want, output)
+		}
+	}
+}
+
+func TestLoggingMiddleware_StandardHeaders(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	h.Use(hub.LoggingMiddleware(logger))
+
+	responses := make(chan *messaging.Message, 1)
+	requester := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		responses <- msg
+		return nil, nil
+	}
+	responder := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("agent-b", "agent-a", msg.ID, "done").
+			Headers(map[string]string{messaging.HeaderTraceID: "trace-own"}).
+			Build(), nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-a", "response"), requester)
+	h.RegisterAgent(mock.NewSimpleChatAgent("agent-b", "response"), responder)
+
+	msg := messaging.NewRequest("agent-a", "agent-b", "task").
+		Headers(map[string]string{
+			messaging.HeaderCorrelationID: "corr-1",
+			messaging.HeaderTraceID:       "trace-1",
+		}).
+		Build()
+
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	var response *messaging.Message
+	select {
+	case response = <-responses:
+	case <-time.After(time.Second):
+		t.Fatal("response was not delivered")
+	}
+
+	if got, _ := response.GetHeader(messaging.HeaderCorrelationID); got != "corr-1" {
+		t.Errorf("response correlation ID = %q, want corr-1", got)
+	}
+	if got, _ := response.GetHeader(messaging.HeaderTraceID); got != "trace-own" {
+		t.Errorf("response trace ID = %q, want handler-set trace-own", got)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"correlation_id=corr-1", "trace_id=trace-1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("log output missing %q: %s", want, output)
+		}
+	}
+}
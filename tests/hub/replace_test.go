@@ -0,0 +1,126 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func TestHub_Replace(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("replace-capture", observer)
+
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "replace-hub"
+	cfg.Observer = "replace-capture"
+	h := hub.New(context.Background(), cfg)
+	defer shutdownHub(h)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handled := make(chan string, 2)
+
+	var once sync.Once
+	handler := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		if msg.Data == "slow" {
+			once.Do(func() { close(started) })
+			<-release
+		}
+		handled <- msgCtx.Agent.ID()
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	if err := h.RegisterAgent(mock.NewSimpleChatAgent("worker-v1", "response"), handler); err != nil {
+		t.Fatalf("RegisterAgent() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := h.Send(ctx, "sender", "worker-v1", "slow"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-started
+
+	if err := h.Replace("worker-v1", mock.NewSimpleChatAgent("worker-v2", "response")); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if got := agentInfo(h, "worker-v1").Status; got != hub.AgentStatusDraining {
+		t.Errorf("Status = %s while the old agent has a handler running, want draining", got)
+	}
+
+	if err := h.Send(ctx, "sender", "worker-v1", "fast"); err != nil {
+		t.Fatalf("Send() after Replace error = %v", err)
+	}
+
+	select {
+	case id := <-handled:
+		if id != "worker-v2" {
+			t.Errorf("message after Replace handled by %s, want worker-v2", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message after Replace was not handled")
+	}
+
+	close(release)
+	if id := <-handled; id != "worker-v1" {
+		t.Errorf("in-flight message handled by %s, want worker-v1", id)
+	}
+
+	if got := agentInfo(h, "worker-v1").Version; got != 2 {
+		t.Errorf("Version = %d, want 2", got)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for agentInfo(h, "worker-v1").Status != hub.AgentStatusActive {
+		if time.Now().After(deadline) {
+			t.Fatal("Status did not return to active after the old agent drained")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var replaced *observability.Event
+	observer.mu.Lock()
+	for _, event := range observer.events {
+		if event.Type == observability.EventHubAgentReplace {
+			replaced = &event
+		}
+	}
+	observer.mu.Unlock()
+
+	if replaced == nil {
+		t.Fatal("no EventHubAgentReplace emitted")
+	}
+	if replaced.Data["old_agent_id"] != "worker-v1" || replaced.Data["new_agent_id"] != "worker-v2" {
+		t.Errorf("event data = %v", replaced.Data)
+	}
+	if replaced.Data["draining_count"] != int64(1) {
+		t.Errorf("draining_count = %v, want 1", replaced.Data["draining_count"])
+	}
+}
+
+func TestHub_Replace_Errors(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), nil)
+
+	if err := h.Replace("missing", mock.NewSimpleChatAgent("new", "response")); !errors.Is(err, hub.ErrAgentNotFound) {
+		t.Errorf("Replace() unknown agent error = %v, want ErrAgentNotFound", err)
+	}
+	if err := h.Replace("worker", nil); err == nil {
+		t.Error("Replace() with nil agent should fail")
+	}
+
+	shutdownHub(h)
+	if err := h.Replace("worker", mock.NewSimpleChatAgent("new", "response")); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Replace() after shutdown error = %v, want ErrHubShutdown", err)
+	}
+}
@@ -0,0 +1,156 @@
+package hub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func collectingHandler(received chan<- *messaging.Message) hub.MessageHandler {
+	return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg
+		return nil, nil
+	}
+}
+
+func expectMessage(t *testing.T, received <-chan *messaging.Message) *messaging.Message {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("message not received")
+		return nil
+	}
+}
+
+func TestHub_ReplyToAgent_RoutesResponse(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	senderInbox := make(chan *messaging.Message, 1)
+	collectorInbox := make(chan *messaging.Message, 1)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("worker", "", "", "done").Build(), nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), collectingHandler(senderInbox))
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), worker)
+	h.RegisterAgent(mock.NewSimpleChatAgent("collector", "response"), collectingHandler(collectorInbox))
+
+	request := messaging.NewRequest("sender", "worker", "task").
+		ReplyToAgent("collector").
+		Build()
+
+	if err := h.SendMessage(context.Background(), request); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	reply := expectMessage(t, collectorInbox)
+	if reply.ReplyTo != request.ID {
+		t.Errorf("reply ReplyTo = %q, want %q", reply.ReplyTo, request.ID)
+	}
+	if reply.To != "collector" {
+		t.Errorf("reply To = %q, want collector", reply.To)
+	}
+
+	select {
+	case msg := <-senderInbox:
+		t.Errorf("sender received %v, want reply routed to collector", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_Response_DefaultsToSender(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	senderInbox := make(chan *messaging.Message, 1)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("worker", "", "", "done").Build(), nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), collectingHandler(senderInbox))
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), worker)
+
+	request := messaging.NewRequest("sender", "worker", "task").Build()
+	if err := h.SendMessage(context.Background(), request); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	reply := expectMessage(t, senderInbox)
+	if reply.ReplyTo != request.ID {
+		t.Errorf("reply ReplyTo = %q, want %q", reply.ReplyTo, request.ID)
+	}
+}
+
+func TestMessageContext_SendReply(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	senderInbox := make(chan *messaging.Message, 1)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			reply := messaging.NewResponse(msgCtx.Agent.ID(), "", "", "later").Build()
+			if err := msgCtx.SendReply(context.Background(), reply); err != nil {
+				t.Errorf("SendReply() error = %v", err)
+			}
+		}()
+		return nil, nil
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), collectingHandler(senderInbox))
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), worker)
+
+	request := messaging.NewRequest("sender", "worker", "task").Build()
+	if err := h.SendMessage(context.Background(), request); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	reply := expectMessage(t, senderInbox)
+	if reply.Data != "later" {
+		t.Errorf("reply Data = %v, want later", reply.Data)
+	}
+	if reply.ReplyTo != request.ID {
+		t.Errorf("reply ReplyTo = %q, want %q", reply.ReplyTo, request.ID)
+	}
+}
+
+func TestMessageContext_SendReply_Request(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		reply := messaging.NewResponse(msgCtx.Agent.ID(), "", "", "ack").Build()
+		return nil, msgCtx.SendReply(ctx, reply)
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("worker", "response"), worker)
+
+	response, err := h.Request(context.Background(), "sender", "worker", "task")
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if response.Data != "ack" {
+		t.Errorf("response Data = %v, want ack", response.Data)
+	}
+}
+
+func TestMessageContext_SendReply_Unbound(t *testing.T) {
+	msgCtx := &hub.MessageContext{HubName: "detached"}
+
+	reply := messaging.NewResponse("a", "b", "id", "data").Build()
+	if err := msgCtx.SendReply(context.Background(), reply); err == nil {
+		t.Error("SendReply() on unbound context should fail")
+	}
+}
@@ -0,0 +1,166 @@
+package hub_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func TestHub_AddRoutingRule(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	received := make(chan string, 4)
+	handler := func(id string) hub.MessageHandler {
+		return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+			received <- id + ":" + msg.To
+			return nil, nil
+		}
+	}
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("fast-agent", "response"), handler("fast-agent"))
+	h.RegisterAgent(mock.NewSimpleChatAgent("slow-agent", "response"), handler("slow-agent"))
+	h.RegisterAgent(mock.NewSimpleChatAgent("default-agent", "response"), handler("default-agent"))
+
+	rules := []hub.RoutingRule{
+		{
+			Name: "high-priority-json",
+			When: hub.RouteCondition{
+				ContentType: "application/json",
+				Headers:     map[string]string{"X-Priority": "high"},
+			},
+			TargetAgent: "fast-agent",
+		},
+		{
+			Name:        "large",
+			Match:       func(msg *messaging.Message) bool { s, _ := msg.Data.(string); return len(s) > 10 },
+			TargetAgent: "slow-agent",
+		},
+	}
+	for _, rule := range rules {
+		if err := h.AddRoutingRule(rule); err != nil {
+			t.Fatalf("AddRoutingRule() error = %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		msg  *messaging.Message
+		want string
+	}{
+		{
+			name: "first rule",
+			msg: messaging.NewNotification("sender", "default-agent", "a very long payload").
+				ContentType("application/json").
+				Headers(map[string]string{"X-Priority": "high"}).
+				Build(),
+			want: "fast-agent:fast-agent",
+		},
+		{
+			name: "second rule",
+			msg:  messaging.NewNotification("sender", "default-agent", "a very long payload").Build(),
+			want: "slow-agent:slow-agent",
+		},
+		{
+			name: "fallback",
+			msg:  messaging.NewNotification("sender", "default-agent", "short").ContentType("application/json").Build(),
+			want: "default-agent:default-agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalTo := tt.msg.To
+			if err := h.SendMessage(ctx, tt.msg); err != nil {
+				t.Fatalf("SendMessage() error = %v", err)
+			}
+			if tt.msg.To != originalTo {
+				t.Errorf("caller's message To changed to %s", tt.msg.To)
+			}
+
+			select {
+			case got := <-received:
+				if got != tt.want {
+					t.Errorf("received %s, want %s", got, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("message not delivered")
+			}
+		})
+	}
+}
+
+func TestHub_AddRoutingRule_Request(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("requester", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("v2", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse(msg.To, msg.From, msg.ID, "from v2").Build(), nil
+	})
+
+	h.AddRoutingRule(hub.RoutingRule{When: hub.RouteCondition{To: "v1"}, TargetAgent: "v2"})
+
+	response, err := h.Request(context.Background(), "requester", "v1", "hello")
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if response.Data != "from v2" {
+		t.Errorf("response = %v, want from v2", response.Data)
+	}
+}
+
+func TestHub_AddRoutingRule_Invalid(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	if err := h.AddRoutingRule(hub.RoutingRule{Name: "empty"}); err == nil {
+		t.Error("AddRoutingRule() without target should fail")
+	}
+}
+
+func TestRoutingRule_JSON(t *testing.T) {
+	data := `{
+		"name": "high-priority-json",
+		"when": {
+			"content_type": "application/json",
+			"headers": {"X-Priority": "high"},
+			"min_priority": 2
+		},
+		"target_agent": "fast-agent"
+	}`
+
+	var rule hub.RoutingRule
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := hub.RouteCondition{
+		ContentType: "application/json",
+		Headers:     map[string]string{"X-Priority": "high"},
+		MinPriority: messaging.PriorityHigh,
+	}
+	if rule.TargetAgent != "fast-agent" || !reflect.DeepEqual(rule.When, want) {
+		t.Errorf("rule = %+v", rule)
+	}
+
+	encoded, err := json.Marshal(hub.RoutingRule{
+		TargetAgent: "x",
+		Match:       func(*messaging.Message) bool { return true },
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(encoded), "match") {
+		t.Errorf("Marshal() = %s, Match should not be serialized", encoded)
+	}
+}
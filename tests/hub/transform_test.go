@@ -0,0 +1,355 @@
+package hub_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+func appendTransformer(suffix string) hub.MessageTransformer {
+	return func(msg *messaging.Message) (*messaging.Message, error) {
+		out := msg.Clone()
+		out.Data = msg.Data.(string) + suffix
+		return out, nil
+	}
+}
+
+func receiveMessage(t *testing.T, received <-chan *messaging.Message) *messaging.Message {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+		return nil
+	}
+}
+
+func TestHub_AddTransformer_Order(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	received := make(chan *messaging.Message, 1)
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg
+		return nil, nil
+	})
+
+	h.AddTransformer(appendTransformer("-a"))
+	h.AddTransformer(appendTransformer("-b"))
+
+	original := messaging.NewNotification("sender", "receiver", "data").Build()
+	if err := h.SendMessage(context.Background(), original); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	if got := receiveMessage(t, received).Data; got != "data-a-b" {
+		t.Errorf("handler received %v, want data-a-b", got)
+	}
+	if original.Data != "data" {
+		t.Errorf("caller's message modified: %v", original.Data)
+	}
+}
+
+func TestHub_AddTransformer_BeforeRouting(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	received := make(chan *messaging.Message, 1)
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("default", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		t.Error("message was not rerouted")
+		return nil, nil
+	})
+	h.RegisterAgent(mock.NewSimpleChatAgent("json-agent", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg
+		return nil, nil
+	})
+
+	h.AddRoutingRule(hub.RoutingRule{
+		Name:        "json",
+		When:        hub.RouteCondition{ContentType: messaging.ContentTypeJSON},
+		TargetAgent: "json-agent",
+	})
+	h.AddTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		out := msg.Clone()
+		out.ContentType = messaging.ContentTypeJSON
+		return out, nil
+	})
+
+	msg := messaging.NewNotification("sender", "default", "data").Build()
+	msg.ContentType = messaging.ContentTypeText
+	if err := h.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	receiveMessage(t, received)
+}
+
+func TestHub_AddTransformer_ErrorDeadLetters(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("sender", "response"), nil)
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		t.Error("handler called for rejected message")
+		return nil, nil
+	})
+
+	h.AddTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		return nil, errors.New("bad signature")
+	})
+
+	msg := messaging.NewNotification("sender", "receiver", "data").Build()
+	err := h.SendMessage(context.Background(), msg)
+	if !errors.Is(err, hub.ErrTransformFailed) {
+		t.Fatalf("SendMessage() error = %v, want ErrTransformFailed", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if letter.Message.ID != msg.ID {
+			t.Errorf("dead letter message = %s, want %s", letter.Message.ID, msg.ID)
+		}
+		if !strings.HasPrefix(letter.Reason, hub.DeadLetterTransformFailed) || !strings.Contains(letter.Reason, "bad signature") {
+			t.Errorf("dead letter reason = %q, want transform failure with error", letter.Reason)
+		}
+		if letter.TargetAgent != "receiver" {
+			t.Errorf("dead letter target = %q, want receiver", letter.TargetAgent)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no dead letter")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestHub_AddTransformer_NilMessage(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("receiver", "response"), nil)
+	h.AddTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		return nil, nil
+	})
+
+	err := h.Send(context.Background(), "sender", "receiver", "data")
+	if !errors.Is(err, hub.ErrTransformFailed) {
+		t.Errorf("Send() error = %v, want ErrTransformFailed", err)
+	}
+}
+
+func TestHub_AddTransformer_AllEntryPoints(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	var calls []string
+	h.AddTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		calls = append(calls, string(msg.Type))
+		return nil, errors.New("rejected")
+	})
+
+	ctx := context.Background()
+	msg := messaging.NewNotification("sender", "receiver", "data").Build()
+
+	errs := map[string]error{
+		"Broadcast": h.Broadcast(ctx, msg),
+		"Publish":   h.Publish(ctx, "topic", msg),
+	}
+	_, errs["Request"] = h.Request(ctx, "sender", "receiver", "data")
+	_, errs["RequestMessage"] = h.RequestMessage(ctx, msg)
+	_, errs["SendToCapable"] = h.SendToCapable(ctx, "summarize", msg)
+
+	for name, err := range errs {
+		if !errors.Is(err, hub.ErrTransformFailed) {
+			t.Errorf("%s() error = %v, want ErrTransformFailed", name, err)
+		}
+	}
+	if len(calls) != len(errs) {
+		t.Errorf("transformer called %d times, want %d", len(calls), len(errs))
+	}
+}
+
+func TestHub_AddResponseTransformer(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("responder", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("responder", msg.From, msg.ID, "reply").Build(), nil
+	})
+
+	var incoming int
+	h.AddTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		incoming++
+		return msg, nil
+	})
+	h.AddResponseTransformer(appendTransformer("-signed"))
+
+	response, err := h.Request(context.Background(), "requester", "responder", "question")
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+
+	if response.Data != "reply-signed" {
+		t.Errorf("response data = %v, want reply-signed", response.Data)
+	}
+	if incoming != 1 {
+		t.Errorf("incoming transformer called %d times, want 1 (request only)", incoming)
+	}
+}
+
+func TestHub_AddResponseTransformer_ErrorDeadLetters(t *testing.T) {
+	h := createTestHub(t)
+	defer shutdownHub(h)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("responder", "response"), func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("responder", msg.From, msg.ID, "reply").Build(), nil
+	})
+	h.AddResponseTransformer(func(msg *messaging.Message) (*messaging.Message, error) {
+		return nil, errors.New("encrypt failed")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := h.Request(ctx, "requester", "responder", "question"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Request() error = %v, want no response before deadline", err)
+	}
+
+	select {
+	case letter := <-h.DeadLetterQueue():
+		if !letter.Message.IsResponse() || !strings.Contains(letter.Reason, "encrypt failed") {
+			t.Errorf("dead letter = %+v, want rejected response", letter)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no dead letter")
+	}
+}
+
+func TestHub_AddTransformer_Validation(t *testing.T) {
+	h := createTestHub(t)
+
+	if err := h.AddTransformer(nil); err == nil {
+		t.Error("AddTransformer(nil) expected error")
+	}
+	if err := h.AddResponseTransformer(nil); err == nil {
+		t.Error("AddResponseTransformer(nil) expected error")
+	}
+
+	shutdownHub(h)
+
+	if err := h.AddTransformer(hub.NewTracingHeaderTransformer()); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("AddTransformer() after shutdown error = %v, want ErrHubShutdown", err)
+	}
+}
+
+func TestNewDecompressionTransformer(t *testing.T) {
+	payload := []byte(`{"document":"contract.pdf"}`)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write(payload)
+	gw.Close()
+
+	var fl bytes.Buffer
+	fw, _ := flate.NewWriter(&fl, flate.DefaultCompression)
+	fw.Write(payload)
+	fw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		data     any
+		want     any
+		wantErr  bool
+	}{
+		{name: "gzip", encoding: "gzip", data: gz.Bytes(), want: payload},
+		{name: "gzip string", encoding: "GZIP", data: gz.String(), want: payload},
+		{name: "deflate", encoding: "deflate", data: fl.Bytes(), want: payload},
+		{name: "no encoding", data: "plain", want: "plain"},
+		{name: "identity", encoding: "identity", data: "plain", want: "plain"},
+		{name: "unsupported", encoding: "br", data: []byte("x"), wantErr: true},
+		{name: "corrupt", encoding: "gzip", data: []byte("not gzip"), wantErr: true},
+		{name: "non-byte data", encoding: "gzip", data: map[string]any{}, wantErr: true},
+	}
+
+	transform := hub.NewDecompressionTransformer()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := messaging.NewNotification("a", "b", tt.data).Build()
+			if tt.encoding != "" {
+				msg = msg.SetHeader(messaging.HeaderContentEncoding, tt.encoding)
+			}
+
+			out, err := transform(msg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			switch want := tt.want.(type) {
+			case []byte:
+				got, ok := out.Data.([]byte)
+				if !ok || !bytes.Equal(got, want) {
+					t.Errorf("data = %v, want %s", out.Data, want)
+				}
+			default:
+				if out.Data != want {
+					t.Errorf("data = %v, want %v", out.Data, want)
+				}
+			}
+
+			if _, exists := out.GetHeader(messaging.HeaderContentEncoding); exists && tt.encoding != "identity" {
+				t.Error("Content-Encoding header not removed")
+			}
+			if tt.encoding != "" && msg.Headers[messaging.HeaderContentEncoding] != tt.encoding {
+				t.Error("input message modified")
+			}
+		})
+	}
+}
+
+func TestNewTracingHeaderTransformer(t *testing.T) {
+	transform := hub.NewTracingHeaderTransformer()
+
+	msg := messaging.NewNotification("a", "b", "data").Build()
+	out, err := transform(msg)
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+
+	traceID, exists := out.GetHeader(messaging.HeaderTraceID)
+	if !exists || traceID == "" {
+		t.Fatal("trace ID not assigned")
+	}
+	if _, exists := msg.GetHeader(messaging.HeaderTraceID); exists {
+		t.Error("input message modified")
+	}
+
+	again, _ := transform(out)
+	if got, _ := again.GetHeader(messaging.HeaderTraceID); got != traceID {
+		t.Errorf("trace ID = %s, want existing %s kept", got, traceID)
+	}
+
+	other, _ := transform(messaging.NewNotification("a", "b", "data").Build())
+	if got, _ := other.GetHeader(messaging.HeaderTraceID); got == traceID {
+		t.Error("trace IDs are not unique")
+	}
+}
@@ -0,0 +1,161 @@
+package messaging_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+)
+
+type taskRequest struct {
+	Task     string `json:"task"`
+	Priority int    `json:"priority"`
+}
+
+func TestMessage_DecodeContent_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{"bytes", []byte(`{"task":"summarize","priority":2}`)},
+		{"string", `{"task":"summarize","priority":2}`},
+		{"map", map[string]any{"task": "summarize", "priority": 2}},
+		{"struct", taskRequest{Task: "summarize", Priority: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := messaging.NewRequest("agent-a", "agent-b", tt.data).
+				ContentType(messaging.ContentTypeJSON).
+				Build()
+
+			var task taskRequest
+			if err := msg.DecodeContent(&task, nil); err != nil {
+				t.Fatalf("DecodeContent() error = %v", err)
+			}
+
+			if task.Task != "summarize" || task.Priority != 2 {
+				t.Errorf("decoded = %+v, want {summarize 2}", task)
+			}
+		})
+	}
+}
+
+func TestMessage_DecodeContent_DefaultsToJSON(t *testing.T) {
+	msg := messaging.NewRequest("agent-a", "agent-b", map[string]any{"task": "review"}).Build()
+
+	if msg.ContentType != "" {
+		t.Fatalf("ContentType = %q, want empty", msg.ContentType)
+	}
+
+	var task taskRequest
+	if err := msg.DecodeContent(&task, messaging.DefaultContentRegistry); err != nil {
+		t.Fatalf("DecodeContent() error = %v", err)
+	}
+	if task.Task != "review" {
+		t.Errorf("Task = %q, want review", task.Task)
+	}
+}
+
+func TestMessage_DecodeContent_Text(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", "hello").
+		ContentType("text/plain; charset=utf-8").
+		Build()
+
+	var text string
+	if err := msg.DecodeContent(&text, nil); err != nil {
+		t.Fatalf("DecodeContent() error = %v", err)
+	}
+	if text != "hello" {
+		t.Errorf("text = %q, want hello", text)
+	}
+
+	var task taskRequest
+	if err := msg.DecodeContent(&task, nil); err == nil {
+		t.Error("decoding text/plain into a struct should fail")
+	}
+}
+
+func TestMessage_DecodeContent_UnknownType(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", []byte{0x01}).
+		ContentType("application/x-unknown").
+		Build()
+
+	var target any
+	err := msg.DecodeContent(&target, nil)
+	if err == nil {
+		t.Fatal("DecodeContent() should fail for unregistered content type")
+	}
+
+	if !strings.Contains(err.Error(), "unknown content type") {
+		t.Errorf("error = %v, want unknown content type", err)
+	}
+	if messaging.MessageIDOf(err) != msg.ID {
+		t.Errorf("MessageIDOf(err) = %q, want %q", messaging.MessageIDOf(err), msg.ID)
+	}
+}
+
+type upperCodec struct{}
+
+func (upperCodec) Marshal(v any) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.New("upper codec encodes strings only")
+	}
+	return []byte(strings.ToUpper(s)), nil
+}
+
+func (upperCodec) Unmarshal(data []byte, target any) error {
+	ptr, ok := target.(*string)
+	if !ok {
+		return errors.New("upper codec decodes into *string only")
+	}
+	*ptr = strings.ToUpper(string(data))
+	return nil
+}
+
+func TestRegisterContentCodec(t *testing.T) {
+	messaging.RegisterContentCodec("Application/X-Upper", upperCodec{})
+
+	codec, err := messaging.GetContentCodec("application/x-upper")
+	if err != nil {
+		t.Fatalf("GetContentCodec() error = %v", err)
+	}
+
+	encoded, err := codec.Marshal("shout")
+	if err != nil || string(encoded) != "SHOUT" {
+		t.Errorf("Marshal() = %q, %v, want SHOUT", encoded, err)
+	}
+
+	msg := messaging.NewNotification("agent-a", "agent-b", []byte("quiet")).
+		ContentType("application/x-upper").
+		Build()
+
+	var text string
+	if err := msg.DecodeContent(&text, nil); err != nil {
+		t.Fatalf("DecodeContent() error = %v", err)
+	}
+	if text != "QUIET" {
+		t.Errorf("text = %q, want QUIET", text)
+	}
+}
+
+func TestDefaultContentRegistry_Marshal(t *testing.T) {
+	data, err := messaging.DefaultContentRegistry.Marshal(taskRequest{Task: "a", Priority: 1}, messaging.ContentTypeJSON)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(data) != `{"task":"a","priority":1}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var decoded taskRequest
+	if err := messaging.DefaultContentRegistry.Unmarshal(data, messaging.ContentTypeJSON, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Task != "a" {
+		t.Errorf("decoded Task = %q, want a", decoded.Task)
+	}
+}
@@ -1,6 +1,10 @@
 package messaging_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -296,6 +300,173 @@ func TestMessage_IDUniqueness(t *testing.T) {
 	}
 }
 
+func TestMessage_WithID(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", "data").
+		WithID("fixed-id").
+		Build()
+
+	if msg.ID != "fixed-id" {
+		t.Errorf("ID = %v, want fixed-id", msg.ID)
+	}
+}
+
+func TestMessage_Build_GeneratesIDWhenEmpty(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", "data").
+		WithID("").
+		Build()
+
+	if msg.ID == "" {
+		t.Error("ID should be generated when empty")
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	base := errors.New("delivery failed")
+	err := messaging.WrapError("msg-1", base)
+
+	if err.Error() != base.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), base.Error())
+	}
+	if !errors.Is(err, base) {
+		t.Error("wrapped error should unwrap to base error")
+	}
+	if got := messaging.MessageIDOf(err); got != "msg-1" {
+		t.Errorf("MessageIDOf() = %q, want msg-1", got)
+	}
+
+	outer := fmt.Errorf("outer: %w", err)
+	if got := messaging.MessageIDOf(outer); got != "msg-1" {
+		t.Errorf("MessageIDOf(outer) = %q, want msg-1", got)
+	}
+}
+
+func TestWrapError_Nil(t *testing.T) {
+	if err := messaging.WrapError("msg-1", nil); err != nil {
+		t.Errorf("WrapError(nil) = %v, want nil", err)
+	}
+	if got := messaging.MessageIDOf(errors.New("plain")); got != "" {
+		t.Errorf("MessageIDOf(plain) = %q, want empty", got)
+	}
+}
+
+func TestMessage_GetHeader(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", "data").
+		Headers(map[string]string{"tenant": "acme"}).
+		Build()
+
+	if value, ok := msg.GetHeader("tenant"); !ok || value != "acme" {
+		t.Errorf("GetHeader(tenant) = %q, %v, want acme, true", value, ok)
+	}
+
+	if _, ok := msg.GetHeader("missing"); ok {
+		t.Error("GetHeader(missing) should report false")
+	}
+
+	empty := messaging.NewNotification("agent-a", "agent-b", "data").Build()
+	if _, ok := empty.GetHeader("tenant"); ok {
+		t.Error("GetHeader on message without headers should report false")
+	}
+}
+
+func TestMessage_SetHeader(t *testing.T) {
+	original := messaging.NewNotification("agent-a", "agent-b", "data").Build()
+
+	updated := original.SetHeader(messaging.HeaderTraceID, "trace-1")
+
+	if value, _ := updated.GetHeader(messaging.HeaderTraceID); value != "trace-1" {
+		t.Errorf("updated header = %q, want trace-1", value)
+	}
+	if updated.ID != original.ID {
+		t.Errorf("SetHeader changed ID: %s != %s", updated.ID, original.ID)
+	}
+	if original.Headers != nil {
+		t.Errorf("original headers modified: %v", original.Headers)
+	}
+
+	again := updated.SetHeader(messaging.HeaderTraceID, "trace-2")
+	if value, _ := updated.GetHeader(messaging.HeaderTraceID); value != "trace-1" {
+		t.Errorf("SetHeader modified receiver: %q", value)
+	}
+	if value, _ := again.GetHeader(messaging.HeaderTraceID); value != "trace-2" {
+		t.Errorf("again header = %q, want trace-2", value)
+	}
+}
+
+func TestMessage_JSON_Headers(t *testing.T) {
+	msg := messaging.NewNotification("agent-a", "agent-b", "data").
+		Headers(map[string]string{messaging.HeaderCorrelationID: "corr-1"}).
+		Build()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded messaging.Message
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if value, _ := decoded.GetHeader(messaging.HeaderCorrelationID); value != "corr-1" {
+		t.Errorf("decoded header = %q, want corr-1 (json: %s)", value, data)
+	}
+}
+
+func TestMessage_TTL(t *testing.T) {
+	msg := messaging.NewMessageWithTTL("agent-a", "agent-b", "data", time.Minute).Build()
+
+	if msg.Type != messaging.MessageTypeNotification {
+		t.Errorf("Type = %v, want notification", msg.Type)
+	}
+	if want := msg.Timestamp.Add(time.Minute); !msg.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", msg.ExpiresAt, want)
+	}
+	if msg.IsExpired(time.Now()) {
+		t.Error("message should not be expired yet")
+	}
+	if !msg.IsExpired(msg.Timestamp.Add(2 * time.Minute)) {
+		t.Error("message should be expired after its TTL")
+	}
+}
+
+func TestMessage_TTL_NonPositive(t *testing.T) {
+	msg := messaging.NewRequest("agent-a", "agent-b", "data").
+		TTL(time.Minute).
+		TTL(0).
+		Build()
+
+	if !msg.ExpiresAt.IsZero() {
+		t.Errorf("ExpiresAt = %v, want zero", msg.ExpiresAt)
+	}
+	if msg.IsExpired(time.Now().Add(time.Hour)) {
+		t.Error("message without expiry should never expire")
+	}
+}
+
+func TestMessage_JSON_ExpiresAt(t *testing.T) {
+	plain, err := json.Marshal(messaging.NewNotification("agent-a", "agent-b", "data").Build())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(plain), "expires_at") {
+		t.Errorf("zero ExpiresAt should be omitted: %s", plain)
+	}
+
+	msg := messaging.NewMessageWithTTL("agent-a", "agent-b", "data", time.Minute).Build()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded messaging.Message
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.ExpiresAt.Equal(msg.ExpiresAt) {
+		t.Errorf("decoded ExpiresAt = %v, want %v", decoded.ExpiresAt, msg.ExpiresAt)
+	}
+}
+
 func TestMessage_TimestampSet(t *testing.T) {
 	before := time.Now()
 	msg := messaging.NewRequest("agent-a", "agent-b", "data").Build()
@@ -0,0 +1,192 @@
+package observability_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func batchEvent(i int) observability.Event {
+	return observability.Event{
+		Type:      observability.EventWorkerComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
+		Data:      map[string]any{"item_index": i},
+	}
+}
+
+func waitForEvents(t *testing.T, obs *captureObserver, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(obs.getEvents()) < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d events within 2s, want %d", len(obs.getEvents()), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBatchingObserver_FlushesOnBatchSize(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 5, time.Hour)
+	defer batched.Close()
+
+	for i := range 4 {
+		batched.OnEvent(context.Background(), batchEvent(i))
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if got := len(inner.getEvents()); got != 0 {
+		t.Fatalf("got %d events before batch filled, want 0", got)
+	}
+
+	batched.OnEvent(context.Background(), batchEvent(4))
+	waitForEvents(t, inner, 5)
+}
+
+func TestBatchingObserver_FlushesOnInterval(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 1000, 20*time.Millisecond)
+	defer batched.Close()
+
+	start := time.Now()
+	batched.OnEvent(context.Background(), batchEvent(0))
+	batched.OnEvent(context.Background(), batchEvent(1))
+
+	waitForEvents(t, inner, 2)
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("flushed after %v, want after the interval", elapsed)
+	}
+}
+
+func TestBatchingObserver_PreservesOrder(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 7, time.Millisecond)
+
+	for i := range 500 {
+		batched.OnEvent(context.Background(), batchEvent(i))
+	}
+	batched.Close()
+
+	events := inner.getEvents()
+	if len(events) != 500 {
+		t.Fatalf("got %d events, want 500", len(events))
+	}
+	for i, event := range events {
+		if event.Data["item_index"] != i {
+			t.Fatalf("event %d has item_index %v, want in emission order", i, event.Data["item_index"])
+		}
+	}
+}
+
+func TestBatchingObserver_CloseFlushesPending(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 100, time.Hour)
+
+	for i := range 3 {
+		batched.OnEvent(context.Background(), batchEvent(i))
+	}
+
+	if err := batched.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := len(inner.getEvents()); got != 3 {
+		t.Errorf("got %d events after Close, want 3", got)
+	}
+	if err := batched.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestBatchingObserver_AfterCloseForwardsDirectly(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 100, time.Hour)
+	batched.Close()
+
+	batched.OnEvent(context.Background(), batchEvent(0))
+
+	if got := len(inner.getEvents()); got != 1 {
+		t.Errorf("got %d events, want 1 forwarded after Close", got)
+	}
+}
+
+func TestBatchingObserver_Flush(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 100, time.Hour)
+	defer batched.Close()
+
+	batched.OnEvent(context.Background(), batchEvent(0))
+	batched.Flush()
+
+	if got := len(inner.getEvents()); got != 1 {
+		t.Errorf("got %d events after Flush, want 1", got)
+	}
+}
+
+type ctxObserver struct {
+	mu   sync.Mutex
+	errs []error
+	vals []any
+}
+
+type ctxKey struct{}
+
+func (o *ctxObserver) OnEvent(ctx context.Context, event observability.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, ctx.Err())
+	o.vals = append(o.vals, ctx.Value(ctxKey{}))
+}
+
+func TestBatchingObserver_ContextOutlivesCancel(t *testing.T) {
+	inner := &ctxObserver{}
+	batched := observability.NewBatchingObserver(inner, 100, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "trace"))
+	batched.OnEvent(ctx, batchEvent(0))
+	cancel()
+	batched.Close()
+
+	if len(inner.errs) != 1 || inner.errs[0] != nil {
+		t.Errorf("inner ctx errs = %v, want not cancelled", inner.errs)
+	}
+	if inner.vals[0] != "trace" {
+		t.Errorf("inner ctx value = %v, want trace", inner.vals[0])
+	}
+}
+
+func TestBatchingObserver_ConcurrentEmitters(t *testing.T) {
+	inner := &captureObserver{}
+	batched := observability.NewBatchingObserver(inner, 32, 5*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for g := range 10 {
+		wg.Go(func() {
+			for i := range 200 {
+				batched.OnEvent(context.Background(), observability.Event{
+					Type: observability.EventWorkerComplete,
+					Data: map[string]any{"id": fmt.Sprintf("%d-%d", g, i)},
+				})
+			}
+		})
+	}
+	wg.Wait()
+	batched.Close()
+
+	if got := len(inner.getEvents()); got != 2000 {
+		t.Errorf("got %d events, want 2000", got)
+	}
+}
+
+func TestNewBatchingObserver_NilInner(t *testing.T) {
+	batched := observability.NewBatchingObserver(nil, 0, 0)
+	batched.OnEvent(context.Background(), batchEvent(0))
+	if err := batched.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,329 @@
+package observability_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func fileEvent(runID string, i int) observability.Event {
+	return observability.Event{
+		Type:      observability.EventNodeComplete,
+		Timestamp: time.Now(),
+		Source:    observability.NewEventSource(observability.SourceGraph, "review"),
+		Data:      map[string]any{"run_id": runID, "node": fmt.Sprintf("n%d", i)},
+	}
+}
+
+func readRecords(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestFileObserver_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+	observer.OnEvent(context.Background(), fileEvent("run-1", 2))
+
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records := readRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	first := records[0]
+	if first["type"] != "node.complete" || first["run_id"] != "run-1" {
+		t.Errorf("record = %v, want type node.complete and run_id run-1", first)
+	}
+	if source, _ := first["source"].(map[string]any); source["kind"] != "graph" || source["name"] != "review" {
+		t.Errorf("source = %v, want graph:review", first["source"])
+	}
+	if data, _ := first["data"].(map[string]any); data["node"] != "n1" {
+		t.Errorf("data = %v, want node n1", first["data"])
+	}
+	if _, ok := first["timestamp"].(string); !ok {
+		t.Errorf("timestamp = %v, want RFC 3339 string", first["timestamp"])
+	}
+}
+
+func TestFileObserver_BuffersUntilFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path, observability.WithFileFlushInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+	defer observer.Close()
+
+	observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+
+	if records := readRecords(t, path); len(records) != 0 {
+		t.Fatalf("got %d records before Flush, want 0", len(records))
+	}
+
+	if err := observer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if records := readRecords(t, path); len(records) != 1 {
+		t.Fatalf("got %d records after Flush, want 1", len(records))
+	}
+}
+
+func TestFileObserver_FlushesOnInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path, observability.WithFileFlushInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+	defer observer.Close()
+
+	observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(readRecords(t, path)) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("event not flushed within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFileObserver_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path,
+		observability.WithFileMaxBytes(400),
+		observability.WithFileMaxBackups(2),
+		observability.WithFileFlushInterval(0),
+	)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	for i := range 30 {
+		observer.OnEvent(context.Background(), fileEvent("run-1", i))
+	}
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("stat %s: %v", name, err)
+		}
+		if info.Size() > 400 {
+			t.Errorf("%s is %d bytes, want <= 400", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("%s.3 exists, want at most 2 backups", path)
+	}
+
+	current := readRecords(t, path)
+	last := current[len(current)-1]["data"].(map[string]any)
+	if last["node"] != "n29" {
+		t.Errorf("last record node = %v, want n29", last["node"])
+	}
+}
+
+func TestFileObserver_RotationWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path,
+		observability.WithFileMaxBytes(400),
+		observability.WithFileMaxBackups(0),
+	)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	for i := range 30 {
+		observer.OnEvent(context.Background(), fileEvent("run-1", i))
+	}
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := os.Stat(path + ".1"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("backup written with max backups 0")
+	}
+	if len(readRecords(t, path)) == 0 {
+		t.Error("current file is empty after rotation")
+	}
+}
+
+func TestFileObserver_Pretty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	observer, err := observability.NewFileObserver(path, observability.WithFilePretty())
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+	observer.OnEvent(context.Background(), fileEvent("run-1", 2))
+	observer.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(content), "\n  \"type\": \"node.complete\"") {
+		t.Errorf("output is not indented:\n%s", content)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	count := 0
+	for decoder.More() {
+		var record map[string]any
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("decoded %d records, want 2", count)
+	}
+}
+
+func TestFileObserver_UnencodableData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	observer.OnEvent(context.Background(), observability.Event{
+		Type:   observability.EventStateSet,
+		Source: observability.NewEventSource(observability.SourceState, "run-1"),
+		Data:   map[string]any{"ch": make(chan int), "key": "value"},
+	})
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records := readRecords(t, path)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	if data := records[0]["data"].(map[string]any); data["key"] != "value" {
+		t.Errorf("data = %v, want stringified values", data)
+	}
+}
+
+func TestFileObserver_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path,
+		observability.WithFileMaxBytes(0),
+		observability.WithFileFlushInterval(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Go(func() {
+			for i := range 100 {
+				observer.OnEvent(context.Background(), fileEvent(fmt.Sprintf("run-%d", g), i))
+			}
+		})
+	}
+	wg.Wait()
+
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if records := readRecords(t, path); len(records) != 800 {
+		t.Errorf("got %d records, want 800", len(records))
+	}
+}
+
+func TestFileObserver_Close(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	observer, err := observability.NewFileObserver(path)
+	if err != nil {
+		t.Fatalf("NewFileObserver() error = %v", err)
+	}
+
+	if err := observer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := observer.Close(); err != nil {
+		t.Errorf("second Close() error = %v, want nil", err)
+	}
+	if err := observer.Flush(); !errors.Is(err, observability.ErrObserverClosed) {
+		t.Errorf("Flush() after Close error = %v, want ErrObserverClosed", err)
+	}
+
+	observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+	if records := readRecords(t, path); len(records) != 0 {
+		t.Errorf("got %d records after Close, want 0", len(records))
+	}
+}
+
+func TestFileObserver_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	for range 2 {
+		observer, err := observability.NewFileObserver(path)
+		if err != nil {
+			t.Fatalf("NewFileObserver() error = %v", err)
+		}
+		observer.OnEvent(context.Background(), fileEvent("run-1", 1))
+		observer.Close()
+	}
+
+	if records := readRecords(t, path); len(records) != 2 {
+		t.Errorf("got %d records, want 2", len(records))
+	}
+}
+
+func TestNewFileObserver_InvalidPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "events.jsonl")
+
+	if _, err := observability.NewFileObserver(path); err == nil {
+		t.Error("NewFileObserver() expected error for missing directory")
+	}
+}
@@ -0,0 +1,198 @@
+package observability_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+)
+
+func filterEvent(eventType observability.EventType, kind, name string, data map[string]any) observability.Event {
+	return observability.Event{
+		Type:   eventType,
+		Source: observability.NewEventSource(kind, name),
+		Data:   data,
+	}
+}
+
+func TestFilterObserver_Allows(t *testing.T) {
+	graphSet := filterEvent(observability.EventStateSet, observability.SourceGraph, "review", nil)
+	graphDone := filterEvent(observability.EventGraphComplete, observability.SourceGraph, "review", nil)
+	otherDone := filterEvent(observability.EventGraphComplete, observability.SourceGraph, "intake", nil)
+	chainDone := filterEvent(observability.EventChainComplete, observability.SourceWorkflow, "ProcessChain", nil)
+	failedStep := filterEvent(observability.EventStepComplete, observability.SourceWorkflow, "ProcessChain", map[string]any{"error": true})
+
+	tests := []struct {
+		name  string
+		opts  observability.FilterOptions
+		event observability.Event
+		want  bool
+	}{
+		{name: "empty options allow all", event: graphSet, want: true},
+		{
+			name:  "allow type match",
+			opts:  observability.FilterOptions{AllowTypes: []observability.EventType{observability.EventGraphComplete}},
+			event: graphDone,
+			want:  true,
+		},
+		{
+			name:  "allow type miss",
+			opts:  observability.FilterOptions{AllowTypes: []observability.EventType{observability.EventGraphComplete}},
+			event: graphSet,
+			want:  false,
+		},
+		{
+			name:  "deny type",
+			opts:  observability.FilterOptions{DenyTypes: []observability.EventType{observability.EventStateSet}},
+			event: graphSet,
+			want:  false,
+		},
+		{
+			name: "deny wins over allow",
+			opts: observability.FilterOptions{
+				AllowTypes: []observability.EventType{observability.EventStateSet},
+				DenyTypes:  []observability.EventType{observability.EventStateSet},
+			},
+			event: graphSet,
+			want:  false,
+		},
+		{
+			name:  "allow source kind prefix",
+			opts:  observability.FilterOptions{AllowSources: []string{"graph"}},
+			event: graphDone,
+			want:  true,
+		},
+		{
+			name:  "allow source miss",
+			opts:  observability.FilterOptions{AllowSources: []string{"graph"}},
+			event: chainDone,
+			want:  false,
+		},
+		{
+			name:  "deny source instance",
+			opts:  observability.FilterOptions{DenySources: []string{"graph:intake"}},
+			event: otherDone,
+			want:  false,
+		},
+		{
+			name:  "deny source other instance",
+			opts:  observability.FilterOptions{DenySources: []string{"graph:intake"}},
+			event: graphDone,
+			want:  true,
+		},
+		{
+			name: "predicate true",
+			opts: observability.FilterOptions{Predicate: func(e observability.Event) bool {
+				return e.Data["error"] == true
+			}},
+			event: failedStep,
+			want:  true,
+		},
+		{
+			name: "predicate false",
+			opts: observability.FilterOptions{Predicate: func(e observability.Event) bool {
+				return e.Data["error"] == true
+			}},
+			event: chainDone,
+			want:  false,
+		},
+		{
+			name: "predicate not consulted after deny",
+			opts: observability.FilterOptions{
+				DenyTypes: []observability.EventType{observability.EventStepComplete},
+				Predicate: func(observability.Event) bool { return true },
+			},
+			event: failedStep,
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &captureObserver{}
+			filter := observability.NewFilterObserver(inner, tt.opts)
+
+			if got := filter.Allows(tt.event); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+
+			filter.OnEvent(context.Background(), tt.event)
+
+			received := len(inner.getEvents()) == 1
+			if received != tt.want {
+				t.Errorf("inner received event = %v, want %v", received, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterObserver_FilteredEventsNeverReachInner(t *testing.T) {
+	inner := &captureObserver{}
+	filter := observability.NewFilterObserver(inner, observability.FilterOptions{
+		DenyTypes: []observability.EventType{
+			observability.EventStateSet,
+			observability.EventEdgeEvaluate,
+		},
+	})
+
+	for range 50 {
+		filter.OnEvent(context.Background(), filterEvent(observability.EventStateSet, observability.SourceState, "run-1", nil))
+		filter.OnEvent(context.Background(), filterEvent(observability.EventEdgeEvaluate, observability.SourceGraph, "review", nil))
+	}
+	filter.OnEvent(context.Background(), filterEvent(observability.EventGraphComplete, observability.SourceGraph, "review", nil))
+
+	events := inner.getEvents()
+	if len(events) != 1 || events[0].Type != observability.EventGraphComplete {
+		t.Errorf("inner received %v, want only EventGraphComplete", events)
+	}
+}
+
+func TestFilterObserver_ComposesWithMultiObserver(t *testing.T) {
+	everything := &captureObserver{}
+	lifecycle := &captureObserver{}
+
+	observer := observability.NewMultiObserver(
+		everything,
+		observability.NewFilterObserver(lifecycle, observability.FilterOptions{
+			Predicate: func(e observability.Event) bool {
+				return e.Type == observability.EventGraphComplete || e.Type == observability.EventGraphError
+			},
+		}),
+	)
+
+	events := []observability.Event{
+		filterEvent(observability.EventGraphStart, observability.SourceGraph, "review", nil),
+		filterEvent(observability.EventStateSet, observability.SourceState, "run-1", nil),
+		filterEvent(observability.EventNodeComplete, observability.SourceGraph, "review", nil),
+		filterEvent(observability.EventGraphError, observability.SourceGraph, "review", map[string]any{"error": "boom"}),
+		filterEvent(observability.EventGraphComplete, observability.SourceGraph, "review", nil),
+	}
+	for _, event := range events {
+		observer.OnEvent(context.Background(), event)
+	}
+
+	if got := len(everything.getEvents()); got != len(events) {
+		t.Errorf("unfiltered sink got %d events, want %d", got, len(events))
+	}
+
+	got := lifecycle.getEvents()
+	if len(got) != 2 || got[0].Type != observability.EventGraphError || got[1].Type != observability.EventGraphComplete {
+		t.Errorf("filtered sink got %v, want graph.error and graph.complete", got)
+	}
+}
+
+func TestNewFilterObserver_CopiesOptions(t *testing.T) {
+	inner := &captureObserver{}
+	opts := observability.FilterOptions{
+		AllowTypes:   []observability.EventType{observability.EventGraphComplete},
+		AllowSources: []string{"graph"},
+	}
+	filter := observability.NewFilterObserver(inner, opts)
+
+	opts.AllowTypes[0] = observability.EventStateSet
+	opts.AllowSources[0] = "state"
+
+	if !filter.Allows(filterEvent(observability.EventGraphComplete, observability.SourceGraph, "review", nil)) {
+		t.Error("filter changed after options were modified")
+	}
+}
@@ -36,7 +36,7 @@ func TestMultiObserver_BroadcastsToAllObservers(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventNodeStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      map[string]any{"key": "value"},
 	}
 
@@ -60,7 +60,7 @@ func TestMultiObserver_EmptyObservers(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventNodeStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 	}
 
 	multi.OnEvent(context.Background(), event)
@@ -75,7 +75,7 @@ func TestMultiObserver_FiltersNilObservers(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventNodeComplete,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 	}
 
 	multi.OnEvent(context.Background(), event)
@@ -95,7 +95,7 @@ func TestMultiObserver_SingleObserver(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventGraphStart,
 		Timestamp: time.Now(),
-		Source:    "graph",
+		Source:    observability.NewEventSource(observability.SourceGraph, "test-graph"),
 		Data:      map[string]any{"name": "test-graph"},
 	}
 
@@ -123,7 +123,7 @@ func TestMultiObserver_PreservesEventData(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventStateSet,
 		Timestamp: time.Now(),
-		Source:    "state",
+		Source:    observability.NewEventSource(observability.SourceState, "run-1"),
 		Data:      originalData,
 	}
 
@@ -161,7 +161,7 @@ func TestMultiObserver_PropagatesContext(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventNodeStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 	}
 
 	multi.OnEvent(ctx, event)
@@ -201,7 +201,7 @@ func TestMultiObserver_ConcurrentEvents(t *testing.T) {
 				event := observability.Event{
 					Type:      observability.EventNodeStart,
 					Timestamp: time.Now(),
-					Source:    "concurrent-test",
+					Source:    observability.NewEventSource(observability.SourceWorkflow, "concurrent-test"),
 					Data:      map[string]any{"goroutine": id, "event": j},
 				}
 				multi.OnEvent(context.Background(), event)
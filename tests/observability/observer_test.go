@@ -13,7 +13,7 @@ func TestObserver_NoOpObserver(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventStateCreate,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      map[string]any{"key": "value"},
 	}
 
@@ -73,15 +73,15 @@ func TestEvent_Structure(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventStateSet,
 		Timestamp: now,
-		Source:    "test-source",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test-source"),
 		Data:      map[string]any{"key": "test-key"},
 	}
 
 	if event.Type != observability.EventStateSet {
 		t.Errorf("Event.Type = %v, want %v", event.Type, observability.EventStateSet)
 	}
-	if event.Source != "test-source" {
-		t.Errorf("Event.Source = %v, want %v", event.Source, "test-source")
+	if event.Source != observability.NewEventSource(observability.SourceWorkflow, "test-source") {
+		t.Errorf("Event.Source = %v, want %v", event.Source, "workflow:test-source")
 	}
 	if event.Data["key"] != "test-key" {
 		t.Errorf("Event.Data[key] = %v, want %v", event.Data["key"], "test-key")
@@ -123,3 +123,37 @@ func TestEventType_Constants(t *testing.T) {
 		}
 	}
 }
+
+func TestEventSource_String(t *testing.T) {
+	tests := []struct {
+		source observability.EventSource
+		want   string
+	}{
+		{observability.NewEventSource(observability.SourceGraph, "review"), "graph:review"},
+		{observability.NewEventSource(observability.SourceHub, ""), "hub"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.source.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	tests := []struct {
+		eventType observability.EventType
+		want      string
+	}{
+		{observability.EventGraphStart, "EventGraphStart"},
+		{observability.EventMessageExpired, "EventMessageExpired"},
+		{observability.EventStateDelete, "EventStateDelete"},
+		{observability.EventType("custom.event"), "custom.event"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.eventType.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
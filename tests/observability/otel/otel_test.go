@@ -0,0 +1,208 @@
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability/otel"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newObserver() (*otel.Observer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return otel.NewObserver(otel.WithTracerProvider(provider)), recorder
+}
+
+func spansByName(spans []sdktrace.ReadOnlySpan) map[string]sdktrace.ReadOnlySpan {
+	named := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		named[span.Name()] = span
+	}
+	return named
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func graph(t *testing.T, observer observability.Observer, fail error) state.StateGraph {
+	t.Helper()
+
+	g, err := state.NewGraphWithDeps(config.DefaultGraphConfig("review"), observer, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	g.AddNodeFunc("analyze", func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("analyzed", true), nil
+	})
+	g.AddNodeFunc("approve", func(ctx context.Context, s state.State) (state.State, error) {
+		return s, fail
+	})
+	g.AddEdge("analyze", "approve", nil)
+	g.SetEntryPoint("analyze")
+	g.SetExitPoint("approve")
+	return g
+}
+
+func TestObserver_GraphSpans(t *testing.T) {
+	observer, recorder := newObserver()
+
+	initial := state.New(nil)
+	if _, err := graph(t, observer, nil).Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	spans := spansByName(recorder.Ended())
+	if len(spans) != 3 {
+		t.Fatalf("ended spans = %v, want graph and two nodes", spans)
+	}
+
+	root := spans["graph review"]
+	if root == nil {
+		t.Fatal("missing graph span")
+	}
+	if got := attr(root, "run_id").AsString(); got != initial.RunID {
+		t.Errorf("run_id = %q, want %q", got, initial.RunID)
+	}
+	if got := attr(root, "exit_point").AsString(); got != "approve" {
+		t.Errorf("exit_point = %q, want completion attributes on the root span", got)
+	}
+
+	for _, name := range []string{"node analyze", "node approve"} {
+		node := spans[name]
+		if node == nil {
+			t.Fatalf("missing %s span", name)
+		}
+		if node.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s parent = %s, want the graph span", name, node.Parent().SpanID())
+		}
+		if node.Status().Code == codes.Error {
+			t.Errorf("%s status = %v, want unset", name, node.Status())
+		}
+	}
+	if got := attr(spans["node analyze"], "iteration").AsInt64(); got != 1 {
+		t.Errorf("iteration = %d, want 1", got)
+	}
+}
+
+func TestObserver_GraphError(t *testing.T) {
+	observer, recorder := newObserver()
+
+	failure := errors.New("reviewer unavailable")
+	if _, err := graph(t, observer, failure).Execute(context.Background(), state.New(nil)); err == nil {
+		t.Fatal("Execute() error = nil, want node failure")
+	}
+
+	spans := spansByName(recorder.Ended())
+	if got := spans["node approve"].Status().Code; got != codes.Error {
+		t.Errorf("node status = %v, want error", got)
+	}
+
+	root := spans["graph review"]
+	if root == nil {
+		t.Fatal("graph span not ended on failure")
+	}
+	if root.Status().Code != codes.Error || len(root.Events()) == 0 || root.Events()[len(root.Events())-1].Name != "exception" {
+		t.Errorf("graph status = %v, events = %v; want the error recorded", root.Status(), root.Events())
+	}
+	if len(recorder.Started()) != len(recorder.Ended()) {
+		t.Errorf("started %d spans, ended %d; want every span ended", len(recorder.Started()), len(recorder.Ended()))
+	}
+}
+
+func TestObserver_ConcurrentRuns(t *testing.T) {
+	observer, recorder := newObserver()
+	g := graph(t, observer, nil)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := g.Execute(context.Background(), state.New(nil)); err != nil {
+				t.Errorf("Execute() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	roots := make(map[string]string)
+	for _, span := range recorder.Ended() {
+		if span.Name() == "graph review" {
+			roots[span.SpanContext().SpanID().String()] = attr(span, "run_id").AsString()
+		}
+	}
+	if len(roots) != 10 {
+		t.Fatalf("got %d graph spans, want 10", len(roots))
+	}
+
+	children := make(map[string]int)
+	for _, span := range recorder.Ended() {
+		if span.Name() != "graph review" {
+			children[span.Parent().SpanID().String()]++
+		}
+	}
+	for id, runID := range roots {
+		if children[id] != 2 {
+			t.Errorf("run %s has %d node spans, want 2", runID, children[id])
+		}
+	}
+}
+
+func TestObserver_ChainSpans(t *testing.T) {
+	observer, recorder := newObserver()
+	observability.RegisterObserver("otel-chain-test", observer)
+
+	processor := func(ctx context.Context, item int, total int) (int, error) {
+		if item < 0 {
+			return total, fmt.Errorf("negative item %d", item)
+		}
+		return total + item, nil
+	}
+
+	cfg := config.ChainConfig{Observer: "otel-chain-test"}
+	if _, err := workflows.ProcessChain(context.Background(), cfg, []int{1, 2, -3}, 0, processor, nil); err == nil {
+		t.Fatal("ProcessChain() error = nil, want processor failure")
+	}
+
+	spans := spansByName(recorder.Ended())
+	root := spans["workflow ProcessChain"]
+	if root == nil {
+		t.Fatalf("ended spans = %v, want a chain span", spans)
+	}
+	if root.Status().Code != codes.Error || root.Status().Description != "processor" {
+		t.Errorf("chain status = %v, want processor error", root.Status())
+	}
+	for _, name := range []string{"step 0", "step 1", "step 2"} {
+		if span := spans[name]; span == nil || span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s span missing or not a child of the chain span", name)
+		}
+	}
+	if spans["step 2"].Status().Code != codes.Error {
+		t.Errorf("failed step status = %v, want error", spans["step 2"].Status())
+	}
+}
+
+func TestObserver_Registered(t *testing.T) {
+	observer, err := observability.GetObserver("otel")
+	if err != nil {
+		t.Fatalf("GetObserver(otel) error = %v", err)
+	}
+	if _, ok := observer.(*otel.Observer); !ok {
+		t.Errorf("GetObserver(otel) = %T, want *otel.Observer", observer)
+	}
+}
@@ -20,7 +20,7 @@ func TestSlogObserver_OnEvent_LogsEventFields(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "test.source",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test.source"),
 		Data: map[string]any{
 			"item_count": 5,
 			"test_key":   "test_value",
@@ -86,7 +86,7 @@ func TestSlogObserver_OnEvent_HandlesAllEventTypes(t *testing.T) {
 			event := observability.Event{
 				Type:      tt.eventType,
 				Timestamp: time.Now(),
-				Source:    "test",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 				Data:      map[string]any{},
 			}
 
@@ -109,7 +109,7 @@ func TestSlogObserver_OnEvent_HandlesEmptyData(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      map[string]any{},
 	}
 
@@ -130,7 +130,7 @@ func TestSlogObserver_OnEvent_HandlesNilData(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      nil,
 	}
 
@@ -151,7 +151,7 @@ func TestSlogObserver_OnEvent_HandlesComplexDataTypes(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventParallelStart,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessParallel",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 		Data: map[string]any{
 			"item_count":   100,
 			"worker_count": 8,
@@ -186,7 +186,7 @@ func TestSlogObserver_OnEvent_PropagatesContext(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      map[string]any{},
 	}
 
@@ -207,7 +207,7 @@ func TestSlogObserver_JSONHandler(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventWorkerStart,
 		Timestamp: time.Now(),
-		Source:    "workflows.ProcessParallel",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "ProcessParallel"),
 		Data: map[string]any{
 			"worker_id":  3,
 			"item_index": 42,
@@ -235,7 +235,7 @@ func TestNewSlogObserver_WithDefaultLogger(t *testing.T) {
 	event := observability.Event{
 		Type:      observability.EventChainStart,
 		Timestamp: time.Now(),
-		Source:    "test",
+		Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 		Data:      map[string]any{},
 	}
 
@@ -256,7 +256,7 @@ func TestSlogObserver_ConcurrentEvents(t *testing.T) {
 			event := observability.Event{
 				Type:      observability.EventWorkerStart,
 				Timestamp: time.Now(),
-				Source:    "test",
+				Source:    observability.NewEventSource(observability.SourceWorkflow, "test"),
 				Data: map[string]any{
 					"worker_id": id,
 				},
@@ -275,3 +275,100 @@ func TestSlogObserver_ConcurrentEvents(t *testing.T) {
 		t.Error("Expected log output from concurrent events")
 	}
 }
+
+// recordHandler is a slog.Handler that keeps every record it handles.
+type recordHandler struct {
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordHandler) WithGroup(string) slog.Handler { return h }
+
+func TestSlogObserverWithLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		event observability.Event
+		want  slog.Level
+	}{
+		{"node event", observability.Event{Type: observability.EventNodeStart}, slog.LevelDebug},
+		{"graph event", observability.Event{Type: observability.EventGraphComplete}, slog.LevelInfo},
+		{"warning event", observability.Event{Type: observability.EventHubCircuitOpen}, slog.LevelWarn},
+		{"unmapped event", observability.Event{Type: observability.EventStateSet}, slog.LevelDebug},
+		{"custom event", observability.Event{Type: "custom.event"}, slog.LevelDebug},
+		{
+			"failed event",
+			observability.Event{Type: observability.EventChainComplete, Data: map[string]any{"error": true}},
+			slog.LevelError,
+		},
+		{
+			"error message",
+			observability.Event{Type: observability.EventNodeComplete, Data: map[string]any{"error": "timeout"}},
+			slog.LevelError,
+		},
+		{
+			"successful event",
+			observability.Event{Type: observability.EventChainComplete, Data: map[string]any{"error": false}},
+			slog.LevelInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &recordHandler{}
+			observer := observability.NewSlogObserverWithLevels(slog.New(handler), observability.DefaultSlogLevels())
+
+			observer.OnEvent(context.Background(), tt.event)
+
+			if len(handler.records) != 1 {
+				t.Fatalf("handled %d records, want 1", len(handler.records))
+			}
+			if got := handler.records[0].Level; got != tt.want {
+				t.Errorf("level = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlogObserverWithLevels_CopiesLevels(t *testing.T) {
+	handler := &recordHandler{}
+	levels := map[observability.EventType]slog.Level{observability.EventNodeStart: slog.LevelWarn}
+	observer := observability.NewSlogObserverWithLevels(slog.New(handler), levels)
+	levels[observability.EventNodeStart] = slog.LevelInfo
+
+	observer.OnEvent(context.Background(), observability.Event{Type: observability.EventNodeStart})
+
+	if got := handler.records[0].Level; got != slog.LevelWarn {
+		t.Errorf("level = %v, want %v", got, slog.LevelWarn)
+	}
+}
+
+func TestSlogObserver_DefaultLevelInfo(t *testing.T) {
+	handler := &recordHandler{}
+	observer := observability.NewSlogObserver(slog.New(handler))
+
+	observer.OnEvent(context.Background(), observability.Event{
+		Type: observability.EventNodeStart,
+		Data: map[string]any{"error": true},
+	})
+
+	if got := handler.records[0].Level; got != slog.LevelInfo {
+		t.Errorf("level = %v, want %v", got, slog.LevelInfo)
+	}
+}
+
+func TestDefaultSlogLevels_ReturnsNewMap(t *testing.T) {
+	levels := observability.DefaultSlogLevels()
+	levels[observability.EventGraphStart] = slog.LevelError
+
+	if observability.DefaultSlogLevels()[observability.EventGraphStart] != slog.LevelInfo {
+		t.Error("DefaultSlogLevels() returned a shared map")
+	}
+}
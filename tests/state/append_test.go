@@ -0,0 +1,115 @@
+package state_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_Append(t *testing.T) {
+	s := state.New(nil).Append("findings", "a", "b")
+
+	if got := s.Data["findings"]; !reflect.DeepEqual(got, []any{"a", "b"}) {
+		t.Fatalf("findings = %#v, want []any{a, b}", got)
+	}
+
+	more := s.Append("findings", "c")
+	if got := more.Data["findings"]; !reflect.DeepEqual(got, []any{"a", "b", "c"}) {
+		t.Errorf("findings = %#v, want []any{a, b, c}", got)
+	}
+	if got := s.Data["findings"]; !reflect.DeepEqual(got, []any{"a", "b"}) {
+		t.Errorf("original findings = %#v, want unchanged", got)
+	}
+}
+
+func TestState_Append_PreservesSliceType(t *testing.T) {
+	s := state.New(nil).Set("tags", []string{"a"}).Append("tags", "b")
+
+	tags, ok := state.GetAs[[]string](s, "tags")
+	if !ok || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Errorf("tags = %#v, want []string{a, b}", s.Data["tags"])
+	}
+}
+
+func TestState_Append_DoesNotAlias(t *testing.T) {
+	shared := make([]string, 1, 4)
+	shared[0] = "a"
+	s := state.New(nil).Set("tags", shared)
+
+	first := s.Append("tags", "b")
+	second := s.Append("tags", "c")
+
+	if got := first.Data["tags"]; !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("first tags = %#v, want []string{a, b}", got)
+	}
+	if got := second.Data["tags"]; !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("second tags = %#v, want []string{a, c}", got)
+	}
+}
+
+func TestState_TryAppend_Errors(t *testing.T) {
+	s := state.New(nil).
+		Set("count", 3).
+		Set("tags", []string{"a"}).
+		Set("locked", []any{}).
+		Freeze("locked")
+
+	tests := []struct {
+		name   string
+		key    string
+		values []any
+	}{
+		{"non-slice", "count", []any{1}},
+		{"element type mismatch", "tags", []any{42}},
+		{"nil into non-nillable", "tags", []any{nil}},
+		{"frozen", "locked", []any{"x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.TryAppend(tt.key, tt.values...)
+			if err == nil {
+				t.Fatal("TryAppend() should fail")
+			}
+			if !reflect.DeepEqual(result.Data[tt.key], s.Data[tt.key]) {
+				t.Errorf("%s = %#v, want unchanged", tt.key, result.Data[tt.key])
+			}
+			if unchanged := s.Append(tt.key, tt.values...); !reflect.DeepEqual(unchanged.Data[tt.key], s.Data[tt.key]) {
+				t.Errorf("Append() changed %s to %#v", tt.key, unchanged.Data[tt.key])
+			}
+		})
+	}
+
+	if _, err := s.TryAppend("locked", "x"); !errors.Is(err, state.ErrFrozenKey) {
+		t.Errorf("TryAppend() on frozen key error = %v, want ErrFrozenKey", err)
+	}
+}
+
+func TestAppendAs(t *testing.T) {
+	type finding struct {
+		Severity string
+	}
+
+	s, err := state.AppendAs(state.New(nil), "findings", finding{"low"})
+	if err != nil {
+		t.Fatalf("AppendAs() error = %v", err)
+	}
+	s, err = state.AppendAs(s, "findings", finding{"high"}, finding{"medium"})
+	if err != nil {
+		t.Fatalf("AppendAs() error = %v", err)
+	}
+
+	findings, ok := state.GetAs[[]finding](s, "findings")
+	if !ok {
+		t.Fatalf("findings = %T, want []finding", s.Data["findings"])
+	}
+	if len(findings) != 3 || findings[2].Severity != "medium" {
+		t.Errorf("findings = %v", findings)
+	}
+
+	if _, err := state.AppendAs(s, "findings", "wrong type"); err == nil {
+		t.Error("AppendAs() with mismatched type should fail")
+	}
+}
@@ -0,0 +1,230 @@
+package state_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func auditGraph(t *testing.T, fail bool) state.StateGraph {
+	t.Helper()
+
+	cfg := config.DefaultGraphConfig("audited")
+	cfg.EnableAuditLog = true
+	cfg.SensitiveKeys = []string{"token"}
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+
+	graph.AddNode("fetch", simpleNode("token", "secret"))
+	graph.AddNode("review", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		count, _ := s.GetInt("count")
+		return s.Set("count", count+1), nil
+	}))
+	graph.AddNode("publish", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		if fail {
+			return s, errors.New("publish rejected")
+		}
+		return s.Set("published", true), nil
+	}))
+
+	graph.AddEdge("fetch", "review", nil)
+	graph.AddEdge("review", "review", func(s state.State) bool {
+		count, _ := s.GetInt("count")
+		return count < 2
+	})
+	graph.AddEdge("review", "publish", nil)
+	graph.SetEntryPoint("fetch")
+	graph.SetExitPoint("publish")
+	return graph
+}
+
+func readAuditLog(t *testing.T, graph state.StateGraph) []state.AuditEntry {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := graph.ExportAuditLog(&buf); err != nil {
+		t.Fatalf("ExportAuditLog() error = %v", err)
+	}
+
+	var entries []state.AuditEntry
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		if !json.Valid(scanner.Bytes()) {
+			t.Fatalf("invalid JSON line: %s", scanner.Text())
+		}
+		var entry state.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestGraph_ExportAuditLog(t *testing.T) {
+	graph := auditGraph(t, false)
+
+	initial := state.New(nil)
+	result, err := graph.ExecuteWithResult(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	entries := readAuditLog(t, graph)
+	if len(entries) != len(result.Path) {
+		t.Fatalf("audit log has %d entries, want one per visited node (%v)", len(entries), result.Path)
+	}
+
+	for i, entry := range entries {
+		if entry.Node != result.Path[i] || entry.Sequence != i+1 {
+			t.Errorf("entries[%d] = %s #%d, want %s #%d", i, entry.Node, entry.Sequence, result.Path[i], i+1)
+		}
+		if entry.RunID != initial.RunID || entry.Graph != "audited" {
+			t.Errorf("entries[%d] run=%s graph=%s", i, entry.RunID, entry.Graph)
+		}
+		if entry.StartedAt.IsZero() || entry.CompletedAt.Before(entry.StartedAt) {
+			t.Errorf("entries[%d] timestamps %v..%v", i, entry.StartedAt, entry.CompletedAt)
+		}
+	}
+
+	if entries[0].Events[0].Type != observability.EventGraphStart {
+		t.Errorf("first event = %s, want graph.start", entries[0].Events[0].Type)
+	}
+	last := entries[len(entries)-1]
+	if last.Events[len(last.Events)-1].Type != observability.EventGraphComplete {
+		t.Errorf("last event = %s, want graph.complete", last.Events[len(last.Events)-1].Type)
+	}
+	if last.State["token"] == "secret" || last.State["published"] != true {
+		t.Errorf("final state = %v, want redacted token and published", last.State)
+	}
+}
+
+func TestGraph_ExportAuditLog_Failure(t *testing.T) {
+	graph := auditGraph(t, true)
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err == nil {
+		t.Fatal("Execute() should fail")
+	}
+
+	entries := readAuditLog(t, graph)
+	last := entries[len(entries)-1]
+	if last.Node != "publish" || !strings.Contains(last.Error, "publish rejected") {
+		t.Errorf("last entry = %s error %q, want publish rejected", last.Node, last.Error)
+	}
+	for _, entry := range entries[:len(entries)-1] {
+		if entry.Error != "" {
+			t.Errorf("entry %s has error %q", entry.Node, entry.Error)
+		}
+	}
+}
+
+func TestGraph_ExportAuditLog_Disabled(t *testing.T) {
+	graph, _ := state.NewGraphWithDeps(config.DefaultGraphConfig("plain"), nil, nil)
+
+	if err := graph.ExportAuditLog(&bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLog() without EnableAuditLog should fail")
+	}
+
+	audited := auditGraph(t, false)
+	if err := audited.ExportAuditLog(&bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLog() before any execution should fail")
+	}
+}
+
+func TestGraph_ExportAuditLogFor_ConcurrentRuns(t *testing.T) {
+	graph := auditGraph(t, false)
+	if err := graph.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	runIDs := make([]string, 8)
+	var wg sync.WaitGroup
+	for i := range runIDs {
+		initial := state.New(nil)
+		runIDs[i] = initial.RunID
+		wg.Go(func() {
+			if _, err := graph.Execute(context.Background(), initial); err != nil {
+				t.Errorf("Execute() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, runID := range runIDs {
+		var buf bytes.Buffer
+		if err := graph.ExportAuditLogFor(runID, &buf); err != nil {
+			t.Fatalf("ExportAuditLogFor(%s) error = %v", runID, err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 4 {
+			t.Errorf("run %s has %d entries, want 4", runID, len(lines))
+		}
+		for _, line := range lines {
+			var entry state.AuditEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if entry.RunID != runID {
+				t.Errorf("entry run_id = %s, want %s", entry.RunID, runID)
+			}
+		}
+	}
+
+	if err := graph.ExportAuditLogFor("unknown-run", &bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLogFor() for an unknown run should fail")
+	}
+}
+
+func TestGraph_ExportAuditLog_CompileFailureKeepsNoTrace(t *testing.T) {
+	cfg := config.DefaultGraphConfig("uncompilable")
+	cfg.EnableAuditLog = true
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNode("orphan", simpleNode("k", "v"))
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err == nil {
+		t.Fatal("Execute() without entry point should fail")
+	}
+
+	if err := graph.ExportAuditLog(&bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLog() after a compile failure should report no execution")
+	}
+	if err := graph.ExportAuditLogFor(initial.RunID, &bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLogFor() after a compile failure should report no execution")
+	}
+}
+
+func TestGraph_ExportAuditLogFor_DropsOldestRuns(t *testing.T) {
+	graph := auditGraph(t, false)
+
+	first := state.New(nil)
+	if _, err := graph.Execute(context.Background(), first); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for range 64 {
+		if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	if err := graph.ExportAuditLogFor(first.RunID, &bytes.Buffer{}); err == nil {
+		t.Error("ExportAuditLogFor() should not retain more than 64 runs")
+	}
+}
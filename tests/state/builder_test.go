@@ -0,0 +1,206 @@
+package state_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+type reviewMeta struct {
+	Reviewer string `json:"reviewer"`
+}
+
+type reviewSchema struct {
+	reviewMeta
+	DocumentID string            `json:"document_id"`
+	Score      int               `json:"score"`
+	Tags       []string          `json:"tags,omitempty"`
+	Notes      map[string]string `json:"notes,omitempty"`
+	Approved   bool
+	Internal   string `json:"-"`
+	hidden     string
+}
+
+func TestStateBuilder_From(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	s := builder.From(reviewSchema{
+		reviewMeta: reviewMeta{Reviewer: "alice"},
+		DocumentID: "doc-1",
+		Score:      87,
+		Approved:   true,
+		Internal:   "secret",
+		hidden:     "x",
+	})
+
+	if got := s.Keys(); strings.Join(got, ",") != "Approved,document_id,reviewer,score" {
+		t.Errorf("Keys() = %v, want [Approved document_id reviewer score]", got)
+	}
+
+	score, ok := state.GetAs[int](s, "score")
+	if !ok || score != 87 {
+		t.Errorf("score = %v, %v, want 87 as int", score, ok)
+	}
+
+	if reviewer, _ := s.GetString("reviewer"); reviewer != "alice" {
+		t.Errorf("reviewer = %q, want alice", reviewer)
+	}
+}
+
+func TestStateBuilder_From_UsesObserver(t *testing.T) {
+	observer := &captureObserver{}
+	builder := state.StateBuilder[reviewSchema]{Observer: observer}
+
+	s := builder.From(reviewSchema{DocumentID: "doc-1"})
+
+	if s.Observer != observer {
+		t.Error("From() did not attach the builder observer")
+	}
+	if len(eventsOfType(observer.events, observability.EventStateCreate)) != 1 {
+		t.Error("From() did not emit EventStateCreate")
+	}
+}
+
+func TestStateBuilder_RoundTrip(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	in := reviewSchema{
+		reviewMeta: reviewMeta{Reviewer: "bob"},
+		DocumentID: "doc-2",
+		Score:      42,
+		Tags:       []string{"legal", "urgent"},
+		Notes:      map[string]string{"p1": "ok"},
+		Approved:   true,
+	}
+
+	out, err := builder.To(builder.From(in))
+	if err != nil {
+		t.Fatalf("To() error = %v", err)
+	}
+
+	if out.Reviewer != in.Reviewer || out.DocumentID != in.DocumentID ||
+		out.Score != in.Score || !out.Approved ||
+		strings.Join(out.Tags, ",") != "legal,urgent" || out.Notes["p1"] != "ok" {
+		t.Errorf("To() = %+v, want %+v", out, in)
+	}
+}
+
+func TestStateBuilder_To_MissingKeys(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	s := state.New(nil).Set("document_id", "doc-3")
+
+	out, err := builder.To(s)
+	if err != nil {
+		t.Fatalf("To() error = %v", err)
+	}
+	if out.DocumentID != "doc-3" || out.Score != 0 || out.Tags != nil {
+		t.Errorf("To() = %+v, want only DocumentID set", out)
+	}
+}
+
+func TestStateBuilder_To_ConvertsJSONValues(t *testing.T) {
+	type nested struct {
+		Page  int    `json:"page"`
+		Label string `json:"label"`
+	}
+	type schema struct {
+		Count int      `json:"count"`
+		Tags  []string `json:"tags"`
+		Item  nested   `json:"item"`
+	}
+
+	var builder state.StateBuilder[schema]
+
+	original := builder.From(schema{
+		Count: 3,
+		Tags:  []string{"a", "b"},
+		Item:  nested{Page: 7, Label: "cover"},
+	})
+
+	var codec state.JSONCodec
+	data, err := codec.Encode(original)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	resumed, err := codec.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	out, err := builder.To(resumed)
+	if err != nil {
+		t.Fatalf("To() error = %v", err)
+	}
+	if out.Count != 3 || strings.Join(out.Tags, ",") != "a,b" || out.Item != (nested{Page: 7, Label: "cover"}) {
+		t.Errorf("To() = %+v after JSON round trip", out)
+	}
+}
+
+func TestStateBuilder_To_ConversionError(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	s := state.New(nil).Set("score", "high")
+
+	_, err := builder.To(s)
+	if err == nil {
+		t.Fatal("To() expected error")
+	}
+	if !strings.Contains(err.Error(), `"score"`) {
+		t.Errorf("error = %v, want key name", err)
+	}
+}
+
+func TestStateBuilder_To_NonStruct(t *testing.T) {
+	var builder state.StateBuilder[int]
+
+	if _, err := builder.To(state.New(nil)); err == nil {
+		t.Error("To() expected error for non-struct type")
+	}
+}
+
+func TestStateBuilder_From_NonStructPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("From() expected panic for non-struct type")
+		}
+	}()
+
+	var builder state.StateBuilder[string]
+	builder.From("value")
+}
+
+func TestStateBuilder_Apply(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	s := state.New(nil).Set("untyped", "kept")
+
+	s = builder.Apply(s, reviewSchema{DocumentID: "doc-4", Score: 10})
+
+	if v, _ := s.GetString("untyped"); v != "kept" {
+		t.Errorf("untyped = %q, want kept", v)
+	}
+	if v, _ := s.GetInt("score"); v != 10 {
+		t.Errorf("score = %d, want 10", v)
+	}
+	if s.Has("tags") {
+		t.Error("omitempty field written when zero")
+	}
+}
+
+func TestStateBuilder_Apply_RespectsFrozen(t *testing.T) {
+	var builder state.StateBuilder[reviewSchema]
+
+	s := state.New(nil).Set("document_id", "doc-5").Freeze("document_id")
+
+	s = builder.Apply(s, reviewSchema{DocumentID: "changed", Score: 5})
+
+	if v, _ := s.GetString("document_id"); v != "doc-5" {
+		t.Errorf("document_id = %q, want frozen value doc-5", v)
+	}
+	if v, _ := s.GetInt("score"); v != 5 {
+		t.Errorf("score = %d, want 5", v)
+	}
+}
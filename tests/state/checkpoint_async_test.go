@@ -0,0 +1,283 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// gatedStore holds every Save until gate is closed, then records the node of
+// each saved checkpoint.
+type gatedStore struct {
+	state.CheckpointStore
+	entered chan struct{}
+	gate    chan struct{}
+	delay   time.Duration
+	once    sync.Once
+	mu      sync.Mutex
+	saved   []string
+}
+
+func newGatedStore() *gatedStore {
+	return &gatedStore{
+		CheckpointStore: state.NewMemoryCheckpointStore(),
+		entered:         make(chan struct{}),
+		gate:            make(chan struct{}),
+	}
+}
+
+func (s *gatedStore) Save(st state.State) error {
+	s.once.Do(func() { close(s.entered) })
+	<-s.gate
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.saved = append(s.saved, st.CheckpointNode)
+	s.mu.Unlock()
+	return s.CheckpointStore.Save(st)
+}
+
+func (s *gatedStore) savedNodes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.saved)
+}
+
+// lockedObserver captures events from the async save worker and the graph.
+type lockedObserver struct {
+	mu     sync.Mutex
+	events []observability.Event
+}
+
+func (o *lockedObserver) OnEvent(ctx context.Context, event observability.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+// asyncGraph builds a linear graph over nodes that checkpoints asynchronously
+// after every node, calling visit as each node runs.
+func asyncGraph(t *testing.T, checkpoint config.CheckpointConfig, observer observability.Observer, store state.CheckpointStore, nodes []string, visit func(node string)) state.StateGraph {
+	t.Helper()
+
+	cfg := config.DefaultGraphConfig("async")
+	cfg.Checkpoint.Merge(&checkpoint)
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Async = true
+
+	graph, err := state.NewGraphWithDeps(cfg, observer, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	for i, name := range nodes {
+		graph.AddNodeFunc(name, func(ctx context.Context, s state.State) (state.State, error) {
+			if visit != nil {
+				visit(name)
+			}
+			return s.Set("step", name), nil
+		})
+		if i > 0 {
+			graph.AddEdge(nodes[i-1], name, nil)
+		}
+	}
+	graph.SetEntryPoint(nodes[0])
+	graph.SetExitPoint(nodes[len(nodes)-1])
+	return graph
+}
+
+// executeWithin runs graph, failing the test if it does not return in time.
+func executeWithin(t *testing.T, graph state.StateGraph, initial state.State) (state.State, error) {
+	t.Helper()
+
+	type outcome struct {
+		final state.State
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		final, err := graph.Execute(context.Background(), initial)
+		done <- outcome{final, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.final, out.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute() did not return")
+		return state.State{}, nil
+	}
+}
+
+func TestGraph_AsyncCheckpointDoesNotBlockExecution(t *testing.T) {
+	store := newGatedStore()
+	graph := asyncGraph(t, config.CheckpointConfig{Preserve: true}, nil, store, []string{"a", "b", "c"}, func(node string) {
+		// Every node runs while the first save is still held.
+		if node == "c" {
+			close(store.gate)
+		}
+	})
+
+	initial := state.New(nil)
+	if _, err := executeWithin(t, graph, initial); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := store.savedNodes(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("saved %v, want [a b c] in order", got)
+	}
+	latest := mustLoad(t, store, initial.RunID)
+	if latest.CheckpointNode != "c" {
+		t.Errorf("latest checkpoint node = %s, want c", latest.CheckpointNode)
+	}
+}
+
+func TestGraph_AsyncCheckpointFlushesBeforeCleanup(t *testing.T) {
+	store := newGatedStore()
+	store.delay = 5 * time.Millisecond
+	close(store.gate)
+
+	observer := &lockedObserver{}
+	graph := asyncGraph(t, config.CheckpointConfig{}, observer, store, []string{"a", "b", "c", "d"}, nil)
+
+	if _, err := executeWithin(t, graph, state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := store.savedNodes(); len(got) != 4 {
+		t.Errorf("saved %v, want every checkpoint flushed", got)
+	}
+	if ids, _ := store.List(); len(ids) != 0 {
+		t.Errorf("List() = %v, want checkpoint deleted after the flushed saves", ids)
+	}
+
+	saves := eventsOfType(observer.events, observability.EventCheckpointSave)
+	if len(saves) != 4 || saves[0].Data["async"] != true {
+		t.Errorf("save events = %v, want 4 async saves", saves)
+	}
+}
+
+func TestGraph_AsyncCheckpointErrors(t *testing.T) {
+	saveErr := errors.New("store offline")
+	store := faultyStore{CheckpointStore: state.NewMemoryCheckpointStore(), saveErr: saveErr}
+
+	var (
+		mu     sync.Mutex
+		failed []string
+	)
+	checkpoint := config.CheckpointConfig{
+		Preserve: true,
+		OnAsyncError: func(runID string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !errors.Is(err, saveErr) {
+				t.Errorf("OnAsyncError() error = %v, want %v", err, saveErr)
+			}
+			failed = append(failed, runID)
+		},
+	}
+
+	observer := &lockedObserver{}
+	graph := asyncGraph(t, checkpoint, observer, store, []string{"a", "b"}, nil)
+
+	initial := state.New(nil)
+	final, err := executeWithin(t, graph, initial)
+	if err != nil {
+		t.Fatalf("Execute() error = %v, want async save failures not to fail the run", err)
+	}
+	if step, _ := final.GetString("step"); step != "b" {
+		t.Errorf("step = %q, want b", step)
+	}
+
+	if !slices.Equal(failed, []string{initial.RunID, initial.RunID}) {
+		t.Errorf("OnAsyncError() runs = %v, want both saves reported", failed)
+	}
+	errs := eventsOfType(observer.events, observability.EventCheckpointError)
+	if len(errs) != 2 || errs[0].Data["operation"] != "save" {
+		t.Errorf("error events = %v, want 2 save failures", errs)
+	}
+}
+
+func TestGraph_AsyncCheckpointOverflowBlock(t *testing.T) {
+	store := newGatedStore()
+	var executed atomic.Int32
+	graph := asyncGraph(t, config.CheckpointConfig{AsyncQueueSize: 1, AsyncOverflow: "block", Preserve: true}, nil, store,
+		[]string{"a", "b", "c", "d", "e", "f"},
+		func(node string) {
+			executed.Add(1)
+			if node == "b" {
+				<-store.entered
+			}
+		})
+
+	// With a's save held and b's queued, c's save waits for room.
+	go func() {
+		for executed.Load() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if got := executed.Load(); got != 3 {
+			t.Errorf("executed %d nodes while the queue was full, want 3", got)
+		}
+		close(store.gate)
+	}()
+
+	if _, err := executeWithin(t, graph, state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := store.savedNodes(); !slices.Equal(got, []string{"a", "b", "c", "d", "e", "f"}) {
+		t.Errorf("saved %v, want every checkpoint", got)
+	}
+}
+
+func TestGraph_AsyncCheckpointOverflowDropOldest(t *testing.T) {
+	store := newGatedStore()
+	graph := asyncGraph(t, config.CheckpointConfig{AsyncQueueSize: 1, AsyncOverflow: "drop_oldest", Preserve: true}, nil, store,
+		[]string{"a", "b", "c", "d", "e", "f"},
+		func(node string) {
+			switch node {
+			case "b":
+				<-store.entered
+			case "f":
+				close(store.gate)
+			}
+		})
+
+	initial := state.New(nil)
+	if _, err := executeWithin(t, graph, initial); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// a's save was in flight; b, c, and d were each displaced by the next
+	// checkpoint while the queue was full.
+	saved := store.savedNodes()
+	if saved[0] != "a" || saved[len(saved)-1] != "f" {
+		t.Errorf("saved %v, want a first and f last", saved)
+	}
+	for _, dropped := range []string{"b", "c", "d"} {
+		if slices.Contains(saved, dropped) {
+			t.Errorf("saved %v, want %s dropped", saved, dropped)
+		}
+	}
+	if latest := mustLoad(t, store, initial.RunID); latest.CheckpointNode != "f" {
+		t.Errorf("latest checkpoint node = %s, want f", latest.CheckpointNode)
+	}
+}
+
+func TestNewGraph_UnknownAsyncOverflow(t *testing.T) {
+	cfg := config.DefaultGraphConfig("async")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Async = true
+	cfg.Checkpoint.AsyncOverflow = "spill"
+
+	if _, err := state.NewGraphWithDeps(cfg, nil, state.NewMemoryCheckpointStore()); err == nil {
+		t.Error("NewGraphWithDeps() error = nil, want unknown overflow rejected")
+	}
+}
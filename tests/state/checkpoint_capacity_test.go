@@ -0,0 +1,154 @@
+package state_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func runCheckpoint(runID string) state.State {
+	s := state.New(nil).Set("run", runID)
+	s.RunID = runID
+	return s
+}
+
+func memoryStats(t *testing.T, store state.CheckpointStore) state.MemoryStoreStats {
+	t.Helper()
+
+	reporter, ok := store.(state.MemoryStatsReporter)
+	if !ok {
+		t.Fatalf("%T does not implement MemoryStatsReporter", store)
+	}
+	return reporter.Stats()
+}
+
+func TestMemoryCheckpointStoreWithCapacity_EvictsLeastRecentlySaved(t *testing.T) {
+	store := state.NewMemoryCheckpointStoreWithCapacity(2)
+
+	store.Save(runCheckpoint("a"))
+	store.Save(runCheckpoint("b"))
+	store.Save(runCheckpoint("a")) // a is now the most recently saved
+	store.Save(runCheckpoint("c")) // evicts b
+
+	if _, err := store.Load("b"); err == nil || !strings.Contains(err.Error(), "checkpoint not found: b") {
+		t.Errorf("Load(b) error = %v, want checkpoint not found", err)
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, err := store.Load(id); err != nil {
+			t.Errorf("Load(%s) error = %v", id, err)
+		}
+	}
+
+	ids, _ := store.List()
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"a", "c"}) {
+		t.Errorf("List() = %v, want [a c]", ids)
+	}
+
+	want := state.MemoryStoreStats{Runs: 2, Capacity: 2, Evictions: 1}
+	if got := memoryStats(t, store); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryCheckpointStoreWithCapacity_EvictsHistory(t *testing.T) {
+	store := state.NewMemoryCheckpointStoreWithCapacity(1)
+	history := store.(state.HistoryStore)
+
+	store.Save(runCheckpoint("a"))
+	history.SaveHistory("a", state.StateHistory{})
+	store.Save(runCheckpoint("b"))
+
+	if _, err := history.LoadHistory("a"); err == nil {
+		t.Error("LoadHistory(a) error = nil, want evicted history")
+	}
+}
+
+func TestMemoryCheckpointStoreWithCapacity_DeleteFreesSlot(t *testing.T) {
+	store := state.NewMemoryCheckpointStoreWithCapacity(2)
+
+	store.Save(runCheckpoint("a"))
+	store.Save(runCheckpoint("b"))
+	store.Delete("a")
+	store.SaveIfAbsent(runCheckpoint("c"))
+
+	if _, err := store.Load("b"); err != nil {
+		t.Errorf("Load(b) error = %v, want b kept after delete freed a slot", err)
+	}
+	if stats := memoryStats(t, store); stats.Runs != 2 || stats.Evictions != 0 {
+		t.Errorf("Stats() = %+v, want 2 runs and no evictions", stats)
+	}
+}
+
+func TestMemoryCheckpointStore_Unbounded(t *testing.T) {
+	for _, store := range []state.CheckpointStore{
+		state.NewMemoryCheckpointStore(),
+		state.NewMemoryCheckpointStoreWithCapacity(0),
+	} {
+		for i := range 100 {
+			store.Save(runCheckpoint(fmt.Sprintf("run-%d", i)))
+		}
+		want := state.MemoryStoreStats{Runs: 100}
+		if got := memoryStats(t, store); got != want {
+			t.Errorf("Stats() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestMemoryCheckpointStore_MaxEntriesOption(t *testing.T) {
+	store, err := state.GetCheckpointStore("memory", map[string]any{"max_entries": float64(3)})
+	if err != nil {
+		t.Fatalf("GetCheckpointStore() error = %v", err)
+	}
+	if stats := memoryStats(t, store); stats.Capacity != 3 {
+		t.Errorf("Capacity = %d, want 3", stats.Capacity)
+	}
+
+	if _, err := state.GetCheckpointStore("memory", map[string]any{"max_entries": "3"}); err == nil {
+		t.Error("GetCheckpointStore() error = nil, want non-integer max_entries rejected")
+	}
+}
+
+func TestMemoryCheckpointStoreWithCapacity_Concurrent(t *testing.T) {
+	const (
+		capacity = 16
+		workers  = 8
+		runs     = 200
+	)
+	store := state.NewMemoryCheckpointStoreWithCapacity(capacity)
+	versioned := store.(state.VersionedCheckpointStore)
+	reporter := store.(state.MemoryStatsReporter)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for i := range runs {
+				id := fmt.Sprintf("w%d-%d", w, i)
+				store.Save(runCheckpoint(id))
+				store.Load(id)
+				store.List()
+				versioned.PruneVersions(id, 1)
+				if i%10 == 0 {
+					store.Delete(id)
+				}
+				reporter.Stats()
+			}
+		})
+	}
+	wg.Wait()
+
+	stats := memoryStats(t, store)
+	if stats.Runs > capacity {
+		t.Errorf("Runs = %d, want at most %d", stats.Runs, capacity)
+	}
+	if ids, _ := store.List(); len(ids) != stats.Runs {
+		t.Errorf("List() returned %d runs, Stats() reports %d", len(ids), stats.Runs)
+	}
+	if stats.Evictions == 0 {
+		t.Error("Evictions = 0, want evictions under load")
+	}
+}
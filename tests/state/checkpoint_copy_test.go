@@ -0,0 +1,135 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// flakyLoadStore fails Load for one run ID.
+type flakyLoadStore struct {
+	state.CheckpointStore
+	broken string
+}
+
+func (s flakyLoadStore) Load(runID string) (state.State, error) {
+	if runID == s.broken {
+		return state.State{}, errors.New("disk read error")
+	}
+	return s.CheckpointStore.Load(runID)
+}
+
+func seedCopySource(t *testing.T) (state.CheckpointStore, []state.State) {
+	t.Helper()
+
+	src := state.NewMemoryCheckpointStore()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	runs := make([]state.State, 0, 3)
+	for i, graph := range []string{"review", "ingest", "review"} {
+		s := state.New(nil).Set("step", i)
+		s.RunID = "run-" + string(rune('a'+i))
+		s.Graph = graph
+		s.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if err := src.Save(s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		runs = append(runs, s)
+	}
+	return src, runs
+}
+
+func TestCopyCheckpoints(t *testing.T) {
+	src, _ := seedCopySource(t)
+	dst, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	review := func(info state.CheckpointInfo) bool { return info.Graph == "review" }
+	report, err := state.CopyCheckpoints(context.Background(), src, dst, review)
+	if err != nil {
+		t.Fatalf("CopyCheckpoints() error = %v", err)
+	}
+	if want := []string{"run-a", "run-c"}; !reflect.DeepEqual(report.Copied, want) {
+		t.Errorf("Copied = %v, want %v", report.Copied, want)
+	}
+	if len(report.Skipped) != 0 || report.Err() != nil {
+		t.Errorf("Skipped = %v, Err() = %v; want none", report.Skipped, report.Err())
+	}
+
+	loaded, err := dst.Load("run-c")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if v, _ := loaded.Get("step"); v != float64(2) || loaded.Graph != "review" {
+		t.Errorf("copied run-c step=%v graph=%q, want 2 in review", v, loaded.Graph)
+	}
+
+	// A second pass copies only what is missing.
+	report, err = state.CopyCheckpoints(context.Background(), src, dst, nil)
+	if err != nil {
+		t.Fatalf("CopyCheckpoints() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Copied, []string{"run-b"}) || !reflect.DeepEqual(report.Skipped, []string{"run-a", "run-c"}) {
+		t.Errorf("rerun Copied = %v, Skipped = %v; want [run-b], [run-a run-c]", report.Copied, report.Skipped)
+	}
+}
+
+func TestCopyCheckpoints_Force(t *testing.T) {
+	src, runs := seedCopySource(t)
+	dst := state.NewMemoryCheckpointStore()
+	dst.Save(runs[0].Set("step", "stale"))
+
+	report, err := state.CopyCheckpointsWithOptions(context.Background(), src, dst, state.CopyOptions{Force: true})
+	if err != nil {
+		t.Fatalf("CopyCheckpointsWithOptions() error = %v", err)
+	}
+	if len(report.Copied) != 3 || len(report.Skipped) != 0 {
+		t.Errorf("Copied = %v, Skipped = %v; want all three copied", report.Copied, report.Skipped)
+	}
+
+	loaded, _ := dst.Load(runs[0].RunID)
+	if v, _ := loaded.Get("step"); v != 0 {
+		t.Errorf("step = %v, want 0 (overwritten)", v)
+	}
+}
+
+func TestCopyCheckpoints_PerRunErrors(t *testing.T) {
+	src, _ := seedCopySource(t)
+	dst := state.NewMemoryCheckpointStore()
+
+	report, err := state.CopyCheckpoints(context.Background(), flakyLoadStore{src, "run-b"}, dst, nil)
+	if err != nil {
+		t.Fatalf("CopyCheckpoints() error = %v", err)
+	}
+	if want := []string{"run-a", "run-c"}; !reflect.DeepEqual(report.Copied, want) {
+		t.Errorf("Copied = %v, want %v (copy continues past failure)", report.Copied, want)
+	}
+	if _, failed := report.Failed["run-b"]; !failed || len(report.Failed) != 1 {
+		t.Errorf("Failed = %v, want run-b only", report.Failed)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "run-b") || !strings.Contains(err.Error(), "disk read error") {
+		t.Errorf("Err() = %v, want run-b disk read error", err)
+	}
+}
+
+func TestCopyCheckpoints_Cancelled(t *testing.T) {
+	src, _ := seedCopySource(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := state.CopyCheckpoints(ctx, src, state.NewMemoryCheckpointStore(), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CopyCheckpoints() error = %v, want context.Canceled", err)
+	}
+	if len(report.Copied) != 0 {
+		t.Errorf("Copied = %v, want none", report.Copied)
+	}
+}
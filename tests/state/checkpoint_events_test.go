@@ -0,0 +1,182 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// faultyStore fails the operations whose errors are set.
+type faultyStore struct {
+	state.CheckpointStore
+	saveErr   error
+	deleteErr error
+}
+
+func (s faultyStore) Save(st state.State) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	return s.CheckpointStore.Save(st)
+}
+
+func (s faultyStore) Delete(runID string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	return s.CheckpointStore.Delete(runID)
+}
+
+func checkpointedGraph(t *testing.T, observer observability.Observer, store state.CheckpointStore) state.StateGraph {
+	t.Helper()
+
+	cfg := config.DefaultGraphConfig("metrics")
+	cfg.Checkpoint.Interval = 1
+	graph, err := state.NewGraphWithDeps(cfg, observer, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNode("a", simpleNode("step", "a"))
+	graph.AddNode("b", simpleNode("step", "b"))
+	graph.AddEdge("a", "b", nil)
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("b")
+	return graph
+}
+
+func eventsOfType(events []observability.Event, eventType observability.EventType) []observability.Event {
+	var matched []observability.Event
+	for _, event := range events {
+		if event.Type == eventType {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+func TestGraph_CheckpointSaveMetrics(t *testing.T) {
+	observer := &captureObserver{}
+	graph := checkpointedGraph(t, observer, state.NewMemoryCheckpointStore())
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	saves := eventsOfType(observer.events, observability.EventCheckpointSave)
+	if len(saves) != 2 {
+		t.Fatalf("got %d save events, want 2", len(saves))
+	}
+	for _, event := range saves {
+		if _, ok := event.Data["duration"].(time.Duration); !ok {
+			t.Errorf("duration = %#v, want time.Duration", event.Data["duration"])
+		}
+		if size, ok := event.Data["size"].(int); !ok || size <= 0 {
+			t.Errorf("size = %#v, want positive int", event.Data["size"])
+		}
+		if event.Data["store"] != "state.memoryCheckpointStore" {
+			t.Errorf("store = %v, want state.memoryCheckpointStore", event.Data["store"])
+		}
+	}
+	if errs := eventsOfType(observer.events, observability.EventCheckpointError); len(errs) != 0 {
+		t.Errorf("got %d error events, want none", len(errs))
+	}
+}
+
+func TestGraph_CheckpointSaveError(t *testing.T) {
+	observer := &captureObserver{}
+	store := faultyStore{CheckpointStore: state.NewMemoryCheckpointStore(), saveErr: errors.New("disk full")}
+	graph := checkpointedGraph(t, observer, store)
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err == nil {
+		t.Fatal("Execute() error = nil, want save failure")
+	}
+
+	errs := eventsOfType(observer.events, observability.EventCheckpointError)
+	if len(errs) != 1 {
+		t.Fatalf("got %d error events, want 1", len(errs))
+	}
+	data := errs[0].Data
+	if data["operation"] != "save" || data["node"] != "a" || data["error"] != "disk full" {
+		t.Errorf("error event data = %v, want save failure at node a", data)
+	}
+}
+
+func TestGraph_CheckpointDeleteError(t *testing.T) {
+	observer := &captureObserver{}
+	store := faultyStore{CheckpointStore: state.NewMemoryCheckpointStore(), deleteErr: errors.New("permission denied")}
+	graph := checkpointedGraph(t, observer, store)
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute() error = %v, want success despite delete failure", err)
+	}
+
+	errs := eventsOfType(observer.events, observability.EventCheckpointError)
+	if len(errs) != 1 {
+		t.Fatalf("got %d error events, want 1", len(errs))
+	}
+	data := errs[0].Data
+	if data["operation"] != "delete" || data["run_id"] != initial.RunID || data["error"] != "permission denied" {
+		t.Errorf("error event data = %v, want delete failure for %s", data, initial.RunID)
+	}
+}
+
+func TestGraph_CheckpointLoadMetrics(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	checkpoint := state.New(nil).Set("step", "a").SetCheckpointNode("a")
+	store.Save(checkpoint)
+
+	observer := &captureObserver{}
+	graph := checkpointedGraph(t, observer, store)
+
+	if _, err := graph.Resume(context.Background(), checkpoint.RunID); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	loads := eventsOfType(observer.events, observability.EventCheckpointLoad)
+	if len(loads) != 1 {
+		t.Fatalf("got %d load events, want 1", len(loads))
+	}
+	if _, ok := loads[0].Data["duration"].(time.Duration); !ok {
+		t.Errorf("duration = %#v, want time.Duration", loads[0].Data["duration"])
+	}
+
+	observer.events = nil
+	if _, err := graph.Resume(context.Background(), "missing"); err == nil {
+		t.Fatal("Resume() error = nil, want missing checkpoint")
+	}
+	errs := eventsOfType(observer.events, observability.EventCheckpointError)
+	if len(errs) != 1 || errs[0].Data["operation"] != "load" || errs[0].Data["run_id"] != "missing" {
+		t.Errorf("error events = %v, want one load failure for missing", errs)
+	}
+}
+
+func TestGraph_CheckpointStoreName(t *testing.T) {
+	cfg := config.DefaultGraphConfig("named")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Store = "memory"
+
+	observer := &captureObserver{}
+	observability.RegisterObserver("checkpoint-metrics", observer)
+	cfg.Observer = "checkpoint-metrics"
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+	graph.AddNode("only", simpleNode("k", "v"))
+	graph.SetEntryPoint("only")
+	graph.SetExitPoint("only")
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	saves := eventsOfType(observer.events, observability.EventCheckpointSave)
+	if len(saves) != 1 || saves[0].Data["store"] != "memory" {
+		t.Errorf("save events = %v, want one from store memory", saves)
+	}
+}
@@ -0,0 +1,128 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// graphCheckpoint returns a checkpoint of runID taken by graph, i minutes
+// after a fixed base time.
+func graphCheckpoint(runID, graph string, i int) state.State {
+	s := checkpointAt(runID, "node", time.Date(2026, 3, 1, 0, i, 0, 0, time.UTC))
+	s.Graph = graph
+	return s
+}
+
+func runIDs(infos []state.CheckpointInfo) []string {
+	ids := make([]string, 0, len(infos))
+	for _, info := range infos {
+		ids = append(ids, info.RunID)
+	}
+	return ids
+}
+
+func graphStores(t *testing.T) map[string]state.CheckpointStore {
+	t.Helper()
+
+	file, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	return map[string]state.CheckpointStore{
+		"memory":   state.NewMemoryCheckpointStore(),
+		"file":     file,
+		"fallback": listOnlyStore{state.NewMemoryCheckpointStore()},
+	}
+}
+
+func TestListCheckpointsByGraph(t *testing.T) {
+	for name, store := range graphStores(t) {
+		t.Run(name, func(t *testing.T) {
+			store.Save(graphCheckpoint("review-2", "review", 2))
+			store.Save(graphCheckpoint("ingest-1", "ingest", 1))
+			store.Save(graphCheckpoint("review-1", "review", 0))
+			store.Save(graphCheckpoint("adhoc", "", 3))
+
+			infos, err := state.ListCheckpointsByGraph(store, "review")
+			if err != nil {
+				t.Fatalf("ListCheckpointsByGraph() error = %v", err)
+			}
+			if got, want := runIDs(infos), []string{"review-1", "review-2"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("review runs = %v, want %v", got, want)
+			}
+			if infos[0].Graph != "review" || infos[0].Node != "node" {
+				t.Errorf("info = %+v, want review graph metadata", infos[0])
+			}
+
+			if infos, _ := state.ListCheckpointsByGraph(store, ""); !reflect.DeepEqual(runIDs(infos), []string{"adhoc"}) {
+				t.Errorf("ungraphed runs = %v, want [adhoc]", runIDs(infos))
+			}
+			if infos, _ := state.ListCheckpointsByGraph(store, "unknown"); len(infos) != 0 {
+				t.Errorf("unknown graph runs = %v, want none", runIDs(infos))
+			}
+
+			// Deleted runs and runs re-saved by another graph leave the index.
+			store.Delete("review-1")
+			store.Save(graphCheckpoint("review-2", "ingest", 4))
+
+			if infos, _ := state.ListCheckpointsByGraph(store, "review"); len(infos) != 0 {
+				t.Errorf("review runs after delete and move = %v, want none", runIDs(infos))
+			}
+			infos, _ = state.ListCheckpointsByGraph(store, "ingest")
+			if got, want := runIDs(infos), []string{"ingest-1", "review-2"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("ingest runs = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFileCheckpointStore_ListByGraphIndexesExistingCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	store, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	store.Save(graphCheckpoint("run-a", "review", 0))
+	store.Save(graphCheckpoint("run-b", "ingest", 1))
+
+	// Simulate a directory written before graph indexing existed.
+	if err := os.RemoveAll(filepath.Join(dir, ".graphs")); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	infos, err := state.ListCheckpointsByGraph(reopened, "review")
+	if err != nil {
+		t.Fatalf("ListCheckpointsByGraph() error = %v", err)
+	}
+	if !reflect.DeepEqual(runIDs(infos), []string{"run-a"}) {
+		t.Errorf("review runs = %v, want [run-a]", runIDs(infos))
+	}
+
+	if ids, _ := reopened.List(); len(ids) != 2 {
+		t.Errorf("List() = %v, want the index directory ignored", ids)
+	}
+}
+
+func TestLoadLatest_UsesGraphIndex(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	store.Save(graphCheckpoint("old", "review", 0))
+	store.Save(graphCheckpoint("new", "review", 5))
+	store.Save(graphCheckpoint("other", "ingest", 9))
+
+	latest, err := state.LoadLatest(store, "review")
+	if err != nil {
+		t.Fatalf("LoadLatest() error = %v", err)
+	}
+	if latest.RunID != "new" {
+		t.Errorf("LoadLatest() = %s, want new", latest.RunID)
+	}
+}
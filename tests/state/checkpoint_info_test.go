@@ -0,0 +1,79 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// listOnlyStore hides the memory store's ListInfo to exercise the fallback.
+type listOnlyStore struct {
+	state.CheckpointStore
+}
+
+func checkpointAt(runID, node string, at time.Time) state.State {
+	s := state.New(nil).Set("payload", "data").SetCheckpointNode(node)
+	s.RunID = runID
+	s.Timestamp = at
+	return s
+}
+
+func TestListCheckpointInfo(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	file, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	stores := map[string]state.CheckpointStore{
+		"memory":   state.NewMemoryCheckpointStore(),
+		"file":     file,
+		"fallback": listOnlyStore{state.NewMemoryCheckpointStore()},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			store.Save(checkpointAt("run-b", "review", base.Add(time.Hour)))
+			store.Save(checkpointAt("run-a", "ingest", base))
+
+			infos, err := state.ListCheckpointInfo(store)
+			if err != nil {
+				t.Fatalf("ListCheckpointInfo() error = %v", err)
+			}
+			if len(infos) != 2 {
+				t.Fatalf("ListCheckpointInfo() returned %d entries, want 2", len(infos))
+			}
+
+			first, second := infos[0], infos[1]
+			if first.RunID != "run-a" || first.Node != "ingest" || !first.Timestamp.Equal(base) {
+				t.Errorf("infos[0] = %+v, want run-a at ingest", first)
+			}
+			if second.RunID != "run-b" || second.Node != "review" {
+				t.Errorf("infos[1] = %+v, want run-b at review", second)
+			}
+			if first.Size <= 0 {
+				t.Errorf("Size = %d, want positive", first.Size)
+			}
+		})
+	}
+}
+
+func TestListCheckpointInfo_LoadError(t *testing.T) {
+	store := listOnlyStore{brokenLoadStore{state.NewMemoryCheckpointStore()}}
+	store.Save(checkpointAt("run-a", "ingest", time.Now()))
+
+	if _, err := state.ListCheckpointInfo(store); err == nil {
+		t.Error("ListCheckpointInfo() should fail when a checkpoint cannot be loaded")
+	}
+}
+
+type brokenLoadStore struct {
+	state.CheckpointStore
+}
+
+func (brokenLoadStore) Load(runID string) (state.State, error) {
+	return state.State{}, errors.New("storage unavailable")
+}
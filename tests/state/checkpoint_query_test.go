@@ -0,0 +1,139 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func graphCheckpointAt(graph, runID, node string, at time.Time) state.State {
+	s := checkpointAt(runID, node, at)
+	s.Graph = graph
+	return s
+}
+
+func TestLoadLatest(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	file, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	stores := map[string]state.CheckpointStore{
+		"memory":   state.NewMemoryCheckpointStore(),
+		"file":     file,
+		"fallback": listOnlyStore{state.NewMemoryCheckpointStore()},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			store.Save(graphCheckpointAt("review", "run-a", "ingest", base))
+			store.Save(graphCheckpointAt("review", "run-c", "classify", base.Add(time.Hour)))
+			store.Save(graphCheckpointAt("review", "run-b", "classify", base.Add(time.Hour)))
+			store.Save(graphCheckpointAt("billing", "run-d", "invoice", base.Add(2*time.Hour)))
+
+			latest, err := state.LoadLatest(store, "review")
+			if err != nil {
+				t.Fatalf("LoadLatest() error = %v", err)
+			}
+			if latest.RunID != "run-c" {
+				t.Errorf("LoadLatest() RunID = %s, want run-c (timestamp tie broken by run ID)", latest.RunID)
+			}
+
+			if _, err := state.LoadLatest(store, "missing"); err == nil {
+				t.Error("LoadLatest() for a graph without checkpoints should fail")
+			}
+		})
+	}
+}
+
+func TestLoadLatest_GraphStampsCheckpoints(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	cfg := config.DefaultGraphConfig("stamped")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNode("only", simpleNode("k", "v"))
+	graph.SetEntryPoint("only")
+	graph.SetExitPoint("only")
+
+	final, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if final.Graph != "stamped" {
+		t.Errorf("final Graph = %q, want stamped", final.Graph)
+	}
+
+	latest, err := state.LoadLatest(store, "stamped")
+	if err != nil {
+		t.Fatalf("LoadLatest() error = %v", err)
+	}
+	if latest.RunID != final.RunID {
+		t.Errorf("LoadLatest() RunID = %s, want %s", latest.RunID, final.RunID)
+	}
+}
+
+func TestLoadAtNode(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	cfg := config.DefaultGraphConfig("looping")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+	cfg.Checkpoint.MaxVersions = 10
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNodeFunc("draft", func(ctx context.Context, s state.State) (state.State, error) {
+		n, _ := s.GetInt("drafts")
+		return s.Set("drafts", n+1), nil
+	})
+	graph.AddNode("review", simpleNode("reviewed", "yes"))
+	graph.AddNode("publish", simpleNode("published", "yes"))
+	graph.AddEdge("draft", "review", nil)
+	graph.AddEdge("review", "draft", func(s state.State) bool {
+		n, _ := s.GetInt("drafts")
+		return n < 2
+	})
+	graph.AddEdge("review", "publish", nil)
+	graph.SetEntryPoint("draft")
+	graph.SetExitPoint("publish")
+
+	final, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	draft, err := state.LoadAtNode(store, final.RunID, "draft")
+	if err != nil {
+		t.Fatalf("LoadAtNode() error = %v", err)
+	}
+	if draft.CheckpointNode != "draft" {
+		t.Errorf("CheckpointNode = %s, want draft", draft.CheckpointNode)
+	}
+	if n, _ := draft.GetInt("drafts"); n != 2 {
+		t.Errorf("drafts = %d, want 2 (last visit)", n)
+	}
+
+	if _, err := state.LoadAtNode(store, final.RunID, "missing"); err == nil {
+		t.Error("LoadAtNode() for a node without checkpoints should fail")
+	}
+
+	plain := listOnlyStore{state.NewMemoryCheckpointStore()}
+	plain.Save(checkpointAt("run-x", "review", time.Now()))
+	if _, err := state.LoadAtNode(plain, "run-x", "review"); err != nil {
+		t.Errorf("LoadAtNode() on latest checkpoint error = %v", err)
+	}
+	if _, err := state.LoadAtNode(plain, "run-x", "draft"); err == nil {
+		t.Error("LoadAtNode() for an earlier node on an unversioned store should fail")
+	}
+}
@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -263,7 +264,7 @@ func TestMemoryCheckpointStore_Overwrite(t *testing.T) {
 }
 
 func TestCheckpointStore_Registry(t *testing.T) {
-	store, err := state.GetCheckpointStore("memory")
+	store, err := state.GetCheckpointStore("memory", nil)
 	if err != nil {
 		t.Fatalf("GetCheckpointStore failed: %v", err)
 	}
@@ -271,10 +272,16 @@ func TestCheckpointStore_Registry(t *testing.T) {
 	if store == nil {
 		t.Error("Expected non-nil store")
 	}
+
+	other, _ := state.GetCheckpointStore("memory", nil)
+	store.Save(state.New(nil))
+	if ids, _ := other.List(); len(ids) != 0 {
+		t.Errorf("Expected memory stores not to share checkpoints, got %v", ids)
+	}
 }
 
 func TestCheckpointStore_Registry_NotFound(t *testing.T) {
-	_, err := state.GetCheckpointStore("nonexistent")
+	_, err := state.GetCheckpointStore("nonexistent", nil)
 	if err == nil {
 		t.Error("Expected error for nonexistent store")
 	}
@@ -284,7 +291,7 @@ func TestCheckpointStore_RegisterCustomStore(t *testing.T) {
 	customStore := state.NewMemoryCheckpointStore()
 	state.RegisterCheckpointStore("custom", customStore)
 
-	retrieved, err := state.GetCheckpointStore("custom")
+	retrieved, err := state.GetCheckpointStore("custom", map[string]any{"ignored": true})
 	if err != nil {
 		t.Fatalf("GetCheckpointStore failed: %v", err)
 	}
@@ -294,6 +301,75 @@ func TestCheckpointStore_RegisterCustomStore(t *testing.T) {
 	}
 }
 
+func TestCheckpointStore_RegisterFactory(t *testing.T) {
+	var received map[string]any
+	state.RegisterCheckpointStoreFactory("factory", func(options map[string]any) (state.CheckpointStore, error) {
+		received = options
+		if options["fail"] == true {
+			return nil, errors.New("bad options")
+		}
+		return state.NewMemoryCheckpointStore(), nil
+	})
+
+	cfg := config.DefaultGraphConfig("factory")
+	cfg.Checkpoint.Store = "factory"
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Options = map[string]any{"tenant": "acme"}
+
+	if _, err := state.NewGraph(cfg); err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+	if received["tenant"] != "acme" || received["codec"] != "json" {
+		t.Errorf("Expected factory to receive config options, got %v", received)
+	}
+
+	cfg.Checkpoint.Options = map[string]any{"fail": true}
+	if _, err := state.NewGraph(cfg); err == nil {
+		t.Error("Expected NewGraph to fail when the factory rejects options")
+	}
+}
+
+func TestCheckpointStore_FileFactory(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+
+	for _, dir := range []string{first, second} {
+		cfg := config.DefaultGraphConfig("file")
+		cfg.Checkpoint.Store = "file"
+		cfg.Checkpoint.Interval = 1
+		cfg.Checkpoint.Preserve = true
+		cfg.Checkpoint.Options = map[string]any{"dir": dir, "codec": "gob"}
+
+		graph, err := state.NewGraph(cfg)
+		if err != nil {
+			t.Fatalf("NewGraph failed: %v", err)
+		}
+		graph.AddNode("only", simpleNode("k", "v"))
+		graph.SetEntryPoint("only")
+		graph.SetExitPoint("only")
+
+		if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+
+	for _, dir := range []string{first, second} {
+		store, err := state.GetCheckpointStore("file", map[string]any{"dir": dir, "codec": "gob"})
+		if err != nil {
+			t.Fatalf("GetCheckpointStore failed: %v", err)
+		}
+		if ids, _ := store.List(); len(ids) != 1 {
+			t.Errorf("Expected one checkpoint in %s, got %v", dir, ids)
+		}
+	}
+
+	if _, err := state.GetCheckpointStore("file", map[string]any{"dir": 42}); err == nil {
+		t.Error("Expected error for non-string dir option")
+	}
+	if _, err := state.GetCheckpointStore("file", nil); err == nil {
+		t.Error("Expected error for missing dir option")
+	}
+}
+
 func TestGraph_Checkpoint_Disabled(t *testing.T) {
 	cfg := config.DefaultGraphConfig("test")
 	cfg.Checkpoint.Interval = 0
@@ -330,7 +406,8 @@ func TestGraph_Checkpoint_SaveAtInterval(t *testing.T) {
 	cfg.Checkpoint.Interval = 1
 	cfg.Checkpoint.Store = "memory"
 
-	graph, err := state.NewGraph(cfg)
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
 	if err != nil {
 		t.Fatalf("NewGraph failed: %v", err)
 	}
@@ -356,7 +433,6 @@ func TestGraph_Checkpoint_SaveAtInterval(t *testing.T) {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
-	store, _ := state.GetCheckpointStore("memory")
 	_, err = store.Load(runID)
 	if err == nil {
 		t.Error("Expected checkpoint to be deleted after successful completion (Preserve=false)")
@@ -369,7 +445,8 @@ func TestGraph_Checkpoint_PreserveOnSuccess(t *testing.T) {
 	cfg.Checkpoint.Store = "memory"
 	cfg.Checkpoint.Preserve = true
 
-	graph, err := state.NewGraph(cfg)
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
 	if err != nil {
 		t.Fatalf("NewGraph failed: %v", err)
 	}
@@ -392,7 +469,6 @@ func TestGraph_Checkpoint_PreserveOnSuccess(t *testing.T) {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
-	store, _ := state.GetCheckpointStore("memory")
 	loaded, err := store.Load(runID)
 	if err != nil {
 		t.Errorf("Expected checkpoint to be preserved, got error: %v", err)
@@ -411,7 +487,8 @@ func TestGraph_Resume_FromCheckpoint(t *testing.T) {
 	cfg.Checkpoint.Store = "memory"
 	cfg.Checkpoint.Preserve = true
 
-	graph, err := state.NewGraph(cfg)
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
 	if err != nil {
 		t.Fatalf("NewGraph failed: %v", err)
 	}
@@ -441,7 +518,6 @@ func TestGraph_Resume_FromCheckpoint(t *testing.T) {
 		t.Errorf("Expected checkpoint at node3, got %s", partialState.CheckpointNode)
 	}
 
-	store, _ := state.GetCheckpointStore("memory")
 	checkpointed := partialState.SetCheckpointNode("node1")
 	store.Save(checkpointed)
 
@@ -466,6 +542,39 @@ func TestGraph_Resume_FromCheckpoint(t *testing.T) {
 	store.Delete(runID)
 }
 
+func TestGraph_Resume_AttachesGraphObserver(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	observer := &captureObserver{}
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, observer, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps failed: %v", err)
+	}
+
+	graph.AddNode("node1", simpleNode("step", "1"))
+	graph.AddNode("node2", simpleNode("step", "2"))
+	graph.AddEdge("node1", "node2", nil)
+	graph.SetEntryPoint("node1")
+	graph.SetExitPoint("node2")
+
+	checkpoint := state.New(nil).Set("step", "1").SetCheckpointNode("node1")
+	store.Save(checkpoint)
+
+	if _, err := graph.Resume(context.Background(), checkpoint.RunID); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	for _, event := range observer.events {
+		if event.Type == observability.EventStateSet && event.Data["key"] == "step" {
+			return
+		}
+	}
+	t.Error("state events from resumed nodes should reach the graph observer")
+}
+
 func TestGraph_Resume_CheckpointingDisabled(t *testing.T) {
 	cfg := config.DefaultGraphConfig("test")
 	cfg.Checkpoint.Interval = 0
@@ -512,7 +621,8 @@ func TestGraph_Resume_AtExitPoint(t *testing.T) {
 	cfg.Checkpoint.Interval = 1
 	cfg.Checkpoint.Store = "memory"
 
-	graph, err := state.NewGraph(cfg)
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
 	if err != nil {
 		t.Fatalf("NewGraph failed: %v", err)
 	}
@@ -531,7 +641,6 @@ func TestGraph_Resume_AtExitPoint(t *testing.T) {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
-	store, _ := state.GetCheckpointStore("memory")
 	checkpointed := initialState.SetCheckpointNode("node1")
 	store.Save(checkpointed)
 
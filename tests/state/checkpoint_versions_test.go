@@ -0,0 +1,188 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestVersionedCheckpointStore(t *testing.T) {
+	file, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	stores := map[string]state.CheckpointStore{
+		"memory": state.NewMemoryCheckpointStore(),
+		"file":   file,
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			store, ok := s.(state.VersionedCheckpointStore)
+			if !ok {
+				t.Fatal("store does not implement VersionedCheckpointStore")
+			}
+
+			base := state.New(nil)
+			for _, node := range []string{"a", "b", "c"} {
+				if err := store.Save(base.Set("node", node).SetCheckpointNode(node)); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+			}
+
+			latest, err := store.Load(base.RunID)
+			if err != nil || latest.CheckpointNode != "c" {
+				t.Fatalf("Load() = %q, %v; want latest at c", latest.CheckpointNode, err)
+			}
+
+			first, err := store.LoadVersion(base.RunID, 1)
+			if err != nil || first.CheckpointNode != "a" {
+				t.Fatalf("LoadVersion(1) = %q, %v; want a", first.CheckpointNode, err)
+			}
+
+			versions, err := store.Versions(base.RunID)
+			if err != nil {
+				t.Fatalf("Versions() error = %v", err)
+			}
+			if len(versions) != 3 {
+				t.Fatalf("Versions() returned %d entries, want 3", len(versions))
+			}
+			for i, info := range versions {
+				if info.Version != i+1 || info.Node != []string{"a", "b", "c"}[i] || info.RunID != base.RunID {
+					t.Errorf("versions[%d] = %+v", i, info)
+				}
+			}
+
+			if err := store.PruneVersions(base.RunID, 2); err != nil {
+				t.Fatalf("PruneVersions() error = %v", err)
+			}
+			if _, err := store.LoadVersion(base.RunID, 1); err == nil {
+				t.Error("LoadVersion(1) after pruning should fail")
+			}
+			if v, _ := store.LoadVersion(base.RunID, 2); v.CheckpointNode != "b" {
+				t.Errorf("LoadVersion(2) after pruning = %q, want b", v.CheckpointNode)
+			}
+
+			store.Save(base.SetCheckpointNode("d"))
+			versions, _ = store.Versions(base.RunID)
+			if last := versions[len(versions)-1]; last.Version != 4 {
+				t.Errorf("version after pruning = %d, want 4", last.Version)
+			}
+
+			ids, _ := store.List()
+			if len(ids) != 1 || ids[0] != base.RunID {
+				t.Errorf("List() = %v, want one run", ids)
+			}
+
+			if err := store.Delete(base.RunID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := store.Versions(base.RunID); err == nil {
+				t.Error("Versions() after Delete should fail")
+			}
+			if _, err := store.LoadVersion(base.RunID, 4); err == nil {
+				t.Error("LoadVersion() after Delete should fail")
+			}
+		})
+	}
+}
+
+func TestGraph_CheckpointMaxVersions(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+
+	cfg := config.DefaultGraphConfig("versions")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+	cfg.Checkpoint.MaxVersions = 2
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNode("node1", simpleNode("step", "1"))
+	graph.AddNode("node2", simpleNode("step", "2"))
+	graph.AddNode("node3", simpleNode("step", "3"))
+	graph.AddEdge("node1", "node2", nil)
+	graph.AddEdge("node2", "node3", nil)
+	graph.SetEntryPoint("node1")
+	graph.SetExitPoint("node3")
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	versions, err := store.(state.VersionedCheckpointStore).Versions(initial.RunID)
+	if err != nil {
+		t.Fatalf("Versions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].Node != "node2" || versions[1].Node != "node3" {
+		t.Errorf("Versions() = %+v, want node2 and node3", versions)
+	}
+}
+
+func TestGraph_ResumeVersion(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+
+	cfg := config.DefaultGraphConfig("resume-version")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.MaxVersions = 5
+
+	graph, _ := state.NewGraphWithDeps(cfg, nil, store)
+
+	failing := true
+	graph.AddNode("plan", simpleNode("plan", "ok"))
+	graph.AddNode("draft", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		if failing {
+			return s.Set("draft", "bad"), nil
+		}
+		return s.Set("draft", "good"), nil
+	}))
+	graph.AddNode("publish", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		if v, _ := s.Get("draft"); v == "bad" {
+			return s, errors.New("draft rejected")
+		}
+		return s.Set("published", true), nil
+	}))
+	graph.AddEdge("plan", "draft", nil)
+	graph.AddEdge("draft", "publish", nil)
+	graph.SetEntryPoint("plan")
+	graph.SetExitPoint("publish")
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err == nil {
+		t.Fatal("Execute() should fail on the bad draft")
+	}
+
+	versions, _ := store.(state.VersionedCheckpointStore).Versions(initial.RunID)
+	if len(versions) != 2 || versions[0].Node != "plan" {
+		t.Fatalf("Versions() = %+v, want plan and draft", versions)
+	}
+
+	failing = false
+	final, err := graph.ResumeVersion(context.Background(), initial.RunID, versions[0].Version)
+	if err != nil {
+		t.Fatalf("ResumeVersion() error = %v", err)
+	}
+	if v, _ := final.Get("draft"); v != "good" {
+		t.Errorf("draft = %v, want good (redone from plan)", v)
+	}
+	if v, _ := final.Get("published"); v != true {
+		t.Errorf("published = %v, want true", v)
+	}
+}
+
+func TestGraph_ResumeVersion_Unsupported(t *testing.T) {
+	cfg := config.DefaultGraphConfig("unversioned")
+	cfg.Checkpoint.Interval = 1
+
+	graph, _ := state.NewGraphWithDeps(cfg, nil, listOnlyStore{state.NewMemoryCheckpointStore()})
+
+	if _, err := graph.ResumeVersion(context.Background(), "run", 1); err == nil {
+		t.Error("ResumeVersion() with an unversioned store should fail")
+	}
+}
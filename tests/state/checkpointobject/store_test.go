@@ -0,0 +1,191 @@
+package checkpointobject_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state/checkpointobject"
+)
+
+// failingClient wraps a MemoryClient and fails Get with a transport error.
+type failingClient struct {
+	*checkpointobject.MemoryClient
+}
+
+func (failingClient) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestStore_SaveLoadDelete(t *testing.T) {
+	client := checkpointobject.NewMemoryClient()
+	store, err := checkpointobject.New(client, checkpointobject.Options{Prefix: "/checkpoints/review/"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	s := state.New(nil).Set("count", 3).SetCheckpointNode("review")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	key := "checkpoints/review/" + s.RunID + ".json"
+	if ct := client.ContentType(key); ct != "application/json" {
+		t.Errorf("ContentType(%s) = %q, want application/json", key, ct)
+	}
+
+	loaded, err := store.Load(s.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.CheckpointNode != "review" {
+		t.Errorf("CheckpointNode = %q, want review", loaded.CheckpointNode)
+	}
+	if v, _ := loaded.Get("count"); v != float64(3) {
+		t.Errorf("count = %v, want 3 (JSON codec)", v)
+	}
+
+	if err := store.Delete(s.RunID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(s.RunID); err != nil {
+		t.Errorf("Delete() of missing checkpoint error = %v", err)
+	}
+	if _, err := store.Load(s.RunID); err == nil || !strings.Contains(err.Error(), "checkpoint not found") {
+		t.Errorf("Load() after Delete error = %v, want checkpoint not found", err)
+	}
+}
+
+func TestStore_LoadTransportError(t *testing.T) {
+	store, _ := checkpointobject.New(failingClient{checkpointobject.NewMemoryClient()}, checkpointobject.Options{})
+
+	_, err := store.Load("run-1")
+	if err == nil {
+		t.Fatal("Load() should fail")
+	}
+	if strings.Contains(err.Error(), "checkpoint not found") {
+		t.Errorf("Load() error = %v, transport failure reported as not found", err)
+	}
+}
+
+func TestStore_ListPaginates(t *testing.T) {
+	client := checkpointobject.NewMemoryClient()
+	client.PageSize = 3
+
+	store, _ := checkpointobject.New(client, checkpointobject.Options{Prefix: "runs", Codec: state.GobCodec{}})
+
+	const runs = 10
+	for i := range runs {
+		s := state.New(nil)
+		s.RunID = fmt.Sprintf("run-%02d", i)
+		if err := store.Save(s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	client.Put(ctx, "runs/nested/run-99.json", []byte("{}"), "application/json")
+	client.Put(ctx, "runs/notes.txt", []byte("x"), "text/plain")
+	client.Put(ctx, "other/run-98.json", []byte("{}"), "application/json")
+
+	if ct := client.ContentType("runs/run-00.json"); ct != "application/octet-stream" {
+		t.Errorf("gob ContentType = %q, want application/octet-stream", ct)
+	}
+
+	ids, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(ids) != runs {
+		t.Fatalf("List() = %v, want %d runs", ids, runs)
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("run-%02d", i); id != want {
+			t.Errorf("ids[%d] = %s, want %s", i, id, want)
+		}
+	}
+}
+
+func TestStore_InvalidInput(t *testing.T) {
+	if _, err := checkpointobject.New(nil, checkpointobject.Options{}); err == nil {
+		t.Error("New() with nil client should fail")
+	}
+
+	store, _ := checkpointobject.New(checkpointobject.NewMemoryClient(), checkpointobject.Options{})
+
+	s := state.New(nil)
+	s.RunID = "a/b"
+	if err := store.Save(s); err == nil {
+		t.Error("Save() with nested run ID should fail")
+	}
+	s.RunID = ""
+	if err := store.Save(s); err == nil {
+		t.Error("Save() without run ID should fail")
+	}
+}
+
+// unreachableClient fails every List call.
+type unreachableClient struct {
+	*checkpointobject.MemoryClient
+}
+
+func (unreachableClient) List(ctx context.Context, prefix, token string) (checkpointobject.Page, error) {
+	return checkpointobject.Page{}, errors.New("no such host")
+}
+
+// pingClient reports health through its own Ping.
+type pingClient struct {
+	*checkpointobject.MemoryClient
+	err error
+}
+
+func (c pingClient) Ping(ctx context.Context) error {
+	return c.err
+}
+
+func TestStore_Ping(t *testing.T) {
+	ctx := context.Background()
+
+	healthy, _ := checkpointobject.New(checkpointobject.NewMemoryClient(), checkpointobject.Options{})
+	if err := state.PingCheckpointStore(ctx, healthy); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+
+	down, _ := checkpointobject.New(unreachableClient{checkpointobject.NewMemoryClient()}, checkpointobject.Options{})
+	if err := state.PingCheckpointStore(ctx, down); err == nil || !strings.Contains(err.Error(), "no such host") {
+		t.Errorf("Ping() error = %v, want list failure", err)
+	}
+
+	pinged, _ := checkpointobject.New(pingClient{checkpointobject.NewMemoryClient(), errors.New("forbidden")}, checkpointobject.Options{})
+	if err := state.PingCheckpointStore(ctx, pinged); err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("Ping() error = %v, want client Ping failure", err)
+	}
+}
+
+// plainClient hides MemoryClient's conditional put.
+type plainClient struct {
+	checkpointobject.Client
+}
+
+func TestStore_SaveIfAbsent(t *testing.T) {
+	store, _ := checkpointobject.New(checkpointobject.NewMemoryClient(), checkpointobject.Options{})
+
+	first := state.New(nil).SetCheckpointNode("start")
+	if saved, err := store.SaveIfAbsent(first); err != nil || !saved {
+		t.Fatalf("SaveIfAbsent() = %v, %v; want true", saved, err)
+	}
+	if saved, err := store.SaveIfAbsent(first.SetCheckpointNode("other")); err != nil || saved {
+		t.Fatalf("second SaveIfAbsent() = %v, %v; want false", saved, err)
+	}
+	if loaded, _ := store.Load(first.RunID); loaded.CheckpointNode != "start" {
+		t.Errorf("CheckpointNode = %q, want start (not overwritten)", loaded.CheckpointNode)
+	}
+
+	plain, _ := checkpointobject.New(plainClient{checkpointobject.NewMemoryClient()}, checkpointobject.Options{})
+	if _, err := plain.SaveIfAbsent(first); err == nil || !strings.Contains(err.Error(), "conditional writes") {
+		t.Errorf("SaveIfAbsent() without ConditionalClient error = %v, want unsupported", err)
+	}
+}
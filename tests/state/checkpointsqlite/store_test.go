@@ -0,0 +1,312 @@
+package checkpointsqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state/checkpointsqlite"
+)
+
+func openStore(t *testing.T, opts checkpointsqlite.Options) *checkpointsqlite.Store {
+	t.Helper()
+
+	store, err := checkpointsqlite.Open(filepath.Join(t.TempDir(), "checkpoints.db"), opts)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStore_SaveLoadDelete(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	s := state.New(nil).Set("count", 3).SetCheckpointNode("review")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load(s.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.CheckpointNode != "review" {
+		t.Errorf("CheckpointNode = %q, want review", loaded.CheckpointNode)
+	}
+	if v, _ := loaded.Get("count"); v != float64(3) {
+		t.Errorf("count = %v, want 3 (JSON codec)", v)
+	}
+
+	if err := store.Delete(s.RunID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(s.RunID); err != nil {
+		t.Errorf("Delete() of missing checkpoint error = %v", err)
+	}
+	if _, err := store.Load(s.RunID); err == nil {
+		t.Error("Load() after Delete should fail")
+	}
+}
+
+func TestStore_SaveUpserts(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{Codec: state.GobCodec{}})
+
+	first := state.New(nil).Set("step", 1).SetCheckpointNode("a")
+	second := state.New(nil)
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := store.Save(second); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := store.Save(first.Set("step", 2).SetCheckpointNode("b")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load(first.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if v, _ := loaded.Get("step"); v != 2 || loaded.CheckpointNode != "b" {
+		t.Errorf("loaded step=%v node=%q, want 2 at b", v, loaded.CheckpointNode)
+	}
+
+	ids, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []string{first.RunID, second.RunID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("List() = %v, want %v (first save order)", ids, want)
+	}
+}
+
+func TestStore_ConcurrentSaves(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	const runs = 20
+	const saves = 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, runs*saves)
+
+	for r := range runs {
+		wg.Go(func() {
+			s := state.New(nil)
+			s.RunID = fmt.Sprintf("run-%02d", r)
+			for i := range saves {
+				if err := store.Save(s.Set("iteration", i)); err != nil {
+					errs <- err
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent Save() error = %v", err)
+	}
+
+	ids, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(ids) != runs {
+		t.Fatalf("List() returned %d runs, want %d", len(ids), runs)
+	}
+
+	for _, id := range ids {
+		loaded, err := store.Load(id)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", id, err)
+		}
+		if v, _ := loaded.Get("iteration"); v != float64(saves-1) {
+			t.Errorf("Load(%s) iteration = %v, want %d", id, v, saves-1)
+		}
+	}
+}
+
+func TestStore_SaveIfAbsent(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	first := state.New(nil).SetCheckpointNode("start")
+	if saved, err := store.SaveIfAbsent(first); err != nil || !saved {
+		t.Fatalf("SaveIfAbsent() = %v, %v; want true", saved, err)
+	}
+	if saved, err := store.SaveIfAbsent(first.SetCheckpointNode("other")); err != nil || saved {
+		t.Fatalf("second SaveIfAbsent() = %v, %v; want false", saved, err)
+	}
+
+	loaded, err := store.Load(first.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.CheckpointNode != "start" {
+		t.Errorf("CheckpointNode = %q, want start (not overwritten)", loaded.CheckpointNode)
+	}
+}
+
+func TestNew_TableName(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "shared.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+
+	review, err := checkpointsqlite.New(db, checkpointsqlite.Options{Table: "review_checkpoints"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ingest, err := checkpointsqlite.New(db, checkpointsqlite.Options{Table: "ingest_checkpoints"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := review.Save(state.New(nil)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if ids, _ := ingest.List(); len(ids) != 0 {
+		t.Errorf("ingest List() = %v, want empty", ids)
+	}
+
+	if err := review.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Errorf("Close() closed a caller-owned database: %v", err)
+	}
+
+	for _, table := range []string{"bad-name", "x; DROP TABLE y", "1abc"} {
+		if _, err := checkpointsqlite.New(db, checkpointsqlite.Options{Table: table}); err == nil {
+			t.Errorf("New() with table %q should fail", table)
+		}
+	}
+}
+
+func TestStore_ListInfo(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := state.New(nil).Set("payload", "data").SetCheckpointNode("review")
+	s.Timestamp = at
+	s.Graph = "documents"
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	infos, err := state.ListCheckpointInfo(store)
+	if err != nil {
+		t.Fatalf("ListCheckpointInfo() error = %v", err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("ListCheckpointInfo() returned %d entries, want 1", len(infos))
+	}
+
+	info := infos[0]
+	if info.RunID != s.RunID || info.Node != "review" || info.Graph != "documents" || !info.Timestamp.Equal(at) || info.Size <= 0 {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestStore_ListByGraph(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	for i, graph := range []string{"review", "ingest", "review", ""} {
+		s := state.New(nil).Set("step", i)
+		s.RunID = fmt.Sprintf("run-%d", i)
+		s.Graph = graph
+		if err := store.Save(s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	infos, err := state.ListCheckpointsByGraph(store, "review")
+	if err != nil {
+		t.Fatalf("ListCheckpointsByGraph() error = %v", err)
+	}
+	if len(infos) != 2 || infos[0].RunID != "run-0" || infos[1].RunID != "run-2" {
+		t.Errorf("ListCheckpointsByGraph(review) = %+v, want run-0 and run-2", infos)
+	}
+
+	if infos, _ := store.ListByGraph("missing"); len(infos) != 0 {
+		t.Errorf("ListByGraph(missing) = %+v, want none", infos)
+	}
+}
+
+func TestNew_MigratesLegacyTable(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "legacy.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE checkpoints (
+		run_id     TEXT PRIMARY KEY,
+		node       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		payload    BLOB NOT NULL
+	)`)
+	if err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := db.Exec(`INSERT INTO checkpoints VALUES ('old-run', 'ingest', ?, x'7b7d')`, created.UnixNano()); err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+
+	store, err := checkpointsqlite.New(db, checkpointsqlite.Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	infos, err := store.ListInfo()
+	if err != nil {
+		t.Fatalf("ListInfo() error = %v", err)
+	}
+	if len(infos) != 1 || infos[0].Node != "ingest" || !infos[0].Timestamp.Equal(created) {
+		t.Errorf("ListInfo() = %+v, want legacy row timestamped at creation", infos)
+	}
+
+	if err := store.Save(state.New(nil)); err != nil {
+		t.Errorf("Save() after migration error = %v", err)
+	}
+}
+
+func TestStore_Ping(t *testing.T) {
+	store := openStore(t, checkpointsqlite.Options{})
+
+	if err := state.PingCheckpointStore(context.Background(), store); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+
+	store.Close()
+	if err := state.PingCheckpointStore(context.Background(), store); err == nil {
+		t.Error("Ping() after Close should fail")
+	}
+}
+
+func TestStore_ListInfo_Compressed(t *testing.T) {
+	codec := state.NewCompressedCodec(nil, state.GzipCompressor{})
+	store := openStore(t, checkpointsqlite.Options{Codec: codec})
+
+	s := state.New(nil).Set("text", strings.Repeat("lorem ipsum ", 5000)).SetCheckpointNode("ocr")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	infos, err := store.ListInfo()
+	if err != nil || len(infos) != 1 {
+		t.Fatalf("ListInfo() = %v, %v; want one checkpoint", infos, err)
+	}
+	if info := infos[0]; info.UncompressedSize <= info.Size {
+		t.Errorf("Size = %d, UncompressedSize = %d; want uncompressed larger", info.Size, info.UncompressedSize)
+	}
+}
@@ -0,0 +1,142 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+type counter struct {
+	values *[]int
+}
+
+func (c counter) Clone() any {
+	values := append([]int(nil), *c.values...)
+	return counter{values: &values}
+}
+
+func TestState_CloneDeep(t *testing.T) {
+	original := state.New(nil).
+		Set("config", map[string]any{"retries": 3, "tags": []any{"a"}}).
+		Set("scores", map[string]int{"alice": 1}).
+		Set("names", []string{"x", "y"}).
+		Set("nested", []map[string]any{{"k": "v"}}).
+		Set("scalar", 42)
+
+	copied := original.CloneDeep()
+
+	cfg := state.MustGetAs[map[string]any](copied, "config")
+	cfg["retries"] = 5
+	cfg["tags"].([]any)[0] = "changed"
+	state.MustGetAs[map[string]int](copied, "scores")["alice"] = 99
+	state.MustGetAs[[]string](copied, "names")[0] = "changed"
+	state.MustGetAs[[]map[string]any](copied, "nested")[0]["k"] = "changed"
+
+	origCfg := state.MustGetAs[map[string]any](original, "config")
+	if origCfg["retries"] != 3 {
+		t.Errorf("nested map mutated: retries = %v", origCfg["retries"])
+	}
+	if origCfg["tags"].([]any)[0] != "a" {
+		t.Errorf("nested slice mutated: tags = %v", origCfg["tags"])
+	}
+	if state.MustGetAs[map[string]int](original, "scores")["alice"] != 1 {
+		t.Error("typed map mutated")
+	}
+	if state.MustGetAs[[]string](original, "names")[0] != "x" {
+		t.Error("typed slice mutated")
+	}
+	if state.MustGetAs[[]map[string]any](original, "nested")[0]["k"] != "v" {
+		t.Error("slice of maps mutated")
+	}
+	if copied.RunID != original.RunID {
+		t.Error("CloneDeep should preserve RunID")
+	}
+}
+
+func TestState_CloneDeep_Cloner(t *testing.T) {
+	values := []int{1, 2}
+	original := state.New(nil).Set("counter", counter{values: &values})
+
+	copied := original.CloneDeep()
+	c := state.MustGetAs[counter](copied, "counter")
+	(*c.values)[0] = 100
+
+	if values[0] != 1 {
+		t.Errorf("Cloner not used: original values = %v", values)
+	}
+}
+
+func TestState_CloneDeep_EmitsEvent(t *testing.T) {
+	observer := &captureObserver{}
+	state.New(observer).CloneDeep()
+
+	last := observer.events[len(observer.events)-1]
+	if last.Data["deep"] != true {
+		t.Errorf("expected deep clone event, got %v", last.Data)
+	}
+}
+
+func TestStateGraph_DeepClone(t *testing.T) {
+	for _, deep := range []bool{false, true} {
+		t.Run(fmt.Sprintf("deep=%v", deep), func(t *testing.T) {
+			graph, err := state.NewGraph(config.GraphConfig{
+				Name:          "deep-clone",
+				Observer:      "noop",
+				MaxIterations: 10,
+				DeepClone:     deep,
+			})
+			if err != nil {
+				t.Fatalf("failed to create graph: %v", err)
+			}
+
+			graph.AddNode("mutate", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+				items := state.MustGetAs[map[string]any](s, "items")
+				items["mutated"] = true
+				return s, nil
+			}))
+			graph.SetEntryPoint("mutate")
+			graph.SetExitPoint("mutate")
+
+			initial := state.New(nil).Set("items", map[string]any{})
+			if _, err := graph.Execute(context.Background(), initial); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			_, mutated := state.MustGetAs[map[string]any](initial, "items")["mutated"]
+			if mutated == deep {
+				t.Errorf("initial state mutated = %v with DeepClone = %v", mutated, deep)
+			}
+		})
+	}
+}
+
+func benchmarkState() state.State {
+	s := state.New(nil)
+	for i := range 20 {
+		s = s.Set(fmt.Sprintf("key-%d", i), map[string]any{
+			"id":     i,
+			"tags":   []any{"a", "b", "c"},
+			"scores": map[string]int{"x": 1, "y": 2},
+		})
+	}
+	return s
+}
+
+func BenchmarkState_Clone(b *testing.B) {
+	s := benchmarkState()
+	b.ResetTimer()
+	for range b.N {
+		s.Clone()
+	}
+}
+
+func BenchmarkState_CloneDeep(b *testing.B) {
+	s := benchmarkState()
+	b.ResetTimer()
+	for range b.N {
+		s.CloneDeep()
+	}
+}
@@ -0,0 +1,228 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+type invoice struct {
+	Number string
+	Total  int
+	Due    time.Time
+}
+
+func init() {
+	state.RegisterType[invoice]()
+}
+
+func typedState() state.State {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return state.New(nil).SetMany(map[string]any{
+		"count":    3,
+		"due":      due,
+		"invoice":  invoice{Number: "INV-1", Total: 250, Due: due},
+		"tags":     []string{"urgent"},
+		"metadata": map[string]any{"source": "ocr", "pages": 2},
+	}).Freeze("invoice")
+}
+
+func TestGobCodec_PreservesTypes(t *testing.T) {
+	original := typedState()
+
+	data, err := state.GobCodec{}.Encode(original)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := state.GobCodec{}.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.Data, original.Data) {
+		t.Errorf("decoded Data = %#v\nwant %#v", decoded.Data, original.Data)
+	}
+	if decoded.RunID != original.RunID {
+		t.Errorf("RunID = %q, want %q", decoded.RunID, original.RunID)
+	}
+	if !decoded.IsFrozen("invoice") {
+		t.Error("decoded state lost frozen keys")
+	}
+}
+
+func TestGobCodec_UnregisteredType(t *testing.T) {
+	type unregistered struct{ Value int }
+
+	_, err := state.GobCodec{}.Encode(state.New(nil).Set("value", unregistered{1}))
+	if err == nil {
+		t.Error("Encode() with unregistered type should fail")
+	}
+}
+
+func TestJSONCodec_LosesTypes(t *testing.T) {
+	data, err := state.JSONCodec{}.Encode(typedState())
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := state.JSONCodec{}.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if _, ok := decoded.Data["count"].(float64); !ok {
+		t.Errorf("count = %T, want float64 after JSON round trip", decoded.Data["count"])
+	}
+	if !decoded.IsFrozen("invoice") {
+		t.Error("decoded state lost frozen keys")
+	}
+}
+
+func TestCodecs_PreserveGraph(t *testing.T) {
+	s := state.New(nil).Set("k", "v")
+	s.Graph = "review"
+
+	for name, codec := range map[string]state.Codec{"json": state.JSONCodec{}, "gob": state.GobCodec{}} {
+		data, err := codec.Encode(s)
+		if err != nil {
+			t.Fatalf("%s Encode() error = %v", name, err)
+		}
+		decoded, err := codec.Decode(data)
+		if err != nil {
+			t.Fatalf("%s Decode() error = %v", name, err)
+		}
+		if decoded.Graph != "review" {
+			t.Errorf("%s Graph = %q, want review", name, decoded.Graph)
+		}
+	}
+}
+
+func TestGetCodec(t *testing.T) {
+	for _, name := range []string{"", "json", "gob"} {
+		if _, err := state.GetCodec(name); err != nil {
+			t.Errorf("GetCodec(%q) error = %v", name, err)
+		}
+	}
+	if _, err := state.GetCodec("xml"); err == nil {
+		t.Error("GetCodec(xml) should fail")
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store, err := state.NewFileCheckpointStore(t.TempDir(), state.GobCodec{})
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	s := typedState()
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	ids, err := store.List()
+	if err != nil || !reflect.DeepEqual(ids, []string{s.RunID}) {
+		t.Errorf("List() = %v, %v, want [%s]", ids, err, s.RunID)
+	}
+
+	loaded, err := store.Load(s.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Data, s.Data) {
+		t.Errorf("loaded Data = %#v, want %#v", loaded.Data, s.Data)
+	}
+
+	if err := store.Delete(s.RunID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(s.RunID); err != nil {
+		t.Errorf("Delete() of missing checkpoint error = %v", err)
+	}
+	if _, err := store.Load(s.RunID); err == nil {
+		t.Error("Load() after Delete should fail")
+	}
+}
+
+func TestFileCheckpointStore_RejectsPathRunID(t *testing.T) {
+	store, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	for _, runID := range []string{"", "..", "../escape", `a\b`} {
+		if _, err := store.Load(runID); err == nil {
+			t.Errorf("Load(%q) should fail", runID)
+		}
+	}
+}
+
+func TestGraph_Resume_GobCodecPreservesTypes(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Store = "file"
+	cfg.Checkpoint.Dir = t.TempDir()
+	cfg.Checkpoint.Codec = "gob"
+	cfg.Checkpoint.Interval = 1
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	fail := true
+	want := typedState()
+
+	graph.AddNodeFunc("load", func(ctx context.Context, s state.State) (state.State, error) {
+		return s.SetMany(want.Data), nil
+	})
+	graph.AddNodeFunc("review", func(ctx context.Context, s state.State) (state.State, error) {
+		if fail {
+			return s, errors.New("transient failure")
+		}
+		return s.Set("reviewed", true), nil
+	})
+	graph.AddNodeFunc("approve", func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("approved", true), nil
+	})
+	graph.AddEdge("load", "review", nil)
+	graph.AddEdge("review", "approve", func(s state.State) bool {
+		count, ok := state.GetAs[int](s, "count")
+		return ok && count > 2
+	})
+	graph.SetEntryPoint("load")
+	graph.SetExitPoint("approve")
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err == nil {
+		t.Fatal("expected first execution to fail")
+	}
+
+	fail = false
+	result, err := graph.Resume(context.Background(), initial.RunID)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	if approved, _ := result.Get("approved"); approved != true {
+		t.Error("int predicate should route to approve after resume")
+	}
+	if got, ok := state.GetAs[invoice](result, "invoice"); !ok || !reflect.DeepEqual(got, want.Data["invoice"]) {
+		t.Errorf("invoice = %#v, want %#v", result.Data["invoice"], want.Data["invoice"])
+	}
+	if got, ok := state.GetAs[time.Time](result, "due"); !ok || !got.Equal(want.Data["due"].(time.Time)) {
+		t.Errorf("due = %#v, want time.Time", result.Data["due"])
+	}
+}
+
+func TestNewGraph_UnknownCodec(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Codec = "xml"
+
+	if _, err := state.NewGraph(cfg); err == nil {
+		t.Error("NewGraph with unknown codec should fail")
+	}
+}
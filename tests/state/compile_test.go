@@ -0,0 +1,218 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func linearGraph(t *testing.T, cfg config.GraphConfig) state.StateGraph {
+	t.Helper()
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNode("a", simpleNode("step", "a"))
+	graph.AddNode("b", simpleNode("step", "b"))
+	graph.AddEdge("a", "b", nil)
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("b")
+	return graph
+}
+
+func TestGraph_Compile(t *testing.T) {
+	graph := linearGraph(t, config.DefaultGraphConfig("compile"))
+
+	if graph.IsCompiled() {
+		t.Fatal("IsCompiled() = true before Compile")
+	}
+	if err := graph.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if !graph.IsCompiled() {
+		t.Fatal("IsCompiled() = false after Compile")
+	}
+	if err := graph.Compile(); err != nil {
+		t.Errorf("second Compile() error = %v", err)
+	}
+
+	mutations := map[string]error{
+		"AddNode":       graph.AddNode("c", simpleNode("step", "c")),
+		"AddEdge":       graph.AddEdge("b", "a", nil),
+		"SetEntryPoint": graph.SetEntryPoint("b"),
+		"SetExitPoint":  graph.SetExitPoint("a"),
+		"SetReducer":    graph.SetReducer("step", state.AppendReducer),
+	}
+	for name, err := range mutations {
+		if !errors.Is(err, state.ErrGraphCompiled) {
+			t.Errorf("%s() after Compile error = %v, want ErrGraphCompiled", name, err)
+		}
+	}
+
+	final, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if v, _ := final.Get("step"); v != "b" {
+		t.Errorf("step = %v, want b", v)
+	}
+}
+
+func TestGraph_Compile_Analysis(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(g state.StateGraph)
+		want  string
+	}{
+		{
+			name: "dead end",
+			build: func(g state.StateGraph) {
+				g.AddNode("a", simpleNode("k", "v"))
+				g.AddNode("b", simpleNode("k", "v"))
+				g.AddNode("done", simpleNode("k", "v"))
+				g.AddEdge("a", "b", func(s state.State) bool { return false })
+				g.AddEdge("a", "done", nil)
+				g.SetEntryPoint("a")
+				g.SetExitPoint("done")
+			},
+			want: "node b has no outgoing edges",
+		},
+		{
+			name: "trapped cycle",
+			build: func(g state.StateGraph) {
+				g.AddNode("a", simpleNode("k", "v"))
+				g.AddNode("loop1", simpleNode("k", "v"))
+				g.AddNode("loop2", simpleNode("k", "v"))
+				g.AddNode("done", simpleNode("k", "v"))
+				g.AddEdge("a", "done", nil)
+				g.AddEdge("a", "loop1", nil)
+				g.AddEdge("loop1", "loop2", nil)
+				g.AddEdge("loop2", "loop1", nil)
+				g.SetEntryPoint("a")
+				g.SetExitPoint("done")
+			},
+			want: "no exit point reachable from nodes: loop1, loop2",
+		},
+		{
+			name: "validation",
+			build: func(g state.StateGraph) {
+				g.AddNode("a", simpleNode("k", "v"))
+			},
+			want: "entry point not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, _ := state.NewGraphWithDeps(config.DefaultGraphConfig(tt.name), nil, nil)
+			tt.build(graph)
+
+			err := graph.Compile()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Compile() error = %v, want %q", err, tt.want)
+			}
+			if graph.IsCompiled() {
+				t.Error("IsCompiled() = true after failed Compile")
+			}
+			if err := graph.AddNode("fix", simpleNode("k", "v")); err != nil {
+				t.Errorf("AddNode() after failed Compile error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGraph_Compile_AutoAndRequired(t *testing.T) {
+	auto := linearGraph(t, config.DefaultGraphConfig("auto"))
+	if _, err := auto.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !auto.IsCompiled() {
+		t.Error("Execute() did not compile the graph")
+	}
+
+	cfg := config.DefaultGraphConfig("required")
+	cfg.RequireCompile = true
+	required := linearGraph(t, cfg)
+
+	if _, err := required.Execute(context.Background(), state.New(nil)); !errors.Is(err, state.ErrGraphNotCompiled) {
+		t.Fatalf("Execute() error = %v, want ErrGraphNotCompiled", err)
+	}
+	if err := required.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if _, err := required.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Errorf("Execute() after Compile error = %v", err)
+	}
+}
+
+func TestGraph_Compile_ConcurrentExecute(t *testing.T) {
+	graph := linearGraph(t, config.DefaultGraphConfig("concurrent"))
+
+	errs := make(chan error, 8)
+	for range 8 {
+		go func() {
+			_, err := graph.Execute(context.Background(), state.New(nil))
+			errs <- err
+		}()
+	}
+	for range 8 {
+		if err := <-errs; err != nil {
+			t.Errorf("Execute() error = %v", err)
+		}
+	}
+}
+
+func TestGraph_Compile_Dispatch(t *testing.T) {
+	observer := &captureObserver{}
+	observability.RegisterObserver("compile-dispatch", observer)
+
+	cfg := config.DefaultGraphConfig("dispatch")
+	cfg.Observer = "compile-dispatch"
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+
+	shadowed := 0
+	graph.AddNode("a", simpleNode("step", "a"))
+	graph.AddNode("b", simpleNode("step", "b"))
+	graph.AddNode("c", simpleNode("step", "c"))
+	graph.AddEdge("a", "c", state.KeyEquals("route", "c"))
+	graph.AddEdge("a", "b", nil)
+	graph.AddEdge("a", "c", func(state.State) bool {
+		shadowed++
+		return true
+	})
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("b")
+	graph.SetExitPoint("c")
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if shadowed != 0 {
+		t.Errorf("edge after unconditional edge evaluated %d times, want 0", shadowed)
+	}
+
+	var evaluated, transitioned []any
+	for _, event := range observer.events {
+		switch event.Type {
+		case observability.EventEdgeEvaluate:
+			evaluated = append(evaluated, event.Data["edge_index"])
+		case observability.EventEdgeTransition:
+			transitioned = append(transitioned, event.Data["edge_index"])
+		}
+	}
+	if len(evaluated) != 2 || evaluated[0] != 0 || evaluated[1] != 1 {
+		t.Errorf("evaluated edge indexes = %v, want [0 1]", evaluated)
+	}
+	if len(transitioned) != 1 || transitioned[0] != 1 {
+		t.Errorf("transitioned edge indexes = %v, want [1]", transitioned)
+	}
+}
@@ -0,0 +1,163 @@
+package state_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// reverseCompressor "compresses" by reversing bytes, standing in for a
+// third-party algorithm such as zstd.
+type reverseCompressor struct{}
+
+func (reverseCompressor) Name() string { return "reverse" }
+
+func (reverseCompressor) Compress(data []byte) ([]byte, error) {
+	out := bytes.Clone(data)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
+func (r reverseCompressor) Decompress(data []byte) ([]byte, error) {
+	return r.Compress(data)
+}
+
+func largeDocumentState() state.State {
+	return state.New(nil).
+		Set("ocr_text", strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20000)).
+		Set("pages", 42).
+		SetCheckpointNode("extract")
+}
+
+func TestCompressedCodec_RoundTrip(t *testing.T) {
+	codec := state.NewCompressedCodec(state.GobCodec{}, state.GzipCompressor{})
+	original := largeDocumentState()
+
+	data, err := codec.Encode(original)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	plain, _ := state.GobCodec{}.Encode(original)
+	if len(data) >= len(plain)/10 {
+		t.Errorf("compressed size %d, want well under uncompressed %d", len(data), len(plain))
+	}
+	if size, ok := state.UncompressedSize(data); !ok || size != len(plain) {
+		t.Errorf("UncompressedSize() = %d, %v; want %d, true", size, ok, len(plain))
+	}
+
+	decoded, err := codec.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if v, _ := decoded.Get("pages"); v != 42 {
+		t.Errorf("pages = %v (%T), want int 42", v, v)
+	}
+	if v, _ := decoded.Get("ocr_text"); v != original.Data["ocr_text"] {
+		t.Error("ocr_text did not survive the round trip")
+	}
+	if decoded.RunID != original.RunID || decoded.CheckpointNode != "extract" {
+		t.Errorf("metadata = %s@%s, want %s@extract", decoded.RunID, decoded.CheckpointNode, original.RunID)
+	}
+}
+
+func TestCompressedCodec_LegacyPayload(t *testing.T) {
+	codec := state.NewCompressedCodec(nil, state.GzipCompressor{})
+	original := state.New(nil).Set("key", "value")
+
+	legacy, _ := state.JSONCodec{}.Encode(original)
+	if _, ok := state.UncompressedSize(legacy); ok {
+		t.Error("UncompressedSize() reported an uncompressed payload as compressed")
+	}
+
+	decoded, err := codec.Decode(legacy)
+	if err != nil {
+		t.Fatalf("Decode() of uncompressed payload error = %v", err)
+	}
+	if v, _ := decoded.Get("key"); v != "value" {
+		t.Errorf("key = %v, want value", v)
+	}
+}
+
+func TestCompressedCodec_RegisteredCompressor(t *testing.T) {
+	state.RegisterCompressor(reverseCompressor{})
+
+	data, err := state.NewCompressedCodec(nil, reverseCompressor{}).Encode(state.New(nil).Set("key", "value"))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// A codec configured with a different compressor still reads the payload.
+	decoded, err := state.NewCompressedCodec(nil, state.GzipCompressor{}).Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if v, _ := decoded.Get("key"); v != "value" {
+		t.Errorf("key = %v, want value", v)
+	}
+
+	if _, err := state.GetCompressor("zstd"); err == nil {
+		t.Error("GetCompressor() of unregistered name should fail")
+	}
+}
+
+func TestFileCheckpointStore_Compression(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := config.DefaultGraphConfig("compressed")
+	cfg.Checkpoint.Store = "file"
+	cfg.Checkpoint.Dir = dir
+	cfg.Checkpoint.Compression = "gzip"
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+	graph.AddNode("extract", simpleNode("status", "extracted"))
+	graph.SetEntryPoint("extract")
+	graph.SetExitPoint("extract")
+
+	initial := largeDocumentState()
+	if _, err := graph.Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	store, err := state.GetCheckpointStore("file", map[string]any{"dir": dir, "compression": "gzip"})
+	if err != nil {
+		t.Fatalf("GetCheckpointStore() error = %v", err)
+	}
+
+	infos, err := state.ListCheckpointInfo(store)
+	if err != nil || len(infos) != 1 {
+		t.Fatalf("ListCheckpointInfo() = %v, %v; want one checkpoint", infos, err)
+	}
+	if info := infos[0]; info.UncompressedSize <= info.Size*10 {
+		t.Errorf("Size = %d, UncompressedSize = %d; want large savings", info.Size, info.UncompressedSize)
+	}
+
+	loaded, err := store.Load(initial.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if v, _ := loaded.Get("status"); v != "extracted" {
+		t.Errorf("status = %v, want extracted", v)
+	}
+}
+
+func TestGraph_UnknownCompression(t *testing.T) {
+	cfg := config.DefaultGraphConfig("bad-compression")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Compression = "lz5"
+
+	if _, err := state.NewGraph(cfg); err == nil || !strings.Contains(err.Error(), "lz5") {
+		t.Errorf("NewGraph() error = %v, want unknown compressor", err)
+	}
+}
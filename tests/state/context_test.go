@@ -0,0 +1,96 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+type ctxKey struct{}
+
+// contextObserver records the request value carried by each event's context.
+type contextObserver struct {
+	values map[observability.EventType][]any
+}
+
+func (c *contextObserver) OnEvent(ctx context.Context, event observability.Event) {
+	if c.values == nil {
+		c.values = make(map[observability.EventType][]any)
+	}
+	c.values[event.Type] = append(c.values[event.Type], ctx.Value(ctxKey{}))
+}
+
+func TestState_WithContext(t *testing.T) {
+	observer := &contextObserver{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-1")
+
+	s := state.New(observer).WithContext(ctx)
+	s = s.Set("a", 1).Merge(state.New(nil).Set("b", 2)).Clone()
+
+	for _, eventType := range []observability.EventType{
+		observability.EventStateSet,
+		observability.EventStateMerge,
+		observability.EventStateClone,
+	} {
+		values := observer.values[eventType]
+		if len(values) == 0 {
+			t.Errorf("no %s events", eventType)
+		}
+		for _, v := range values {
+			if v != "req-1" {
+				t.Errorf("%s context value = %v, want req-1", eventType, v)
+			}
+		}
+	}
+
+	if s.Context() != ctx {
+		t.Error("derived State lost its context binding")
+	}
+}
+
+func TestState_Context_DefaultsToBackground(t *testing.T) {
+	observer := &contextObserver{}
+	s := state.New(observer).Set("a", 1)
+
+	if s.Context() != context.Background() {
+		t.Error("unbound State should use context.Background()")
+	}
+	if got := observer.values[observability.EventStateSet]; len(got) != 1 || got[0] != nil {
+		t.Errorf("set event context values = %v, want [nil]", got)
+	}
+	if s.WithContext(context.TODO()).WithContext(nil).Context() != context.Background() {
+		t.Error("WithContext(nil) should remove the binding")
+	}
+}
+
+func TestGraph_Execute_BindsNodeContext(t *testing.T) {
+	observer := &contextObserver{}
+
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	graph.AddNodeFunc("work", func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("done", true), nil
+	})
+	graph.SetEntryPoint("work")
+	graph.SetExitPoint("work")
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-2")
+	result, err := graph.Execute(ctx, state.New(observer))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	values := observer.values[observability.EventStateSet]
+	if len(values) != 1 || values[0] != "req-2" {
+		t.Errorf("node set event context values = %v, want [req-2]", values)
+	}
+	if result.Context() != context.Background() {
+		t.Error("State returned by Execute should be unbound")
+	}
+}
@@ -0,0 +1,222 @@
+package state_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestDiff(t *testing.T) {
+	before := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"unchanged": "same",
+		"changed":   1,
+		"removed":   true,
+		"nested":    map[string]any{"a": []int{1, 2}},
+	})
+
+	after := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"unchanged": "same",
+		"changed":   2,
+		"added":     "new",
+		"nested":    map[string]any{"a": []int{1, 2}},
+	})
+
+	diff := state.Diff(before, after)
+
+	if !reflect.DeepEqual(diff.Added, map[string]any{"added": "new"}) {
+		t.Errorf("Added = %v", diff.Added)
+	}
+
+	if !reflect.DeepEqual(diff.Removed, map[string]any{"removed": true}) {
+		t.Errorf("Removed = %v", diff.Removed)
+	}
+
+	expectedChanged := map[string]state.ValueChange{"changed": {Old: 1, New: 2}}
+	if !reflect.DeepEqual(diff.Changed, expectedChanged) {
+		t.Errorf("Changed = %v, want %v", diff.Changed, expectedChanged)
+	}
+
+	if diff.IsEmpty() {
+		t.Error("expected non-empty diff")
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).Set("key", []string{"a"})
+
+	diff := state.Diff(s, s.Clone())
+	if !diff.IsEmpty() {
+		t.Errorf("expected empty diff, got %+v", diff)
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	if string(data) != "{}" {
+		t.Errorf("expected empty JSON object, got %s", data)
+	}
+}
+
+func TestDiff_JSON(t *testing.T) {
+	before := state.New(observability.NoOpObserver{}).Set("count", 1)
+	after := before.Set("count", 2).Set("status", "done")
+
+	data, err := json.Marshal(state.Diff(before, after))
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	expected := `{"added":{"status":"done"},"changed":{"count":{"old":1,"new":2}}}`
+	if string(data) != expected {
+		t.Errorf("JSON = %s, want %s", data, expected)
+	}
+}
+
+func TestDiff_Summary(t *testing.T) {
+	before := state.New(observability.NoOpObserver{}).SetMany(map[string]any{"b": 1, "x": 1})
+	after := state.New(observability.NoOpObserver{}).SetMany(map[string]any{"a": 1, "b": 2, "z": 1})
+
+	summary := state.Diff(before, after).Summary()
+
+	expected := map[string][]string{
+		"added":   {"a", "z"},
+		"removed": {"x"},
+		"changed": {"b"},
+	}
+
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Summary = %v, want %v", summary, expected)
+	}
+}
+
+func TestStateGraph_Execute_NodeDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodeDiff bool
+	}{
+		{name: "enabled", nodeDiff: true},
+		{name: "disabled", nodeDiff: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := &captureObserver{}
+			observability.RegisterObserver("diff-capture", observer)
+
+			cfg := config.DefaultGraphConfig("diff-test")
+			cfg.Observer = "diff-capture"
+			cfg.NodeDiff = tt.nodeDiff
+
+			graph, err := state.NewGraph(cfg)
+			if err != nil {
+				t.Fatalf("failed to create graph: %v", err)
+			}
+
+			graph.AddNode("a", newTestNode("result", "a"))
+			graph.SetEntryPoint("a")
+			graph.SetExitPoint("a")
+
+			initial := state.New(observability.NoOpObserver{})
+			if _, err := graph.Execute(context.Background(), initial); err != nil {
+				t.Fatalf("execution failed: %v", err)
+			}
+
+			var complete *observability.Event
+			for i := range observer.events {
+				if observer.events[i].Type == observability.EventNodeComplete {
+					complete = &observer.events[i]
+				}
+			}
+
+			if complete == nil {
+				t.Fatal("expected NodeComplete event")
+			}
+
+			diff, exists := complete.Data["diff"]
+			if exists != tt.nodeDiff {
+				t.Fatalf("diff present = %v, want %v", exists, tt.nodeDiff)
+			}
+
+			if !tt.nodeDiff {
+				return
+			}
+
+			summary := diff.(map[string][]string)
+			if !reflect.DeepEqual(summary["added"], []string{"result"}) {
+				t.Errorf("added = %v, want [result]", summary["added"])
+			}
+		})
+	}
+}
+
+func TestState_Equal(t *testing.T) {
+	a := state.New(observability.NoOpObserver{}).
+		Set("user", "alice").
+		Set("tags", []string{"x", "y"})
+	b := state.New(&captureObserver{}).
+		Set("tags", []string{"x", "y"}).
+		Set("user", "alice").
+		SetCheckpointNode("review")
+
+	if a.RunID == b.RunID {
+		t.Fatal("test requires distinct run IDs")
+	}
+
+	if !a.Equal(b) {
+		t.Errorf("Equal() = false, want true; diff:\n%s", a.EqualDiff(b))
+	}
+
+	if diff := a.EqualDiff(b); diff != "" {
+		t.Errorf("EqualDiff() = %q, want empty", diff)
+	}
+}
+
+func TestState_Equal_EmptyAndNil(t *testing.T) {
+	empty := state.New(observability.NoOpObserver{})
+	zero := state.State{}
+
+	if !empty.Equal(zero) {
+		t.Error("empty State should equal zero State")
+	}
+}
+
+func TestState_Equal_NumericTypes(t *testing.T) {
+	a := state.New(observability.NoOpObserver{}).Set("count", 1)
+	b := state.New(observability.NoOpObserver{}).Set("count", 1.0)
+
+	if a.Equal(b) {
+		t.Error("int and float64 values should not be equal")
+	}
+}
+
+func TestState_EqualDiff(t *testing.T) {
+	want := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"status":  "approved",
+		"removed": true,
+		"same":    1,
+	})
+	got := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"status": "rejected",
+		"added":  "new",
+		"same":   1,
+	})
+
+	if want.Equal(got) {
+		t.Fatal("Equal() = true, want false")
+	}
+
+	expected := `+ added: "new"
+- removed: true
+~ status: "approved" -> "rejected"`
+
+	if diff := want.EqualDiff(got); diff != expected {
+		t.Errorf("EqualDiff() =\n%s\nwant\n%s", diff, expected)
+	}
+}
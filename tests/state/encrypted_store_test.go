@@ -0,0 +1,142 @@
+package state_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestEncryptedStore_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	inner, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	store, err := state.NewEncryptedStore(inner, testKey(1))
+	if err != nil {
+		t.Fatalf("NewEncryptedStore() error = %v", err)
+	}
+
+	s := state.New(nil).Set("document", "customer contract").SetCheckpointNode("review")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, s.RunID+".checkpoint"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(raw), "customer contract") {
+		t.Error("checkpoint file contains plaintext state")
+	}
+
+	loaded, err := store.Load(s.RunID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if v, _ := loaded.Get("document"); v != "customer contract" {
+		t.Errorf("document = %v, want customer contract", v)
+	}
+	if loaded.CheckpointNode != "review" {
+		t.Errorf("CheckpointNode = %q, want review", loaded.CheckpointNode)
+	}
+
+	ids, _ := store.List()
+	if len(ids) != 1 || ids[0] != s.RunID {
+		t.Errorf("List() = %v, want [%s]", ids, s.RunID)
+	}
+	if err := store.Delete(s.RunID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := inner.Load(s.RunID); err == nil {
+		t.Error("Delete() did not reach the inner store")
+	}
+}
+
+func TestEncryptedStore_Integrity(t *testing.T) {
+	inner := state.NewMemoryCheckpointStore()
+	store, _ := state.NewEncryptedStore(inner, testKey(1))
+
+	s := state.New(nil).Set("k", "v")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	envelope, _ := inner.Load(s.RunID)
+	encoded := envelope.Data["encrypted_checkpoint"].(string)
+	tampered := []byte(encoded)
+	tampered[len(tampered)/2] ^= 'A' ^ 'B'
+	inner.Save(envelope.Set("encrypted_checkpoint", string(tampered)))
+
+	if _, err := store.Load(s.RunID); !errors.Is(err, state.ErrCheckpointIntegrity) {
+		t.Errorf("Load() tampered error = %v, want ErrCheckpointIntegrity", err)
+	}
+
+	other, _ := state.NewEncryptedStore(inner, testKey(2))
+	inner.Save(envelope)
+	if _, err := other.Load(s.RunID); !errors.Is(err, state.ErrCheckpointIntegrity) {
+		t.Errorf("Load() with wrong key error = %v, want ErrCheckpointIntegrity", err)
+	}
+
+	moved := envelope
+	moved.RunID = "other-run"
+	inner.Save(moved)
+	if _, err := store.Load("other-run"); !errors.Is(err, state.ErrCheckpointIntegrity) {
+		t.Errorf("Load() of ciphertext moved between runs error = %v, want ErrCheckpointIntegrity", err)
+	}
+}
+
+func TestEncryptedStore_KeyRotation(t *testing.T) {
+	inner := state.NewMemoryCheckpointStore()
+	oldStore, _ := state.NewEncryptedStore(inner, testKey(1))
+
+	s := state.New(nil).Set("k", "v")
+	if err := oldStore.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	rotated, err := state.NewEncryptedStore(inner, testKey(2), testKey(1))
+	if err != nil {
+		t.Fatalf("NewEncryptedStore() error = %v", err)
+	}
+	if _, err := rotated.Load(s.RunID); err != nil {
+		t.Fatalf("Load() with previous key error = %v", err)
+	}
+
+	if err := rotated.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := oldStore.Load(s.RunID); !errors.Is(err, state.ErrCheckpointIntegrity) {
+		t.Errorf("re-saved checkpoint still readable with retired key only: %v", err)
+	}
+}
+
+func TestNewEncryptedStore_Validation(t *testing.T) {
+	inner := state.NewMemoryCheckpointStore()
+
+	for _, size := range []int{0, 8, 31, 64} {
+		if _, err := state.NewEncryptedStore(inner, make([]byte, size)); err == nil {
+			t.Errorf("NewEncryptedStore() with %d-byte key should fail", size)
+		}
+	}
+	if _, err := state.NewEncryptedStore(inner, testKey(1), make([]byte, 5)); err == nil {
+		t.Error("NewEncryptedStore() with invalid previous key should fail")
+	}
+	if _, err := state.NewEncryptedStore(nil, testKey(1)); err == nil {
+		t.Error("NewEncryptedStore() with nil inner store should fail")
+	}
+	for _, size := range []int{16, 24, 32} {
+		if _, err := state.NewEncryptedStore(inner, make([]byte, size)); err != nil {
+			t.Errorf("NewEncryptedStore() with %d-byte key error = %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func savedFileCheckpoint(t *testing.T) (dir string, store state.CheckpointStore, s state.State) {
+	t.Helper()
+
+	dir = t.TempDir()
+	store, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	s = state.New(nil).Set("document", "report.pdf").SetCheckpointNode("review")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return dir, store, s
+}
+
+func TestFileCheckpointStore_Corruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(data []byte) []byte
+	}{
+		{"truncated payload", func(data []byte) []byte { return data[:len(data)-5] }},
+		{"truncated header", func(data []byte) []byte { return data[:10] }},
+		{"empty file", func(data []byte) []byte { return nil }},
+		{"bit flip", func(data []byte) []byte {
+			flipped := append([]byte(nil), data...)
+			flipped[len(flipped)-3] ^= 0x01
+			return flipped
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, store, s := savedFileCheckpoint(t)
+			path := filepath.Join(dir, s.RunID+".checkpoint")
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if err := os.WriteFile(path, tt.corrupt(data), 0o600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			_, err = store.Load(s.RunID)
+			var corrupt *state.CorruptCheckpointError
+			if !errors.As(err, &corrupt) {
+				t.Fatalf("Load() error = %v, want *CorruptCheckpointError", err)
+			}
+			if corrupt.RunID != s.RunID || corrupt.Path != path {
+				t.Errorf("CorruptCheckpointError = %+v, want run %s at %s", corrupt, s.RunID, path)
+			}
+			if !strings.Contains(err.Error(), "ResumeVersion") {
+				t.Errorf("error %q should suggest ResumeVersion", err)
+			}
+		})
+	}
+}
+
+func TestFileCheckpointStore_LegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	store, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	s := state.New(nil).Set("key", "value").SetCheckpointNode("node")
+	data, err := state.JSONCodec{}.Encode(s)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, s.RunID+".checkpoint"), data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := store.Load(s.RunID)
+	if err != nil {
+		t.Fatalf("Load() of a checkpoint without checksum error = %v", err)
+	}
+	if v, _ := loaded.Get("key"); v != "value" {
+		t.Errorf("key = %v, want value", v)
+	}
+}
+
+func TestFileCheckpointStore_NoTempFiles(t *testing.T) {
+	dir, store, s := savedFileCheckpoint(t)
+	if err := store.Save(s.Set("document", "summary.pdf")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	for _, d := range []string{dir, filepath.Join(dir, s.RunID+".versions")} {
+		entries, err := os.ReadDir(d)
+		if err != nil {
+			t.Fatalf("ReadDir() error = %v", err)
+		}
+		for _, entry := range entries {
+			if strings.Contains(entry.Name(), ".tmp") {
+				t.Errorf("temporary file %s left in %s", entry.Name(), d)
+			}
+		}
+	}
+
+	ids, _ := store.List()
+	if len(ids) != 1 || ids[0] != s.RunID {
+		t.Errorf("List() = %v, want [%s]", ids, s.RunID)
+	}
+}
+
+func TestGraph_ResumeCorruptCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	store, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	cfg := config.DefaultGraphConfig("corrupt-resume")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.MaxVersions = 5
+
+	graph, _ := state.NewGraphWithDeps(cfg, nil, store)
+
+	failing := true
+	graph.AddNode("plan", simpleNode("plan", "ok"))
+	graph.AddNode("draft", simpleNode("draft", "ok"))
+	graph.AddNode("publish", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		if failing {
+			return s, errors.New("publish failed")
+		}
+		return s.Set("published", true), nil
+	}))
+	graph.AddEdge("plan", "draft", nil)
+	graph.AddEdge("draft", "publish", nil)
+	graph.SetEntryPoint("plan")
+	graph.SetExitPoint("publish")
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err == nil {
+		t.Fatal("Execute() should fail at publish")
+	}
+
+	// Corrupt both the latest checkpoint and its version file.
+	versioned := store.(state.VersionedCheckpointStore)
+	versions, _ := versioned.Versions(initial.RunID)
+	if len(versions) != 2 {
+		t.Fatalf("Versions() = %+v, want plan and draft", versions)
+	}
+	latest := versions[len(versions)-1].Version
+	for _, path := range []string{
+		filepath.Join(dir, initial.RunID+".checkpoint"),
+		filepath.Join(dir, initial.RunID+".versions", "2.checkpoint"),
+	} {
+		data, _ := os.ReadFile(path)
+		os.WriteFile(path, data[:len(data)/2], 0o600)
+	}
+
+	_, err = graph.Resume(context.Background(), initial.RunID)
+	var corrupt *state.CorruptCheckpointError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("Resume() error = %v, want *CorruptCheckpointError", err)
+	}
+
+	if _, err := versioned.LoadVersion(initial.RunID, latest); !errors.As(err, &corrupt) || corrupt.Version != latest {
+		t.Errorf("LoadVersion(%d) error = %v, want corrupt version %d", latest, err, latest)
+	}
+
+	remaining, err := versioned.Versions(initial.RunID)
+	if err != nil || len(remaining) != 1 || remaining[0].Node != "plan" {
+		t.Fatalf("Versions() = %+v, %v; want only the intact plan version", remaining, err)
+	}
+
+	failing = false
+	final, err := graph.ResumeVersion(context.Background(), initial.RunID, remaining[0].Version)
+	if err != nil {
+		t.Fatalf("ResumeVersion() error = %v", err)
+	}
+	if v, _ := final.Get("published"); v != true {
+		t.Errorf("published = %v, want true", v)
+	}
+}
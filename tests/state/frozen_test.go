@@ -0,0 +1,259 @@
+package state_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func newFrozenState(observer observability.Observer) state.State {
+	return state.New(observer).
+		Set("document", "original").
+		Set("status", "draft").
+		Freeze("document")
+}
+
+func TestState_Freeze(t *testing.T) {
+	s := newFrozenState(nil)
+
+	if !s.IsFrozen("document") {
+		t.Error("document should be frozen")
+	}
+	if s.IsFrozen("status") {
+		t.Error("status should not be frozen")
+	}
+
+	unfrozen := state.New(nil).Set("document", "original")
+	if unfrozen.IsFrozen("document") {
+		t.Error("Freeze modified a different State")
+	}
+
+	more := s.Freeze("status", "pending")
+	if !reflect.DeepEqual(more.FrozenKeys(), []string{"document", "pending", "status"}) {
+		t.Errorf("FrozenKeys() = %v", more.FrozenKeys())
+	}
+	if !reflect.DeepEqual(s.FrozenKeys(), []string{"document"}) {
+		t.Errorf("original FrozenKeys() = %v, want [document]", s.FrozenKeys())
+	}
+}
+
+func TestState_TrySet_Frozen(t *testing.T) {
+	s := newFrozenState(nil)
+
+	result, err := s.TrySet("document", "edited")
+	if !errors.Is(err, state.ErrFrozenKey) {
+		t.Fatalf("TrySet() error = %v, want ErrFrozenKey", err)
+	}
+	if value, _ := result.GetString("document"); value != "original" {
+		t.Errorf("document = %q, want original", value)
+	}
+
+	result, err = s.TrySet("status", "final")
+	if err != nil {
+		t.Fatalf("TrySet() on unfrozen key error = %v", err)
+	}
+	if value, _ := result.GetString("status"); value != "final" {
+		t.Errorf("status = %q, want final", value)
+	}
+}
+
+func TestState_TrySetMany_Frozen(t *testing.T) {
+	s := newFrozenState(nil)
+
+	result, err := s.TrySetMany(map[string]any{"document": "edited", "status": "final"})
+	if !errors.Is(err, state.ErrFrozenKey) {
+		t.Fatalf("TrySetMany() error = %v, want ErrFrozenKey", err)
+	}
+	if value, _ := result.GetString("status"); value != "draft" {
+		t.Errorf("status = %q, want draft (no partial writes)", value)
+	}
+}
+
+func TestState_Set_FrozenSkipped(t *testing.T) {
+	observer := &captureObserver{}
+	s := newFrozenState(observer)
+	observer.events = nil
+
+	result := s.Set("document", "edited")
+	if value, _ := result.GetString("document"); value != "original" {
+		t.Errorf("document = %q, want original", value)
+	}
+
+	result = s.SetMany(map[string]any{"document": "edited", "status": "final"})
+	if value, _ := result.GetString("document"); value != "original" {
+		t.Errorf("SetMany document = %q, want original", value)
+	}
+	if value, _ := result.GetString("status"); value != "final" {
+		t.Errorf("SetMany status = %q, want final", value)
+	}
+
+	result = s.SetPath("document.title", "x")
+	if value, _ := result.GetString("document"); value != "original" {
+		t.Errorf("SetPath document = %v, want original", value)
+	}
+
+	reported := 0
+	for _, event := range observer.events {
+		if event.Type != observability.EventStateSet {
+			continue
+		}
+		if frozen, ok := event.Data["frozen"].([]string); ok && reflect.DeepEqual(frozen, []string{"document"}) {
+			reported++
+		}
+	}
+	if reported != 3 {
+		t.Errorf("%d set events reported frozen keys, want 3", reported)
+	}
+}
+
+func TestState_Update_Frozen(t *testing.T) {
+	s := newFrozenState(nil)
+
+	_, err := s.Update("document", func(current any, exists bool) (any, error) {
+		t.Error("update function should not be called for frozen key")
+		return current, nil
+	})
+	if !errors.Is(err, state.ErrFrozenKey) {
+		t.Errorf("Update() error = %v, want ErrFrozenKey", err)
+	}
+}
+
+func TestState_Merge_Frozen(t *testing.T) {
+	observer := &captureObserver{}
+	s := newFrozenState(observer)
+	other := state.New(nil).
+		Set("document", "replaced").
+		Set("summary", "short").
+		Freeze("summary")
+	observer.events = nil
+
+	merged := s.Merge(other)
+
+	if value, _ := merged.GetString("document"); value != "original" {
+		t.Errorf("document = %q, want original", value)
+	}
+	if value, _ := merged.GetString("summary"); value != "short" {
+		t.Errorf("summary = %q, want short", value)
+	}
+	if !merged.IsFrozen("document") || !merged.IsFrozen("summary") {
+		t.Errorf("merged FrozenKeys() = %v, want both sides' frozen keys", merged.FrozenKeys())
+	}
+
+	var mergeEvent *observability.Event
+	for i := range observer.events {
+		if observer.events[i].Type == observability.EventStateMerge {
+			mergeEvent = &observer.events[i]
+		}
+	}
+	if mergeEvent == nil {
+		t.Fatal("EventStateMerge not emitted")
+	}
+	if frozen := mergeEvent.Data["frozen"]; !reflect.DeepEqual(frozen, []string{"document"}) {
+		t.Errorf("merge event frozen = %v, want [document]", frozen)
+	}
+
+	resolved := s.MergeWith(other, func(key string, ours, theirs any) any { return theirs })
+	if value, _ := resolved.GetString("document"); value != "original" {
+		t.Errorf("MergeWith document = %q, want original", value)
+	}
+}
+
+func TestState_Freeze_PreservedByCopies(t *testing.T) {
+	s := newFrozenState(nil)
+
+	copies := map[string]state.State{
+		"Clone":        s.Clone(),
+		"CloneDeep":    s.CloneDeep(),
+		"Set":          s.Set("status", "final"),
+		"WithObserver": s.WithObserver(nil),
+	}
+
+	for name, copied := range copies {
+		if !copied.IsFrozen("document") {
+			t.Errorf("%s lost frozen keys", name)
+		}
+	}
+}
+
+func TestState_Freeze_JSON(t *testing.T) {
+	s := newFrozenState(nil)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded state.State
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !decoded.IsFrozen("document") {
+		t.Errorf("decoded FrozenKeys() = %v, want [document]", decoded.FrozenKeys())
+	}
+}
+
+func TestGraph_FrozenKeys(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	store := state.NewMemoryCheckpointStore()
+	graph, err := state.NewGraphWithDeps(cfg, observability.NoOpObserver{}, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps failed: %v", err)
+	}
+
+	graph.AddNode("edit", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("document", "edited").Set("status", "reviewed"), nil
+	}))
+	graph.SetEntryPoint("edit")
+	graph.SetExitPoint("edit")
+
+	initial := newFrozenState(nil)
+	result, err := graph.Execute(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if value, _ := result.GetString("document"); value != "original" {
+		t.Errorf("document = %q, want original", value)
+	}
+
+	saved, err := store.Load(initial.RunID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !saved.IsFrozen("document") {
+		t.Error("checkpoint lost frozen keys")
+	}
+}
+
+func TestGraph_FrozenKeys_NodeViolation(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	graph.AddNode("rebuild", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return state.NewFromMap(nil, map[string]any{"document": "rewritten"}), nil
+	}))
+	graph.SetEntryPoint("rebuild")
+	graph.SetExitPoint("rebuild")
+
+	_, err = graph.Execute(context.Background(), newFrozenState(nil))
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecutionError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, state.ErrFrozenKey) {
+		t.Errorf("error = %v, want ErrFrozenKey", err)
+	}
+}
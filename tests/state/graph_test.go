@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
 )
 
 func newTestNode(key string, value any) state.StateNode {
@@ -18,12 +20,6 @@ func newTestNode(key string, value any) state.StateNode {
 	})
 }
 
-func newErrorNode(err error) state.StateNode {
-	return state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
-		return s, err
-	})
-}
-
 func TestNewGraph(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -141,6 +137,37 @@ func TestStateGraph_AddNode_Duplicate(t *testing.T) {
 	}
 }
 
+func TestStateGraph_AddNodeFunc(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	err = graph.AddNodeFunc("mark", func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("marked", true), nil
+	})
+	if err != nil {
+		t.Fatalf("AddNodeFunc failed: %v", err)
+	}
+	graph.SetEntryPoint("mark")
+	graph.SetExitPoint("mark")
+
+	if err := graph.AddNodeFunc("nil", nil); err == nil {
+		t.Error("expected error for nil function, got nil")
+	}
+	if err := graph.AddNodeFunc("mark", func(ctx context.Context, s state.State) (state.State, error) { return s, nil }); err == nil {
+		t.Error("expected duplicate node error, got nil")
+	}
+
+	result, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if marked, _ := result.Get("marked"); marked != true {
+		t.Errorf("marked = %v, want true", marked)
+	}
+}
+
 func TestStateGraph_AddEdge(t *testing.T) {
 	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
 	if err != nil {
@@ -412,6 +439,76 @@ func TestStateGraph_Execute_Cycle(t *testing.T) {
 	}
 }
 
+func TestStateGraph_Execute_ExecutionTimeout(t *testing.T) {
+	cfg := config.DefaultGraphConfig("timeout")
+	cfg.Observer = "noop"
+	cfg.MaxIterations = 10000
+	cfg.ExecutionTimeout = 50 * time.Millisecond
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	slow := func(ctx context.Context, s state.State) (state.State, error) {
+		time.Sleep(10 * time.Millisecond)
+		return s, nil
+	}
+	graph.AddNodeFunc("a", slow)
+	graph.AddNodeFunc("b", slow)
+	graph.AddNodeFunc("done", slow)
+	graph.AddEdge("a", "b", nil)
+	graph.AddEdge("b", "a", state.Not(state.KeyExists("done")))
+	graph.AddEdge("b", "done", nil)
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("done")
+
+	_, err = graph.Execute(context.Background(), state.New(nil))
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecutionError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, state.ErrExecutionTimeout) {
+		t.Errorf("error = %v, want ErrExecutionTimeout", err)
+	}
+	if len(execErr.Path) < 2 {
+		t.Errorf("Path = %v, want the nodes executed before the timeout", execErr.Path)
+	}
+}
+
+func TestStateGraph_Execute_ExecutionTimeout_NodeContext(t *testing.T) {
+	cfg := config.DefaultGraphConfig("timeout")
+	cfg.Observer = "noop"
+	cfg.ExecutionTimeout = 20 * time.Millisecond
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNodeFunc("wait", func(ctx context.Context, s state.State) (state.State, error) {
+		select {
+		case <-ctx.Done():
+			return s, ctx.Err()
+		case <-time.After(time.Second):
+			return s, nil
+		}
+	})
+	graph.SetEntryPoint("wait")
+	graph.SetExitPoint("wait")
+
+	start := time.Now()
+	_, err = graph.Execute(context.Background(), state.New(nil))
+
+	if !errors.Is(err, state.ErrExecutionTimeout) {
+		t.Errorf("error = %v, want ErrExecutionTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Execute took %v, want node cancelled at the deadline", elapsed)
+	}
+}
+
 func TestStateGraph_Execute_MaxIterations(t *testing.T) {
 	cfg := config.GraphConfig{
 		Name:          "iteration-test",
@@ -429,6 +526,7 @@ func TestStateGraph_Execute_MaxIterations(t *testing.T) {
 	graph.AddNode("never-reached", newTestNode("step", "never-reached"))
 	graph.AddEdge("a", "b", nil)
 	graph.AddEdge("b", "a", nil)
+	graph.AddEdge("b", "never-reached", state.KeyExists("never"))
 	graph.SetEntryPoint("a")
 	graph.SetExitPoint("never-reached")
 
@@ -488,7 +586,7 @@ func TestStateGraph_Execute_NodeError(t *testing.T) {
 	expectedErr := fmt.Errorf("node failed")
 
 	graph.AddNode("start", newTestNode("step", "start"))
-	graph.AddNode("fail", newErrorNode(expectedErr))
+	graph.AddNode("fail", sttest.MockNode(state.State{}, expectedErr))
 	graph.AddEdge("start", "fail", nil)
 	graph.SetEntryPoint("start")
 	graph.SetExitPoint("fail")
@@ -763,3 +861,212 @@ func containsAtIndex(s, substr string) bool {
 	}
 	return false
 }
+
+func TestStateGraph_Validate_Acyclic(t *testing.T) {
+	newAcyclicGraph := func() state.StateGraph {
+		cfg := config.DefaultGraphConfig("acyclic")
+		cfg.Observer = "noop"
+		cfg.Acyclic = true
+		g, _ := state.NewGraph(cfg)
+		g.AddNode("a", newTestNode("step", "a"))
+		g.AddNode("b", newTestNode("step", "b"))
+		g.AddNode("c", newTestNode("step", "c"))
+		g.AddNode("exit", newTestNode("step", "exit"))
+		g.SetEntryPoint("a")
+		g.SetExitPoint("exit")
+		return g
+	}
+
+	tests := []struct {
+		name          string
+		edges         func(g state.StateGraph)
+		expectError   bool
+		expectedCycle string
+	}{
+		{
+			name: "feed-forward graph",
+			edges: func(g state.StateGraph) {
+				g.AddEdge("a", "b", nil)
+				g.AddEdge("a", "c", state.KeyExists("x"))
+				g.AddEdge("b", "exit", nil)
+				g.AddEdge("c", "exit", nil)
+			},
+			expectError: false,
+		},
+		{
+			name: "unconditional cycle",
+			edges: func(g state.StateGraph) {
+				g.AddEdge("a", "b", nil)
+				g.AddEdge("b", "c", nil)
+				g.AddEdge("c", "a", nil)
+				g.AddEdge("c", "exit", nil)
+			},
+			expectError:   true,
+			expectedCycle: "a -> b -> c -> a",
+		},
+		{
+			name: "conditional edge cycle",
+			edges: func(g state.StateGraph) {
+				g.AddEdge("a", "b", nil)
+				g.AddEdge("b", "c", nil)
+				g.AddEdge("c", "b", state.KeyEquals("retry", true))
+				g.AddEdge("c", "exit", nil)
+			},
+			expectError:   true,
+			expectedCycle: "b -> c -> b",
+		},
+		{
+			name: "self loop",
+			edges: func(g state.StateGraph) {
+				g.AddEdge("a", "a", state.KeyExists("again"))
+				g.AddEdge("a", "exit", nil)
+			},
+			expectError:   true,
+			expectedCycle: "a -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newAcyclicGraph()
+			tt.edges(g)
+
+			err := g.Validate()
+
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("unexpected validation error: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected cycle error, got nil")
+			}
+			if !contains(err.Error(), tt.expectedCycle) {
+				t.Errorf("expected error to contain cycle %q, got %v", tt.expectedCycle, err)
+			}
+
+			_, execErr := g.Execute(context.Background(), state.New(observability.NoOpObserver{}))
+			if execErr == nil {
+				t.Error("expected Execute to fail validation for cyclic graph")
+			}
+		})
+	}
+}
+
+func TestStateGraph_Validate_CyclesAllowedByDefault(t *testing.T) {
+	cfg := config.DefaultGraphConfig("cyclic")
+	cfg.Observer = "noop"
+	g, _ := state.NewGraph(cfg)
+	g.AddNode("a", newTestNode("step", "a"))
+	g.AddNode("b", newTestNode("step", "b"))
+	g.AddEdge("a", "b", nil)
+	g.AddEdge("b", "a", state.KeyExists("never"))
+	g.SetEntryPoint("a")
+	g.SetExitPoint("b")
+
+	if err := g.Validate(); err != nil {
+		t.Errorf("expected cyclic graph to validate when Acyclic is false, got %v", err)
+	}
+}
+
+func TestStateGraph_Validate_Unreachable(t *testing.T) {
+	newGraph := func() state.StateGraph {
+		cfg := config.DefaultGraphConfig("reachability")
+		cfg.Observer = "noop"
+		g, _ := state.NewGraph(cfg)
+		for _, name := range []string{"start", "review", "fallback", "orphan", "island", "exit"} {
+			g.AddNode(name, newTestNode("step", name))
+		}
+		g.SetEntryPoint("start")
+		g.SetExitPoint("exit")
+		return g
+	}
+
+	t.Run("all reachable", func(t *testing.T) {
+		g := newGraph()
+		g.AddEdge("start", "review", nil)
+		g.AddEdge("review", "exit", nil)
+		g.AddEdge("review", "fallback", state.KeyExists("error"))
+		g.AddEdge("fallback", "orphan", nil)
+		g.AddEdge("orphan", "island", nil)
+		g.AddEdge("island", "exit", nil)
+
+		if err := g.Validate(); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	})
+
+	t.Run("unreachable nodes", func(t *testing.T) {
+		g := newGraph()
+		g.AddEdge("start", "review", nil)
+		g.AddEdge("review", "exit", nil)
+		g.AddEdge("orphan", "island", nil)
+		g.AddEdge("island", "exit", nil)
+
+		err := g.Validate()
+		if err == nil {
+			t.Fatal("expected unreachable node error, got nil")
+		}
+		if !contains(err.Error(), "fallback, island, orphan") {
+			t.Errorf("expected error to list unreachable nodes, got %v", err)
+		}
+
+		if _, execErr := g.Execute(context.Background(), state.New(nil)); execErr == nil {
+			t.Error("expected Execute to fail validation")
+		}
+	})
+}
+
+func TestStateGraph_Execute_AcyclicNoCycleEvents(t *testing.T) {
+	observer := &captureObserver{}
+
+	cfg := config.DefaultGraphConfig("acyclic-exec")
+	cfg.Acyclic = true
+	g, _ := state.NewGraphWithDeps(cfg, observer, nil)
+	g.AddNode("a", newTestNode("a", true))
+	g.AddNode("b", newTestNode("b", true))
+	g.AddEdge("a", "b", nil)
+	g.SetEntryPoint("a")
+	g.SetExitPoint("b")
+
+	result, err := g.Execute(context.Background(), state.New(observability.NoOpObserver{}))
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if _, exists := result.Get("b"); !exists {
+		t.Error("expected exit node to execute")
+	}
+
+	for _, event := range observer.events {
+		if event.Type == observability.EventCycleDetected {
+			t.Error("unexpected EventCycleDetected in acyclic mode")
+		}
+	}
+}
+
+func TestStateGraph_Execute_ExecutionErrorMessageID(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("ask", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s, messaging.WrapError("msg-7", errors.New("request timed out"))
+	}))
+	graph.SetEntryPoint("ask")
+	graph.SetExitPoint("ask")
+
+	_, err = graph.Execute(context.Background(), state.New(observability.NoOpObserver{}))
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecutionError, got %T", err)
+	}
+
+	if execErr.MessageID != "msg-7" {
+		t.Errorf("MessageID = %q, want msg-7", execErr.MessageID)
+	}
+}
@@ -0,0 +1,171 @@
+package state_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// groupedGraph builds extract -> normalize -> classify -> publish with the
+// first two nodes in "preprocessing" and classify in "analysis".
+func groupedGraph(t *testing.T, observer observability.Observer) state.StateGraph {
+	t.Helper()
+
+	graph, err := state.NewGraphWithDeps(config.DefaultGraphConfig("grouped"), observer, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+
+	sleepy := func(ctx context.Context, s state.State) (state.State, error) {
+		time.Sleep(5 * time.Millisecond)
+		return s, nil
+	}
+	for _, name := range []string{"extract", "normalize", "classify", "publish"} {
+		graph.AddNodeFunc(name, sleepy)
+	}
+	graph.AddEdge("extract", "normalize", nil)
+	graph.AddEdge("normalize", "classify", nil)
+	graph.AddEdge("classify", "publish", nil)
+	graph.SetEntryPoint("extract")
+	graph.SetExitPoint("publish")
+
+	for node, group := range map[string]string{
+		"extract":   "preprocessing",
+		"normalize": "preprocessing",
+		"classify":  "analysis",
+	} {
+		if err := graph.SetNodeGroup(node, group); err != nil {
+			t.Fatalf("SetNodeGroup(%s) error = %v", node, err)
+		}
+	}
+	return graph
+}
+
+func TestGraph_SetNodeGroup_Errors(t *testing.T) {
+	graph := groupedGraph(t, nil)
+
+	if err := graph.SetNodeGroup("missing", "analysis"); err == nil {
+		t.Error("SetNodeGroup() error = nil for unknown node")
+	}
+
+	if err := graph.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if err := graph.SetNodeGroup("publish", "output"); err == nil {
+		t.Error("SetNodeGroup() error = nil after Compile")
+	}
+}
+
+func TestGraph_NodeGroupEvents(t *testing.T) {
+	observer := &captureObserver{}
+	graph := groupedGraph(t, observer)
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := map[string]any{
+		"extract":   "preprocessing",
+		"normalize": "preprocessing",
+		"classify":  "analysis",
+		"publish":   nil,
+	}
+	for _, eventType := range []observability.EventType{observability.EventNodeStart, observability.EventNodeComplete} {
+		for _, event := range eventsOfType(observer.events, eventType) {
+			node := event.Data["node"].(string)
+			if got := event.Data["group"]; got != want[node] {
+				t.Errorf("%s %s group = %v, want %v", eventType, node, got, want[node])
+			}
+		}
+	}
+}
+
+func TestGraph_GroupDurations(t *testing.T) {
+	graph := groupedGraph(t, nil)
+
+	result, err := graph.ExecuteWithResult(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("ExecuteWithResult() error = %v", err)
+	}
+
+	if len(result.GroupDurations) != 2 {
+		t.Fatalf("GroupDurations = %v, want preprocessing and analysis only", result.GroupDurations)
+	}
+	if got := result.GroupDurations["preprocessing"]; got < 10*time.Millisecond {
+		t.Errorf("preprocessing = %s, want both nodes' time", got)
+	}
+	if got := result.GroupDurations["analysis"]; got < 5*time.Millisecond {
+		t.Errorf("analysis = %s, want classify's time", got)
+	}
+}
+
+func TestGraph_ExportMermaid_NodeGroups(t *testing.T) {
+	graph := groupedGraph(t, nil)
+	graph.SetNodeGroup("classify", "")
+
+	output := graph.ExportMermaid()
+
+	// Nodes sort as classify, extract, normalize, publish.
+	expected := strings.Join([]string{
+		`    subgraph g0["preprocessing"]`,
+		`        n1(["extract"])`,
+		`        n2["normalize"]`,
+		`    end`,
+	}, "\n")
+	if !strings.Contains(output, expected) {
+		t.Errorf("expected preprocessing subgraph, got:\n%s", output)
+	}
+	if strings.Count(output, "subgraph") != 1 {
+		t.Errorf("expected the ungrouped classify node outside any subgraph, got:\n%s", output)
+	}
+	if !strings.Contains(output, "    n0[\"classify\"]\n") {
+		t.Errorf("expected classify at top level, got:\n%s", output)
+	}
+}
+
+func TestGraph_ExportDOT_NodeGroups(t *testing.T) {
+	graph := groupedGraph(t, nil)
+
+	output := graph.ExportDOT()
+
+	// Groups sort as analysis, preprocessing.
+	expected := strings.Join([]string{
+		`    subgraph cluster_1 {`,
+		`        label="preprocessing";`,
+		`        "extract" [shape=oval];`,
+		`        "normalize";`,
+		`    }`,
+	}, "\n")
+	if !strings.Contains(output, expected) {
+		t.Errorf("expected preprocessing cluster, got:\n%s", output)
+	}
+	if !strings.Contains(output, "    subgraph cluster_0 {\n        label=\"analysis\";\n        \"classify\";\n    }\n") {
+		t.Errorf("expected analysis cluster, got:\n%s", output)
+	}
+	if !strings.Contains(output, "    \"publish\" [shape=doublecircle];\n") {
+		t.Errorf("expected publish at top level, got:\n%s", output)
+	}
+	if !strings.Contains(output, `    "extract" -> "normalize";`) {
+		t.Errorf("expected extract -> normalize edge, got:\n%s", output)
+	}
+}
+
+func TestGraph_ExportGroups_EscapesLabels(t *testing.T) {
+	graph := groupedGraph(t, nil)
+	graph.SetNodeGroup("classify", `say "hi" \ bye`)
+
+	mermaid := graph.ExportMermaid()
+	if !strings.Contains(mermaid, `subgraph g1["say #quot;hi#quot; \ bye"]`) {
+		t.Errorf("expected escaped Mermaid subgraph label, got:\n%s", mermaid)
+	}
+
+	dot := graph.ExportDOT()
+	if !strings.Contains(dot, `label="say \"hi\" \\ bye";`) {
+		t.Errorf("expected escaped DOT cluster label, got:\n%s", dot)
+	}
+}
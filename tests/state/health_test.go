@@ -0,0 +1,95 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+// unreachableStore is a memory store whose health check always fails.
+type unreachableStore struct {
+	state.CheckpointStore
+}
+
+func (unreachableStore) Ping(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestPingCheckpointStore(t *testing.T) {
+	if err := state.PingCheckpointStore(context.Background(), state.NewMemoryCheckpointStore()); err != nil {
+		t.Errorf("PingCheckpointStore() memory store error = %v", err)
+	}
+
+	bad := unreachableStore{state.NewMemoryCheckpointStore()}
+	if err := state.PingCheckpointStore(context.Background(), bad); err == nil {
+		t.Error("PingCheckpointStore() should report an unreachable store")
+	}
+
+	encrypted, _ := state.NewEncryptedStore(bad, testKey(1))
+	if err := state.PingCheckpointStore(context.Background(), encrypted); err == nil {
+		t.Error("PingCheckpointStore() should delegate through the encrypted store")
+	}
+}
+
+func TestFileCheckpointStore_Ping(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "checkpoints")
+	store, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	if err := state.PingCheckpointStore(context.Background(), store); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+
+	os.RemoveAll(dir)
+	if err := state.PingCheckpointStore(context.Background(), store); err == nil {
+		t.Error("Ping() after removing the directory should fail")
+	}
+}
+
+func TestNewGraph_PingsCheckpointStore(t *testing.T) {
+	cfg := config.DefaultGraphConfig("unreachable")
+	cfg.Checkpoint.Interval = 1
+
+	bad := unreachableStore{state.NewMemoryCheckpointStore()}
+	_, err := state.NewGraphWithDeps(cfg, nil, bad)
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("NewGraphWithDeps() error = %v, want wrapped ping failure", err)
+	}
+
+	state.RegisterCheckpointStore("unreachable", bad)
+	cfg.Checkpoint.Store = "unreachable"
+	if _, err := state.NewGraph(cfg); err == nil {
+		t.Error("NewGraph() with an unreachable store should fail")
+	}
+
+	cfg.Checkpoint.Interval = 0
+	if _, err := state.NewGraphWithDeps(cfg, nil, bad); err != nil {
+		t.Errorf("NewGraphWithDeps() without checkpointing error = %v", err)
+	}
+}
+
+func TestGraph_PingCheckpointStore(t *testing.T) {
+	cfg := config.DefaultGraphConfig("healthy")
+	cfg.Checkpoint.Interval = 1
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, state.NewMemoryCheckpointStore())
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	if err := graph.PingCheckpointStore(context.Background()); err != nil {
+		t.Errorf("PingCheckpointStore() error = %v", err)
+	}
+
+	disabled, _ := state.NewGraphWithDeps(config.DefaultGraphConfig("disabled"), nil, nil)
+	if err := disabled.PingCheckpointStore(context.Background()); err != nil {
+		t.Errorf("PingCheckpointStore() without checkpointing error = %v", err)
+	}
+}
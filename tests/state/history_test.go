@@ -0,0 +1,217 @@
+package state_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
+)
+
+func newHistoryGraph(t *testing.T, cfg config.GraphConfig, store state.CheckpointStore) state.StateGraph {
+	t.Helper()
+
+	graph, err := state.NewGraphWithDeps(cfg, observability.NoOpObserver{}, store)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("a", newTestNode("step", "a"))
+	graph.AddNode("b", newTestNode("step", "b"))
+	graph.AddNode("c", newTestNode("step", "c"))
+	graph.AddEdge("a", "b", nil)
+	graph.AddEdge("b", "c", nil)
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("c")
+
+	return graph
+}
+
+func TestStateGraph_ExecuteWithResult_History(t *testing.T) {
+	cfg := config.DefaultGraphConfig("history-test")
+	cfg.TrackHistory = true
+
+	graph := newHistoryGraph(t, cfg, nil)
+
+	initial := state.New(observability.NoOpObserver{}).Set("step", "initial")
+	result, err := graph.ExecuteWithResult(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if !slices.Equal(result.Path, []string{"a", "b", "c"}) {
+		t.Errorf("Path = %v, want [a b c]", result.Path)
+	}
+
+	if len(result.History) != 4 {
+		t.Fatalf("History length = %d, want 4", len(result.History))
+	}
+
+	if !slices.Equal(result.History.Nodes(), []string{"a", "b", "c"}) {
+		t.Errorf("History.Nodes() = %v, want [a b c]", result.History.Nodes())
+	}
+
+	tests := []struct {
+		node     string
+		expected string
+	}{
+		{node: "a", expected: "initial"},
+		{node: "b", expected: "a"},
+		{node: "c", expected: "b"},
+	}
+
+	for _, tt := range tests {
+		before, ok := result.History.Before(tt.node)
+		if !ok {
+			t.Errorf("Before(%s) not found", tt.node)
+			continue
+		}
+		if step, _ := before.Get("step"); step != tt.expected {
+			t.Errorf("Before(%s) step = %v, want %v", tt.node, step, tt.expected)
+		}
+	}
+
+	if step, _ := result.State.Get("step"); step != "c" {
+		t.Errorf("final step = %v, want c", step)
+	}
+}
+
+func TestStateGraph_ExecuteWithResult_HistoryDisabled(t *testing.T) {
+	graph := newHistoryGraph(t, config.DefaultGraphConfig("history-test"), nil)
+
+	result, err := graph.ExecuteWithResult(context.Background(), state.New(observability.NoOpObserver{}))
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if result.History != nil {
+		t.Errorf("expected no history, got %d entries", len(result.History))
+	}
+
+	if len(result.Path) != 3 {
+		t.Errorf("Path length = %d, want 3", len(result.Path))
+	}
+}
+
+func TestStateGraph_ExecuteWithResult_MaxHistory(t *testing.T) {
+	cfg := config.DefaultGraphConfig("history-test")
+	cfg.TrackHistory = true
+	cfg.MaxHistory = 2
+
+	graph := newHistoryGraph(t, cfg, nil)
+
+	result, err := graph.ExecuteWithResult(context.Background(), state.New(observability.NoOpObserver{}))
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	if !slices.Equal(result.History.Nodes(), []string{"b", "c"}) {
+		t.Errorf("History.Nodes() = %v, want [b c]", result.History.Nodes())
+	}
+
+	if _, ok := result.History.Before("b"); ok {
+		t.Error("expected Before(b) to be unavailable after its predecessor was discarded")
+	}
+}
+
+func TestStateGraph_ExecuteWithResult_HistoryOnFailure(t *testing.T) {
+	cfg := config.DefaultGraphConfig("history-test")
+	cfg.TrackHistory = true
+
+	graph, err := state.NewGraphWithDeps(cfg, observability.NoOpObserver{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("a", newTestNode("step", "a"))
+	graph.AddNode("fail", sttest.MockNode(state.State{}, context.DeadlineExceeded))
+	graph.AddEdge("a", "fail", nil)
+	graph.SetEntryPoint("a")
+	graph.SetExitPoint("fail")
+
+	result, err := graph.ExecuteWithResult(context.Background(), state.New(observability.NoOpObserver{}))
+	if err == nil {
+		t.Fatal("expected execution error")
+	}
+
+	before, ok := result.History.Before("a")
+	if !ok || before.Has("step") {
+		t.Errorf("expected initial state before a, got %v (ok=%v)", before.Data, ok)
+	}
+
+	if !slices.Equal(result.History.Nodes(), []string{"a"}) {
+		t.Errorf("History.Nodes() = %v, want [a]", result.History.Nodes())
+	}
+}
+
+func TestStateGraph_History_PersistedWithCheckpoint(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+
+	cfg := config.DefaultGraphConfig("history-test")
+	cfg.TrackHistory = true
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	graph := newHistoryGraph(t, cfg, store)
+
+	initial := state.New(observability.NoOpObserver{})
+	result, err := graph.ExecuteWithResult(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	historyStore, ok := store.(state.HistoryStore)
+	if !ok {
+		t.Fatal("memory checkpoint store should implement HistoryStore")
+	}
+
+	history, err := historyStore.LoadHistory(initial.RunID)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+
+	if len(history) != len(result.History) {
+		t.Errorf("persisted history length = %d, want %d", len(history), len(result.History))
+	}
+
+	store.Delete(initial.RunID)
+	if _, err := historyStore.LoadHistory(initial.RunID); err == nil {
+		t.Error("expected history to be removed with checkpoint")
+	}
+}
+
+func TestStateGraph_History_DeepSnapshots(t *testing.T) {
+	cfg := config.DefaultGraphConfig("history-test")
+	cfg.TrackHistory = true
+	cfg.DeepClone = true
+
+	graph, err := state.NewGraphWithDeps(cfg, observability.NoOpObserver{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	mutate := state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		items, _ := s.Get("items")
+		items.([]string)[0] = "mutated"
+		return s, nil
+	})
+
+	graph.AddNode("mutate", mutate)
+	graph.SetEntryPoint("mutate")
+	graph.SetExitPoint("mutate")
+
+	initial := state.New(observability.NoOpObserver{}).Set("items", []string{"original"})
+	result, err := graph.ExecuteWithResult(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	before, _ := result.History.Before("mutate")
+	items, _ := before.Get("items")
+	if items.([]string)[0] != "original" {
+		t.Errorf("initial snapshot was mutated: %v", items)
+	}
+}
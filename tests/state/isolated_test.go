@@ -0,0 +1,107 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestIsolatedRunner_ConcurrentRuns(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	cfg := config.DefaultGraphConfig("isolated")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	graph, err := state.NewGraphWithDeps(cfg, nil, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps() error = %v", err)
+	}
+	graph.AddNodeFunc("tag", func(ctx context.Context, s state.State) (state.State, error) {
+		doc, _ := s.GetString("document")
+		meta, _ := state.GetAs[map[string]any](s, "meta")
+		meta["tagged"] = doc
+		return s.Set("result", doc+"-done"), nil
+	})
+	graph.SetEntryPoint("tag")
+	graph.SetExitPoint("tag")
+
+	runner := state.NewIsolatedRunner(graph)
+	if !runner.Graph().IsCompiled() {
+		t.Fatal("NewIsolatedRunner() did not compile the graph")
+	}
+
+	seed := state.New(nil).Set("meta", map[string]any{})
+
+	const runs = 16
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	runIDs := make(map[string]bool)
+
+	for i := range runs {
+		wg.Go(func() {
+			doc := fmt.Sprintf("doc-%02d", i)
+			final, err := runner.Run(context.Background(), seed.Set("document", doc))
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+				return
+			}
+			if v, _ := final.GetString("result"); v != doc+"-done" {
+				t.Errorf("result = %q, want %s-done", v, doc)
+			}
+			mu.Lock()
+			runIDs[final.RunID] = true
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	if len(runIDs) != runs {
+		t.Errorf("runs used %d distinct run IDs, want %d", len(runIDs), runs)
+	}
+	if ids, _ := store.List(); len(ids) != runs {
+		t.Errorf("store holds %d checkpoints, want %d", len(ids), runs)
+	}
+
+	meta, _ := state.GetAs[map[string]any](seed, "meta")
+	if len(meta) != 0 {
+		t.Errorf("seed state mutated by runs: %v", meta)
+	}
+}
+
+func TestIsolatedRunner_FreshRunID(t *testing.T) {
+	graph := linearGraph(t, config.DefaultGraphConfig("fresh-id"))
+	runner := state.NewIsolatedRunner(graph)
+
+	initial := state.New(nil)
+	final, err := runner.Run(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if final.RunID == "" || final.RunID == initial.RunID {
+		t.Errorf("RunID = %q, want a new run ID", final.RunID)
+	}
+}
+
+func TestIsolatedRunner_LocksGraph(t *testing.T) {
+	graph := linearGraph(t, config.DefaultGraphConfig("locked"))
+	state.NewIsolatedRunner(graph)
+
+	if err := graph.AddNode("late", simpleNode("k", "v")); !errors.Is(err, state.ErrGraphCompiled) {
+		t.Errorf("AddNode() after NewIsolatedRunner error = %v, want ErrGraphCompiled", err)
+	}
+}
+
+func TestIsolatedRunner_CompileError(t *testing.T) {
+	graph, _ := state.NewGraphWithDeps(config.DefaultGraphConfig("broken"), nil, nil)
+	graph.AddNode("a", simpleNode("k", "v"))
+
+	runner := state.NewIsolatedRunner(graph)
+	if _, err := runner.Run(context.Background(), state.New(nil)); err == nil {
+		t.Error("Run() on a graph that failed to compile should fail")
+	}
+}
@@ -0,0 +1,110 @@
+package state_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_JSONRoundTrip(t *testing.T) {
+	original := state.New(nil).
+		Set("user", "alice").
+		Set("count", 3).
+		Set("tags", []string{"a", "b"}).
+		Set("meta", map[string]any{"ok": true}).
+		SetCheckpointNode("review")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var restored state.State
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if restored.RunID != original.RunID {
+		t.Errorf("RunID = %s, want %s", restored.RunID, original.RunID)
+	}
+	if restored.CheckpointNode != "review" {
+		t.Errorf("CheckpointNode = %s, want review", restored.CheckpointNode)
+	}
+	if !restored.Timestamp.Equal(original.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", restored.Timestamp, original.Timestamp)
+	}
+
+	if user, _ := restored.GetString("user"); user != "alice" {
+		t.Errorf("user = %q, want alice", user)
+	}
+	if count, ok := restored.GetInt("count"); !ok || count != 3 {
+		t.Errorf("count = %d, %v; want 3, true", count, ok)
+	}
+	if tags, ok := state.GetAs[[]any](restored, "tags"); !ok || len(tags) != 2 {
+		t.Errorf("tags = %v, want 2 elements", tags)
+	}
+
+	if _, ok := restored.Observer.(observability.NoOpObserver); !ok {
+		t.Errorf("Observer = %T, want NoOpObserver", restored.Observer)
+	}
+}
+
+func TestState_MarshalJSON_UnsupportedValue(t *testing.T) {
+	s := state.New(nil).Set("ok", 1).Set("callback", func() {})
+
+	_, err := json.Marshal(s)
+	if err == nil {
+		t.Fatal("Marshal() should fail for function value")
+	}
+	if !strings.Contains(err.Error(), `"callback"`) {
+		t.Errorf("error should name the key, got: %v", err)
+	}
+}
+
+func TestState_UnmarshalJSON_EmptyData(t *testing.T) {
+	var s state.State
+	if err := json.Unmarshal([]byte(`{"run_id":"abc","timestamp":"2025-01-01T00:00:00Z"}`), &s); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if s.Data == nil {
+		t.Error("Data should be non-nil after unmarshal")
+	}
+	if s.RunID != "abc" {
+		t.Errorf("RunID = %s, want abc", s.RunID)
+	}
+	if !s.Timestamp.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Timestamp = %v", s.Timestamp)
+	}
+
+	if err := json.Unmarshal([]byte(`{"data": 5}`), &s); err == nil {
+		t.Error("Unmarshal() should fail for malformed data")
+	}
+}
+
+func TestState_WithObserver(t *testing.T) {
+	original := state.New(nil).Set("key", "value")
+	observer := &captureObserver{}
+
+	attached := original.WithObserver(observer)
+
+	if attached.RunID != original.RunID {
+		t.Error("WithObserver should preserve RunID")
+	}
+	if val, _ := attached.Get("key"); val != "value" {
+		t.Error("WithObserver should preserve data")
+	}
+
+	attached.Set("other", 1)
+	if len(observer.events) == 0 {
+		t.Error("attached observer should receive events")
+	}
+
+	if _, ok := original.WithObserver(nil).Observer.(observability.NoOpObserver); !ok {
+		t.Error("WithObserver(nil) should use NoOpObserver")
+	}
+}
@@ -0,0 +1,82 @@
+package state_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestMergeResolvers(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver state.ConflictResolver
+		ours     any
+		theirs   any
+		want     any
+	}{
+		{"take first", state.TakeFirst(), "left", "right", "left"},
+		{"take last", state.TakeLast(), "left", "right", "right"},
+		{"take max theirs", state.TakeMax(), 0.4, 0.9, 0.9},
+		{"take max ours", state.TakeMax(), 12, 7, 12},
+		{"take max mixed", state.TakeMax(), 3, 2.5, 3},
+		{"take max int64", state.TakeMax(), int64(5), int64(3), int64(5)},
+		{"take max large int64", state.TakeMax(), int64(1<<62 + 1), int64(1 << 62), int64(1<<62 + 1)},
+		{"take max uint", state.TakeMax(), uint(9), uint(4), uint(9)},
+		{"take max uint vs negative int", state.TakeMax(), uint(1), -5, uint(1)},
+		{"take max float32", state.TakeMax(), float32(2.5), float32(1.5), float32(2.5)},
+		{"take max float32 theirs", state.TakeMax(), float32(0.1), 0.9, 0.9},
+		{"take max int32 vs float64", state.TakeMax(), int32(7), 6.5, int32(7)},
+		{"take max non-numeric", state.TakeMax(), 3, "high", "high"},
+		{"concatenate slices", state.Concatenate(), []string{"a"}, []string{"b", "c"}, []string{"a", "b", "c"}},
+		{"concatenate element", state.Concatenate(), []int{1}, 2, []int{1, 2}},
+		{"concatenate non-slice", state.Concatenate(), "a", "b", "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left := state.New(nil).Set("key", tt.ours)
+			right := state.New(nil).Set("key", tt.theirs)
+
+			got, _ := left.MergeWith(right, tt.resolver).Get("key")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("key = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_MergeWith_ConflictEvents(t *testing.T) {
+	observer := &captureObserver{}
+	left := state.New(observer).
+		Set("confidence", 0.6).
+		Set("owner", "alice").
+		SetIn("legal", "risk", 2)
+	right := state.New(nil).
+		Set("confidence", 0.8).
+		Set("summary", "ok").
+		SetIn("legal", "risk", 5)
+
+	observer.events = nil
+	left.MergeWith(right, state.TakeMax())
+
+	conflicts := eventsOfType(observer.events, observability.EventStateMergeConflict)
+	if len(conflicts) != 2 {
+		t.Fatalf("got %d conflict events, want 2", len(conflicts))
+	}
+
+	want := []map[string]any{
+		{"key": "confidence", "ours": 0.6, "theirs": 0.8, "resolved": 0.8},
+		{"key": "legal.risk", "ours": 2, "theirs": 5, "resolved": 5},
+	}
+	for i, event := range conflicts {
+		if !reflect.DeepEqual(event.Data, want[i]) {
+			t.Errorf("conflict %d data = %v, want %v", i, event.Data, want[i])
+		}
+	}
+
+	if last := observer.events[len(observer.events)-1]; last.Type != observability.EventStateMerge {
+		t.Errorf("last event = %s, want the merge summary", last.Type)
+	}
+}
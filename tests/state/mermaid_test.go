@@ -0,0 +1,167 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
+)
+
+func newMermaidGraph(t *testing.T) state.StateGraph {
+	t.Helper()
+
+	graph, err := state.NewGraph(config.GraphConfig{
+		Name:          "mermaid-test",
+		Observer:      "noop",
+		MaxIterations: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("analyze", newTestNode("step", "analyze"))
+	graph.AddNode("review", newTestNode("step", "review"))
+	graph.AddNode("publish", newTestNode("step", "publish"))
+
+	graph.AddEdge("analyze", "review", nil)
+	graph.AddEdge("review", "analyze", state.Not(state.KeyExists("approved")))
+	graph.AddEdge("review", "publish", state.KeyExists("approved"))
+
+	graph.SetEntryPoint("analyze")
+	graph.SetExitPoint("publish")
+
+	return graph
+}
+
+func TestStateGraph_ExportMermaid(t *testing.T) {
+	graph := newMermaidGraph(t)
+
+	output := graph.ExportMermaid()
+
+	expected := []string{
+		"flowchart TD",
+		`n0(["analyze"])`,
+		`n1(("publish"))`,
+		`n2["review"]`,
+		"n0 --> n2",
+		"n2 -.-> n0",
+		"n2 -.-> n1",
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+
+	if strings.Contains(output, "classDef") {
+		t.Errorf("structure-only export should not contain styles, got:\n%s", output)
+	}
+}
+
+func TestStateGraph_ExportMermaid_Deterministic(t *testing.T) {
+	graph := newMermaidGraph(t)
+
+	first := graph.ExportMermaid()
+	for range 10 {
+		if graph.ExportMermaid() != first {
+			t.Fatal("expected deterministic output")
+		}
+	}
+}
+
+func TestStateGraph_ExportMermaidWithPath(t *testing.T) {
+	graph := newMermaidGraph(t)
+
+	output := graph.ExportMermaidWithPath([]string{"analyze", "review", "analyze", "review", "publish"})
+
+	expected := []string{
+		`n0(["analyze<br/>#1, #3"])`,
+		`n2["review<br/>#2, #4"]`,
+		`n1(("publish<br/>#5"))`,
+		`n0 -->|"#1, #3"| n2`,
+		`n2 -.->|"#2"| n0`,
+		`n2 -.->|"#4"| n1`,
+		"class n0,n1,n2 visited",
+		"linkStyle 0,1,2 stroke:#28a745,stroke-width:3px",
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestStateGraph_ExportMermaidWithPath_PartialPath(t *testing.T) {
+	graph := newMermaidGraph(t)
+
+	output := graph.ExportMermaidWithPath([]string{"analyze", "review"})
+
+	if !strings.Contains(output, "class n0,n2 visited") {
+		t.Errorf("expected only visited nodes styled, got:\n%s", output)
+	}
+	if !strings.Contains(output, "linkStyle 0 stroke") {
+		t.Errorf("expected only traversed edge styled, got:\n%s", output)
+	}
+	if !strings.Contains(output, `n1(("publish"))`) {
+		t.Errorf("expected unvisited node without annotation, got:\n%s", output)
+	}
+}
+
+func TestStateGraph_ExportMermaidWithPath_UnknownNodes(t *testing.T) {
+	graph := newMermaidGraph(t)
+
+	output := graph.ExportMermaidWithPath([]string{"analyze", "legacy", "review"})
+
+	expected := []string{
+		`u0["legacy (unknown)<br/>#2"]`,
+		`n0 -.-|"#1"| u0`,
+		`u0 -.-|"#2"| n2`,
+		"class u0 unknown",
+		"linkStyle 3,4 stroke:#dc3545",
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestStateGraph_ExportMermaidWithPath_FromExecutionError(t *testing.T) {
+	graph, err := state.NewGraph(config.GraphConfig{
+		Name:          "mermaid-failure",
+		Observer:      "noop",
+		MaxIterations: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("start", newTestNode("step", "start"))
+	graph.AddNode("fail", sttest.MockNode(state.State{}, errors.New("boom")))
+	graph.AddNode("end", newTestNode("step", "end"))
+	graph.AddEdge("start", "fail", nil)
+	graph.AddEdge("fail", "end", nil)
+	graph.SetEntryPoint("start")
+	graph.SetExitPoint("end")
+
+	_, err = graph.Execute(context.Background(), state.New(observability.NoOpObserver{}))
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecutionError, got %v", err)
+	}
+
+	output := graph.ExportMermaidWithPath(execErr.Path)
+
+	if !strings.Contains(output, `"fail<br/>#2"`) {
+		t.Errorf("expected failing node annotated, got:\n%s", output)
+	}
+	if strings.Contains(output, `end<br/>`) {
+		t.Errorf("expected unreached node unannotated, got:\n%s", output)
+	}
+}
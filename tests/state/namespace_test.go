@@ -0,0 +1,197 @@
+package state_test
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_SetIn_GetIn(t *testing.T) {
+	original := state.New(observability.NoOpObserver{})
+
+	s := original.
+		SetIn("legal", "summary", "contract is valid").
+		SetIn("finance", "summary", "budget approved").
+		SetIn("legal", "risk", "low").
+		Set("summary", "top-level")
+
+	tests := []struct {
+		ns       string
+		key      string
+		expected any
+		exists   bool
+	}{
+		{ns: "legal", key: "summary", expected: "contract is valid", exists: true},
+		{ns: "legal", key: "risk", expected: "low", exists: true},
+		{ns: "finance", key: "summary", expected: "budget approved", exists: true},
+		{ns: "finance", key: "risk", exists: false},
+		{ns: "missing", key: "summary", exists: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.ns, tt.key), func(t *testing.T) {
+			value, exists := s.GetIn(tt.ns, tt.key)
+			if exists != tt.exists {
+				t.Fatalf("GetIn() exists = %v, want %v", exists, tt.exists)
+			}
+			if value != tt.expected {
+				t.Errorf("GetIn() = %v, want %v", value, tt.expected)
+			}
+		})
+	}
+
+	if value, _ := s.Get("summary"); value != "top-level" {
+		t.Errorf("top-level summary = %v, want top-level", value)
+	}
+
+	if len(original.Namespaces()) != 0 {
+		t.Error("original state was modified")
+	}
+
+	if !slices.Equal(s.Namespaces(), []string{"finance", "legal"}) {
+		t.Errorf("Namespaces() = %v, want [finance legal]", s.Namespaces())
+	}
+}
+
+func TestState_SetIn_Immutability(t *testing.T) {
+	s1 := state.New(observability.NoOpObserver{}).SetIn("legal", "summary", "v1")
+	s2 := s1.SetIn("legal", "summary", "v2")
+
+	if value, _ := s1.GetIn("legal", "summary"); value != "v1" {
+		t.Errorf("s1 namespace modified: %v", value)
+	}
+	if value, _ := s2.GetIn("legal", "summary"); value != "v2" {
+		t.Errorf("s2 summary = %v, want v2", value)
+	}
+}
+
+func TestState_Namespace(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).SetIn("legal", "summary", "valid")
+
+	legal := s.Namespace("legal")
+	if value, _ := legal.Get("summary"); value != "valid" {
+		t.Errorf("Namespace().Get() = %v, want valid", value)
+	}
+	if legal.RunID != s.RunID {
+		t.Error("Namespace() should preserve RunID")
+	}
+
+	legal = legal.Set("reviewed", true)
+	if _, exists := s.GetIn("legal", "reviewed"); exists {
+		t.Error("Namespace() changes should not affect the parent")
+	}
+
+	updated := s.MergeNamespace("legal", legal)
+	if value, _ := updated.GetIn("legal", "reviewed"); value != true {
+		t.Errorf("MergeNamespace() reviewed = %v, want true", value)
+	}
+	if value, _ := updated.GetIn("legal", "summary"); value != "valid" {
+		t.Errorf("MergeNamespace() summary = %v, want valid", value)
+	}
+
+	if empty := s.Namespace("missing"); empty.Len() != 0 || empty.Data == nil {
+		t.Error("Namespace() for a missing namespace should be empty and non-nil")
+	}
+}
+
+func TestState_MergeNamespace_Nested(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).
+		SetIn("legal", "summary", "valid")
+
+	sub := state.New(observability.NoOpObserver{}).
+		SetIn("contracts", "count", 3)
+
+	merged := s.MergeNamespace("legal", sub)
+	merged = merged.MergeNamespace("legal", state.New(observability.NoOpObserver{}).SetIn("contracts", "signed", 2))
+
+	contracts := merged.Namespace("legal").Namespace("contracts")
+	if value, _ := contracts.Get("count"); value != 3 {
+		t.Errorf("nested count = %v, want 3", value)
+	}
+	if value, _ := contracts.Get("signed"); value != 2 {
+		t.Errorf("nested signed = %v, want 2", value)
+	}
+}
+
+func TestState_Merge_Namespaces(t *testing.T) {
+	left := state.New(observability.NoOpObserver{}).
+		SetIn("legal", "summary", "from left").
+		SetIn("legal", "risk", "low").
+		Set("status", "left")
+
+	right := state.New(observability.NoOpObserver{}).
+		SetIn("legal", "summary", "from right").
+		SetIn("finance", "budget", 100).
+		Set("status", "right")
+
+	merged := left.Merge(right)
+
+	expected := map[string]any{"summary": "from right", "risk": "low"}
+	if !reflect.DeepEqual(merged.Namespace("legal").Data, expected) {
+		t.Errorf("legal namespace = %v, want %v", merged.Namespace("legal").Data, expected)
+	}
+	if value, _ := merged.GetIn("finance", "budget"); value != 100 {
+		t.Errorf("finance budget = %v, want 100", value)
+	}
+	if value, _ := merged.Get("status"); value != "right" {
+		t.Errorf("status = %v, want right", value)
+	}
+
+	if value, _ := left.GetIn("legal", "summary"); value != "from left" {
+		t.Error("Merge() modified the original namespace")
+	}
+}
+
+func TestState_MergeWith_Namespaces(t *testing.T) {
+	observer := &captureObserver{}
+
+	left := state.New(observer).SetIn("legal", "findings", []string{"a"})
+	right := state.New(observability.NoOpObserver{}).SetIn("legal", "findings", []string{"b"})
+
+	merged := left.MergeWith(right, func(key string, ours, theirs any) any {
+		return append(ours.([]string), theirs.([]string)...)
+	})
+
+	findings, _ := merged.GetIn("legal", "findings")
+	if !reflect.DeepEqual(findings, []string{"a", "b"}) {
+		t.Errorf("findings = %v, want [a b]", findings)
+	}
+
+	last := observer.events[len(observer.events)-1]
+	if last.Type != observability.EventStateMerge {
+		t.Fatalf("last event = %s, want %s", last.Type, observability.EventStateMerge)
+	}
+	conflicts := last.Data["conflicts"].([]string)
+	if !slices.Equal(conflicts, []string{"legal.findings"}) {
+		t.Errorf("conflicts = %v, want [legal.findings]", conflicts)
+	}
+}
+
+func TestNamespacePredicates(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).SetIn("finance", "status", "approved")
+
+	tests := []struct {
+		name      string
+		predicate state.TransitionPredicate
+		expected  bool
+	}{
+		{name: "exists", predicate: state.KeyExistsIn("finance", "status"), expected: true},
+		{name: "missing key", predicate: state.KeyExistsIn("finance", "budget"), expected: false},
+		{name: "missing namespace", predicate: state.KeyExistsIn("legal", "status"), expected: false},
+		{name: "equals", predicate: state.KeyEqualsIn("finance", "status", "approved"), expected: true},
+		{name: "not equals", predicate: state.KeyEqualsIn("finance", "status", "rejected"), expected: false},
+		{name: "top-level not namespaced", predicate: state.KeyEquals("status", "approved"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.predicate(s); result != tt.expected {
+				t.Errorf("predicate = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package state_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_Namespaced(t *testing.T) {
+	s := state.New(nil).Set("result", "top-level")
+
+	ocr := s.Namespaced("ocr").Set("result", "text").Set("pages", 3)
+	review := ocr.State().Namespaced("review").Set("result", "approved")
+
+	final := review.State()
+	if v, _ := final.Get("result"); v != "top-level" {
+		t.Errorf("result = %v, want top-level", v)
+	}
+	if v, _ := final.Get("ocr/result"); v != "text" {
+		t.Errorf("ocr/result = %v, want text", v)
+	}
+	if v, _ := final.Namespaced("review").Get("result"); v != "approved" {
+		t.Errorf("review result = %v, want approved", v)
+	}
+
+	if !final.Namespaced("ocr").Has("pages") || final.Namespaced("review").Has("pages") {
+		t.Error("Has() does not respect the namespace")
+	}
+
+	if keys := final.Namespaced("ocr").Keys(); !reflect.DeepEqual(keys, []string{"pages", "result"}) {
+		t.Errorf("Keys() = %v, want [pages result]", keys)
+	}
+
+	if s.Has("ocr/result") {
+		t.Error("Set() modified the original State")
+	}
+}
+
+func TestState_Namespaced_Delete(t *testing.T) {
+	observer := &captureObserver{}
+	s := state.New(observer).Set("ocr/result", "text").Set("result", "keep")
+
+	ns := s.Namespaced("ocr").Delete("result")
+
+	if ns.Has("result") {
+		t.Error("Delete() left the namespaced key")
+	}
+	if !ns.State().Has("result") {
+		t.Error("Delete() removed a key outside the namespace")
+	}
+	if !s.Has("ocr/result") {
+		t.Error("Delete() modified the original State")
+	}
+
+	last := observer.events[len(observer.events)-1]
+	if last.Type != observability.EventStateDelete || last.Data["key"] != "ocr/result" {
+		t.Errorf("last event = %s %v, want EventStateDelete for ocr/result", last.Type, last.Data)
+	}
+}
+
+func TestState_Namespaced_Nested(t *testing.T) {
+	ns := state.New(nil).Namespaced("pipeline").Namespaced("ocr")
+	if ns.Namespace() != "pipeline/ocr" {
+		t.Errorf("Namespace() = %q, want pipeline/ocr", ns.Namespace())
+	}
+
+	s := ns.Set("result", "text").State()
+	if !s.Has("pipeline/ocr/result") {
+		t.Errorf("keys = %v, want pipeline/ocr/result", s.Keys())
+	}
+	if keys := s.Namespaced("pipeline").Keys(); !reflect.DeepEqual(keys, []string{"ocr/result"}) {
+		t.Errorf("outer Keys() = %v, want [ocr/result]", keys)
+	}
+}
+
+func TestState_Delete_Frozen(t *testing.T) {
+	s := state.New(nil).Set("id", "42").Freeze("id")
+
+	if !s.Delete("id").Has("id") {
+		t.Error("Delete() removed a frozen key")
+	}
+	if s.Delete("missing").Len() != 1 {
+		t.Error("Delete() of a missing key changed the State")
+	}
+}
@@ -133,3 +133,20 @@ func TestFunctionNode_StateImmutability(t *testing.T) {
 		t.Error("Execute() result should contain modifications")
 	}
 }
+
+func TestFuncNode_Execute(t *testing.T) {
+	increment := func(ctx context.Context, s state.State) (state.State, error) {
+		count, _ := state.GetAs[int](s, "count")
+		return s.Set("count", count+1), nil
+	}
+
+	var node state.StateNode = state.FuncNode(increment)
+
+	result, err := node.Execute(context.Background(), state.New(nil).Set("count", 1))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if count, _ := result.Get("count"); count != 2 {
+		t.Errorf("count = %v, want 2", count)
+	}
+}
@@ -0,0 +1,136 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func newPathState() state.State {
+	return state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"analysis": map[string]any{
+			"scores": map[string]any{
+				"risk": 0.8,
+			},
+			"summary": "ok",
+		},
+		"golang.org": map[string]any{"x": "time"},
+		`back\slash`: 1,
+		"list":       []any{"a"},
+	})
+}
+
+func TestState_GetPath(t *testing.T) {
+	s := newPathState()
+
+	tests := []struct {
+		name   string
+		path   string
+		want   any
+		exists bool
+	}{
+		{"top-level", "analysis.summary", "ok", true},
+		{"nested", "analysis.scores.risk", 0.8, true},
+		{"missing leaf", "analysis.scores.cost", nil, false},
+		{"missing intermediate", "analysis.missing.risk", nil, false},
+		{"non-map intermediate", "analysis.summary.length", nil, false},
+		{"slice intermediate", "list.0", nil, false},
+		{"escaped dot", `golang\.org.x`, "time", true},
+		{"unescaped dot", "golang.org.x", nil, false},
+		{"escaped backslash", `back\\slash`, 1, true},
+		{"lone backslash kept", `back\slash`, 1, true},
+		{"empty path", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exists := s.GetPath(tt.path)
+			if exists != tt.exists {
+				t.Fatalf("GetPath(%q) exists = %v, want %v", tt.path, exists, tt.exists)
+			}
+			if got != tt.want {
+				t.Errorf("GetPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_SetPath(t *testing.T) {
+	original := newPathState()
+
+	updated := original.SetPath("analysis.scores.risk", 0.2)
+
+	if risk, _ := updated.GetPath("analysis.scores.risk"); risk != 0.2 {
+		t.Errorf("updated risk = %v, want 0.2", risk)
+	}
+	if summary, _ := updated.GetPath("analysis.summary"); summary != "ok" {
+		t.Errorf("sibling summary = %v, want ok", summary)
+	}
+	if risk, _ := original.GetPath("analysis.scores.risk"); risk != 0.8 {
+		t.Errorf("original risk = %v, want 0.8 (nested maps must be copied)", risk)
+	}
+}
+
+func TestState_SetPath_CreatesIntermediates(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).SetPath("a.b.c", "deep")
+
+	if value, ok := s.GetPath("a.b.c"); !ok || value != "deep" {
+		t.Errorf("GetPath(a.b.c) = %v, %v, want deep, true", value, ok)
+	}
+
+	a, ok := state.GetAs[map[string]any](s, "a")
+	if !ok {
+		t.Fatalf("a is %T, want map[string]any", s.Data["a"])
+	}
+	if _, ok := a["b"].(map[string]any); !ok {
+		t.Errorf("a.b is %T, want map[string]any", a["b"])
+	}
+}
+
+func TestState_SetPath_ReplacesNonMap(t *testing.T) {
+	s := newPathState().SetPath("analysis.summary.length", 2)
+
+	if value, ok := s.GetPath("analysis.summary.length"); !ok || value != 2 {
+		t.Errorf("GetPath = %v, %v, want 2, true", value, ok)
+	}
+}
+
+func TestState_SetPath_Escaped(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).SetPath(`config.api\.example\.com`, "token")
+
+	config, ok := state.GetAs[map[string]any](s, "config")
+	if !ok {
+		t.Fatal("config map not created")
+	}
+	if config["api.example.com"] != "token" {
+		t.Errorf("config = %v, want key api.example.com", config)
+	}
+}
+
+func TestState_SetPath_EmptyPath(t *testing.T) {
+	s := newPathState()
+	updated := s.SetPath("", "ignored")
+
+	if !updated.Equal(s) {
+		t.Errorf("SetPath(\"\") changed state:\n%s", s.EqualDiff(updated))
+	}
+}
+
+func TestState_SetPath_EmitsEvent(t *testing.T) {
+	observer := &captureObserver{}
+	s := state.New(observer)
+	observer.events = nil
+
+	s.SetPath("a.b", 1)
+
+	found := false
+	for _, event := range observer.events {
+		if event.Type == observability.EventStateSet && event.Data["path"] == "a.b" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("EventStateSet with path not emitted")
+	}
+}
@@ -0,0 +1,150 @@
+package state_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func newProvenanceGraph(t *testing.T, track bool) state.StateGraph {
+	t.Helper()
+
+	cfg := config.DefaultGraphConfig("test")
+	cfg.TrackProvenance = track
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	graph.AddNode("classify", newTestNode("classification", "invoice"))
+	graph.AddNodeFunc("review", func(ctx context.Context, s state.State) (state.State, error) {
+		branch := state.New(nil).Set("classification", "receipt").Set("reviewer", "alice")
+		return s.Set("classification", "invoice").Merge(branch), nil
+	})
+	graph.AddNode("approve", newTestNode("approved", true))
+	graph.AddEdge("classify", "review", nil)
+	graph.AddEdge("review", "approve", nil)
+	graph.SetEntryPoint("classify")
+	graph.SetExitPoint("approve")
+
+	return graph
+}
+
+func TestGraph_TrackProvenance(t *testing.T) {
+	graph := newProvenanceGraph(t, true)
+
+	result, err := graph.Execute(context.Background(), state.New(nil).Set("input", "doc"))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	tests := []struct {
+		key       string
+		node      string
+		iteration int
+	}{
+		{"classification", "review", 2},
+		{"reviewer", "review", 2},
+		{"approved", "approve", 3},
+	}
+
+	for _, tt := range tests {
+		p, ok := result.Provenance(tt.key)
+		if !ok {
+			t.Errorf("Provenance(%q) missing", tt.key)
+			continue
+		}
+		if p.Node != tt.node || p.Iteration != tt.iteration {
+			t.Errorf("Provenance(%q) = %s@%d, want %s@%d", tt.key, p.Node, p.Iteration, tt.node, tt.iteration)
+		}
+		if p.Timestamp.IsZero() {
+			t.Errorf("Provenance(%q) has zero timestamp", tt.key)
+		}
+	}
+
+	if _, ok := result.Provenance("input"); ok {
+		t.Error("unchanged initial key should have no provenance")
+	}
+}
+
+func TestGraph_TrackProvenance_DisabledByDefault(t *testing.T) {
+	graph := newProvenanceGraph(t, false)
+
+	result, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if _, ok := result.Provenance("classification"); ok {
+		t.Error("provenance should not be recorded when TrackProvenance is disabled")
+	}
+}
+
+func TestGraph_TrackProvenance_RemovedKeys(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.TrackProvenance = true
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("NewGraph failed: %v", err)
+	}
+
+	graph.AddNode("draft", newTestNode("draft", "v1"))
+	graph.AddNodeFunc("publish", func(ctx context.Context, s state.State) (state.State, error) {
+		return state.NewFromMap(nil, map[string]any{"published": true}), nil
+	})
+	graph.AddEdge("draft", "publish", nil)
+	graph.SetEntryPoint("draft")
+	graph.SetExitPoint("publish")
+
+	result, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if _, ok := result.Provenance("draft"); ok {
+		t.Error("removed key should have no provenance")
+	}
+	if p, ok := result.Provenance("published"); !ok || p.Node != "publish" {
+		t.Errorf("Provenance(published) = %+v, %v, want publish", p, ok)
+	}
+}
+
+func TestProvenance_SurvivesSerialization(t *testing.T) {
+	graph := newProvenanceGraph(t, true)
+
+	result, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	want, _ := result.Provenance("classification")
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var fromJSON state.State
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	encoded, err := state.GobCodec{}.Encode(result)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	fromGob, err := state.GobCodec{}.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	for name, decoded := range map[string]state.State{"json": fromJSON, "gob": fromGob} {
+		got, ok := decoded.Provenance("classification")
+		if !ok || got.Node != want.Node || got.Iteration != want.Iteration || !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("%s: Provenance(classification) = %+v, %v, want %+v", name, got, ok, want)
+		}
+	}
+}
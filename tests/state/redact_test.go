@@ -0,0 +1,187 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func newSensitiveGraph(t *testing.T, cfg config.GraphConfig, observer observability.Observer, store state.CheckpointStore) state.StateGraph {
+	t.Helper()
+
+	graph, err := state.NewGraphWithDeps(cfg, observer, store)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps failed: %v", err)
+	}
+
+	graph.AddNode("fetch", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("api_key", "sk-secret").SetIn("customer", "email", "alice@example.com"), nil
+	}))
+	graph.AddNode("done", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s.Set("status", "complete"), nil
+	}))
+	graph.AddEdge("fetch", "done", nil)
+	graph.SetEntryPoint("fetch")
+	graph.SetExitPoint("done")
+	graph.MarkSensitive("api_key", "customer.email")
+
+	return graph
+}
+
+func TestGraph_MarkSensitive_EventSnapshots(t *testing.T) {
+	observer := &captureObserver{}
+	graph := newSensitiveGraph(t, config.DefaultGraphConfig("test"), observer, nil)
+
+	result, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if key, _ := result.GetString("api_key"); key != "sk-secret" {
+		t.Errorf("result api_key = %q, execution state must not be redacted", key)
+	}
+
+	checked := 0
+	for _, event := range observer.events {
+		var snapshot map[string]any
+		switch event.Type {
+		case observability.EventNodeStart:
+			snapshot, _ = event.Data["input_snapshot"].(map[string]any)
+		case observability.EventNodeComplete:
+			snapshot, _ = event.Data["output_snapshot"].(map[string]any)
+		default:
+			continue
+		}
+
+		if value, exists := snapshot["api_key"]; exists {
+			checked++
+			if value != state.RedactedValue {
+				t.Errorf("%s snapshot api_key = %v, want redacted", event.Type, value)
+			}
+		}
+
+		if customer, ok := snapshot["ns:customer"].(map[string]any); ok {
+			if customer["email"] != state.RedactedValue {
+				t.Errorf("%s snapshot customer.email = %v, want redacted", event.Type, customer["email"])
+			}
+		}
+	}
+
+	if checked == 0 {
+		t.Fatal("no snapshot contained api_key")
+	}
+}
+
+func TestGraph_MarkSensitive_Checkpoint(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	store := state.NewMemoryCheckpointStore()
+	graph := newSensitiveGraph(t, cfg, observability.NoOpObserver{}, store)
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	saved, err := store.Load(initial.RunID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if key, _ := saved.GetString("api_key"); key != state.RedactedValue {
+		t.Errorf("checkpoint api_key = %q, want redacted", key)
+	}
+	if email, _ := saved.GetIn("customer", "email"); email != state.RedactedValue {
+		t.Errorf("checkpoint customer.email = %v, want redacted", email)
+	}
+	if status, _ := saved.GetString("status"); status != "complete" {
+		t.Errorf("checkpoint status = %q, want complete", status)
+	}
+}
+
+func TestGraph_Resume_RedactedCheckpoint(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Checkpoint.Interval = 1
+	cfg.Checkpoint.Preserve = true
+
+	store := state.NewMemoryCheckpointStore()
+	graph := newSensitiveGraph(t, cfg, observability.NoOpObserver{}, store)
+
+	initial := state.New(nil)
+	if _, err := graph.Execute(context.Background(), initial); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	saved, _ := store.Load(initial.RunID)
+	store.Save(saved.SetCheckpointNode("fetch"))
+
+	_, err := graph.Resume(context.Background(), initial.RunID)
+	if !errors.Is(err, state.ErrRedactedCheckpoint) {
+		t.Fatalf("Resume error = %v, want ErrRedactedCheckpoint", err)
+	}
+
+	want := "checkpoint contains redacted values: api_key, customer.email"
+	if err.Error() != want {
+		t.Errorf("Resume error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestGraph_MarkSensitive_History(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.TrackHistory = true
+
+	graph := newSensitiveGraph(t, cfg, observability.NoOpObserver{}, nil)
+
+	result, err := graph.ExecuteWithResult(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("ExecuteWithResult failed: %v", err)
+	}
+
+	for _, entry := range result.History {
+		if key, exists := entry.State.GetString("api_key"); exists && key != state.RedactedValue {
+			t.Errorf("history entry %q api_key = %q, want redacted", entry.Node, key)
+		}
+	}
+
+	if key, _ := result.State.GetString("api_key"); key != "sk-secret" {
+		t.Errorf("result api_key = %q, want sk-secret", key)
+	}
+}
+
+func TestGraph_SensitiveKeys_Config(t *testing.T) {
+	cfg := config.DefaultGraphConfig("test")
+	cfg.SensitiveKeys = []string{"customer"}
+
+	observer := &captureObserver{}
+	graph, err := state.NewGraphWithDeps(cfg, observer, nil)
+	if err != nil {
+		t.Fatalf("NewGraphWithDeps failed: %v", err)
+	}
+
+	graph.AddNode("fetch", state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+		return s.SetIn("customer", "email", "alice@example.com"), nil
+	}))
+	graph.SetEntryPoint("fetch")
+	graph.SetExitPoint("fetch")
+
+	if _, err := graph.Execute(context.Background(), state.New(nil)); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	for _, event := range observer.events {
+		if event.Type != observability.EventNodeComplete {
+			continue
+		}
+
+		snapshot := event.Data["output_snapshot"].(map[string]any)
+		if snapshot["ns:customer"] != state.RedactedValue {
+			t.Errorf("output_snapshot namespace = %v, want redacted", snapshot["ns:customer"])
+		}
+	}
+}
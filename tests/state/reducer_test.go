@@ -0,0 +1,188 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
+)
+
+func TestReducers(t *testing.T) {
+	tests := []struct {
+		name        string
+		reducer     state.Reducer
+		current     any
+		update      any
+		expected    any
+		expectError bool
+	}{
+		{name: "overwrite replaces value", reducer: state.OverwriteReducer, current: "old", update: "new", expected: "new"},
+		{name: "overwrite ignores type", reducer: state.OverwriteReducer, current: 1, update: "new", expected: "new"},
+
+		{name: "append slice", reducer: state.AppendReducer, current: []string{"a"}, update: []string{"b", "c"}, expected: []string{"a", "b", "c"}},
+		{name: "append element", reducer: state.AppendReducer, current: []string{"a"}, update: "b", expected: []string{"a", "b"}},
+		{name: "append to any slice", reducer: state.AppendReducer, current: []any{1}, update: "b", expected: []any{1, "b"}},
+		{name: "append nil current", reducer: state.AppendReducer, current: nil, update: []string{"a"}, expected: []string{"a"}},
+		{name: "append to non-slice", reducer: state.AppendReducer, current: "a", update: "b", expectError: true},
+		{name: "append mismatched element", reducer: state.AppendReducer, current: []string{"a"}, update: 1, expectError: true},
+		{name: "append mismatched slice", reducer: state.AppendReducer, current: []string{"a"}, update: []int{1}, expectError: true},
+
+		{name: "sum ints", reducer: state.SumReducer, current: 2, update: 3, expected: 5},
+		{name: "sum floats", reducer: state.SumReducer, current: 1.5, update: 2.0, expected: 3.5},
+		{name: "sum int and float", reducer: state.SumReducer, current: 1, update: 0.5, expected: 1.5},
+		{name: "sum nil current", reducer: state.SumReducer, current: nil, update: 4, expected: 4},
+		{name: "sum string", reducer: state.SumReducer, current: 1, update: "2", expectError: true},
+		{name: "sum non-numeric current", reducer: state.SumReducer, current: "1", update: 2, expectError: true},
+
+		{
+			name:     "merge maps",
+			reducer:  state.MergeMapReducer,
+			current:  map[string]any{"a": 1, "b": 2},
+			update:   map[string]any{"b": 3, "c": 4},
+			expected: map[string]any{"a": 1, "b": 3, "c": 4},
+		},
+		{name: "merge nil current", reducer: state.MergeMapReducer, current: nil, update: map[string]any{"a": 1}, expected: map[string]any{"a": 1}},
+		{name: "merge non-map current", reducer: state.MergeMapReducer, current: []string{}, update: map[string]any{}, expectError: true},
+		{name: "merge non-map update", reducer: state.MergeMapReducer, current: map[string]any{}, update: "x", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.reducer(tt.current, tt.update)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got result %v", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v (%T), got %v (%T)", tt.expected, tt.expected, result, result)
+			}
+		})
+	}
+}
+
+func TestMergeMapReducer_DoesNotModifyInputs(t *testing.T) {
+	current := map[string]any{"a": 1}
+	update := map[string]any{"b": 2}
+
+	if _, err := state.MergeMapReducer(current, update); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(current) != 1 || len(update) != 1 {
+		t.Errorf("inputs modified: current=%v update=%v", current, update)
+	}
+}
+
+func TestStateGraph_SetReducer_Validation(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	if err := graph.SetReducer("", state.AppendReducer); err == nil {
+		t.Error("expected error for empty key")
+	}
+
+	if err := graph.SetReducer("messages", nil); err == nil {
+		t.Error("expected error for nil reducer")
+	}
+
+	if err := graph.SetReducer("messages", state.AppendReducer); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStateGraph_Execute_Reducers(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.SetReducer("messages", state.AppendReducer)
+	graph.SetReducer("tokens", state.SumReducer)
+	graph.SetReducer("status", state.OverwriteReducer)
+
+	step := func(message string, tokens int, status string) state.StateNode {
+		return state.NewFunctionNode(func(ctx context.Context, s state.State) (state.State, error) {
+			return s.SetMany(map[string]any{
+				"messages": []string{message},
+				"tokens":   tokens,
+				"status":   status,
+			}), nil
+		})
+	}
+
+	graph.AddNode("first", step("hello", 10, "started"))
+	graph.AddNode("second", step("world", 5, "running"))
+	graph.AddNode("passthrough", sttest.EchoNode())
+	graph.AddEdge("first", "second", nil)
+	graph.AddEdge("second", "passthrough", nil)
+	graph.SetEntryPoint("first")
+	graph.SetExitPoint("passthrough")
+
+	initial := state.New(observability.NoOpObserver{}).Set("messages", []string{"system"})
+
+	final, err := graph.Execute(context.Background(), initial)
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+
+	messages, _ := final.Get("messages")
+	if !reflect.DeepEqual(messages, []string{"system", "hello", "world"}) {
+		t.Errorf("expected appended messages, got %v", messages)
+	}
+
+	if tokens, _ := final.Get("tokens"); tokens != 15 {
+		t.Errorf("expected tokens 15, got %v", tokens)
+	}
+
+	if status, _ := final.Get("status"); status != "running" {
+		t.Errorf("expected status running, got %v", status)
+	}
+}
+
+func TestStateGraph_Execute_ReducerTypeMismatch(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("test"))
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.SetReducer("count", state.SumReducer)
+	graph.AddNode("bad", newTestNode("count", "not a number"))
+	graph.SetEntryPoint("bad")
+	graph.SetExitPoint("bad")
+
+	initial := state.New(observability.NoOpObserver{}).Set("count", 1)
+
+	_, err = graph.Execute(context.Background(), initial)
+	if err == nil {
+		t.Fatal("expected reducer error")
+	}
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecutionError, got %T", err)
+	}
+
+	if execErr.NodeName != "bad" {
+		t.Errorf("expected node bad, got %s", execErr.NodeName)
+	}
+
+	if !strings.Contains(err.Error(), `reducer for key "count"`) {
+		t.Errorf("expected reducer key in error, got %v", err)
+	}
+}
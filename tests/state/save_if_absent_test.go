@@ -0,0 +1,194 @@
+package state_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func saveIfAbsentStores(t *testing.T) map[string]func() state.CheckpointStore {
+	t.Helper()
+
+	file := func() state.CheckpointStore {
+		store, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+		if err != nil {
+			t.Fatalf("NewFileCheckpointStore() error = %v", err)
+		}
+		return store
+	}
+
+	return map[string]func() state.CheckpointStore{
+		"memory": state.NewMemoryCheckpointStore,
+		"file":   file,
+		"encrypted": func() state.CheckpointStore {
+			store, err := state.NewEncryptedStore(file(), testKey(1))
+			if err != nil {
+				t.Fatalf("NewEncryptedStore() error = %v", err)
+			}
+			return store
+		},
+	}
+}
+
+func TestCheckpointStore_SaveIfAbsent(t *testing.T) {
+	for name, newStore := range saveIfAbsentStores(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+
+			first := state.New(nil).Set("attempt", "first").SetCheckpointNode("start")
+			saved, err := store.SaveIfAbsent(first)
+			if err != nil || !saved {
+				t.Fatalf("SaveIfAbsent() = %v, %v; want true", saved, err)
+			}
+
+			saved, err = store.SaveIfAbsent(first.Set("attempt", "second"))
+			if err != nil || saved {
+				t.Fatalf("second SaveIfAbsent() = %v, %v; want false", saved, err)
+			}
+
+			loaded, err := store.Load(first.RunID)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if v, _ := loaded.Get("attempt"); v != "first" {
+				t.Errorf("attempt = %v, want first (not overwritten)", v)
+			}
+
+			if err := store.Save(first.Set("attempt", "saved")); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if v, _ := mustLoad(t, store, first.RunID).Get("attempt"); v != "saved" {
+				t.Errorf("attempt after Save = %v, want saved", v)
+			}
+		})
+	}
+}
+
+func TestCheckpointStore_SaveIfAbsent_Concurrent(t *testing.T) {
+	for name, newStore := range saveIfAbsentStores(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			initial := state.New(nil).SetCheckpointNode("start")
+
+			var wins atomic.Int32
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Go(func() {
+					saved, err := store.SaveIfAbsent(initial)
+					if err != nil {
+						t.Errorf("SaveIfAbsent() error = %v", err)
+					}
+					if saved {
+						wins.Add(1)
+					}
+				})
+			}
+			wg.Wait()
+
+			if n := wins.Load(); n != 1 {
+				t.Errorf("%d concurrent SaveIfAbsent calls saved, want exactly 1", n)
+			}
+		})
+	}
+}
+
+func TestFileCheckpointStore_SaveIfAbsent_Versions(t *testing.T) {
+	store, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	initial := state.New(nil).SetCheckpointNode("start")
+	if _, err := store.SaveIfAbsent(initial); err != nil {
+		t.Fatalf("SaveIfAbsent() error = %v", err)
+	}
+	if err := store.Save(initial.SetCheckpointNode("next")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	versions, err := store.(state.VersionedCheckpointStore).Versions(initial.RunID)
+	if err != nil || len(versions) != 2 || versions[0].Node != "start" {
+		t.Errorf("Versions() = %+v, %v; want start then next", versions, err)
+	}
+}
+
+func TestFileCheckpointStore_SaveIfAbsent_NoPartialReads(t *testing.T) {
+	dir := t.TempDir()
+
+	// Separate stores share no lock, like processes sharing the directory.
+	writer, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	reader, err := state.NewFileCheckpointStore(dir, nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	payload := strings.Repeat("x", 1<<20)
+	runs := make([]state.State, 20)
+	for i := range runs {
+		runs[i] = state.New(nil).Set("payload", payload).SetCheckpointNode("start" + strconv.Itoa(i))
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		defer close(done)
+		for _, run := range runs {
+			if saved, err := writer.SaveIfAbsent(run); err != nil || !saved {
+				t.Errorf("SaveIfAbsent() = %v, %v; want true", saved, err)
+			}
+		}
+	})
+	wg.Go(func() {
+		for {
+			for _, run := range runs {
+				loaded, err := reader.Load(run.RunID)
+				var corrupt *state.CorruptCheckpointError
+				if errors.As(err, &corrupt) {
+					t.Errorf("Load() observed a partial file: %v", err)
+					return
+				}
+				if err == nil {
+					if v, _ := loaded.Get("payload"); v != payload {
+						t.Errorf("Load() returned a truncated payload of %d bytes", len(v.(string)))
+						return
+					}
+				}
+			}
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	})
+	wg.Wait()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func mustLoad(t *testing.T, store state.CheckpointStore, runID string) state.State {
+	t.Helper()
+
+	s, err := store.Load(runID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return s
+}
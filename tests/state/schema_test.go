@@ -0,0 +1,164 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestSchema_Validate_Kinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  state.Kind
+		value any
+		valid bool
+	}{
+		{name: "string", kind: state.KindString, value: "text", valid: true},
+		{name: "string mismatch", kind: state.KindString, value: 1, valid: false},
+		{name: "int", kind: state.KindInt, value: 42, valid: true},
+		{name: "int64", kind: state.KindInt, value: int64(42), valid: true},
+		{name: "uint", kind: state.KindInt, value: uint(42), valid: true},
+		{name: "float for int", kind: state.KindInt, value: 42.0, valid: false},
+		{name: "bool", kind: state.KindBool, value: true, valid: true},
+		{name: "bool mismatch", kind: state.KindBool, value: "true", valid: false},
+		{name: "float64", kind: state.KindFloat, value: 1.5, valid: true},
+		{name: "float32", kind: state.KindFloat, value: float32(1.5), valid: true},
+		{name: "int for float", kind: state.KindFloat, value: 1, valid: false},
+		{name: "slice", kind: state.KindSlice, value: []string{"a"}, valid: true},
+		{name: "array", kind: state.KindSlice, value: [2]int{1, 2}, valid: true},
+		{name: "map", kind: state.KindMap, value: map[string]any{}, valid: true},
+		{name: "map mismatch", kind: state.KindMap, value: []any{}, valid: false},
+		{name: "any", kind: state.KindAny, value: struct{}{}, valid: true},
+		{name: "any nil", kind: state.KindAny, value: nil, valid: true},
+		{name: "nil for string", kind: state.KindString, value: nil, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := state.NewSchema().Require("key", tt.kind)
+			s := state.New(observability.NoOpObserver{}).Set("key", tt.value)
+
+			err := schema.Validate(s)
+			if tt.valid && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
+
+func TestSchema_Validate_AllViolations(t *testing.T) {
+	schema := state.NewSchema().
+		Require("id", state.KindString).
+		Require("count", state.KindInt).
+		Optional("tags", state.KindSlice).
+		Optional("notes", state.KindString)
+
+	s := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"count":      1.5,
+		"tags":       "not-a-slice",
+		"undeclared": true,
+	})
+
+	err := schema.Validate(s)
+
+	var schemaErr *state.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Validate() error = %v, want SchemaError", err)
+	}
+
+	if !slices.Equal(schemaErr.Keys(), []string{"count", "id", "tags"}) {
+		t.Errorf("Keys() = %v, want [count id tags]", schemaErr.Keys())
+	}
+
+	for _, v := range schemaErr.Violations {
+		if v.Key == "id" && !v.Missing {
+			t.Error("id violation should be reported as missing")
+		}
+		if v.Key == "count" && v.Actual != "float64" {
+			t.Errorf("count Actual = %s, want float64", v.Actual)
+		}
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"count: expected int, got float64", "id: required string is missing"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q missing %q", msg, want)
+		}
+	}
+}
+
+func TestSchema_Validate_Valid(t *testing.T) {
+	schema := state.NewSchema().
+		Require("id", state.KindString).
+		Optional("tags", state.KindSlice)
+
+	s := state.New(observability.NoOpObserver{}).Set("id", "doc-1")
+
+	if err := schema.Validate(s); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestGetSchema_Unknown(t *testing.T) {
+	if _, err := state.GetSchema("does-not-exist"); err == nil {
+		t.Error("expected error for unknown schema")
+	}
+
+	cfg := config.DefaultGraphConfig("test")
+	cfg.Observer = "noop"
+	cfg.Schema = "does-not-exist"
+	if _, err := state.NewGraph(cfg); err == nil {
+		t.Error("NewGraph() expected error for unknown schema")
+	}
+}
+
+func TestStateGraph_Execute_Schema(t *testing.T) {
+	state.RegisterSchema("schema-test", state.NewSchema().
+		Require("count", state.KindInt).
+		Optional("label", state.KindString))
+
+	cfg := config.DefaultGraphConfig("schema-test")
+	cfg.Observer = "noop"
+	cfg.Schema = "schema-test"
+
+	graph, err := state.NewGraph(cfg)
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	graph.AddNode("good", newTestNode("label", "ok"))
+	graph.AddNode("drift", newTestNode("count", 2.5))
+	graph.AddEdge("good", "drift", nil)
+	graph.SetEntryPoint("good")
+	graph.SetExitPoint("drift")
+
+	initial := state.New(observability.NoOpObserver{}).Set("count", 1)
+	_, err = graph.Execute(context.Background(), initial)
+
+	var schemaErr *state.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Execute() error = %v, want SchemaError", err)
+	}
+
+	if schemaErr.Node != "drift" {
+		t.Errorf("SchemaError.Node = %s, want drift", schemaErr.Node)
+	}
+
+	if !slices.Equal(schemaErr.Keys(), []string{"count"}) {
+		t.Errorf("SchemaError.Keys() = %v, want [count]", schemaErr.Keys())
+	}
+
+	var execErr *state.ExecutionError
+	if !errors.As(err, &execErr) || execErr.NodeName != "drift" {
+		t.Errorf("expected ExecutionError at drift, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package state_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_Size_Empty(t *testing.T) {
+	s := state.New(observability.NoOpObserver{})
+
+	if size := s.Size(); size != 0 {
+		t.Errorf("Size() = %d, want 0", size)
+	}
+}
+
+func TestState_Size_Values(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		// `"k"` is 3 bytes for every case.
+		{"string", "hello", 3 + 7},
+		{"escaped string", "a\"b", 3 + 6},
+		{"int", 12345, 3 + 5},
+		{"bool", true, 3 + 4},
+		{"nil", nil, 3 + 4},
+		{"byte slice", []byte{1, 2, 3}, 3 + 6},
+		{"string slice", []string{"a", "b"}, 3 + 9},
+		{"nested map", map[string]any{"inner": map[string]any{"n": 1}}, 3 + 17},
+		{"channel", make(chan int), 3},
+		{"function", func() {}, 3},
+		{"map with function", map[string]any{"fn": func() {}}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := state.New(observability.NoOpObserver{}).Set("k", tt.value)
+
+			if size := s.Size(); size != tt.expected {
+				t.Errorf("Size() = %d, want %d", size, tt.expected)
+			}
+		})
+	}
+}
+
+func TestState_Size_SumsKeys(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).
+		Set("a", "x").
+		Set("bb", 1)
+
+	// `"a"` + `"x"` = 6, `"bb"` + `1` = 5
+	if size := s.Size(); size != 11 {
+		t.Errorf("Size() = %d, want 11", size)
+	}
+}
+
+func TestState_Size_Memoized(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).Set("k", "v")
+
+	first := s.Size()
+	if second := s.Size(); second != first {
+		t.Errorf("Size() = %d on second call, want %d", second, first)
+	}
+
+	updated := s.Set("k", "longer value")
+	if updated.Size() <= first {
+		t.Errorf("updated Size() = %d, want > %d", updated.Size(), first)
+	}
+
+	if s.Size() != first {
+		t.Errorf("original Size() = %d after update, want %d", s.Size(), first)
+	}
+}
+
+func TestState_LargestKeys(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).SetMany(map[string]any{
+		"small":  1,
+		"large":  "a much longer string value",
+		"medium": "medium",
+		"tied":   2,
+	})
+
+	got := s.LargestKeys(2)
+	want := []state.KeySize{
+		{Key: "large", Bytes: 7 + 28},
+		{Key: "medium", Bytes: 8 + 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LargestKeys(2) = %v, want %v", got, want)
+	}
+
+	all := s.LargestKeys(10)
+	if len(all) != 4 {
+		t.Fatalf("LargestKeys(10) returned %d keys, want 4", len(all))
+	}
+
+	// "small" and "tied" are the same size and ordered alphabetically.
+	if all[2].Key != "small" || all[3].Key != "tied" {
+		t.Errorf("tie order = [%s %s], want [small tied]", all[2].Key, all[3].Key)
+	}
+}
+
+func TestState_LargestKeys_NonPositive(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).Set("k", "v")
+
+	if got := s.LargestKeys(0); len(got) != 0 {
+		t.Errorf("LargestKeys(0) = %v, want empty", got)
+	}
+	if got := s.LargestKeys(-1); len(got) != 0 {
+		t.Errorf("LargestKeys(-1) = %v, want empty", got)
+	}
+}
+
+func TestState_LargestKeys_ReturnsCopy(t *testing.T) {
+	s := state.New(observability.NoOpObserver{}).Set("k", "v")
+
+	got := s.LargestKeys(1)
+	got[0].Bytes = 0
+
+	if again := s.LargestKeys(1); again[0].Bytes == 0 {
+		t.Error("modifying LargestKeys result affected memoized sizes")
+	}
+}
@@ -0,0 +1,68 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+)
+
+func TestState_Snapshot(t *testing.T) {
+	s := state.New(nil).Set("draft", "v1").Freeze("draft").Set("score", 7).SetCheckpointNode("critique")
+
+	before := time.Now()
+	snap := s.Snapshot()
+
+	if snap.RunID != s.RunID || snap.CheckpointNode != "critique" || !snap.Timestamp.Equal(s.Timestamp) {
+		t.Errorf("snapshot metadata = %+v, want State's run metadata", snap)
+	}
+	if snap.CreatedAt.Before(before) {
+		t.Errorf("CreatedAt = %v, want at or after %v", snap.CreatedAt, before)
+	}
+
+	diverged := s.Set("score", 2)
+	if v, _ := diverged.Get("score"); v != 2 {
+		t.Fatalf("score = %v, want 2", v)
+	}
+	if snap.Data["score"] != 7 {
+		t.Errorf("snapshot data changed by later Set: %v", snap.Data["score"])
+	}
+
+	observer := &captureObserver{}
+	restored := state.RestoreSnapshot(snap, observer)
+
+	if v, _ := restored.Get("score"); v != 7 {
+		t.Errorf("restored score = %v, want 7", v)
+	}
+	if restored.RunID != s.RunID || restored.CheckpointNode != "critique" {
+		t.Errorf("restored metadata = %s@%s", restored.RunID, restored.CheckpointNode)
+	}
+	if !restored.IsFrozen("draft") {
+		t.Error("restored State lost frozen key")
+	}
+	if restored.Observer != observer {
+		t.Error("restored State does not use the given observer")
+	}
+	if len(observer.events) != 1 || observer.events[0].Type != observability.EventStateCreate {
+		t.Errorf("events = %v, want one EventStateCreate", observer.events)
+	}
+
+	again := state.RestoreSnapshot(snap, nil)
+	restored.Data["score"] = 100
+	if v, _ := again.Get("score"); v != 7 {
+		t.Errorf("restores share data: score = %v, want 7", v)
+	}
+}
+
+func TestRestoreSnapshot_ZeroValue(t *testing.T) {
+	s := state.RestoreSnapshot(state.StateSnapshot{}, nil)
+
+	if s.Data == nil {
+		t.Error("restored Data is nil")
+	}
+	if _, ok := s.Observer.(observability.NoOpObserver); !ok {
+		t.Errorf("Observer = %T, want NoOpObserver", s.Observer)
+	}
+	s.Set("k", "v")
+}
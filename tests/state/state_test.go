@@ -2,6 +2,11 @@ package state_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
@@ -272,3 +277,611 @@ func TestState_Merge_IsImmutable(t *testing.T) {
 		t.Error("Merge() should create new state with merged values")
 	}
 }
+
+func TestGetAs(t *testing.T) {
+	s := state.New(nil).
+		Set("name", "alice").
+		Set("count", 42).
+		Set("ratio", 0.5).
+		Set("tags", []string{"a", "b"})
+
+	t.Run("matching type", func(t *testing.T) {
+		name, ok := state.GetAs[string](s, "name")
+		if !ok || name != "alice" {
+			t.Errorf("GetAs[string] = %q, %v; want alice, true", name, ok)
+		}
+
+		tags, ok := state.GetAs[[]string](s, "tags")
+		if !ok || len(tags) != 2 {
+			t.Errorf("GetAs[[]string] = %v, %v; want [a b], true", tags, ok)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		val, ok := state.GetAs[string](s, "missing")
+		if ok || val != "" {
+			t.Errorf("GetAs on missing key = %q, %v; want zero, false", val, ok)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		val, ok := state.GetAs[int](s, "name")
+		if ok || val != 0 {
+			t.Errorf("GetAs[int] on string = %d, %v; want 0, false", val, ok)
+		}
+	})
+
+	t.Run("no numeric widening", func(t *testing.T) {
+		tests := []struct {
+			name string
+			ok   bool
+		}{
+			{name: "int as float64", ok: func() bool { _, ok := state.GetAs[float64](s, "count"); return ok }()},
+			{name: "int as int64", ok: func() bool { _, ok := state.GetAs[int64](s, "count"); return ok }()},
+			{name: "float64 as int", ok: func() bool { _, ok := state.GetAs[int](s, "ratio"); return ok }()},
+			{name: "float64 as float32", ok: func() bool { _, ok := state.GetAs[float32](s, "ratio"); return ok }()},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.ok {
+					t.Error("numeric types must not be converted")
+				}
+			})
+		}
+	})
+
+	t.Run("interface type", func(t *testing.T) {
+		val, ok := state.GetAs[any](s, "count")
+		if !ok || val != 42 {
+			t.Errorf("GetAs[any] = %v, %v; want 42, true", val, ok)
+		}
+	})
+}
+
+func TestMustGetAs(t *testing.T) {
+	s := state.New(nil).Set("count", 42)
+
+	if got := state.MustGetAs[int](s, "count"); got != 42 {
+		t.Errorf("MustGetAs[int] = %d, want 42", got)
+	}
+
+	tests := []struct {
+		name     string
+		call     func()
+		contains []string
+	}{
+		{
+			name:     "missing key",
+			call:     func() { state.MustGetAs[string](s, "missing") },
+			contains: []string{`"missing"`, "not found", "string"},
+		},
+		{
+			name:     "type mismatch",
+			call:     func() { state.MustGetAs[float64](s, "count") },
+			contains: []string{`"count"`, "int", "float64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("expected panic")
+				}
+				msg, ok := r.(string)
+				if !ok {
+					t.Fatalf("expected string panic, got %T", r)
+				}
+				for _, substr := range tt.contains {
+					if !strings.Contains(msg, substr) {
+						t.Errorf("panic message %q missing %q", msg, substr)
+					}
+				}
+			}()
+			tt.call()
+		})
+	}
+}
+
+func TestState_CoercingAccessors(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		wantStr   string
+		strOK     bool
+		wantInt   int
+		intOK     bool
+		wantBool  bool
+		boolOK    bool
+		wantFloat float64
+		floatOK   bool
+	}{
+		{name: "string", value: "42", wantStr: "42", strOK: true},
+		{name: "bool", value: true, wantBool: true, boolOK: true},
+		{name: "int", value: 7, wantInt: 7, intOK: true, wantFloat: 7, floatOK: true},
+		{name: "int8", value: int8(-3), wantInt: -3, intOK: true, wantFloat: -3, floatOK: true},
+		{name: "int32", value: int32(12), wantInt: 12, intOK: true, wantFloat: 12, floatOK: true},
+		{name: "int64", value: int64(99), wantInt: 99, intOK: true, wantFloat: 99, floatOK: true},
+		{name: "uint", value: uint(5), wantInt: 5, intOK: true, wantFloat: 5, floatOK: true},
+		{name: "uint64 overflow", value: uint64(math.MaxUint64), wantFloat: math.MaxUint64, floatOK: true},
+		{name: "float32", value: float32(2.5), wantFloat: 2.5, floatOK: true},
+		{name: "float64 integral", value: 3.0, wantInt: 3, intOK: true, wantFloat: 3, floatOK: true},
+		{name: "float64 fractional", value: 3.5, wantFloat: 3.5, floatOK: true},
+		{name: "float64 out of range", value: 1e300, wantFloat: 1e300, floatOK: true},
+		{name: "json.Number integer", value: json.Number("10"), wantStr: "10", strOK: true, wantInt: 10, intOK: true, wantFloat: 10, floatOK: true},
+		{name: "json.Number integral float", value: json.Number("4.0"), wantStr: "4.0", strOK: true, wantInt: 4, intOK: true, wantFloat: 4, floatOK: true},
+		{name: "json.Number fractional", value: json.Number("1.25"), wantStr: "1.25", strOK: true, wantFloat: 1.25, floatOK: true},
+		{name: "slice", value: []int{1}},
+		{name: "nil", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := state.New(nil).Set("key", tt.value)
+
+			if got, ok := s.GetString("key"); got != tt.wantStr || ok != tt.strOK {
+				t.Errorf("GetString = %q, %v; want %q, %v", got, ok, tt.wantStr, tt.strOK)
+			}
+			if got, ok := s.GetInt("key"); got != tt.wantInt || ok != tt.intOK {
+				t.Errorf("GetInt = %d, %v; want %d, %v", got, ok, tt.wantInt, tt.intOK)
+			}
+			if got, ok := s.GetBool("key"); got != tt.wantBool || ok != tt.boolOK {
+				t.Errorf("GetBool = %v, %v; want %v, %v", got, ok, tt.wantBool, tt.boolOK)
+			}
+			if got, ok := s.GetFloat64("key"); got != tt.wantFloat || ok != tt.floatOK {
+				t.Errorf("GetFloat64 = %v, %v; want %v, %v", got, ok, tt.wantFloat, tt.floatOK)
+			}
+		})
+	}
+}
+
+func TestState_CoercingAccessors_FromJSON(t *testing.T) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(`{"count": 3, "ratio": 0.75, "done": true, "name": "x"}`), &data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	s := state.New(nil)
+	for k, v := range data {
+		s = s.Set(k, v)
+	}
+
+	if count, ok := s.GetInt("count"); !ok || count != 3 {
+		t.Errorf("GetInt(count) = %d, %v; want 3, true", count, ok)
+	}
+	if ratio, ok := s.GetFloat64("ratio"); !ok || ratio != 0.75 {
+		t.Errorf("GetFloat64(ratio) = %v, %v; want 0.75, true", ratio, ok)
+	}
+	if done, ok := s.GetBool("done"); !ok || !done {
+		t.Errorf("GetBool(done) = %v, %v; want true, true", done, ok)
+	}
+	if name, ok := s.GetString("name"); !ok || name != "x" {
+		t.Errorf("GetString(name) = %q, %v; want x, true", name, ok)
+	}
+	if _, ok := s.GetInt("missing"); ok {
+		t.Error("GetInt on missing key should return false")
+	}
+}
+
+func TestState_Keys(t *testing.T) {
+	empty := state.New(nil)
+	keys := empty.Keys()
+	if keys == nil || len(keys) != 0 {
+		t.Errorf("Keys() on empty state = %#v, want empty non-nil slice", keys)
+	}
+
+	encoded, err := json.Marshal(keys)
+	if err != nil || string(encoded) != "[]" {
+		t.Errorf("json.Marshal(Keys()) = %s, %v; want []", encoded, err)
+	}
+
+	s := state.New(nil).Set("zeta", 1).Set("alpha", 2).Set("mid", 3)
+	keys = s.Keys()
+
+	expected := []string{"alpha", "mid", "zeta"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Keys() = %v, want %v", keys, expected)
+	}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("Keys()[%d] = %s, want %s", i, keys[i], key)
+		}
+	}
+
+	keys[0] = "modified"
+	if !s.Has("alpha") || s.Has("modified") {
+		t.Error("modifying Keys() result should not affect state")
+	}
+}
+
+func TestState_Len(t *testing.T) {
+	s := state.New(nil)
+	if s.Len() != 0 {
+		t.Errorf("Len() on empty state = %d, want 0", s.Len())
+	}
+
+	s2 := s.Set("a", 1).Set("b", 2).Set("a", 3)
+	if s2.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s2.Len())
+	}
+	if s.Len() != 0 {
+		t.Error("original state should be unchanged")
+	}
+}
+
+func TestState_Has(t *testing.T) {
+	s := state.New(nil).Set("present", "value").Set("nil-value", nil)
+
+	if !s.Has("present") {
+		t.Error("Has(present) = false, want true")
+	}
+	if !s.Has("nil-value") {
+		t.Error("Has(nil-value) = false, want true for key set to nil")
+	}
+	if s.Has("missing") {
+		t.Error("Has(missing) = true, want false")
+	}
+}
+
+func TestState_ToMap(t *testing.T) {
+	s := state.New(nil).Set("user", "alice").Set("count", 3)
+
+	data := s.ToMap()
+	if len(data) != 2 || data["user"] != "alice" || data["count"] != 3 {
+		t.Errorf("ToMap() = %v, want user=alice count=3", data)
+	}
+
+	data["user"] = "mallory"
+	data["extra"] = true
+
+	if val, _ := s.Get("user"); val != "alice" {
+		t.Errorf("modifying ToMap() result changed state: user = %v", val)
+	}
+	if s.Has("extra") {
+		t.Error("modifying ToMap() result added key to state")
+	}
+
+	if empty := state.New(nil).ToMap(); empty == nil {
+		t.Error("ToMap() on empty state should return non-nil map")
+	}
+}
+
+func TestState_All(t *testing.T) {
+	s := state.New(nil).SetMany(map[string]any{"c": 3, "a": 1, "b": 2})
+
+	var keys []string
+	var values []any
+	for key, value := range s.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Errorf("keys = %v, want sorted [a b c]", keys)
+	}
+	if !reflect.DeepEqual(values, []any{1, 2, 3}) {
+		t.Errorf("values = %v, want [1 2 3]", values)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("iteration after break = %d, want 1", count)
+	}
+}
+
+func TestState_All_Snapshot(t *testing.T) {
+	s := state.New(nil).SetMany(map[string]any{"a": 1, "b": 2})
+
+	var keys []string
+	for key := range s.All() {
+		keys = append(keys, key)
+		s.Data["z"] = "added during iteration"
+		delete(s.Data, "b")
+	}
+
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("keys = %v, want snapshot [a b]", keys)
+	}
+}
+
+func TestState_Range(t *testing.T) {
+	s := state.New(nil).SetMany(map[string]any{"c": 3, "a": 1, "b": 2})
+
+	var keys []string
+	s.Range(func(key string, value any) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("keys = %v, want [a b] (stopped after b)", keys)
+	}
+}
+
+func TestNewFromMap_JSONPayload(t *testing.T) {
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(`{"user":"alice","tags":["a","b"]}`), &payload); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	first := state.NewFromMap(nil, payload)
+	second := state.NewFromMap(nil, payload)
+
+	if first.RunID == second.RunID {
+		t.Error("each NewFromMap call should generate a new RunID")
+	}
+	if tags, ok := state.GetAs[[]any](first, "tags"); !ok || len(tags) != 2 {
+		t.Errorf("tags = %v, want decoded slice", first.Data["tags"])
+	}
+	if !reflect.DeepEqual(first.ToMap(), payload) {
+		t.Errorf("ToMap() = %v, want %v", first.ToMap(), payload)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	observer := &captureObserver{}
+	input := map[string]any{"user": "alice"}
+
+	s := state.FromMap(observer, input)
+	input["user"] = "bob"
+
+	if v, _ := s.Get("user"); v != "alice" {
+		t.Errorf("user = %v, want alice (copied from input)", v)
+	}
+	if len(observer.events) != 1 || observer.events[0].Type != observability.EventStateCreate {
+		t.Errorf("events = %v, want one EventStateCreate", observer.events)
+	}
+}
+
+func TestNewFromMap(t *testing.T) {
+	observer := &captureObserver{}
+	input := map[string]any{"user": "alice", "count": 3}
+
+	s := state.NewFromMap(observer, input)
+
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+	if val, _ := s.Get("user"); val != "alice" {
+		t.Errorf("Get(user) = %v, want alice", val)
+	}
+	if s.RunID == "" {
+		t.Error("RunID should be set")
+	}
+
+	input["user"] = "mallory"
+	if val, _ := s.Get("user"); val != "alice" {
+		t.Error("modifying input map should not affect state")
+	}
+
+	if len(observer.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(observer.events))
+	}
+	if observer.events[0].Type != observability.EventStateCreate {
+		t.Errorf("event type = %s, want %s", observer.events[0].Type, observability.EventStateCreate)
+	}
+	if observer.events[0].Data["keys"] != 2 {
+		t.Errorf("event keys = %v, want 2", observer.events[0].Data["keys"])
+	}
+
+	nilState := state.NewFromMap(nil, nil)
+	if nilState.Data == nil || nilState.Len() != 0 {
+		t.Error("NewFromMap with nil map should produce empty state")
+	}
+	if nilState.Observer == nil {
+		t.Error("NewFromMap with nil observer should default to NoOpObserver")
+	}
+}
+
+func TestState_SetMany(t *testing.T) {
+	observer := &captureObserver{}
+	original := state.New(observer).Set("existing", "keep")
+	observer.events = nil
+
+	updated := original.SetMany(map[string]any{
+		"b":        2,
+		"a":        1,
+		"existing": "replaced",
+	})
+
+	if updated.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", updated.Len())
+	}
+	if val, _ := updated.Get("existing"); val != "replaced" {
+		t.Errorf("existing = %v, want replaced", val)
+	}
+	if val, _ := original.Get("existing"); val != "keep" || original.Has("a") {
+		t.Error("SetMany should not modify original state")
+	}
+
+	var sets []observability.Event
+	for _, event := range observer.events {
+		if event.Type == observability.EventStateSet {
+			sets = append(sets, event)
+		}
+	}
+	if len(sets) != 1 {
+		t.Fatalf("expected 1 set event, got %d", len(sets))
+	}
+
+	keys, ok := sets[0].Data["keys"].([]string)
+	if !ok || len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "existing" {
+		t.Errorf("event keys = %v, want [a b existing]", sets[0].Data["keys"])
+	}
+}
+
+func benchmarkValues() map[string]any {
+	values := make(map[string]any, 20)
+	for i := range 20 {
+		values[strings.Repeat("k", i+1)] = i
+	}
+	return values
+}
+
+func BenchmarkState_SetSequential(b *testing.B) {
+	s := state.New(nil).Set("base", true)
+	values := benchmarkValues()
+
+	b.ResetTimer()
+	for range b.N {
+		next := s
+		for key, value := range values {
+			next = next.Set(key, value)
+		}
+	}
+}
+
+func BenchmarkState_SetMany(b *testing.B) {
+	s := state.New(nil).Set("base", true)
+	values := benchmarkValues()
+
+	b.ResetTimer()
+	for range b.N {
+		s.SetMany(values)
+	}
+}
+
+func TestState_MergeWith(t *testing.T) {
+	observer := &captureObserver{}
+	left := state.New(observer).
+		Set("findings", []string{"a"}).
+		Set("score", 3).
+		Set("left_only", true)
+	right := state.New(nil).
+		Set("findings", []string{"b", "c"}).
+		Set("score", 7).
+		Set("right_only", true)
+
+	observer.events = nil
+
+	merged := left.MergeWith(right, func(key string, ours, theirs any) any {
+		switch key {
+		case "findings":
+			return append(append([]string{}, ours.([]string)...), theirs.([]string)...)
+		case "score":
+			return max(ours.(int), theirs.(int))
+		}
+		return theirs
+	})
+
+	findings := state.MustGetAs[[]string](merged, "findings")
+	if len(findings) != 3 || findings[0] != "a" || findings[2] != "c" {
+		t.Errorf("findings = %v, want [a b c]", findings)
+	}
+	if score := state.MustGetAs[int](merged, "score"); score != 7 {
+		t.Errorf("score = %d, want 7", score)
+	}
+	if !merged.Has("left_only") || !merged.Has("right_only") {
+		t.Error("non-conflicting keys from both states should be present")
+	}
+	if len(state.MustGetAs[[]string](left, "findings")) != 1 {
+		t.Error("MergeWith should not modify original state")
+	}
+
+	if len(observer.events) == 0 {
+		t.Fatal("expected merge event")
+	}
+	last := observer.events[len(observer.events)-1]
+	if last.Type != observability.EventStateMerge {
+		t.Fatalf("last event = %s, want %s", last.Type, observability.EventStateMerge)
+	}
+	conflicts, ok := last.Data["conflicts"].([]string)
+	if !ok || len(conflicts) != 2 || conflicts[0] != "findings" || conflicts[1] != "score" {
+		t.Errorf("conflicts = %v, want [findings score]", last.Data["conflicts"])
+	}
+}
+
+func TestState_MergeWith_NilResolver(t *testing.T) {
+	left := state.New(nil).Set("key", "ours")
+	right := state.New(nil).Set("key", "theirs")
+
+	merged := left.MergeWith(right, nil)
+
+	if val, _ := merged.Get("key"); val != "theirs" {
+		t.Errorf("key = %v, want theirs", val)
+	}
+}
+
+func TestState_Update(t *testing.T) {
+	increment := func(current any, exists bool) (any, error) {
+		if !exists {
+			return 1, nil
+		}
+		n, ok := current.(int)
+		if !ok {
+			return nil, fmt.Errorf("not an int: %T", current)
+		}
+		return n + 1, nil
+	}
+
+	s1 := state.New(observability.NoOpObserver{})
+
+	s2, err := s1.Update("count", increment)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	s3, err := s2.Update("count", increment)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if count, _ := s3.Get("count"); count != 2 {
+		t.Errorf("count = %v, want 2", count)
+	}
+	if s1.Has("count") {
+		t.Error("original state was modified")
+	}
+
+	bad := s1.Set("count", "one")
+	result, err := bad.Update("count", increment)
+	if err == nil {
+		t.Fatal("expected error from fn")
+	}
+	if !strings.Contains(err.Error(), `"count"`) {
+		t.Errorf("error %q should name the key", err)
+	}
+	if value, _ := result.Get("count"); value != "one" {
+		t.Errorf("state changed on error: count = %v", value)
+	}
+}
+
+func TestUpdateAs(t *testing.T) {
+	s := state.New(observability.NoOpObserver{})
+
+	s, err := state.UpdateAs(s, "count", func(n int) int { return n + 5 })
+	if err != nil {
+		t.Fatalf("UpdateAs() error = %v", err)
+	}
+	if count, _ := s.Get("count"); count != 5 {
+		t.Errorf("count from zero value = %v, want 5", count)
+	}
+
+	s, err = state.UpdateAs(s, "log", func(log []string) []string { return append(log, "a") })
+	if err != nil {
+		t.Fatalf("UpdateAs() error = %v", err)
+	}
+	s, _ = state.UpdateAs(s, "log", func(log []string) []string { return append(log, "b") })
+
+	log, _ := state.GetAs[[]string](s, "log")
+	if len(log) != 2 || log[0] != "a" || log[1] != "b" {
+		t.Errorf("log = %v, want [a b]", log)
+	}
+
+	mismatched := s.Set("count", 1.5)
+	result, err := state.UpdateAs(mismatched, "count", func(n int) int { return n + 1 })
+	if err == nil {
+		t.Fatal("expected type mismatch error")
+	}
+	if !strings.Contains(err.Error(), "float64") {
+		t.Errorf("error %q should name the actual type", err)
+	}
+	if value, _ := result.Get("count"); value != 1.5 {
+		t.Errorf("state changed on error: count = %v", value)
+	}
+}
@@ -0,0 +1,71 @@
+package testing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	sttest "github.com/JaimeStill/go-agents-orchestration/pkg/state/testing"
+)
+
+func TestMockNode(t *testing.T) {
+	response := state.New(nil).Set("category", "urgent")
+	node := sttest.MockNode(response, nil)
+
+	got, err := node.Execute(context.Background(), state.New(nil).Set("input", true))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Has("input") || !got.Has("category") {
+		t.Errorf("Execute() keys = %v, want the mock response", got.Keys())
+	}
+
+	failure := errors.New("model unavailable")
+	if _, err := sttest.MockNode(state.State{}, failure).Execute(context.Background(), state.New(nil)); !errors.Is(err, failure) {
+		t.Errorf("Execute() error = %v, want %v", err, failure)
+	}
+}
+
+func TestEchoNode(t *testing.T) {
+	input := state.New(nil).Set("k", "v")
+
+	got, err := sttest.EchoNode().Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if v, _ := got.GetString("k"); got.RunID != input.RunID || v != "v" {
+		t.Errorf("Execute() = %+v, want input unchanged", got)
+	}
+}
+
+func TestNodes_RouteGraph(t *testing.T) {
+	graph, err := state.NewGraph(config.DefaultGraphConfig("routing"))
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+
+	escalations, archives := 0, 0
+	graph.AddNode("classify", sttest.MockNode(state.New(nil).Set("category", "urgent"), nil))
+	graph.AddNode("escalate", sttest.CountingNode(&escalations))
+	graph.AddNode("archive", sttest.CountingNode(&archives))
+	graph.AddNode("done", sttest.EchoNode())
+	graph.AddEdge("classify", "escalate", state.KeyEquals("category", "urgent"))
+	graph.AddEdge("classify", "archive", nil)
+	graph.AddEdge("escalate", "done", nil)
+	graph.AddEdge("archive", "done", nil)
+	graph.SetEntryPoint("classify")
+	graph.SetExitPoint("done")
+
+	final, err := graph.Execute(context.Background(), state.New(nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if escalations != 1 || archives != 0 {
+		t.Errorf("escalations = %d, archives = %d; want 1, 0", escalations, archives)
+	}
+	if category, _ := final.GetString("category"); category != "urgent" {
+		t.Errorf("category = %q, want urgent", category)
+	}
+}
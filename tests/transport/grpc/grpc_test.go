@@ -0,0 +1,305 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hub"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/hubpb"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
+	grpctransport "github.com/JaimeStill/go-agents-orchestration/pkg/transport/grpc"
+	"github.com/JaimeStill/go-agents/pkg/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves a new hub over an in-memory listener and returns the hub
+// and a connected client.
+func startServer(t *testing.T) (hub.Hub, hub.Hub) {
+	t.Helper()
+
+	cfg := config.DefaultHubConfig()
+	cfg.Name = "server-hub"
+	h := hub.New(context.Background(), cfg)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	hubpb.RegisterHubServiceServer(srv, grpctransport.NewGRPCHubServer(h))
+	go srv.Serve(lis)
+
+	client, err := grpctransport.NewGRPCHubClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewGRPCHubClient() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		client.Shutdown(ctx)
+		srv.Stop()
+		h.Shutdown(ctx)
+	})
+
+	return h, client
+}
+
+func collectingHandler(received chan<- *messaging.Message) hub.MessageHandler {
+	return func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		received <- msg
+		return nil, nil
+	}
+}
+
+func expectMessage(t *testing.T, received <-chan *messaging.Message) *messaging.Message {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not received")
+		return nil
+	}
+}
+
+func TestGRPC_ClientToServerAgent(t *testing.T) {
+	h, client := startServer(t)
+
+	inbox := make(chan *messaging.Message, 1)
+	h.RegisterAgent(mock.NewSimpleChatAgent("local", "response"), collectingHandler(inbox))
+
+	sent := messaging.NewNotification("remote", "local", map[string]any{"task": "index"}).
+		Priority(messaging.PriorityHigh).
+		Build()
+	sent = sent.SetHeader(messaging.HeaderTraceID, "trace-1")
+
+	if err := client.SendMessage(context.Background(), sent); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	received := expectMessage(t, inbox)
+	if received.ID != sent.ID {
+		t.Errorf("ID = %q, want %q", received.ID, sent.ID)
+	}
+	if received.Priority != messaging.PriorityHigh {
+		t.Errorf("Priority = %v, want high", received.Priority)
+	}
+	if trace, _ := received.GetHeader(messaging.HeaderTraceID); trace != "trace-1" {
+		t.Errorf("trace header = %q, want trace-1", trace)
+	}
+	data, ok := received.Data.(map[string]any)
+	if !ok || data["task"] != "index" {
+		t.Errorf("Data = %#v, want map with task=index", received.Data)
+	}
+	if !received.Timestamp.Equal(sent.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", received.Timestamp, sent.Timestamp)
+	}
+}
+
+func TestGRPC_ServerToRemoteAgent(t *testing.T) {
+	h, client := startServer(t)
+
+	inbox := make(chan *messaging.Message, 1)
+	if err := client.RegisterAgent(mock.NewSimpleChatAgent("remote", "response"), collectingHandler(inbox)); err != nil {
+		t.Fatalf("RegisterAgent() error = %v", err)
+	}
+
+	if err := h.Send(context.Background(), "local", "remote", "hello"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	received := expectMessage(t, inbox)
+	if received.Data != "hello" {
+		t.Errorf("Data = %v, want hello", received.Data)
+	}
+}
+
+func TestGRPC_RequestToRemoteAgent(t *testing.T) {
+	h, client := startServer(t)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse(msgCtx.Agent.ID(), "", "", "done: "+msg.Data.(string)).Build(), nil
+	}
+	if err := client.RegisterAgent(mock.NewSimpleChatAgent("remote", "response"), worker); err != nil {
+		t.Fatalf("RegisterAgent() error = %v", err)
+	}
+	h.RegisterAgent(mock.NewSimpleChatAgent("local", "response"), nil)
+
+	response, err := h.Request(context.Background(), "local", "remote", "task")
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if response.Data != "done: task" {
+		t.Errorf("response Data = %v, want done: task", response.Data)
+	}
+}
+
+func TestGRPC_RequestFromClient(t *testing.T) {
+	h, client := startServer(t)
+
+	worker := func(ctx context.Context, msg *messaging.Message, msgCtx *hub.MessageContext) (*messaging.Message, error) {
+		return messaging.NewResponse("local", "", "", "ack").Build(), nil
+	}
+	h.RegisterAgent(mock.NewSimpleChatAgent("local", "response"), worker)
+
+	response, err := client.Request(context.Background(), "remote", "local", "task")
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if response.Data != "ack" {
+		t.Errorf("response Data = %v, want ack", response.Data)
+	}
+}
+
+func TestGRPC_Broadcast(t *testing.T) {
+	h, client := startServer(t)
+
+	inboxA := make(chan *messaging.Message, 1)
+	inboxB := make(chan *messaging.Message, 1)
+	h.RegisterAgent(mock.NewSimpleChatAgent("a", "response"), collectingHandler(inboxA))
+	h.RegisterAgent(mock.NewSimpleChatAgent("b", "response"), collectingHandler(inboxB))
+
+	msg := messaging.NewBroadcast("remote", "news").Build()
+	if err := client.Broadcast(context.Background(), msg); err != nil {
+		t.Fatalf("Broadcast() error = %v", err)
+	}
+
+	expectMessage(t, inboxA)
+	expectMessage(t, inboxB)
+}
+
+func TestGRPC_ErrorMapping(t *testing.T) {
+	_, client := startServer(t)
+
+	err := client.Send(context.Background(), "remote", "missing", "data")
+	if !errors.Is(err, hub.ErrAgentNotFound) {
+		t.Errorf("Send() error = %v, want ErrAgentNotFound", err)
+	}
+	if messaging.MessageIDOf(err) == "" {
+		t.Error("error should carry the message ID")
+	}
+}
+
+func TestGRPC_DuplicateRegistration(t *testing.T) {
+	h, client := startServer(t)
+
+	h.RegisterAgent(mock.NewSimpleChatAgent("taken", "response"), nil)
+
+	if err := client.RegisterAgent(mock.NewSimpleChatAgent("taken", "response"), nil); err == nil {
+		t.Error("RegisterAgent() with an ID registered on the server should fail")
+	}
+}
+
+func TestGRPC_UnregisterRemovesProxy(t *testing.T) {
+	h, client := startServer(t)
+
+	if err := client.RegisterAgent(mock.NewSimpleChatAgent("remote", "response"), nil); err != nil {
+		t.Fatalf("RegisterAgent() error = %v", err)
+	}
+	if len(h.ListAgents()) != 1 {
+		t.Fatalf("server agents = %d, want 1", len(h.ListAgents()))
+	}
+
+	if err := client.UnregisterAgent("remote"); err != nil {
+		t.Fatalf("UnregisterAgent() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(h.ListAgents()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("proxy agent not unregistered from server hub")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGRPC_Topics(t *testing.T) {
+	h, client := startServer(t)
+	ctx := context.Background()
+
+	remoteInbox := make(chan *messaging.Message, 1)
+	if err := client.RegisterAgent(mock.NewSimpleChatAgent("remote", "response"), collectingHandler(remoteInbox)); err != nil {
+		t.Fatalf("RegisterAgent() error = %v", err)
+	}
+	localInbox := make(chan *messaging.Message, 1)
+	h.RegisterAgent(mock.NewSimpleChatAgent("local", "response"), collectingHandler(localInbox))
+
+	if err := client.Subscribe("remote", "news"); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if err := client.Subscribe("local", "news"); err != nil {
+		t.Fatalf("Subscribe() for a server agent error = %v", err)
+	}
+
+	if err := client.Publish(ctx, "news", messaging.NewNotification("remote", "", "from remote").Build()); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got := expectMessage(t, localInbox); got.Data != "from remote" || got.Topic != "news" {
+		t.Errorf("local received Data = %v Topic = %q, want the remote publication", got.Data, got.Topic)
+	}
+
+	if err := h.Publish(ctx, "news", messaging.NewNotification("local", "", "from local").Build()); err != nil {
+		t.Fatalf("server Publish() error = %v", err)
+	}
+	if got := expectMessage(t, remoteInbox); got.Data != "from local" {
+		t.Errorf("remote received Data = %v, want the server publication", got.Data)
+	}
+
+	if err := client.Unsubscribe("remote", "news"); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if err := client.Unsubscribe("remote", "news"); err == nil {
+		t.Error("second Unsubscribe() should fail")
+	}
+	if err := client.Subscribe("missing", "news"); !errors.Is(err, hub.ErrAgentNotFound) {
+		t.Errorf("Subscribe() unknown agent error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+func sendToCapable(client hub.Hub) error {
+	_, err := client.SendToCapable(context.Background(), "summarize", messaging.NewRequest("remote", "", "x").Build())
+	return err
+}
+
+func TestGRPC_UnsupportedOperations(t *testing.T) {
+	_, client := startServer(t)
+
+	ops := map[string]error{
+		"Pause":          client.Pause("remote"),
+		"SendToCapable":  sendToCapable(client),
+		"Replace":        client.Replace("remote", mock.NewSimpleChatAgent("new", "hi")),
+		"AddRoutingRule": client.AddRoutingRule(hub.RoutingRule{TargetAgent: "remote"}),
+		"AddTransformer": client.AddTransformer(hub.NewTracingHeaderTransformer()),
+	}
+
+	for name, err := range ops {
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("%s() error = %v, want ErrUnsupported", name, err)
+		}
+	}
+}
+
+func TestGRPC_ShutdownClient(t *testing.T) {
+	_, client := startServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if err := client.Send(context.Background(), "a", "b", "x"); !errors.Is(err, hub.ErrHubShutdown) {
+		t.Errorf("Send() after Shutdown error = %v, want ErrHubShutdown", err)
+	}
+}
@@ -0,0 +1,167 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+type tally struct {
+	Seen  []string `json:"seen"`
+	Total int      `json:"total"`
+}
+
+func resumableConfig(runID string) config.ResumableChainConfig {
+	return config.ResumableChainConfig{
+		ChainConfig: config.ChainConfig{Observer: "noop"},
+		RunID:       runID,
+	}
+}
+
+func TestProcessChainResumable_ResumesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	store, err := state.NewFileCheckpointStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	cfg := resumableConfig("batch-1")
+	cfg.StepNames = []string{"one", "two", "three", "four"}
+	items := []string{"a", "b", "c", "d"}
+
+	var calls []string
+	failOn := "c"
+	processor := func(ctx context.Context, item string, current tally) (tally, error) {
+		calls = append(calls, item)
+		if item == failOn {
+			return current, errors.New("transient failure")
+		}
+		current.Seen = append(current.Seen, item)
+		current.Total++
+		return current, nil
+	}
+
+	_, err = workflows.ProcessChainResumable(ctx, cfg, items, tally{}, processor, store)
+	var chainErr *workflows.ChainError[string, tally]
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("first run error = %v, want ChainError", err)
+	}
+	if chainErr.StepIndex != 2 || chainErr.StepName != "three" {
+		t.Errorf("failed at step %d (%q), want 2 (three)", chainErr.StepIndex, chainErr.StepName)
+	}
+
+	failOn = "d"
+	calls = nil
+	_, err = workflows.ProcessChainResumable(ctx, cfg, items, tally{}, processor, store)
+	if !errors.As(err, &chainErr) || chainErr.StepIndex != 3 || chainErr.StepName != "four" {
+		t.Fatalf("second run error = %v, want failure at step 3 (four)", err)
+	}
+	if !slices.Equal(calls, []string{"c", "d"}) {
+		t.Errorf("second run processed %v, want [c d]", calls)
+	}
+
+	failOn = ""
+	calls = nil
+	result, err := workflows.ProcessChainResumable(ctx, cfg, items, tally{}, processor, store)
+	if err != nil {
+		t.Fatalf("third run error = %v", err)
+	}
+	if !slices.Equal(calls, []string{"d"}) {
+		t.Errorf("third run processed %v, want [d]", calls)
+	}
+	if !slices.Equal(result.Final.Seen, items) || result.Final.Total != 4 || result.Steps != 4 {
+		t.Errorf("result = %+v (steps %d), want all four items", result.Final, result.Steps)
+	}
+
+	if ids, _ := store.List(); len(ids) != 0 {
+		t.Errorf("checkpoints after success = %v, want none", ids)
+	}
+}
+
+func TestProcessChainResumable_NoCheckpoint(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	processor := func(ctx context.Context, item int, current int) (int, error) {
+		return current + item, nil
+	}
+
+	result, err := workflows.ProcessChainResumable(context.Background(), resumableConfig("fresh"), []int{1, 2, 3}, 10, processor, store)
+	if err != nil {
+		t.Fatalf("ProcessChainResumable() error = %v", err)
+	}
+	if result.Final != 16 || result.Steps != 3 {
+		t.Errorf("result = %d after %d steps, want 16 after 3", result.Final, result.Steps)
+	}
+}
+
+func TestProcessChainResumable_StateContext(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	cfg := resumableConfig("state-run")
+	items := []string{"x", "y"}
+
+	fail := true
+	processor := func(ctx context.Context, item string, s state.State) (state.State, error) {
+		if item == "y" && fail {
+			return s, errors.New("boom")
+		}
+		return s.Set(item, true), nil
+	}
+
+	if _, err := workflows.ProcessChainResumable(context.Background(), cfg, items, state.New(nil), processor, store); err == nil {
+		t.Fatal("first run error = nil, want failure")
+	}
+
+	fail = false
+	result, err := workflows.ProcessChainResumable(context.Background(), cfg, items, state.New(nil), processor, store)
+	if err != nil {
+		t.Fatalf("second run error = %v", err)
+	}
+	if !result.Final.Has("x") || !result.Final.Has("y") {
+		t.Errorf("final keys = %v, want x and y", result.Final.Keys())
+	}
+}
+
+func TestProcessChainResumable_InvalidArguments(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	processor := func(ctx context.Context, item int, current int) (int, error) {
+		return current, nil
+	}
+
+	tests := []struct {
+		name  string
+		cfg   config.ResumableChainConfig
+		store state.CheckpointStore
+	}{
+		{name: "missing run ID", cfg: resumableConfig(""), store: store},
+		{name: "nil store", cfg: resumableConfig("run"), store: nil},
+		{name: "concurrent", cfg: config.ResumableChainConfig{ChainConfig: config.ChainConfig{Observer: "noop", MaxConcurrency: 4}, RunID: "run"}, store: store},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := workflows.ProcessChainResumable(context.Background(), tt.cfg, []int{1}, 0, processor, tt.store); err == nil {
+				t.Error("ProcessChainResumable() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestProcessChainResumable_CheckpointBeyondItems(t *testing.T) {
+	store := state.NewMemoryCheckpointStore()
+	cfg := resumableConfig("long-run")
+	processor := func(ctx context.Context, item int, current int) (int, error) {
+		if item == 3 {
+			return current, errors.New("stop")
+		}
+		return current + item, nil
+	}
+
+	workflows.ProcessChainResumable(context.Background(), cfg, []int{1, 2, 3}, 0, processor, store)
+
+	if _, err := workflows.ProcessChainResumable(context.Background(), cfg, []int{1}, 0, processor, store); err == nil {
+		t.Error("ProcessChainResumable() error = nil, want checkpoint/item mismatch")
+	}
+}
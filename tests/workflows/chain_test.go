@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/messaging"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/state"
 	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
 )
 
@@ -332,8 +336,8 @@ func TestProcessChain_ObserverIntegration(t *testing.T) {
 		if event.Type != expectedEvents[i] {
 			t.Errorf("Event %d: expected type %v, got %v", i, expectedEvents[i], event.Type)
 		}
-		if event.Source != "workflows.ProcessChain" {
-			t.Errorf("Event %d: expected source 'workflows.ProcessChain', got %q", i, event.Source)
+		if event.Source != observability.NewEventSource(observability.SourceWorkflow, "ProcessChain") {
+			t.Errorf("Event %d: expected source 'workflow:ProcessChain', got %q", i, event.Source)
 		}
 	}
 }
@@ -537,3 +541,364 @@ func TestProcessChain_NoProgressCallback(t *testing.T) {
 		t.Errorf("Expected final state %q, got %q", expected, result.Final)
 	}
 }
+
+func TestProcessChainStream_EmitsEachStep(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.ChainConfig{Observer: "noop"}
+
+	items := []string{"a", "b", "c"}
+	processor := func(ctx context.Context, item string, state string) (string, error) {
+		return state + item, nil
+	}
+
+	stream, err := workflows.ProcessChainStream(ctx, cfg, items, "", processor)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var results []workflows.ChainStepResult[string, string]
+	for step := range stream {
+		results = append(results, step)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 step results, got %d", len(results))
+	}
+
+	expected := []string{"a", "ab", "abc"}
+	for i, step := range results {
+		if step.Err != nil {
+			t.Errorf("Step %d: unexpected error %v", i, step.Err)
+		}
+		if step.StepIndex != i {
+			t.Errorf("Step %d: StepIndex = %d", i, step.StepIndex)
+		}
+		if step.Item != items[i] {
+			t.Errorf("Step %d: Item = %q, want %q", i, step.Item, items[i])
+		}
+		if step.State != expected[i] {
+			t.Errorf("Step %d: State = %q, want %q", i, step.State, expected[i])
+		}
+	}
+}
+
+func TestProcessChainStream_ErrorClosesStream(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.ChainConfig{Observer: "noop"}
+	testErr := errors.New("step failed")
+
+	items := []int{1, 2, 3, 4}
+	processor := func(ctx context.Context, item int, state int) (int, error) {
+		if item == 3 {
+			return state, testErr
+		}
+		return state + item, nil
+	}
+
+	stream, err := workflows.ProcessChainStream(ctx, cfg, items, 0, processor)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var results []workflows.ChainStepResult[int, int]
+	for step := range stream {
+		results = append(results, step)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 2 successes and 1 failure, got %d results", len(results))
+	}
+
+	last := results[2]
+	if !errors.Is(last.Err, testErr) {
+		t.Errorf("Expected final result to carry error, got %v", last.Err)
+	}
+	if last.StepIndex != 2 || last.Item != 3 {
+		t.Errorf("Expected failure at step 2 (item 3), got step %d (item %d)", last.StepIndex, last.Item)
+	}
+	if last.State != 3 {
+		t.Errorf("Expected state at failure 3, got %d", last.State)
+	}
+
+	var chainErr *workflows.ChainError[int, int]
+	if !errors.As(last.Err, &chainErr) {
+		t.Errorf("Expected ChainError, got %T", last.Err)
+	}
+}
+
+func TestProcessChainStream_Backpressure(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.ChainConfig{Observer: "noop"}
+
+	var processed atomic.Int32
+	processor := func(ctx context.Context, item int, state int) (int, error) {
+		processed.Add(1)
+		return state + item, nil
+	}
+
+	stream, err := workflows.ProcessChainStream(ctx, cfg, []int{1, 2, 3}, 0, processor)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	first := <-stream
+	time.Sleep(20 * time.Millisecond)
+
+	if first.StepIndex != 0 {
+		t.Errorf("Expected first step, got %d", first.StepIndex)
+	}
+	if processed.Load() > 2 {
+		t.Errorf("Expected chain to wait for consumer, processed %d items", processed.Load())
+	}
+
+	for range stream {
+	}
+}
+
+func TestProcessChainStream_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := config.ChainConfig{Observer: "noop"}
+
+	processor := func(ctx context.Context, item int, state int) (int, error) {
+		return state + item, nil
+	}
+
+	stream, err := workflows.ProcessChainStream(ctx, cfg, []int{1, 2, 3, 4, 5}, 0, processor)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	<-stream
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range stream {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected stream to close after cancellation")
+	}
+}
+
+func TestProcessChainStream_InvalidObserver(t *testing.T) {
+	cfg := config.ChainConfig{Observer: "nonexistent"}
+	processor := func(ctx context.Context, item int, state int) (int, error) {
+		return state, nil
+	}
+
+	stream, err := workflows.ProcessChainStream(context.Background(), cfg, []int{1}, 0, processor)
+	if err == nil {
+		t.Fatal("Expected error for unknown observer")
+	}
+	if stream != nil {
+		t.Error("Expected nil stream on error")
+	}
+}
+
+func TestProcessChain_ChainErrorMessageID(t *testing.T) {
+	cfg := config.DefaultChainConfig()
+
+	processor := func(ctx context.Context, item string, current string) (string, error) {
+		return "", messaging.WrapError("msg-42", errors.New("agent failed"))
+	}
+
+	_, err := workflows.ProcessChain(context.Background(), cfg, []string{"a"}, "start", processor, nil)
+
+	var chainErr *workflows.ChainError[string, string]
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("Expected ChainError, got %T", err)
+	}
+
+	if chainErr.MessageID != "msg-42" {
+		t.Errorf("MessageID = %q, want msg-42", chainErr.MessageID)
+	}
+}
+
+func TestProcessChain_StepNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.ChainConfig
+		failAt  int
+		wantMsg string
+	}{
+		{
+			name:    "named step",
+			cfg:     config.DefaultChainConfig().WithStepNames("extract", "classify", "summarize"),
+			failAt:  1,
+			wantMsg: "chain failed at step 1 (classify): boom",
+		},
+		{
+			name:    "beyond names",
+			cfg:     config.DefaultChainConfig().WithStepNames("extract"),
+			failAt:  2,
+			wantMsg: "chain failed at step 2: boom",
+		},
+		{
+			name:    "empty name",
+			cfg:     config.DefaultChainConfig().WithStepNames("extract", ""),
+			failAt:  1,
+			wantMsg: "chain failed at step 1: boom",
+		},
+		{
+			name: "concurrent",
+			cfg: config.ChainConfig{
+				MaxConcurrency: 2,
+				Observer:       "noop",
+				StepNames:      []string{"a", "b", "c"},
+			},
+			failAt:  2,
+			wantMsg: "chain failed at step 2 (c): boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := func(ctx context.Context, item int, s state.State) (state.State, error) {
+				if item == tt.failAt {
+					return s, errors.New("boom")
+				}
+				return s.Set(fmt.Sprintf("item_%d", item), item), nil
+			}
+
+			_, err := workflows.ProcessChain(context.Background(), tt.cfg, []int{0, 1, 2}, state.New(nil), processor, nil)
+			if err == nil || err.Error() != tt.wantMsg {
+				t.Errorf("Error() = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestProcessChain_MaxConcurrency(t *testing.T) {
+	cfg := config.ChainConfig{
+		CaptureIntermediateStates: true,
+		MaxConcurrency:            3,
+		Observer:                  "noop",
+	}
+
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	var inFlight, peak atomic.Int32
+
+	processor := func(ctx context.Context, item int, s state.State) (state.State, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Duration(10-item) * time.Millisecond)
+		return s.Set(fmt.Sprintf("item_%d", item), item*item), nil
+	}
+
+	var progressed []int
+	progress := func(completed, total int, s state.State) {
+		progressed = append(progressed, completed)
+		if s.Len() != completed+1 {
+			t.Errorf("progress(%d) state has %d keys, want %d", completed, s.Len(), completed+1)
+		}
+	}
+
+	initial := state.New(nil).Set("source", "batch")
+	result, err := workflows.ProcessChain(context.Background(), cfg, items, initial, processor, progress)
+	if err != nil {
+		t.Fatalf("ProcessChain() error = %v", err)
+	}
+
+	if p := peak.Load(); p < 2 || p > 3 {
+		t.Errorf("peak concurrency = %d, want 2..3", p)
+	}
+	if result.Steps != len(items) {
+		t.Errorf("Steps = %d, want %d", result.Steps, len(items))
+	}
+	for _, item := range items {
+		if v, _ := result.Final.Get(fmt.Sprintf("item_%d", item)); v != item*item {
+			t.Errorf("item_%d = %v, want %d", item, v, item*item)
+		}
+	}
+
+	if len(result.Intermediate) != len(items)+1 {
+		t.Fatalf("len(Intermediate) = %d, want %d", len(result.Intermediate), len(items)+1)
+	}
+	for i, s := range result.Intermediate {
+		if !s.Has("source") || s.Len() != i+1 {
+			t.Errorf("Intermediate[%d] has %d keys, want %d", i, s.Len(), i+1)
+		}
+		if i > 0 && !s.Has(fmt.Sprintf("item_%d", i-1)) {
+			t.Errorf("Intermediate[%d] missing item_%d", i, i-1)
+		}
+	}
+
+	for i, completed := range progressed {
+		if completed != i+1 {
+			t.Fatalf("progress order = %v, want ascending", progressed)
+		}
+	}
+}
+
+func TestProcessChain_MaxConcurrency_CollectsAllErrors(t *testing.T) {
+	cfg := config.ChainConfig{
+		MaxConcurrency: 6,
+		Observer:       "noop",
+	}
+
+	items := []int{0, 1, 2, 3, 4, 5}
+	errTwo := errors.New("item 2 failed")
+	errFive := errors.New("item 5 failed")
+
+	var started sync.WaitGroup
+	started.Add(len(items))
+
+	processor := func(ctx context.Context, item int, s state.State) (state.State, error) {
+		started.Done()
+		started.Wait()
+
+		switch item {
+		case 2:
+			return s, errTwo
+		case 5:
+			return s, errFive
+		}
+		return s.Set(fmt.Sprintf("item_%d", item), true), nil
+	}
+
+	_, err := workflows.ProcessChain(context.Background(), cfg, items, state.New(nil), processor, nil)
+
+	var chainErr *workflows.ChainError[int, state.State]
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("error = %v, want ChainError", err)
+	}
+	if chainErr.StepIndex != 2 {
+		t.Errorf("StepIndex = %d, want 2", chainErr.StepIndex)
+	}
+	if len(chainErr.Errors) != 2 || chainErr.Errors[0].Index != 2 || chainErr.Errors[1].Index != 5 {
+		t.Errorf("Errors = %+v, want failures at 2 and 5", chainErr.Errors)
+	}
+	if !errors.Is(err, errTwo) || !errors.Is(err, errFive) {
+		t.Errorf("error = %v, want both step errors", err)
+	}
+	if !chainErr.State.Has("item_1") || chainErr.State.Has("item_3") {
+		t.Errorf("State keys = %v, want steps before the first failure", chainErr.State.Keys())
+	}
+}
+
+func TestProcessChain_MaxConcurrency_RequiresMerger(t *testing.T) {
+	cfg := config.ChainConfig{
+		MaxConcurrency: 2,
+		Observer:       "noop",
+	}
+
+	processor := func(ctx context.Context, item string, current string) (string, error) {
+		return current + item, nil
+	}
+
+	if _, err := workflows.ProcessChain(context.Background(), cfg, []string{"a"}, "", processor, nil); err == nil {
+		t.Error("ProcessChain() with non-Merger context should fail")
+	}
+}
@@ -0,0 +1,204 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/observability"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+func upperStage(name string) workflows.PipelineStage[string] {
+	return workflows.PipelineStage[string]{
+		Name: name,
+		Fn: func(ctx context.Context, in string) (string, error) {
+			return strings.ToUpper(in), nil
+		},
+	}
+}
+
+func suffixStage(name, suffix string) workflows.PipelineStage[string] {
+	return workflows.PipelineStage[string]{
+		Name: name,
+		Fn: func(ctx context.Context, in string) (string, error) {
+			return in + suffix, nil
+		},
+	}
+}
+
+func TestProcessPipeline_ExecutesStagesInOrder(t *testing.T) {
+	cfg := config.PipelineConfig{Observer: "noop"}
+	stages := []workflows.PipelineStage[string]{
+		suffixStage("first", "-a"),
+		upperStage("upper"),
+		suffixStage("last", "-b"),
+	}
+
+	result, err := workflows.ProcessPipeline(context.Background(), cfg, stages, "doc")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result != "DOC-A-b" {
+		t.Errorf("Expected %q, got %q", "DOC-A-b", result)
+	}
+}
+
+func TestProcessPipeline_Empty(t *testing.T) {
+	cfg := config.PipelineConfig{Observer: "noop"}
+
+	result, err := workflows.ProcessPipeline(context.Background(), cfg, nil, "doc")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result != "doc" {
+		t.Errorf("Expected initial value, got %q", result)
+	}
+}
+
+func TestProcessPipeline_StageError(t *testing.T) {
+	cfg := config.PipelineConfig{Observer: "noop"}
+	testErr := errors.New("classifier unavailable")
+
+	executed := false
+	stages := []workflows.PipelineStage[string]{
+		suffixStage("extract", "-x"),
+		{
+			Name: "classify",
+			Fn: func(ctx context.Context, in string) (string, error) {
+				return "", testErr
+			},
+		},
+		{
+			Name: "summarize",
+			Fn: func(ctx context.Context, in string) (string, error) {
+				executed = true
+				return in, nil
+			},
+		},
+	}
+
+	result, err := workflows.ProcessPipeline(context.Background(), cfg, stages, "doc")
+
+	if !errors.Is(err, testErr) {
+		t.Fatalf("Expected stage error, got: %v", err)
+	}
+	if executed {
+		t.Error("Stages after failure should not execute")
+	}
+	if result != "doc-x" {
+		t.Errorf("Expected input of failing stage, got %q", result)
+	}
+
+	var pipeErr *workflows.PipelineError[string]
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("Expected PipelineError, got %T", err)
+	}
+	if pipeErr.StageIndex != 1 || pipeErr.StageName != "classify" {
+		t.Errorf("Expected failure at stage 1 (classify), got %d (%s)", pipeErr.StageIndex, pipeErr.StageName)
+	}
+	if !strings.Contains(err.Error(), "classify") {
+		t.Errorf("Expected error message to include stage name, got: %v", err)
+	}
+}
+
+func TestProcessPipeline_NilStageFunc(t *testing.T) {
+	cfg := config.PipelineConfig{Observer: "noop"}
+
+	executed := false
+	stages := []workflows.PipelineStage[string]{
+		{
+			Name: "extract",
+			Fn: func(ctx context.Context, in string) (string, error) {
+				executed = true
+				return in, nil
+			},
+		},
+		{Name: "classify"},
+	}
+
+	result, err := workflows.ProcessPipeline(context.Background(), cfg, stages, "doc")
+
+	var pipeErr *workflows.PipelineError[string]
+	if !errors.As(err, &pipeErr) {
+		t.Fatalf("Expected PipelineError, got %v", err)
+	}
+	if pipeErr.StageIndex != 1 || pipeErr.StageName != "classify" {
+		t.Errorf("Expected failure at stage 1 (classify), got %d (%s)", pipeErr.StageIndex, pipeErr.StageName)
+	}
+	if executed {
+		t.Error("No stage should execute when a stage is invalid")
+	}
+	if result != "doc" {
+		t.Errorf("Expected initial input, got %q", result)
+	}
+}
+
+func TestProcessPipeline_ContextCancellation(t *testing.T) {
+	cfg := config.PipelineConfig{Observer: "noop"}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stages := []workflows.PipelineStage[string]{
+		{
+			Name: "cancel",
+			Fn: func(ctx context.Context, in string) (string, error) {
+				cancel()
+				return in, nil
+			},
+		},
+		upperStage("never"),
+	}
+
+	_, err := workflows.ProcessPipeline(ctx, cfg, stages, "doc")
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	var pipeErr *workflows.PipelineError[string]
+	if errors.As(err, &pipeErr) && pipeErr.StageName != "never" {
+		t.Errorf("Expected cancellation reported at stage 'never', got %q", pipeErr.StageName)
+	}
+}
+
+func TestProcessPipeline_ObserverEvents(t *testing.T) {
+	observer := newCaptureObserver()
+	observability.RegisterObserver("pipeline-capture", observer)
+	cfg := config.PipelineConfig{Observer: "pipeline-capture"}
+
+	stages := []workflows.PipelineStage[string]{
+		upperStage("upper"),
+		suffixStage("suffix", "!"),
+	}
+
+	_, err := workflows.ProcessPipeline(context.Background(), cfg, stages, "doc")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []observability.EventType{
+		observability.EventPipelineStart,
+		observability.EventStageStart,
+		observability.EventStageComplete,
+		observability.EventStageStart,
+		observability.EventStageComplete,
+		observability.EventPipelineComplete,
+	}
+
+	if len(observer.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(observer.events))
+	}
+	for i, eventType := range expected {
+		if observer.events[i].Type != eventType {
+			t.Errorf("Event %d: expected %s, got %s", i, eventType, observer.events[i].Type)
+		}
+	}
+
+	if observer.events[3].Data["stage_name"] != "suffix" {
+		t.Errorf("Expected stage name in event data, got %v", observer.events[3].Data["stage_name"])
+	}
+}
@@ -0,0 +1,227 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestProcessParallelWithPool(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 2, QueueDepth: 10})
+	defer pool.Close()
+
+	var running, peak atomic.Int32
+	processor := func(ctx context.Context, n int) (int, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		return n * n, nil
+	}
+
+	cfg := config.ParallelConfig{MaxWorkers: 4, Observer: "noop"}
+	for range 3 {
+		result, err := workflows.ProcessParallelWithPool(context.Background(), pool, cfg, []int{1, 2, 3, 4, 5, 6}, processor, nil)
+		if err != nil {
+			t.Fatalf("ProcessParallelWithPool() error = %v", err)
+		}
+		want := []int{1, 4, 9, 16, 25, 36}
+		for i, got := range result.Results {
+			if got != want[i] {
+				t.Fatalf("Results = %v, want %v", result.Results, want)
+			}
+		}
+	}
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak concurrency = %d, want at most the pool's 2 workers", got)
+	}
+	if got := pool.Workers(); got > 2 {
+		t.Errorf("Workers() = %d after reuse, want at most 2", got)
+	}
+}
+
+func TestProcessParallelWithPool_WorkersExceedPoolCapacity(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 1, QueueDepth: 1})
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	cfg := config.ParallelConfig{MaxWorkers: 3, Observer: "noop"}
+	processor := func(ctx context.Context, n int) (int, error) {
+		return n * 2, nil
+	}
+
+	result, err := workflows.ProcessParallelWithPool(ctx, pool, cfg, []int{1, 2, 3, 4, 5}, processor, nil)
+	if err != nil {
+		t.Fatalf("ProcessParallelWithPool() error = %v", err)
+	}
+	if len(result.Results) != 5 {
+		t.Errorf("got %d results, want 5", len(result.Results))
+	}
+}
+
+func TestProcessParallelWithPool_ConcurrentCallsShareSmallPool(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 2, QueueDepth: 1})
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := config.ParallelConfig{MaxWorkers: 4, Observer: "noop"}
+	processor := func(ctx context.Context, n int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return n, nil
+	}
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for range 6 {
+		wg.Go(func() {
+			result, err := workflows.ProcessParallelWithPool(ctx, pool, cfg, items, processor, nil)
+			if err == nil && len(result.Results) != len(items) {
+				err = errors.New("missing results")
+			}
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("ProcessParallelWithPool() error = %v", err)
+		}
+	}
+}
+
+func TestWorkerPool_Monitoring(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 1, QueueDepth: 5})
+
+	gate := make(chan struct{})
+	var ran atomic.Int32
+	task := func() {
+		<-gate
+		ran.Add(1)
+	}
+	for range 4 {
+		if err := pool.Submit(context.Background(), task); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	waitFor(t, "the first task to start", func() bool { return pool.Active() == 1 })
+	if got := pool.QueueDepth(); got != 3 {
+		t.Errorf("QueueDepth() = %d, want 3", got)
+	}
+	if got := pool.Workers(); got != 1 {
+		t.Errorf("Workers() = %d, want 1", got)
+	}
+
+	close(gate)
+	pool.Close()
+
+	if got := ran.Load(); got != 4 {
+		t.Errorf("ran %d tasks, want Close to finish all 4", got)
+	}
+	if pool.Workers() != 0 || pool.Active() != 0 || pool.QueueDepth() != 0 {
+		t.Errorf("after Close: Workers=%d Active=%d QueueDepth=%d, want all 0", pool.Workers(), pool.Active(), pool.QueueDepth())
+	}
+}
+
+func TestWorkerPool_Closed(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.DefaultWorkerPoolConfig())
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+
+	if err := pool.Submit(context.Background(), func() {}); !errors.Is(err, workflows.ErrPoolClosed) {
+		t.Errorf("Submit() error = %v, want ErrPoolClosed", err)
+	}
+
+	processor := func(ctx context.Context, n int) (int, error) { return n, nil }
+	_, err := workflows.ProcessParallelWithPool(context.Background(), pool, config.ParallelConfig{Observer: "noop"}, []int{1, 2}, processor, nil)
+	if !errors.Is(err, workflows.ErrPoolClosed) {
+		t.Errorf("ProcessParallelWithPool() error = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestWorkerPool_MaxQueueWait(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 1, QueueDepth: 1, MaxQueueWait: 10 * time.Millisecond})
+	gate := make(chan struct{})
+	defer func() {
+		close(gate)
+		pool.Close()
+	}()
+
+	block := func() { <-gate }
+	pool.Submit(context.Background(), block)
+	waitFor(t, "the worker to start", func() bool { return pool.Active() == 1 })
+	pool.Submit(context.Background(), block)
+
+	if err := pool.Submit(context.Background(), block); !errors.Is(err, workflows.ErrPoolQueueFull) {
+		t.Errorf("Submit() error = %v, want ErrPoolQueueFull", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pool.Submit(ctx, block); !errors.Is(err, context.Canceled) {
+		t.Errorf("Submit() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestWorkerPool_IdleTimeout(t *testing.T) {
+	pool := workflows.NewWorkerPool(config.WorkerPoolConfig{Workers: 4, QueueDepth: 10, IdleTimeout: 10 * time.Millisecond})
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(8)
+	for range 8 {
+		pool.Submit(context.Background(), func() {
+			time.Sleep(time.Millisecond)
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	waitFor(t, "idle workers to retire", func() bool { return pool.Workers() == 0 })
+
+	done := make(chan struct{})
+	if err := pool.Submit(context.Background(), func() { close(done) }); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task not run after workers retired")
+	}
+}
@@ -0,0 +1,210 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+func newSagaConfig() config.SagaConfig {
+	cfg := config.DefaultSagaConfig()
+	cfg.Observer = "noop"
+	return cfg
+}
+
+func appendStep(name string, log *[]string, compensateErr error) workflows.SagaStep[[]string] {
+	return workflows.SagaStep[[]string]{
+		Name: name,
+		Execute: func(ctx context.Context, s []string) ([]string, error) {
+			return append(s, name), nil
+		},
+		Compensate: func(ctx context.Context, s []string) error {
+			*log = append(*log, "undo:"+name+":"+strings.Join(s, ","))
+			return compensateErr
+		},
+	}
+}
+
+func failingStep(name string, err error) workflows.SagaStep[[]string] {
+	return workflows.SagaStep[[]string]{
+		Name: name,
+		Execute: func(ctx context.Context, s []string) ([]string, error) {
+			return s, err
+		},
+		Compensate: func(ctx context.Context, s []string) error {
+			return errors.New("failed step must not be compensated")
+		},
+	}
+}
+
+func TestProcessSaga_Success(t *testing.T) {
+	var log []string
+	steps := []workflows.SagaStep[[]string]{
+		appendStep("a", &log, nil),
+		appendStep("b", &log, nil),
+		appendStep("c", &log, nil),
+	}
+
+	result, err := workflows.ProcessSaga(context.Background(), newSagaConfig(), steps, []string{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Join(result, ",") != "a,b,c" {
+		t.Errorf("Expected a,b,c, got %v", result)
+	}
+	if len(log) != 0 {
+		t.Errorf("Expected no compensations, got %v", log)
+	}
+}
+
+func TestProcessSaga_CompensatesInReverseOrder(t *testing.T) {
+	var log []string
+	testErr := errors.New("payment declined")
+	steps := []workflows.SagaStep[[]string]{
+		appendStep("a", &log, nil),
+		appendStep("b", &log, nil),
+		failingStep("c", testErr),
+		appendStep("d", &log, nil),
+	}
+
+	result, err := workflows.ProcessSaga(context.Background(), newSagaConfig(), steps, []string{})
+
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !errors.Is(err, testErr) {
+		t.Errorf("Expected error to wrap step error, got: %v", err)
+	}
+
+	expected := []string{"undo:b:a,b", "undo:a:a"}
+	if strings.Join(log, "|") != strings.Join(expected, "|") {
+		t.Errorf("Compensation log = %v, want %v", log, expected)
+	}
+
+	if strings.Join(result, ",") != "a,b" {
+		t.Errorf("Expected state at failure a,b, got %v", result)
+	}
+
+	var sagaErr *workflows.SagaError[[]string]
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("Expected SagaError, got %T", err)
+	}
+	if sagaErr.StepIndex != 2 {
+		t.Errorf("StepIndex = %d, want 2", sagaErr.StepIndex)
+	}
+	if sagaErr.StepName != "c" {
+		t.Errorf("StepName = %q, want %q", sagaErr.StepName, "c")
+	}
+	if len(sagaErr.CompensationErrors) != 0 {
+		t.Errorf("Expected no compensation errors, got %d", len(sagaErr.CompensationErrors))
+	}
+}
+
+func TestProcessSaga_CompensationErrorsDoNotAbortChain(t *testing.T) {
+	var log []string
+	compErr := errors.New("release failed")
+	steps := []workflows.SagaStep[[]string]{
+		appendStep("a", &log, nil),
+		appendStep("b", &log, compErr),
+		appendStep("c", &log, nil),
+		failingStep("d", errors.New("boom")),
+	}
+
+	_, err := workflows.ProcessSaga(context.Background(), newSagaConfig(), steps, []string{})
+
+	if len(log) != 3 {
+		t.Fatalf("Expected all 3 completed steps compensated, got %v", log)
+	}
+
+	var sagaErr *workflows.SagaError[[]string]
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("Expected SagaError, got %T", err)
+	}
+	if len(sagaErr.CompensationErrors) != 1 {
+		t.Fatalf("Expected 1 compensation error, got %d", len(sagaErr.CompensationErrors))
+	}
+
+	compensation := sagaErr.CompensationErrors[0]
+	if compensation.StepName != "b" || compensation.StepIndex != 1 {
+		t.Errorf("Compensation error for step %d (%s), want 1 (b)", compensation.StepIndex, compensation.StepName)
+	}
+	if !errors.Is(compensation.Err, compErr) {
+		t.Errorf("Expected compensation error, got %v", compensation.Err)
+	}
+	if !strings.Contains(err.Error(), "1 compensations failed") {
+		t.Errorf("Expected error message to report compensation failures, got: %v", err)
+	}
+}
+
+func TestProcessSaga_NilCompensateSkipped(t *testing.T) {
+	var log []string
+	readOnly := workflows.SagaStep[[]string]{
+		Name: "read",
+		Execute: func(ctx context.Context, s []string) ([]string, error) {
+			return append(s, "read"), nil
+		},
+	}
+	steps := []workflows.SagaStep[[]string]{
+		appendStep("a", &log, nil),
+		readOnly,
+		failingStep("c", errors.New("boom")),
+	}
+
+	_, err := workflows.ProcessSaga(context.Background(), newSagaConfig(), steps, []string{})
+
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(log) != 1 || log[0] != "undo:a:a" {
+		t.Errorf("Expected only step a compensated, got %v", log)
+	}
+}
+
+func TestProcessSaga_CancellationCompensates(t *testing.T) {
+	var log []string
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancelling := workflows.SagaStep[[]string]{
+		Name: "cancel",
+		Execute: func(ctx context.Context, s []string) ([]string, error) {
+			cancel()
+			return append(s, "cancel"), nil
+		},
+		Compensate: func(ctx context.Context, s []string) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log = append(log, "undo:cancel")
+			return nil
+		},
+	}
+	steps := []workflows.SagaStep[[]string]{
+		cancelling,
+		appendStep("b", &log, nil),
+	}
+
+	_, err := workflows.ProcessSaga(ctx, newSagaConfig(), steps, []string{})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if len(log) != 1 || log[0] != "undo:cancel" {
+		t.Errorf("Expected compensation with live context, got %v", log)
+	}
+}
+
+func TestProcessSaga_Empty(t *testing.T) {
+	result, err := workflows.ProcessSaga(context.Background(), newSagaConfig(), nil, []string{"initial"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result) != 1 || result[0] != "initial" {
+		t.Errorf("Expected initial state, got %v", result)
+	}
+}
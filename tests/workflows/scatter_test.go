@@ -0,0 +1,197 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+)
+
+func newScatterGatherConfig(failFast bool) config.ScatterGatherConfig {
+	cfg := config.DefaultScatterGatherConfig()
+	cfg.FailFastNil = &failFast
+	cfg.Observer = "noop"
+	return cfg
+}
+
+func joinGather(results []string) (string, error) {
+	return strings.Join(results, ","), nil
+}
+
+func TestProcessScatterGather_AllSucceed(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(true)
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "legal:" + in, nil },
+		func(ctx context.Context, in string) (string, error) { return "finance:" + in, nil },
+		func(ctx context.Context, in string) (string, error) { return "tech:" + in, nil },
+	}
+
+	output, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, joinGather)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if output != "legal:q,finance:q,tech:q" {
+		t.Errorf("Expected results in processor order, got %q", output)
+	}
+}
+
+func TestProcessScatterGather_RunsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(true)
+
+	var running, peak atomic.Int32
+	processor := func(ctx context.Context, in int) (int, error) {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return in, nil
+	}
+
+	processors := []workflows.TaskProcessor[int, int]{processor, processor, processor, processor}
+
+	sum := func(results []int) (int, error) {
+		total := 0
+		for _, r := range results {
+			total += r
+		}
+		return total, nil
+	}
+
+	output, err := workflows.ProcessScatterGather(ctx, cfg, 5, processors, sum)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if output != 20 {
+		t.Errorf("Expected 20, got %d", output)
+	}
+	if peak.Load() < 2 {
+		t.Errorf("Expected processors to run concurrently, peak concurrency was %d", peak.Load())
+	}
+}
+
+func TestProcessScatterGather_FailFast(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(true)
+	testErr := errors.New("processor failed")
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "ok", nil },
+		func(ctx context.Context, in string) (string, error) { return "", testErr },
+	}
+
+	gatherCalled := false
+	gather := func(results []string) (string, error) {
+		gatherCalled = true
+		return "", nil
+	}
+
+	_, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, gather)
+
+	if err == nil {
+		t.Fatal("Expected error in fail-fast mode")
+	}
+	if !errors.Is(err, testErr) {
+		t.Errorf("Expected error to wrap processor error, got: %v", err)
+	}
+	if gatherCalled {
+		t.Error("Gather should not be called when a processor fails in fail-fast mode")
+	}
+}
+
+func TestProcessScatterGather_PartialFailure(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(false)
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "a", nil },
+		func(ctx context.Context, in string) (string, error) { return "", errors.New("unavailable") },
+		func(ctx context.Context, in string) (string, error) { return "c", nil },
+	}
+
+	output, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, joinGather)
+
+	if err != nil {
+		t.Fatalf("Expected no error for partial failure, got: %v", err)
+	}
+	if output != "a,c" {
+		t.Errorf("Expected gather to receive successful results only, got %q", output)
+	}
+}
+
+func TestProcessScatterGather_AllFail(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(false)
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "", errors.New("down") },
+		func(ctx context.Context, in string) (string, error) { return "", errors.New("down") },
+	}
+
+	_, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, joinGather)
+
+	if err == nil {
+		t.Fatal("Expected error when all processors fail")
+	}
+
+	var pErr *workflows.ParallelError[int]
+	if !errors.As(err, &pErr) {
+		t.Fatalf("Expected ParallelError, got %T", err)
+	}
+	if len(pErr.Errors) != 2 {
+		t.Errorf("Expected 2 task errors, got %d", len(pErr.Errors))
+	}
+}
+
+func TestProcessScatterGather_GatherError(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(true)
+	gatherErr := errors.New("synthesis failed")
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "a", nil },
+	}
+
+	gather := func(results []string) (string, error) {
+		return "", gatherErr
+	}
+
+	_, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, gather)
+
+	if !errors.Is(err, gatherErr) {
+		t.Errorf("Expected gather error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "gather failed") {
+		t.Errorf("Expected error to identify gather phase, got: %v", err)
+	}
+}
+
+func TestProcessScatterGather_InvalidObserver(t *testing.T) {
+	ctx := context.Background()
+	cfg := newScatterGatherConfig(true)
+	cfg.Observer = "nonexistent"
+
+	processors := []workflows.TaskProcessor[string, string]{
+		func(ctx context.Context, in string) (string, error) { return "a", nil },
+	}
+
+	_, err := workflows.ProcessScatterGather(ctx, cfg, "q", processors, joinGather)
+
+	if err == nil {
+		t.Fatal("Expected error for unknown observer")
+	}
+}
@@ -0,0 +1,136 @@
+package testing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JaimeStill/go-agents-orchestration/pkg/config"
+	"github.com/JaimeStill/go-agents-orchestration/pkg/workflows"
+	wftest "github.com/JaimeStill/go-agents-orchestration/pkg/workflows/testing"
+)
+
+// failureRecorder captures assertion failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestMockAgent(t *testing.T) {
+	agent := wftest.NewMockAgent(map[string]string{
+		"What is AI?": "Artificial intelligence.",
+	})
+
+	resp, err := agent.Chat(context.Background(), "What is AI?")
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if got := resp.Content(); got != "Artificial intelligence." {
+		t.Errorf("Content() = %q, want canned response", got)
+	}
+
+	stream, err := agent.ChatStream(context.Background(), "What is AI?")
+	if err != nil {
+		t.Fatalf("ChatStream() error = %v", err)
+	}
+	var streamed strings.Builder
+	for chunk := range stream {
+		streamed.WriteString(chunk.Content())
+	}
+	if streamed.String() != "Artificial intelligence." {
+		t.Errorf("streamed %q, want canned response", streamed.String())
+	}
+
+	if _, err := agent.Chat(context.Background(), "What is ML?"); err == nil || !strings.Contains(err.Error(), "What is ML?") {
+		t.Errorf("Chat() unknown prompt error = %v, want error naming the prompt", err)
+	}
+	if _, err := agent.Embed(context.Background(), "text"); err == nil {
+		t.Error("Embed() error = nil, want unsupported")
+	}
+}
+
+func TestRecordingAgent(t *testing.T) {
+	recorder := wftest.NewRecordingAgent().RespondWith("ok")
+
+	items := []string{"intro", "body", "conclusion"}
+	processor := func(ctx context.Context, section string, summary []string) ([]string, error) {
+		resp, err := recorder.Chat(ctx, "Summarize: "+section)
+		if err != nil {
+			return summary, err
+		}
+		return append(summary, resp.Content()), nil
+	}
+
+	result, err := workflows.ProcessChain(context.Background(), config.ChainConfig{Observer: "noop"}, items, nil, processor, nil)
+	if err != nil {
+		t.Fatalf("ProcessChain() error = %v", err)
+	}
+	wftest.AssertChainResult(t, result,
+		wftest.HasSteps[[]string](3),
+		wftest.FinalEquals([]string{"ok", "ok", "ok"}),
+	)
+
+	want := []string{"Summarize: intro", "Summarize: body", "Summarize: conclusion"}
+	if got := recorder.Prompts(); !slices.Equal(got, want) {
+		t.Errorf("Prompts() = %v, want %v", got, want)
+	}
+
+	recorder.Reset()
+	recorder.Respond(func(prompt string) (string, error) {
+		return "", errors.New("rate limited")
+	})
+	if _, err := recorder.Chat(context.Background(), "again"); err == nil {
+		t.Error("Chat() error = nil, want responder error")
+	}
+	if got := recorder.Prompts(); !slices.Equal(got, []string{"again"}) {
+		t.Errorf("Prompts() after Reset = %v, want [again]", got)
+	}
+}
+
+func TestRecordingAgent_Concurrent(t *testing.T) {
+	recorder := wftest.NewRecordingAgent()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			recorder.Chat(context.Background(), fmt.Sprintf("prompt-%d", i))
+		})
+	}
+	wg.Wait()
+
+	if got := len(recorder.Prompts()); got != 50 {
+		t.Errorf("recorded %d prompts, want 50", got)
+	}
+}
+
+func TestAssertChainResult_ReportsEveryFailure(t *testing.T) {
+	result := workflows.ChainResult[int]{Final: 7, Steps: 2}
+	recorder := &failureRecorder{TB: t}
+
+	wftest.AssertChainResult(recorder, result,
+		wftest.HasSteps[int](3),
+		wftest.FinalEquals(8),
+		wftest.FinalSatisfies(func(final int) string {
+			if final%2 != 0 {
+				return "want an even total"
+			}
+			return ""
+		}),
+		wftest.HasIntermediateStates[int](0),
+	)
+
+	if len(recorder.failures) != 3 {
+		t.Fatalf("got %d failures, want 3: %v", len(recorder.failures), recorder.failures)
+	}
+	if !strings.Contains(recorder.failures[2], "want an even total") {
+		t.Errorf("FinalSatisfies failure = %q, want the check's description", recorder.failures[2])
+	}
+}